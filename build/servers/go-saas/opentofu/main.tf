@@ -65,6 +65,10 @@ module "server" {
               {
                 containerPort = var.project.containers.main.ports.http.port
                 hostPort      = var.project.containers.main.ports.http.port
+              },
+              {
+                containerPort = var.project.containers.main.ports.admin.port
+                hostPort      = var.project.containers.main.ports.admin.port
               }
             ]
             logConfiguration = {
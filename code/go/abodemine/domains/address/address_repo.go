@@ -67,6 +67,7 @@ func (repo *repository) SearchPropertyAddressRecord(r *arc.Request, in *SearchPr
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get OpenSearch client",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -143,6 +144,7 @@ func (repo *repository) SearchPropertyAddressRecord(r *arc.Request, in *SearchPr
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to marshal query.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -158,6 +160,7 @@ func (repo *repository) SearchPropertyAddressRecord(r *arc.Request, in *SearchPr
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to search addresses",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer resp.Body.Close()
@@ -170,6 +173,7 @@ func (repo *repository) SearchPropertyAddressRecord(r *arc.Request, in *SearchPr
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to decode search response",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -327,6 +331,7 @@ func (repo *repository) ScanPropertyAddressRecord(r *arc.Request, in *ScanProper
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to scan row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -530,6 +535,7 @@ func (repo *repository) SelectPropertyAddressRecord(r *arc.Request, in *SelectPr
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -584,6 +590,7 @@ func (repo *repository) SelectFipsRecord(r *arc.Request, in *SelectFipsRecordInp
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -606,6 +613,7 @@ func (repo *repository) SelectFipsRecord(r *arc.Request, in *SelectFipsRecordInp
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -640,6 +648,7 @@ func (repo *repository) SelectZip5Record(r *arc.Request, in *SelectZip5RecordInp
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -662,6 +671,7 @@ func (repo *repository) SelectZip5Record(r *arc.Request, in *SelectZip5RecordInp
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
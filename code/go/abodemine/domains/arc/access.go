@@ -0,0 +1,75 @@
+package arc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type accessRecordCtxKey struct{}
+
+// AccessRecord collects what the access log and the metrics of a
+// call need to know but only the domains learn: who made the call
+// and how much quota it was charged.
+type AccessRecord struct {
+	mu             sync.Mutex
+	organizationId uuid.UUID
+	keyId          uuid.UUID
+	quotaCharge    int64
+}
+
+// SetSession records the organization and api key of the call.
+func (a *AccessRecord) SetSession(s ServerSession) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.organizationId = s.OrganizationId()
+	a.keyId = s.KeyId()
+}
+
+// AddQuotaCharge records a quota transaction charged for the call.
+func (a *AccessRecord) AddQuotaCharge(n int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.quotaCharge += n
+}
+
+func (a *AccessRecord) OrganizationId() uuid.UUID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.organizationId
+}
+
+func (a *AccessRecord) KeyId() uuid.UUID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.keyId
+}
+
+func (a *AccessRecord) QuotaCharge() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.quotaCharge
+}
+
+// WithAccessRecord returns a copy of ctx carrying record.
+func WithAccessRecord(ctx context.Context, record *AccessRecord) context.Context {
+	return context.WithValue(ctx, accessRecordCtxKey{}, record)
+}
+
+// AccessRecordFromContext returns the record carried by ctx,
+// or nil if the call is not logged.
+func AccessRecordFromContext(ctx context.Context) *AccessRecord {
+	if ctx == nil {
+		return nil
+	}
+
+	record, _ := ctx.Value(accessRecordCtxKey{}).(*AccessRecord)
+
+	return record
+}
@@ -9,6 +9,7 @@ import (
 const (
 	QuotaExhaustedDaily   = "daily"
 	QuotaExhaustedMonthly = "monthly"
+	QuotaExhaustedExpired = "expired"
 )
 
 type ApiQuota struct {
@@ -28,6 +29,11 @@ type ApiQuota struct {
 	RecorderLayoutEnabled     bool
 	RentEstimateLayoutEnabled bool
 	SaleEstimateLayoutEnabled bool
+	MarketStatsLayoutEnabled  bool
+	TimelineLayoutEnabled     bool
+
+	// Zero when the quota never expires.
+	ExpiresAt time.Time
 }
 
 type ApiQuotaTransaction struct {
@@ -49,4 +55,47 @@ type ApiQuotaTransaction struct {
 	RecorderLayoutAmount     int32
 	RentEstimateLayoutAmount int32
 	SaleEstimateLayoutAmount int32
+	MarketStatsLayoutAmount  int32
+	TimelineLayoutAmount     int32
+}
+
+type QuotaPeriod int16
+
+const (
+	QuotaPeriodDaily   QuotaPeriod = 100
+	QuotaPeriodMonthly QuotaPeriod = 200
+)
+
+// QuotaPeriodStart returns the start of the period that contains t.
+// Periods are in UTC, so that the quotas are enforced, notified,
+// reported and billed over the same days and months.
+func QuotaPeriodStart(period QuotaPeriod, t time.Time) time.Time {
+	t = t.UTC()
+
+	if period == QuotaPeriodDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ApiQuotaNotification records that an organization crossed
+// a percentage of one of its quotas. Each percentage is
+// recorded once per period.
+type ApiQuotaNotification struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Meta      map[string]any
+
+	OrganizationId uuid.UUID
+	// The key of the request that crossed the percentage.
+	ApiKeyId *uuid.UUID
+
+	QuotaPeriod QuotaPeriod
+	PeriodStart time.Time
+	Percent     int16
+
+	Quota int64
+	Usage int64
 }
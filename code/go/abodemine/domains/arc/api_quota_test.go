@@ -0,0 +1,58 @@
+package arc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaPeriodStart(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not found")
+	}
+
+	testCases := []*struct {
+		name   string
+		period QuotaPeriod
+		in     time.Time
+		out    time.Time
+	}{
+		{
+			name:   "daily",
+			period: QuotaPeriodDaily,
+			in:     time.Date(2026, time.March, 31, 23, 59, 0, 0, time.UTC),
+			out:    time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "monthly",
+			period: QuotaPeriodMonthly,
+			in:     time.Date(2026, time.March, 31, 23, 59, 0, 0, time.UTC),
+			out:    time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Still March 31 in New York, April 1 in UTC.
+			name:   "daily-utc",
+			period: QuotaPeriodDaily,
+			in:     time.Date(2026, time.March, 31, 21, 0, 0, 0, newYork),
+			out:    time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "monthly-utc",
+			period: QuotaPeriodMonthly,
+			in:     time.Date(2026, time.March, 31, 21, 0, 0, 0, newYork),
+			out:    time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.out, QuotaPeriodStart(tc.period, tc.in))
+		})
+	}
+
+	// The previous month of a month end is not normalized past it.
+	start := QuotaPeriodStart(QuotaPeriodMonthly, time.Date(2026, time.March, 31, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), start.AddDate(0, -1, 0))
+}
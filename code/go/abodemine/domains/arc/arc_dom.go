@@ -11,6 +11,7 @@ import (
 
 	"abodemine/lib/errors"
 	"abodemine/lib/gconf"
+	"abodemine/lib/health"
 	"abodemine/lib/val"
 )
 
@@ -20,10 +21,15 @@ type Domain interface {
 	CreateServerSession(r *Request, in *CreateServerSessionInput) (ServerSession, error)
 	SelectServerSession(r *Request, in *SelectServerSessionInput) (ServerSession, error)
 	DeleteServerSession(r *Request, in *DeleteServerSessionInput) error
+	ListServerSessions(r *Request, in *ListServerSessionsInput) (*ListServerSessionsOutput, error)
+	DeleteUserServerSessions(r *Request, in *DeleteUserServerSessionsInput) (*DeleteUserServerSessionsOutput, error)
 
 	DeploymentEnvironment() int
 	Values() *gconf.Values
 
+	// Dependencies implements health.Source.
+	Dependencies() []*health.Dependency
+
 	SelectAWS(k string) (aws.Config, error)
 	SelectCasbin(k string) (*casbin.Enforcer, error)
 	SelectDuration(k string) (time.Duration, error)
@@ -0,0 +1,50 @@
+package arc
+
+import (
+	"context"
+	"sync"
+)
+
+type errorRecordCtxKey struct{}
+
+// ErrorRecord collects the error a call responded with, so that
+// the error reporter gets the full chain, while the response
+// only carries its sanitized form.
+type ErrorRecord struct {
+	mu  sync.Mutex
+	err error
+}
+
+// SetErr records the error of the response.
+func (e *ErrorRecord) SetErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.err = err
+}
+
+// Err returns the error of the response, or nil if the call
+// did not respond with one.
+func (e *ErrorRecord) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.err
+}
+
+// WithErrorRecord returns a copy of ctx carrying record.
+func WithErrorRecord(ctx context.Context, record *ErrorRecord) context.Context {
+	return context.WithValue(ctx, errorRecordCtxKey{}, record)
+}
+
+// ErrorRecordFromContext returns the record carried by ctx,
+// or nil if the errors of the call are not reported.
+func ErrorRecordFromContext(ctx context.Context) *ErrorRecord {
+	if ctx == nil {
+		return nil
+	}
+
+	record, _ := ctx.Value(errorRecordCtxKey{}).(*ErrorRecord)
+
+	return record
+}
@@ -0,0 +1,78 @@
+package arc
+
+import (
+	"context"
+	"sort"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/health"
+)
+
+// Dependencies returns the Postgres pools, OpenSearch clients and
+// Valkey clients of the domain, for the readiness checks.
+func (dom *domain) Dependencies() []*health.Dependency {
+	var out []*health.Dependency
+
+	pgxPoolKeys := dom.pgxPool.Keys()
+	sort.Strings(pgxPoolKeys)
+
+	for _, k := range pgxPoolKeys {
+		pool := dom.pgxPool.Get(k)
+
+		out = append(out, &health.Dependency{
+			Kind: "postgres",
+			Name: k,
+			Ping: pool.Ping,
+		})
+	}
+
+	openSearchKeys := dom.openSearch.Keys()
+	sort.Strings(openSearchKeys)
+
+	for _, k := range openSearchKeys {
+		client := dom.openSearch.Get(k)
+
+		out = append(out, &health.Dependency{
+			Kind: "opensearch",
+			Name: k,
+			Ping: func(ctx context.Context) error {
+				res, err := client.Ping(client.Ping.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+
+				defer res.Body.Close()
+
+				if res.IsError() {
+					return &errors.Object{
+						Id:     "d5f93dd5-8c74-46d0-8bda-39451db19b27",
+						Code:   errors.Code_UNAVAILABLE,
+						Detail: "OpenSearch ping failed.",
+						Meta: map[string]any{
+							"status": res.StatusCode,
+						},
+					}
+				}
+
+				return nil
+			},
+		})
+	}
+
+	valkeyKeys := dom.valkey.Keys()
+	sort.Strings(valkeyKeys)
+
+	for _, k := range valkeyKeys {
+		client := dom.valkey.Get(k)
+
+		out = append(out, &health.Dependency{
+			Kind: "valkey",
+			Name: k,
+			Ping: func(ctx context.Context) error {
+				return client.Do(ctx, client.B().Ping().Build()).Error()
+			},
+		})
+	}
+
+	return out
+}
@@ -10,53 +10,36 @@ import (
 )
 
 type httpApiResponse struct {
-	Data   any              `json:"data,omitempty"`
-	Errors []*errors.Object `json:"errors,omitempty"`
+	Data any `json:"data,omitempty"`
 }
 
 func HttpApiDataResponse(dom Domain, w http.ResponseWriter, code int, data any) {
 	HttpApiResponse(dom, w, code, data, "", nil)
 }
 
-func HttpApiErrorResponse(dom Domain, w http.ResponseWriter, requestId string, err error) {
+// HttpApiErrorResponse writes err as problem details, and records
+// it in the ErrorRecord of the request for the error reporter.
+func HttpApiErrorResponse(dom Domain, w http.ResponseWriter, r *http.Request, requestId string, err error) {
+	if record := ErrorRecordFromContext(r.Context()); record != nil && err != nil {
+		record.SetErr(err)
+	}
+
 	HttpApiResponse(dom, w, 0, nil, requestId, err)
 }
 
 func HttpApiResponse(dom Domain, w http.ResponseWriter, code int, data any, requestId string, err error) {
-	w.Header().Set("Content-Type", "application/json")
-
-	var objects []*errors.Object
-
 	if err != nil {
-		objects = errors.Sanitize(
-			err,
-			// dom.DeploymentEnvironment() == app.DeploymentEnvironment_LOCAL,
-			false,
-		).Objects
-
-		firstError := objects[0]
-		firstError.RequestId = requestId
-
-		code = firstError.HTTPStatusCode()
-
-		if firstError.Code == errors.Code_INTERNAL {
-			// Ensure we log the full chain on internal errors.
-			log.Error().
-				Err(err).
-				Str("label", "Internal Error").
-				Str("request_id", requestId).
-				Str("layer", "api_response").
-				Send()
-		}
+		httpApiProblemResponse(w, requestId, err)
+		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
 	out := httpApiResponse{
-		Data:   data,
-		Errors: objects,
+		Data: data,
 	}
 
-	w.WriteHeader(code)
-
 	if err := json.NewEncoder(w).Encode(out); err != nil {
 		log.Error().
 			Str("id", "74603aee-4187-439b-95f2-cd98952a7964").
@@ -67,3 +50,44 @@ func HttpApiResponse(dom Domain, w http.ResponseWriter, code int, data any, requ
 		return
 	}
 }
+
+// httpApiProblemResponse writes the sanitized error as problem
+// details, the same way for all the servers.
+func httpApiProblemResponse(w http.ResponseWriter, requestId string, err error) {
+	chain := errors.Sanitize(
+		err,
+		// dom.DeploymentEnvironment() == app.DeploymentEnvironment_LOCAL,
+		false,
+	)
+
+	// The server sets the header before the request is created,
+	// so the errors of unauthenticated calls carry the id too.
+	if requestId == "" {
+		requestId = w.Header().Get(RequestIdHeader)
+	}
+
+	firstError := chain.First()
+	firstError.RequestId = requestId
+
+	if firstError.Code == errors.Code_INTERNAL {
+		// Ensure we log the full chain on internal errors.
+		log.Error().
+			Err(err).
+			Str("label", "Internal Error").
+			Str("request_id", requestId).
+			Str("layer", "api_response").
+			Send()
+	}
+
+	problem := errors.NewProblem(chain, requestId)
+
+	w.Header().Set("Content-Type", errors.ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Error().
+			Str("id", "5f74072f-7820-4548-bdd7-0527adc6c662").
+			Err(err).
+			Msg("Failed to encode http api problem.")
+	}
+}
@@ -0,0 +1,53 @@
+package arc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type idempotentCallCtxKey struct{}
+
+// IdempotentCall is an api call made with an Idempotency-Key.
+// It collects the quota transactions charged for the call so
+// they can be stored with its response.
+type IdempotentCall struct {
+	Key string
+
+	mu             sync.Mutex
+	transactionIds []uuid.UUID
+}
+
+// AddTransaction records a quota transaction charged for the call.
+func (c *IdempotentCall) AddTransaction(id uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.transactionIds = append(c.transactionIds, id)
+}
+
+// TransactionIds returns the quota transactions charged for the call.
+func (c *IdempotentCall) TransactionIds() []uuid.UUID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]uuid.UUID(nil), c.transactionIds...)
+}
+
+// WithIdempotentCall returns a copy of ctx carrying call.
+func WithIdempotentCall(ctx context.Context, call *IdempotentCall) context.Context {
+	return context.WithValue(ctx, idempotentCallCtxKey{}, call)
+}
+
+// IdempotentCallFromContext returns the call carried by ctx,
+// or nil if the call was made without an Idempotency-Key.
+func IdempotentCallFromContext(ctx context.Context) *IdempotentCall {
+	if ctx == nil {
+		return nil
+	}
+
+	call, _ := ctx.Value(idempotentCallCtxKey{}).(*IdempotentCall)
+
+	return call
+}
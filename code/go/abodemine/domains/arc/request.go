@@ -5,9 +5,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"abodemine/lib/errors"
 	"abodemine/lib/flags"
+	"abodemine/lib/tracing"
 	"abodemine/lib/val"
 )
 
@@ -63,6 +68,45 @@ func (r *Request) Context() context.Context {
 	return r.ctx
 }
 
+// Logger returns a logger binding the request id, the route and,
+// once authenticated, the session and organization of the request.
+// Domains SHOULD log with it rather than the global logger.
+func (r *Request) Logger() *zerolog.Logger {
+	c := log.With().Str("request_id", r.id.String())
+
+	if route := RouteFromContext(r.ctx); route != "" {
+		c = c.Str("route", route)
+	}
+
+	if r.session != nil {
+		c = c.
+			Str("session_id", r.session.Id().String()).
+			Str("organization_id", r.session.OrganizationId().String())
+	}
+
+	logger := c.Logger()
+
+	return &logger
+}
+
+// StartSpan returns a copy of the request whose context holds a
+// new child span, which the caller MUST end. Queries and commands
+// run with the copy are recorded as children of the span.
+func (r *Request) StartSpan(name string, attrs ...attribute.KeyValue) (*Request, trace.Span) {
+	ctx, span := tracing.Tracer().Start(r.ctx, name, trace.WithAttributes(attrs...))
+
+	newRequest := *r
+	newRequest.ctx = ctx
+
+	return &newRequest, span
+}
+
+// Span returns the span of the request context, which is a no-op
+// span when the request is not traced.
+func (r *Request) Span() trace.Span {
+	return trace.SpanFromContext(r.ctx)
+}
+
 func (r *Request) Dom() Domain {
 	return r.domain
 }
@@ -112,6 +156,7 @@ func (r *Request) CasbinEnforce(key string, vals ...any) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to enforce auth.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -200,10 +245,15 @@ func (dom *domain) CreateRequest(in *CreateRequestInput) (*Request, error) {
 		}
 	}
 
-	if in.Id != uuid.Nil {
+	switch {
+	case in.Id != uuid.Nil:
 		r.id = in.Id
-	} else {
-		v, err := val.NewUUID4()
+	case RequestIdFromContext(in.Context) != uuid.Nil:
+		// Share the id set by the server, so all the requests of a
+		// call are logged with it.
+		r.id = RequestIdFromContext(in.Context)
+	default:
+		v, err := val.NewUUID7()
 		if err != nil {
 			return nil, errors.Forward(err, "89e3919a-8172-466e-836c-bba7ec73e853")
 		}
@@ -217,5 +267,7 @@ func (dom *domain) CreateRequest(in *CreateRequestInput) (*Request, error) {
 		r.ctx = context.TODO()
 	}
 
+	r.Span().SetAttributes(attribute.String("abodemine.request_id", r.id.String()))
+
 	return r, nil
 }
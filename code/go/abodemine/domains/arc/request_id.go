@@ -0,0 +1,88 @@
+package arc
+
+import (
+	"context"
+	"os"
+
+	"github.com/google/uuid"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+)
+
+// RequestIdHeader carries the request id of a call. It is accepted
+// from the client and echoed in the response.
+const RequestIdHeader = "X-Request-Id"
+
+// RunIdEnv carries the request id of a launcher to the tasks it
+// starts, so that their lines share the id of the run.
+const RunIdEnv = "ABODEMINE_RUN_ID"
+
+type requestIdCtxKey struct{}
+
+type routeCtxKey struct{}
+
+// WithRequestId returns a copy of ctx carrying the request id, which
+// CreateRequest uses when its input has none.
+func WithRequestId(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, requestIdCtxKey{}, id)
+}
+
+// RequestIdFromContext returns the request id carried by ctx, or
+// uuid.Nil.
+func RequestIdFromContext(ctx context.Context) uuid.UUID {
+	if ctx == nil {
+		return uuid.Nil
+	}
+
+	id, _ := ctx.Value(requestIdCtxKey{}).(uuid.UUID)
+
+	return id
+}
+
+// WithRoute returns a copy of ctx carrying the route template of
+// the call, such as /api/admin/users/:userId/sessions.
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeCtxKey{}, route)
+}
+
+func RouteFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	route, _ := ctx.Value(routeCtxKey{}).(string)
+
+	return route
+}
+
+// RunIdFromEnv returns the run id set by the launcher of the task,
+// or a new UUIDv7 if the task was started by hand.
+func RunIdFromEnv() (uuid.UUID, error) {
+	s := os.Getenv(RunIdEnv)
+
+	if s == "" {
+		id, err := val.NewUUID7()
+		if err != nil {
+			return uuid.Nil, errors.Forward(err, "f35109a8-b322-4c84-97c8-105c5ff2fbb1")
+		}
+
+		return id, nil
+	}
+
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, &errors.Object{
+			Id:     "b8f2034c-9551-46a9-9529-7100ba325d8f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid run id.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"env": RunIdEnv,
+			},
+		}
+	}
+
+	return id, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fxamacker/cbor/v2"
@@ -45,9 +46,13 @@ var validSessionTypes = map[uint16]struct{}{
 	SessionTypeApiServer:     {},
 	SessionTypeSaasServer:    {},
 	SessionTypeSaasWebClient: {},
+
+	SessionTypeESOWhiteLabelServer:    {},
+	SessionTypeESOWhiteLabelWebClient: {},
 }
 
 type ServerSession interface {
+	CreatedAt() time.Time
 	Flags() []string
 	Id() uuid.UUID
 	KeyHash() string
@@ -56,6 +61,7 @@ type ServerSession interface {
 	OrganizationId() uuid.UUID
 	RoleName() string
 	SessionType() uint16
+	Tenant() string
 	Timezone() string
 	Username() string
 	UserId() uuid.UUID
@@ -82,6 +88,12 @@ type serverSessionPayload struct {
 
 	Flags []string `cbor:"9,keyasint,omitempty"`
 
+	// Tenant is the name of the white-label tenant
+	// of ESO sessions.
+	Tenant string `cbor:"10,keyasint,omitempty"`
+
+	CreatedAt time.Time `cbor:"11,keyasint,omitempty"`
+
 	keyHash string
 	keyType int16
 }
@@ -110,6 +122,10 @@ func NewServerSessionFromBytes(in *NewServerSessionFromBytesInput) (*serverSessi
 	return sess, nil
 }
 
+func (c *serverSessionContainer) CreatedAt() time.Time {
+	return c.payload.CreatedAt
+}
+
 func (c *serverSessionContainer) Flags() []string {
 	return c.payload.Flags
 }
@@ -142,6 +158,10 @@ func (c *serverSessionContainer) SessionType() uint16 {
 	return c.payload.SessionType
 }
 
+func (c *serverSessionContainer) Tenant() string {
+	return c.payload.Tenant
+}
+
 func (c *serverSessionContainer) Timezone() string {
 	return c.payload.Timezone
 }
@@ -166,6 +186,7 @@ func (c *serverSessionContainer) MarshalBinary() ([]byte, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to marshal.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -183,6 +204,7 @@ func (c *serverSessionContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to create zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -199,6 +221,7 @@ func (c *serverSessionContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to write to zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -208,6 +231,7 @@ func (c *serverSessionContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to close zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -239,6 +263,7 @@ func (c *serverSessionContainer) UnmarshalBinary(data []byte) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to create zstd reader.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -251,6 +276,7 @@ func (c *serverSessionContainer) UnmarshalBinary(data []byte) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to read from zstd reader.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -263,6 +289,7 @@ func (c *serverSessionContainer) UnmarshalBinary(data []byte) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to unmarshal server session.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -277,6 +304,7 @@ type CreateServerSessionInput struct {
 	KeyType        int16
 	RoleName       string
 	SessionType    uint16
+	Tenant         string
 	Timezone       string
 	Username       string
 	TTL            time.Duration
@@ -342,9 +370,11 @@ func (dom *domain) CreateServerSession(r *Request, in *CreateServerSessionInput)
 			KeyId:          in.KeyId,
 			RoleName:       in.RoleName,
 			SessionType:    in.SessionType,
+			Tenant:         in.Tenant,
 			Timezone:       in.Timezone,
 			Username:       in.Username,
 			Flags:          in.Flags,
+			CreatedAt:      time.Now().UTC().Truncate(time.Second),
 			keyHash:        in.KeyHash,
 			keyType:        in.KeyType,
 		},
@@ -369,17 +399,16 @@ func (dom *domain) CreateServerSession(r *Request, in *CreateServerSessionInput)
 		return nil, errors.Forward(err, "d722b1fe-1054-40c2-bcb3-4e8be2dbfb6f")
 	}
 
-	key := fmt.Sprintf(
-		"{%s}:sess:%s:%s",
-		base64.StdEncoding.EncodeToString(in.OrganizationId[:])[:22],
-		val.Uint16ToRawBase64(in.SessionType),
-		base64.StdEncoding.EncodeToString(id[:])[:22],
-	)
+	keys := []string{serverSessionKey(in.OrganizationId, in.SessionType, id)}
+
+	if in.UserId != uuid.Nil {
+		keys = append(keys, userServerSessionsKey(in.OrganizationId, in.UserId))
+	}
 
 	scriptOut := createScript.Exec(
 		r.Context(),
 		valkeyCli,
-		[]string{key},
+		keys,
 		[]string{
 			strconv.Itoa(int(in.TTL.Seconds())),
 			string(payload),
@@ -392,6 +421,7 @@ func (dom *domain) CreateServerSession(r *Request, in *CreateServerSessionInput)
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute script.",
 			Cause:  scriptOut.Error().Error(),
+			Err:    scriptOut.Error(),
 		}
 	}
 
@@ -402,6 +432,7 @@ func (dom *domain) CreateServerSession(r *Request, in *CreateServerSessionInput)
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -457,12 +488,7 @@ func (dom *domain) SelectServerSession(r *Request, in *SelectServerSessionInput)
 		return nil, errors.Forward(err, "effb77d0-d491-427a-857e-ade0c5353c1b")
 	}
 
-	key := fmt.Sprintf(
-		"{%s}:sess:%s:%s",
-		base64.StdEncoding.EncodeToString(in.OrganizationId[:])[:22],
-		val.Uint16ToRawBase64(in.SessionType),
-		base64.StdEncoding.EncodeToString(in.Id[:])[:22],
-	)
+	key := serverSessionKey(in.OrganizationId, in.SessionType, in.Id)
 
 	scriptOut := selectSessionScript.Exec(
 		r.Context(),
@@ -485,6 +511,7 @@ func (dom *domain) SelectServerSession(r *Request, in *SelectServerSessionInput)
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to execute script.",
 				Cause:  scriptOut.Error().Error(),
+				Err:    scriptOut.Error(),
 			}
 		}
 	}
@@ -496,6 +523,7 @@ func (dom *domain) SelectServerSession(r *Request, in *SelectServerSessionInput)
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -514,6 +542,7 @@ func (dom *domain) SelectServerSession(r *Request, in *SelectServerSessionInput)
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output[0].",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -530,6 +559,9 @@ type DeleteServerSessionInput struct {
 	OrganizationId uuid.UUID
 	Id             uuid.UUID
 	SessionType    uint16
+
+	// UserId removes the session from the index of the user.
+	UserId uuid.UUID
 }
 
 func (dom *domain) DeleteServerSession(r *Request, in *DeleteServerSessionInput) error {
@@ -567,17 +599,16 @@ func (dom *domain) DeleteServerSession(r *Request, in *DeleteServerSessionInput)
 		return errors.Forward(err, "5916ebe2-6e9c-4005-8a91-5a850b23265b")
 	}
 
-	key := fmt.Sprintf(
-		"{%s}:sess:%s:%s",
-		base64.StdEncoding.EncodeToString(in.OrganizationId[:])[:22],
-		val.Uint16ToRawBase64(in.SessionType),
-		base64.StdEncoding.EncodeToString(in.Id[:])[:22],
-	)
+	keys := []string{serverSessionKey(in.OrganizationId, in.SessionType, in.Id)}
+
+	if in.UserId != uuid.Nil {
+		keys = append(keys, userServerSessionsKey(in.OrganizationId, in.UserId))
+	}
 
 	scriptOut := selectSessionScript.Exec(
 		r.Context(),
 		valkeyCli,
-		[]string{key},
+		keys,
 		nil,
 	)
 
@@ -587,12 +618,286 @@ func (dom *domain) DeleteServerSession(r *Request, in *DeleteServerSessionInput)
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute script.",
 			Cause:  scriptOut.Error().Error(),
+			Err:    scriptOut.Error(),
 		}
 	}
 
 	return nil
 }
 
+type ListServerSessionsInput struct {
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+}
+
+type ListedServerSession struct {
+	Session   ServerSession
+	ExpiresAt time.Time
+}
+
+type ListServerSessionsOutput struct {
+	Sessions []*ListedServerSession
+}
+
+// ListServerSessions lists the active sessions of a user, of all
+// session types. Sessions created without a UserId, e.g. of api
+// keys, are not listed.
+func (dom *domain) ListServerSessions(r *Request, in *ListServerSessionsInput) (*ListServerSessionsOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "e387a1f4-afea-48d2-817c-1bfd8e3ace85",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	indexed, err := selectUserServerSessionKeys(r, in.OrganizationId, in.UserId)
+	if err != nil {
+		return nil, errors.Forward(err, "4591861a-632f-481e-83fa-5406d243e65d")
+	}
+
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeySession)
+	if err != nil {
+		return nil, errors.Forward(err, "050d6786-405f-4988-ba11-cf55056e42e4")
+	}
+
+	selectSessionScript, err := r.Dom().SelectValkeyScript("select-session")
+	if err != nil {
+		return nil, errors.Forward(err, "12cd1b61-445e-4f37-9ea1-fbffd976c155")
+	}
+
+	out := &ListServerSessionsOutput{}
+
+	for _, v := range indexed {
+		scriptOut := selectSessionScript.Exec(
+			r.Context(),
+			valkeyCli,
+			[]string{v.key},
+			nil,
+		)
+
+		if scriptOut.Error() != nil {
+			// Deleted without updating the index.
+			if scriptOut.Error().Error() == "KEY_NOT_FOUND" {
+				continue
+			}
+
+			return nil, &errors.Object{
+				Id:     "7d2c45e1-1493-419a-b445-00ad74eb539f",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to execute script.",
+				Cause:  scriptOut.Error().Error(),
+				Err:    scriptOut.Error(),
+			}
+		}
+
+		scriptOutArray, err := scriptOut.ToArray()
+		if err != nil || len(scriptOutArray) < 1 {
+			return nil, &errors.Object{
+				Id:     "2aa99a49-82b0-490a-8832-a705175899e7",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse script output.",
+			}
+		}
+
+		payloadStr, err := scriptOutArray[0].ToString()
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "d731dbca-c8c2-4ae2-8e45-53cc858cf273",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse script output[0].",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		sess := new(serverSessionContainer)
+
+		if err := sess.UnmarshalBinary([]byte(payloadStr)); err != nil {
+			return nil, errors.Forward(err, "e5b20ba5-c49c-4718-94b0-67a001cdb15e")
+		}
+
+		out.Sessions = append(out.Sessions, &ListedServerSession{
+			Session:   sess,
+			ExpiresAt: v.expiresAt,
+		})
+	}
+
+	return out, nil
+}
+
+type DeleteUserServerSessionsInput struct {
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+
+	// ExceptIds are kept, e.g. the session of the request.
+	ExceptIds []uuid.UUID
+}
+
+type DeleteUserServerSessionsOutput struct {
+	Deleted int
+}
+
+// DeleteUserServerSessions deletes the sessions of a user,
+// logging the user out everywhere.
+func (dom *domain) DeleteUserServerSessions(r *Request, in *DeleteUserServerSessionsInput) (*DeleteUserServerSessionsOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "e728e6f4-0e69-4bf0-95e7-a5794d8aa3a6",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	indexed, err := selectUserServerSessionKeys(r, in.OrganizationId, in.UserId)
+	if err != nil {
+		return nil, errors.Forward(err, "34f1483f-4e1e-4cb0-8e5d-0e4d63f443a9")
+	}
+
+	except := make(map[string]struct{}, len(in.ExceptIds))
+
+	for _, id := range in.ExceptIds {
+		except[base64.StdEncoding.EncodeToString(id[:])[:22]] = struct{}{}
+	}
+
+	keys := []string{userServerSessionsKey(in.OrganizationId, in.UserId)}
+
+	for _, v := range indexed {
+		// The id is the last segment of the key.
+		if _, ok := except[v.key[strings.LastIndexByte(v.key, ':')+1:]]; ok {
+			continue
+		}
+
+		keys = append(keys, v.key)
+	}
+
+	out := &DeleteUserServerSessionsOutput{}
+
+	if len(keys) == 1 {
+		return out, nil
+	}
+
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeySession)
+	if err != nil {
+		return nil, errors.Forward(err, "b733b1f5-dd97-4298-bd26-ce83d45e224f")
+	}
+
+	deleteScript, err := r.Dom().SelectValkeyScript("delete-user-sessions")
+	if err != nil {
+		return nil, errors.Forward(err, "e3765420-4fed-435b-940e-40f650d4728a")
+	}
+
+	deleted, err := deleteScript.Exec(
+		r.Context(),
+		valkeyCli,
+		keys,
+		nil,
+	).AsInt64()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "c3895751-5c3b-44e8-977d-94a7908c2a3d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out.Deleted = int(deleted)
+
+	return out, nil
+}
+
+type userServerSessionKey struct {
+	key       string
+	expiresAt time.Time
+}
+
+func selectUserServerSessionKeys(r *Request, organizationId, userId uuid.UUID) ([]*userServerSessionKey, error) {
+	if organizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "24c3b97e-d16f-43cd-97b5-26da39992cb9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing OrganizationId.",
+		}
+	}
+
+	if userId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "2683beb9-b5fa-40e6-a9c6-a4820e4af81c",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing UserId.",
+		}
+	}
+
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeySession)
+	if err != nil {
+		return nil, errors.Forward(err, "ef276962-5fa9-48f1-9a14-0022ad97933a")
+	}
+
+	selectScript, err := r.Dom().SelectValkeyScript("select-user-sessions")
+	if err != nil {
+		return nil, errors.Forward(err, "70ba4dfe-7f58-4bb3-acda-0369ecf4a17b")
+	}
+
+	scriptOutArray, err := selectScript.Exec(
+		r.Context(),
+		valkeyCli,
+		[]string{userServerSessionsKey(organizationId, userId)},
+		nil,
+	).AsStrSlice()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "d9bbb307-da4a-47ff-96f5-7a4bf440239d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	var out []*userServerSessionKey
+
+	for i := 0; i+1 < len(scriptOutArray); i += 2 {
+		expiresAt, err := strconv.ParseFloat(scriptOutArray[i+1], 64)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "7fe46238-4637-498f-b333-03f831f616ab",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse session expiry.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out = append(out, &userServerSessionKey{
+			key:       scriptOutArray[i],
+			expiresAt: time.Unix(int64(expiresAt), 0).UTC(),
+		})
+	}
+
+	return out, nil
+}
+
+func serverSessionKey(organizationId uuid.UUID, sessionType uint16, id uuid.UUID) string {
+	return fmt.Sprintf(
+		"{%s}:sess:%s:%s",
+		base64.StdEncoding.EncodeToString(organizationId[:])[:22],
+		val.Uint16ToRawBase64(sessionType),
+		base64.StdEncoding.EncodeToString(id[:])[:22],
+	)
+}
+
+// userServerSessionsKey shares the hash slot of the sessions
+// of the organization.
+func userServerSessionsKey(organizationId, userId uuid.UUID) string {
+	return fmt.Sprintf(
+		"{%s}:sess-user:%s",
+		base64.StdEncoding.EncodeToString(organizationId[:])[:22],
+		base64.StdEncoding.EncodeToString(userId[:])[:22],
+	)
+}
+
 type WebClientSession struct {
 	DisplayName     string `json:"display_name,omitempty"`
 	IsLoading       bool   `json:"is_loading,omitempty"`
@@ -400,3 +400,101 @@ func TestDomain_DeleteServerSession(t *testing.T) {
 		})
 	}
 }
+
+func TestDomain_ListServerSessions(t *testing.T) {
+	ctx := context.Background()
+	config := conf.MustResolveAndLoadOnce(ctx)
+	dom := NewDomain(&NewDomainInput{
+		AWS:          config.AWS,
+		OpenSearch:   config.OpenSearch,
+		PgxPool:      config.PgxPool,
+		Valkey:       config.Valkey,
+		ValkeyScript: config.ValkeyScript,
+	})
+
+	r, err := dom.CreateRequest(&CreateRequestInput{
+		Context: ctx,
+	})
+	if err != nil {
+		t.Fatalf("failed to CreateRequest: %s", must.MarshalJSONIndent(err, "", "	"))
+	}
+
+	organizationId := uuid.New()
+	userId := uuid.New()
+
+	var created []ServerSession
+
+	for _, sessionType := range []uint16{SessionTypeSaasServer, SessionTypeSaasServer, SessionTypeESOWhiteLabelServer} {
+		sess, err := dom.CreateServerSession(r, &CreateServerSessionInput{
+			OrganizationId: organizationId,
+			UserId:         userId,
+			SessionType:    sessionType,
+			TTL:            time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("failed to CreateServerSession: %s", must.MarshalJSONIndent(err, "", "	"))
+		}
+
+		created = append(created, sess)
+	}
+
+	listOut, err := dom.ListServerSessions(r, &ListServerSessionsInput{
+		OrganizationId: organizationId,
+		UserId:         userId,
+	})
+	if err != nil {
+		t.Fatalf("failed to ListServerSessions: %s", must.MarshalJSONIndent(err, "", "	"))
+	}
+
+	assert.Len(t, listOut.Sessions, 3)
+
+	for _, v := range listOut.Sessions {
+		assert.WithinDuration(t, time.Now().Add(time.Minute), v.ExpiresAt, 5*time.Second)
+		assert.False(t, v.Session.CreatedAt().IsZero())
+	}
+
+	// Deleting a single session updates the index.
+
+	if err := dom.DeleteServerSession(r, &DeleteServerSessionInput{
+		OrganizationId: organizationId,
+		Id:             created[0].Id(),
+		SessionType:    created[0].SessionType(),
+		UserId:         userId,
+	}); err != nil {
+		t.Fatalf("failed to DeleteServerSession: %s", must.MarshalJSONIndent(err, "", "	"))
+	}
+
+	// Deleting all sessions keeps the excepted ones.
+
+	deleteOut, err := dom.DeleteUserServerSessions(r, &DeleteUserServerSessionsInput{
+		OrganizationId: organizationId,
+		UserId:         userId,
+		ExceptIds:      []uuid.UUID{created[2].Id()},
+	})
+	if err != nil {
+		t.Fatalf("failed to DeleteUserServerSessions: %s", must.MarshalJSONIndent(err, "", "	"))
+	}
+
+	assert.Equal(t, 1, deleteOut.Deleted)
+
+	listOut, err = dom.ListServerSessions(r, &ListServerSessionsInput{
+		OrganizationId: organizationId,
+		UserId:         userId,
+	})
+	if err != nil {
+		t.Fatalf("failed to ListServerSessions: %s", must.MarshalJSONIndent(err, "", "	"))
+	}
+
+	if assert.Len(t, listOut.Sessions, 1) {
+		assert.Equal(t, created[2].Id(), listOut.Sessions[0].Session.Id())
+	}
+
+	_, err = dom.SelectServerSession(r, &SelectServerSessionInput{
+		OrganizationId: organizationId,
+		Id:             created[1].Id(),
+		SessionType:    created[1].SessionType(),
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, "e3e4ded9-9b1a-483c-a23b-60e743f95ab5", err.(*errors.Object).Id, "Error.Id mismatch")
+	}
+}
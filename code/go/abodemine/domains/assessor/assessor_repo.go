@@ -295,6 +295,7 @@ func (repo *repository) SelectAssessorRecord(r *arc.Request, in *SelectAssessorR
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -563,6 +564,7 @@ func (repo *repository) SelectAssessorRecord(r *arc.Request, in *SelectAssessorR
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
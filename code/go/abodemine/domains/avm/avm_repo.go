@@ -77,6 +77,7 @@ func (repo *repository) SelectSaleAvmRecord(r *arc.Request, in *SelectSaleAvmRec
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -137,6 +138,7 @@ func (repo *repository) SelectSaleAvmRecord(r *arc.Request, in *SelectSaleAvmRec
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
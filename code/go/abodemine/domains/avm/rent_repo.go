@@ -41,6 +41,7 @@ func (repo *repository) SelectRentalAvmRecord(r *arc.Request, in *SelectRentalAv
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -66,6 +67,7 @@ func (repo *repository) SelectRentalAvmRecord(r *arc.Request, in *SelectRentalAv
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
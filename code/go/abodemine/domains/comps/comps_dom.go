@@ -0,0 +1,395 @@
+package comps
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/flags"
+	"abodemine/lib/ptr"
+	"abodemine/lib/val"
+	"abodemine/projects/api/domains/auth"
+)
+
+const (
+	DefaultRadiusMiles = 1.0
+	DefaultMonths      = 12
+	DefaultCount       = 10
+
+	MaxRadiusMiles = 10.0
+	MaxMonths      = 60
+	MaxCount       = 50
+
+	// Maximum number of sales scored for a single subject.
+	maxCandidates = 500
+)
+
+// Weights of each characteristic in the similarity score.
+// Characteristics missing from either the subject or the comp
+// are left out and the remaining weights are normalized.
+const (
+	weightDistance   = 0.25
+	weightLivingArea = 0.25
+	weightRecency    = 0.15
+	weightBedrooms   = 0.10
+	weightBathrooms  = 0.10
+	weightYearBuilt  = 0.10
+	weightLotSize    = 0.05
+)
+
+// Price adjustment rates, as a fraction of the comp sale price.
+var (
+	// Applied to the comp price per square foot.
+	livingAreaAdjustmentRate = decimal.NewFromFloat(0.5)
+	bedroomAdjustmentRate    = decimal.NewFromFloat(0.02)
+	bathroomAdjustmentRate   = decimal.NewFromFloat(0.015)
+	yearBuiltAdjustmentRate  = decimal.NewFromFloat(0.0025)
+	// Applied to the relative lot size difference.
+	lotSizeAdjustmentRate = decimal.NewFromFloat(0.05)
+
+	// Year built and lot size adjustments are capped
+	// to this fraction of the comp sale price.
+	maxAttributeAdjustmentRate = decimal.NewFromFloat(0.1)
+)
+
+type Domain interface {
+	SelectComps(r *arc.Request, in *SelectCompsInput) (*SelectCompsOutput, error)
+}
+
+type domain struct {
+	repository Repository
+
+	authDomain auth.Domain
+}
+
+type NewDomainInput struct {
+	Repository Repository
+
+	AuthDomain auth.Domain
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+		authDomain: in.AuthDomain,
+	}
+}
+
+type SelectCompsInput struct {
+	Aupid *uuid.UUID
+
+	// Search radius around the subject. Defaults to DefaultRadiusMiles.
+	RadiusMiles float64
+
+	// Only sales in the last Months months are considered.
+	// Defaults to DefaultMonths.
+	Months int
+
+	// Maximum number of comps returned. Defaults to DefaultCount.
+	Count int
+}
+
+type SelectCompsOutput struct {
+	RadiusMiles float64
+	Months      int
+	Count       int
+
+	// Sorted by descending similarity score.
+	CompSaleEntities []*entities.CompSale
+}
+
+func (dom *domain) SelectComps(r *arc.Request, in *SelectCompsInput) (*SelectCompsOutput, error) {
+	if !r.HasFlag(flags.ApiCompsLayoutEnabled) {
+		return nil, &errors.Object{
+			Id:     "92d220c0-67be-436c-936c-96267f320f36",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Detail: "The comps layout is not enabled for this organization.",
+		}
+	}
+
+	if in == nil || in.Aupid == nil {
+		return nil, &errors.Object{
+			Id:     "c69e54af-5bdd-42a7-86ed-58ed438d0f08",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/aupid",
+			Detail: "Aupid is required.",
+		}
+	}
+
+	radius := val.Ternary(in.RadiusMiles == 0, DefaultRadiusMiles, in.RadiusMiles)
+	if radius < 0 || radius > MaxRadiusMiles {
+		return nil, &errors.Object{
+			Id:     "90927502-478b-41d2-96a2-86df1d385172",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/radius",
+			Detail: fmt.Sprintf("Radius must be between 0 and %.0f miles.", MaxRadiusMiles),
+		}
+	}
+
+	months := val.Ternary(in.Months == 0, DefaultMonths, in.Months)
+	if months < 0 || months > MaxMonths {
+		return nil, &errors.Object{
+			Id:     "b873ae27-50d7-43c4-b0e5-40feb8540deb",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/months",
+			Detail: fmt.Sprintf("Months must be between 1 and %d.", MaxMonths),
+		}
+	}
+
+	count := val.Ternary(in.Count == 0, DefaultCount, in.Count)
+	if count < 0 || count > MaxCount {
+		return nil, &errors.Object{
+			Id:     "5fb4d8ea-b196-4475-8691-1dbde841296b",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/count",
+			Detail: fmt.Sprintf("Count must be between 1 and %d.", MaxCount),
+		}
+	}
+
+	out := &SelectCompsOutput{
+		RadiusMiles: radius,
+		Months:      months,
+		Count:       count,
+	}
+
+	selectSubjectRecordOut, err := dom.repository.SelectSubjectRecord(r, &SelectSubjectRecordInput{
+		Aupid: in.Aupid,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "6bd1f145-3f05-4bf7-9ed2-f25997658674")
+	}
+
+	subject := selectSubjectRecordOut.Record
+	if subject == nil {
+		return out, nil
+	}
+
+	now := time.Now().UTC()
+	soldSince := now.AddDate(0, -months, 0)
+
+	selectCandidateRecordsOut, err := dom.repository.SelectCandidateRecords(r, &SelectCandidateRecordsInput{
+		Aupid:            in.Aupid,
+		PropertyUseGroup: subject.PropertyUseGroup,
+		RadiusMiles:      radius,
+		SoldSince:        soldSince,
+		Limit:            maxCandidates,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "f93ac5e6-38e7-4b21-b2ab-8787d6b500f2")
+	}
+
+	out.CompSaleEntities = rankComps(&rankCompsInput{
+		Subject:     &subject.Characteristics,
+		Candidates:  selectCandidateRecordsOut.Records,
+		RadiusMiles: radius,
+		Now:         now,
+		Window:      now.Sub(soldSince),
+		Count:       count,
+	})
+
+	_, err = dom.authDomain.InsertApiQuotaTransaction(r, &auth.InsertApiQuotaTransactionInput{
+		Entity: &arc.ApiQuotaTransaction{
+			Description:       ptr.String("/api/v3/comps"),
+			CompsLayoutAmount: int32(len(out.CompSaleEntities)),
+		},
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "ce734175-dea7-4c47-bf52-51ee9cc37f7d")
+	}
+
+	return out, nil
+}
+
+type rankCompsInput struct {
+	Subject     *Characteristics
+	Candidates  []*CandidateRecord
+	RadiusMiles float64
+	Now         time.Time
+	Window      time.Duration
+	Count       int
+}
+
+// rankComps keeps the most recent sale of each candidate property,
+// scores it against the subject and returns the best Count comps.
+func rankComps(in *rankCompsInput) []*entities.CompSale {
+	latest := make(map[uuid.UUID]*CandidateRecord)
+	var order []uuid.UUID
+
+	for _, candidate := range in.Candidates {
+		if candidate == nil || candidate.Aupid == nil || !candidate.SalePrice.IsPositive() {
+			continue
+		}
+
+		prev, ok := latest[*candidate.Aupid]
+		if !ok {
+			order = append(order, *candidate.Aupid)
+		}
+
+		// Recorded sales win over MLS sales of the same date.
+		if !ok ||
+			candidate.SaleDate.After(prev.SaleDate) ||
+			(candidate.SaleDate.Equal(prev.SaleDate) && candidate.Source == entities.CompSaleSourceRecorder) {
+			latest[*candidate.Aupid] = candidate
+		}
+	}
+
+	comps := make([]*entities.CompSale, 0, len(order))
+
+	for _, aupid := range order {
+		candidate := latest[aupid]
+		adjustments := adjustPrice(in.Subject, candidate)
+
+		comps = append(comps, &entities.CompSale{
+			Aupid:           candidate.Aupid,
+			Source:          candidate.Source,
+			SaleDate:        candidate.SaleDate,
+			SalePrice:       candidate.SalePrice,
+			DistanceMiles:   candidate.DistanceMiles,
+			LivingAreaSqFt:  candidate.LivingAreaSqFt,
+			Bedrooms:        candidate.Bedrooms,
+			Bathrooms:       candidate.Bathrooms,
+			YearBuilt:       candidate.YearBuilt,
+			LotSizeSqFt:     candidate.LotSizeSqFt,
+			SimilarityScore: scoreComp(in, candidate),
+			Adjustments:     adjustments,
+			AdjustedPrice:   candidate.SalePrice.Add(adjustments.Total),
+		})
+	}
+
+	sort.SliceStable(comps, func(i, j int) bool {
+		if comps[i].SimilarityScore != comps[j].SimilarityScore {
+			return comps[i].SimilarityScore > comps[j].SimilarityScore
+		}
+
+		return comps[i].DistanceMiles < comps[j].DistanceMiles
+	})
+
+	if len(comps) > in.Count {
+		comps = comps[:in.Count]
+	}
+
+	return comps
+}
+
+// scoreComp returns the weighted similarity, between 0 and 1,
+// of a candidate sale to the subject property.
+func scoreComp(in *rankCompsInput, candidate *CandidateRecord) float64 {
+	var score, weights float64
+
+	add := func(weight, similarity float64) {
+		score += weight * math.Max(0, math.Min(1, similarity))
+		weights += weight
+	}
+
+	if in.RadiusMiles > 0 {
+		add(weightDistance, 1-candidate.DistanceMiles/in.RadiusMiles)
+	}
+
+	if in.Window > 0 {
+		add(weightRecency, 1-float64(in.Now.Sub(candidate.SaleDate))/float64(in.Window))
+	}
+
+	subject := in.Subject
+
+	if subject.LivingAreaSqFt != nil && candidate.LivingAreaSqFt != nil && *subject.LivingAreaSqFt > 0 {
+		add(weightLivingArea, 1-relativeDiff(float64(*candidate.LivingAreaSqFt), float64(*subject.LivingAreaSqFt)))
+	}
+
+	if subject.Bedrooms != nil && candidate.Bedrooms != nil {
+		add(weightBedrooms, 1-math.Abs(float64(*candidate.Bedrooms-*subject.Bedrooms))/3)
+	}
+
+	if subject.Bathrooms != nil && candidate.Bathrooms != nil {
+		add(weightBathrooms, 1-candidate.Bathrooms.Sub(*subject.Bathrooms).Abs().InexactFloat64()/3)
+	}
+
+	if subject.YearBuilt != nil && candidate.YearBuilt != nil {
+		add(weightYearBuilt, 1-math.Abs(float64(*candidate.YearBuilt-*subject.YearBuilt))/50)
+	}
+
+	if subject.LotSizeSqFt != nil && candidate.LotSizeSqFt != nil && subject.LotSizeSqFt.IsPositive() {
+		add(weightLotSize, 1-relativeDiff(candidate.LotSizeSqFt.InexactFloat64(), subject.LotSizeSqFt.InexactFloat64()))
+	}
+
+	if weights == 0 {
+		return 0
+	}
+
+	return math.Round(score/weights*10000) / 10000
+}
+
+// adjustPrice computes the amounts to add to the candidate sale price
+// to account for its differences from the subject. A comp smaller than
+// the subject gets a positive adjustment and vice versa.
+func adjustPrice(subject *Characteristics, candidate *CandidateRecord) *entities.CompAdjustments {
+	adjustments := &entities.CompAdjustments{}
+	price := candidate.SalePrice
+	maxAdjustment := price.Mul(maxAttributeAdjustmentRate)
+
+	if subject.LivingAreaSqFt != nil && candidate.LivingAreaSqFt != nil && *candidate.LivingAreaSqFt > 0 {
+		pricePerSqFt := price.Div(decimal.NewFromInt(int64(*candidate.LivingAreaSqFt)))
+		diff := decimal.NewFromInt(int64(*subject.LivingAreaSqFt - *candidate.LivingAreaSqFt))
+		adjustments.LivingArea = diff.Mul(pricePerSqFt).Mul(livingAreaAdjustmentRate)
+	}
+
+	if subject.Bedrooms != nil && candidate.Bedrooms != nil {
+		diff := decimal.NewFromInt(int64(*subject.Bedrooms - *candidate.Bedrooms))
+		adjustments.Bedrooms = diff.Mul(price).Mul(bedroomAdjustmentRate)
+	}
+
+	if subject.Bathrooms != nil && candidate.Bathrooms != nil {
+		diff := subject.Bathrooms.Sub(*candidate.Bathrooms)
+		adjustments.Bathrooms = diff.Mul(price).Mul(bathroomAdjustmentRate)
+	}
+
+	if subject.YearBuilt != nil && candidate.YearBuilt != nil {
+		diff := decimal.NewFromInt(int64(*subject.YearBuilt - *candidate.YearBuilt))
+		adjustments.YearBuilt = clamp(diff.Mul(price).Mul(yearBuiltAdjustmentRate), maxAdjustment)
+	}
+
+	if subject.LotSizeSqFt != nil && candidate.LotSizeSqFt != nil && candidate.LotSizeSqFt.IsPositive() {
+		diff := subject.LotSizeSqFt.Sub(*candidate.LotSizeSqFt).Div(*candidate.LotSizeSqFt)
+		adjustments.LotSize = clamp(diff.Mul(price).Mul(lotSizeAdjustmentRate), maxAdjustment)
+	}
+
+	adjustments.LivingArea = adjustments.LivingArea.Round(0)
+	adjustments.Bedrooms = adjustments.Bedrooms.Round(0)
+	adjustments.Bathrooms = adjustments.Bathrooms.Round(0)
+	adjustments.YearBuilt = adjustments.YearBuilt.Round(0)
+	adjustments.LotSize = adjustments.LotSize.Round(0)
+
+	adjustments.Total = adjustments.LivingArea.
+		Add(adjustments.Bedrooms).
+		Add(adjustments.Bathrooms).
+		Add(adjustments.YearBuilt).
+		Add(adjustments.LotSize)
+
+	return adjustments
+}
+
+func relativeDiff(v, ref float64) float64 {
+	return math.Abs(v-ref) / ref
+}
+
+func clamp(v, limit decimal.Decimal) decimal.Decimal {
+	if v.GreaterThan(limit) {
+		return limit
+	}
+
+	if v.LessThan(limit.Neg()) {
+		return limit.Neg()
+	}
+
+	return v
+}
@@ -0,0 +1,120 @@
+package comps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"abodemine/entities"
+	"abodemine/lib/val"
+)
+
+func TestRankComps(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	similar := uuid.New()
+	distant := uuid.New()
+	larger := uuid.New()
+
+	subject := &Characteristics{
+		LivingAreaSqFt: val.PtrRef(2000),
+		Bedrooms:       val.PtrRef(3),
+		Bathrooms:      val.PtrRef(decimal.NewFromInt(2)),
+		YearBuilt:      val.PtrRef(1990),
+		LotSizeSqFt:    val.PtrRef(decimal.NewFromInt(6000)),
+	}
+
+	candidate := func(aupid uuid.UUID, source string, saleDate time.Time, distance float64, area int) *CandidateRecord {
+		return &CandidateRecord{
+			Characteristics: Characteristics{
+				LivingAreaSqFt: val.PtrRef(area),
+				Bedrooms:       val.PtrRef(3),
+				Bathrooms:      val.PtrRef(decimal.NewFromInt(2)),
+				YearBuilt:      val.PtrRef(1990),
+				LotSizeSqFt:    val.PtrRef(decimal.NewFromInt(6000)),
+			},
+			Aupid:         &aupid,
+			Source:        source,
+			SaleDate:      saleDate,
+			SalePrice:     decimal.NewFromInt(400000),
+			DistanceMiles: distance,
+		}
+	}
+
+	comps := rankComps(&rankCompsInput{
+		Subject: subject,
+		Candidates: []*CandidateRecord{
+			candidate(distant, entities.CompSaleSourceRecorder, now.AddDate(0, -1, 0), 0.9, 2000),
+			// Older sale of the same property, dropped.
+			candidate(similar, entities.CompSaleSourceRecorder, now.AddDate(0, -10, 0), 0.1, 2000),
+			candidate(similar, entities.CompSaleSourceListing, now.AddDate(0, -1, 0), 0.1, 2000),
+			// Same date as the MLS sale, the recorded sale wins.
+			candidate(similar, entities.CompSaleSourceRecorder, now.AddDate(0, -1, 0), 0.1, 2000),
+			candidate(larger, entities.CompSaleSourceRecorder, now.AddDate(0, -1, 0), 0.1, 2500),
+		},
+		RadiusMiles: 1,
+		Now:         now,
+		Window:      now.Sub(now.AddDate(-1, 0, 0)),
+		Count:       2,
+	})
+
+	if assert.Len(t, comps, 2) {
+		assert.Equal(t, similar, *comps[0].Aupid)
+		assert.Equal(t, entities.CompSaleSourceRecorder, comps[0].Source)
+		assert.True(t, comps[0].Adjustments.Total.IsZero())
+		assert.True(t, comps[0].AdjustedPrice.Equal(decimal.NewFromInt(400000)))
+
+		assert.Equal(t, larger, *comps[1].Aupid)
+		assert.Greater(t, comps[0].SimilarityScore, comps[1].SimilarityScore)
+	}
+}
+
+func TestAdjustPrice(t *testing.T) {
+	subject := &Characteristics{
+		LivingAreaSqFt: val.PtrRef(2000),
+		Bedrooms:       val.PtrRef(4),
+		Bathrooms:      val.PtrRef(decimal.NewFromInt(3)),
+		YearBuilt:      val.PtrRef(2000),
+		LotSizeSqFt:    val.PtrRef(decimal.NewFromInt(12000)),
+	}
+
+	adjustments := adjustPrice(subject, &CandidateRecord{
+		Characteristics: Characteristics{
+			LivingAreaSqFt: val.PtrRef(1600),
+			Bedrooms:       val.PtrRef(3),
+			Bathrooms:      val.PtrRef(decimal.NewFromInt(2)),
+			YearBuilt:      val.PtrRef(1900),
+			LotSizeSqFt:    val.PtrRef(decimal.NewFromInt(6000)),
+		},
+		SalePrice: decimal.NewFromInt(400000),
+	})
+
+	// 400 sqft at half of 250 per sqft.
+	assert.Equal(t, "50000", adjustments.LivingArea.String())
+	assert.Equal(t, "8000", adjustments.Bedrooms.String())
+	assert.Equal(t, "6000", adjustments.Bathrooms.String())
+	// Capped to 10% of the sale price.
+	assert.Equal(t, "40000", adjustments.YearBuilt.String())
+	assert.Equal(t, "20000", adjustments.LotSize.String())
+	assert.Equal(t, "124000", adjustments.Total.String())
+}
+
+func TestScoreCompMissingCharacteristics(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	score := scoreComp(&rankCompsInput{
+		Subject:     &Characteristics{},
+		RadiusMiles: 1,
+		Now:         now,
+		Window:      365 * 24 * time.Hour,
+	}, &CandidateRecord{
+		SaleDate:      now,
+		DistanceMiles: 0,
+	})
+
+	// Only distance and recency are scored.
+	assert.Equal(t, 1.0, score)
+}
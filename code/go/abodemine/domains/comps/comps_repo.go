@@ -0,0 +1,250 @@
+package comps
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+)
+
+const metersPerMile = 1609.344
+
+type Repository interface {
+	SelectSubjectRecord(r *arc.Request, in *SelectSubjectRecordInput) (*SelectSubjectRecordOutput, error)
+	SelectCandidateRecords(r *arc.Request, in *SelectCandidateRecordsInput) (*SelectCandidateRecordsOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// Characteristics are the attributes used to score
+// the similarity between a subject and its comps.
+type Characteristics struct {
+	LivingAreaSqFt *int
+	Bedrooms       *int
+	Bathrooms      *decimal.Decimal
+	YearBuilt      *int
+	LotSizeSqFt    *decimal.Decimal
+}
+
+type SubjectRecord struct {
+	Characteristics
+
+	PropertyUseGroup *string
+}
+
+type SelectSubjectRecordInput struct {
+	Aupid *uuid.UUID
+}
+
+type SelectSubjectRecordOutput struct {
+	Record *SubjectRecord
+}
+
+func (repo *repository) SelectSubjectRecord(r *arc.Request, in *SelectSubjectRecordInput) (*SelectSubjectRecordOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"ad_df_assessor.area_building",
+			"ad_df_assessor.bedrooms_count",
+			"ad_df_assessor.bath_count",
+			"ad_df_assessor.year_built",
+			"ad_df_assessor.area_lot_sf",
+			"ad_df_assessor.property_use_group",
+		).
+		From("properties").
+		LeftJoin("ad_df_assessor on properties.ad_attom_id = ad_df_assessor.attomid").
+		Where("properties.id = ?", in.Aupid).
+		Where("properties.location is not null")
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "7d16eb4f-1484-423b-ab4c-f262d8843ca4",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresDatapipe, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "6bbf176e-2d92-42dd-837a-a5843442eb9f")
+	}
+
+	record := &SubjectRecord{}
+
+	if err := row.Scan(
+		&record.LivingAreaSqFt,
+		&record.Bedrooms,
+		&record.Bathrooms,
+		&record.YearBuilt,
+		&record.LotSizeSqFt,
+		&record.PropertyUseGroup,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &SelectSubjectRecordOutput{}, nil
+		}
+
+		return nil, &errors.Object{
+			Id:     "b1824044-1c40-4573-b909-f690209e4ebe",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to select row.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out := &SelectSubjectRecordOutput{
+		Record: record,
+	}
+
+	return out, nil
+}
+
+type CandidateRecord struct {
+	Characteristics
+
+	Aupid         *uuid.UUID
+	Source        string
+	SaleDate      time.Time
+	SalePrice     decimal.Decimal
+	DistanceMiles float64
+}
+
+type SelectCandidateRecordsInput struct {
+	Aupid            *uuid.UUID
+	PropertyUseGroup *string
+	RadiusMiles      float64
+	SoldSince        time.Time
+	Limit            uint64
+}
+
+type SelectCandidateRecordsOutput struct {
+	Records []*CandidateRecord
+}
+
+// SelectCandidateRecords returns the arm's-length sales, both recorded
+// and MLS sold, of the properties around the subject property.
+// A property may be returned once per sale.
+func (repo *repository) SelectCandidateRecords(r *arc.Request, in *SelectCandidateRecordsInput) (*SelectCandidateRecordsOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"properties.id",
+			"sales.source",
+			"sales.sale_date",
+			"sales.sale_price",
+		).
+		Column("st_distance(properties.location::geography, subject.location::geography) / ?", metersPerMile).
+		Columns(
+			"ad_df_assessor.area_building",
+			"ad_df_assessor.bedrooms_count",
+			"ad_df_assessor.bath_count",
+			"ad_df_assessor.year_built",
+			"ad_df_assessor.area_lot_sf",
+		).
+		Prefix("with subject as (select location from properties where id = ?)", in.Aupid).
+		From("subject").
+		Join("properties on st_dwithin(properties.location::geography, subject.location::geography, ?)", in.RadiusMiles*metersPerMile).
+		Join("ad_df_assessor on properties.ad_attom_id = ad_df_assessor.attomid").
+		JoinClause(
+			`join lateral (
+				select ? as source, ad_df_recorder.recording_date as sale_date, ad_df_recorder.transfer_amount as sale_price
+				from ad_df_recorder
+				where
+					ad_df_recorder.attomid = properties.ad_attom_id
+					and ad_df_recorder.arms_length_flag = 1
+					and ad_df_recorder.transfer_amount > 0
+					and ad_df_recorder.recording_date >= ?
+				union all
+				select ? as source, ad_df_listing.mls_sold_date as sale_date, ad_df_listing.mls_sold_price::numeric as sale_price
+				from ad_df_listing
+				where
+					ad_df_listing.attom_id = properties.ad_attom_id
+					and ad_df_listing.mls_sold_price > 0
+					and ad_df_listing.mls_sold_date >= ?
+			) sales on true`,
+			entities.CompSaleSourceRecorder,
+			in.SoldSince,
+			entities.CompSaleSourceListing,
+			in.SoldSince,
+		).
+		Where("properties.id <> ?", in.Aupid).
+		OrderBy("5").
+		Limit(in.Limit)
+
+	if in.PropertyUseGroup != nil {
+		builder = builder.Where("ad_df_assessor.property_use_group = ?", *in.PropertyUseGroup)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "420d0b9f-5e27-4851-ae48-0fda8a2aa3b5",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresDatapipe, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "1d75163e-8403-4440-8972-062aa76a6e9d")
+	}
+	defer rows.Close()
+
+	out := &SelectCandidateRecordsOutput{}
+
+	for rows.Next() {
+		record := &CandidateRecord{}
+
+		if err := rows.Scan(
+			&record.Aupid,
+			&record.Source,
+			&record.SaleDate,
+			&record.SalePrice,
+			&record.DistanceMiles,
+			&record.LivingAreaSqFt,
+			&record.Bedrooms,
+			&record.Bathrooms,
+			&record.YearBuilt,
+			&record.LotSizeSqFt,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "ac5289fb-8e35-4566-9f83-865e9349069e",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to select row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "543a277e-13f3-48a0-8769-f63bd397b11a",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to iterate rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
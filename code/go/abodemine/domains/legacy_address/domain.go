@@ -99,6 +99,7 @@ func (dom *domain) SearchByAddress(r *arc.Request, in *SearchRecordInput, index
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to search by address",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -44,6 +44,7 @@ func (repo *repository) SearchByAddress(r *arc.Request, input *SearchRecordInput
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Error marshalling OpenSearch query",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -62,6 +63,7 @@ func (repo *repository) SearchByAddress(r *arc.Request, input *SearchRecordInput
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Error searching OpenSearch",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer res.Body.Close()
@@ -83,6 +85,7 @@ func (repo *repository) SearchByAddress(r *arc.Request, input *SearchRecordInput
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Error decoding OpenSearch response",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -44,6 +44,7 @@ func (rep *repository) SearchMlsListings(r *arc.Request, in *SearchListingsInput
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -331,10 +332,11 @@ func (rep *repository) SearchMlsListings(r *arc.Request, in *SearchListingsInput
 		)
 		if err != nil {
 			return nil, &errors.Object{
-				Id:     "5a6b7c8d-9e0f-1g2h-3i4j-5k6l7m8n9o0p",
+				Id:     "c1d90b60-7c58-4602-ad7f-b2ed14037f35",
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to scan property row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -347,6 +349,7 @@ func (rep *repository) SearchMlsListings(r *arc.Request, in *SearchListingsInput
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Error iterating through result rows.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1162,6 +1165,7 @@ func (repo *repository) SelectListingRecord(r *arc.Request, in *SelectListingRec
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1458,6 +1462,7 @@ func (repo *repository) SelectListingRecord(r *arc.Request, in *SelectListingRec
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -1487,6 +1492,7 @@ func (rep *repository) GetLatLonFromAupid(r *arc.Request, aupid string) (float64
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1506,6 +1512,7 @@ func (rep *repository) GetLatLonFromAupid(r *arc.Request, aupid string) (float64
 				Code:   errors.Code_NOT_FOUND,
 				Detail: "Aupid not found.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -1514,6 +1521,7 @@ func (rep *repository) GetLatLonFromAupid(r *arc.Request, aupid string) (float64
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to scan row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1540,6 +1548,7 @@ func (rep *repository) GetLatLonFromAddressId(r *arc.Request, addressId uuid.UUI
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1550,6 +1559,7 @@ func (rep *repository) GetLatLonFromAddressId(r *arc.Request, addressId uuid.UUI
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to query row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1564,6 +1574,7 @@ func (rep *repository) GetLatLonFromAddressId(r *arc.Request, addressId uuid.UUI
 				Code:   errors.Code_NOT_FOUND,
 				Detail: "Address not found.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -1572,6 +1583,7 @@ func (rep *repository) GetLatLonFromAddressId(r *arc.Request, addressId uuid.UUI
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to scan row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -0,0 +1,284 @@
+package market
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/flags"
+	"abodemine/lib/geom"
+	"abodemine/lib/ptr"
+	"abodemine/lib/val"
+	"abodemine/projects/api/domains/auth"
+)
+
+const (
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+
+	listingStatusSold = "Sold"
+
+	maxZip5Codes   = 100
+	maxDateRange   = 5 * 366 * 24 * time.Hour
+	maxPolygonArea = 30000.0 // maximum area of polygon in square miles
+)
+
+var (
+	// Statuses of the listings counted in the inventory.
+	listingStatusesOpen = []string{"Active", "Pending"}
+
+	fipsRegexp = regexp.MustCompile(`^[0-9]{5}$`)
+	zip5Regexp = regexp.MustCompile(`^[0-9]{5}$`)
+)
+
+type Domain interface {
+	SelectMarketStats(r *arc.Request, in *SelectMarketStatsInput) (*SelectMarketStatsOutput, error)
+}
+
+type domain struct {
+	repository Repository
+
+	authDomain auth.Domain
+}
+
+type NewDomainInput struct {
+	Repository Repository
+
+	AuthDomain auth.Domain
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+		authDomain: in.AuthDomain,
+	}
+}
+
+type SelectMarketStatsInput struct {
+	// Interval is one of IntervalWeek or IntervalMonth.
+	// Defaults to IntervalMonth.
+	Interval string
+
+	// Dates in the YYYY-MM-DD format. Both are inclusive.
+	StartDate string
+	EndDate   string
+
+	// Exactly one market area must be provided.
+	Zip5Codes []string
+	Fips      string
+	Polygon   []geom.Point
+}
+
+type SelectMarketStatsOutput struct {
+	Interval  string
+	StartDate time.Time
+	EndDate   time.Time
+
+	MarketStatsEntities []*entities.MarketStats
+}
+
+func (dom *domain) SelectMarketStats(r *arc.Request, in *SelectMarketStatsInput) (*SelectMarketStatsOutput, error) {
+	if !r.HasFlag(flags.ApiMarketStatsLayoutEnabled) {
+		return nil, &errors.Object{
+			Id:     "26dac94d-7087-49dc-bc9a-7bf2131d7c86",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Detail: "The marketStats layout is not enabled for this organization.",
+		}
+	}
+
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "314df57f-9d95-47aa-8c1d-ca9ef06a7efa",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	interval := strings.ToLower(strings.TrimSpace(in.Interval))
+
+	switch interval {
+	case "":
+		interval = IntervalMonth
+	case IntervalWeek, IntervalMonth:
+	default:
+		return nil, &errors.Object{
+			Id:     "158cf44e-3b9d-49b7-bcb8-7397631f726b",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/interval",
+			Detail: fmt.Sprintf("Invalid interval: %s. Expected one of: %s, %s.", in.Interval, IntervalWeek, IntervalMonth),
+		}
+	}
+
+	startDate, err := time.Parse(time.DateOnly, in.StartDate)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "e0cd7bdf-361e-4a12-8ab6-a6772feb8fb5",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/startDate",
+			Detail: "Invalid start date format. Expected format: YYYY-MM-DD.",
+		}
+	}
+
+	endDate, err := time.Parse(time.DateOnly, in.EndDate)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "6db5336c-c235-49ee-9038-49c5546a3f67",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/endDate",
+			Detail: "Invalid end date format. Expected format: YYYY-MM-DD.",
+		}
+	}
+
+	if startDate.After(endDate) {
+		return nil, &errors.Object{
+			Id:     "b5c915d1-68ec-4c88-80bf-d657146ac86e",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/startDate",
+			Detail: "Start date cannot be after end date.",
+		}
+	}
+
+	if endDate.Sub(startDate) > maxDateRange {
+		return nil, &errors.Object{
+			Id:     "7eda426f-6b41-4fa4-a224-590e705fd6f1",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/endDate",
+			Detail: "The date range cannot exceed 5 years.",
+		}
+	}
+
+	if err := validateMarketArea(in); err != nil {
+		return nil, errors.Forward(err, "aef670a4-8e60-4e05-9886-c7a4f27ef210")
+	}
+
+	selectMarketStatsRecordsOut, err := dom.repository.SelectMarketStatsRecords(r, &SelectMarketStatsRecordsInput{
+		Interval:  interval,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Zip5Codes: in.Zip5Codes,
+		Fips:      in.Fips,
+		Polygon:   in.Polygon,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "817ed68d-9ada-4991-bbfc-26ce206c0b33")
+	}
+
+	records := selectMarketStatsRecordsOut.Records
+
+	for _, record := range records {
+		record.PeriodEnd = periodEnd(interval, record.PeriodStart)
+	}
+
+	// Queries without any period are charged too, as they cost as much.
+	_, err = dom.authDomain.InsertApiQuotaTransaction(r, &auth.InsertApiQuotaTransactionInput{
+		Entity: &arc.ApiQuotaTransaction{
+			Description:             ptr.String("/api/v3/market-stats"),
+			MarketStatsLayoutAmount: max(int32(len(records)), 1),
+		},
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "76ece0ff-206c-4273-a7f8-408c06a6c6fc")
+	}
+
+	out := &SelectMarketStatsOutput{
+		Interval:            interval,
+		StartDate:           startDate,
+		EndDate:             endDate,
+		MarketStatsEntities: records,
+	}
+
+	return out, nil
+}
+
+func validateMarketArea(in *SelectMarketStatsInput) error {
+	var areas int
+
+	if len(in.Zip5Codes) > 0 {
+		areas++
+	}
+
+	if in.Fips != "" {
+		areas++
+	}
+
+	if len(in.Polygon) > 0 {
+		areas++
+	}
+
+	if areas != 1 {
+		return &errors.Object{
+			Id:     "d3850f71-a34d-477e-b666-3ca160b1f3d3",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Exactly one of the following market areas is required: zip5Codes, fips, geoPolygon.",
+		}
+	}
+
+	if len(in.Zip5Codes) > maxZip5Codes {
+		return &errors.Object{
+			Id:     "4116c93a-8a89-4060-8d71-0d0efef583a3",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/zip5Codes",
+			Detail: fmt.Sprintf("At most %d zip5 codes are allowed.", maxZip5Codes),
+		}
+	}
+
+	for i, zip5 := range in.Zip5Codes {
+		if !zip5Regexp.MatchString(zip5) {
+			return &errors.Object{
+				Id:     "63c72efd-3791-4be7-9ec2-68104ff2dcfc",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Path:   fmt.Sprintf("/zip5Codes/%d", i),
+				Detail: fmt.Sprintf("Invalid zip5 code: %s.", zip5),
+			}
+		}
+	}
+
+	if in.Fips != "" && !fipsRegexp.MatchString(in.Fips) {
+		return &errors.Object{
+			Id:     "6b07d29d-6c08-477b-9ff0-07c81c3c9a1f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Path:   "/fips",
+			Detail: "Invalid fips code. Expected a 5-digit county FIPS code.",
+		}
+	}
+
+	if len(in.Polygon) > 0 {
+		if len(in.Polygon) < 3 {
+			return &errors.Object{
+				Id:     "8824f35a-8d33-4889-b156-58bde51c9dda",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Path:   "/geoPolygon/points",
+				Detail: "Polygon must have at least 3 points.",
+			}
+		}
+
+		if area := geom.CalculatePolygonArea(in.Polygon); area > maxPolygonArea {
+			return &errors.Object{
+				Id:     "81b99c83-1c44-43c6-8769-9149987cebf4",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Path:   "/geoPolygon/points",
+				Detail: fmt.Sprintf("Polygon area exceeds %f square miles.", maxPolygonArea),
+			}
+		}
+	}
+
+	return nil
+}
+
+// periodEnd returns the last (inclusive) day of the bucket
+// that starts at the given date.
+func periodEnd(interval string, start time.Time) time.Time {
+	if interval == IntervalWeek {
+		return start.AddDate(0, 0, 6)
+	}
+
+	return start.AddDate(0, 1, -1)
+}
@@ -0,0 +1,165 @@
+package market
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/lib/geom"
+	"abodemine/projects/api/domains/auth"
+)
+
+func TestValidateMarketArea(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *SelectMarketStatsInput
+		errorId string
+	}{
+		{
+			name:    "Missing Area",
+			in:      &SelectMarketStatsInput{},
+			errorId: "d3850f71-a34d-477e-b666-3ca160b1f3d3",
+		},
+		{
+			name: "Multiple Areas",
+			in: &SelectMarketStatsInput{
+				Zip5Codes: []string{"97211"},
+				Fips:      "41051",
+			},
+			errorId: "d3850f71-a34d-477e-b666-3ca160b1f3d3",
+		},
+		{
+			name: "Invalid Zip5",
+			in: &SelectMarketStatsInput{
+				Zip5Codes: []string{"97211", "9721"},
+			},
+			errorId: "63c72efd-3791-4be7-9ec2-68104ff2dcfc",
+		},
+		{
+			name: "Invalid Fips",
+			in: &SelectMarketStatsInput{
+				Fips: "4105",
+			},
+			errorId: "6b07d29d-6c08-477b-9ff0-07c81c3c9a1f",
+		},
+		{
+			name: "Polygon Too Small",
+			in: &SelectMarketStatsInput{
+				Polygon: []geom.Point{{Lat: 1, Lon: 1}, {Lat: 2, Lon: 2}},
+			},
+			errorId: "8824f35a-8d33-4889-b156-58bde51c9dda",
+		},
+		{
+			name: "Zip5 Codes",
+			in: &SelectMarketStatsInput{
+				Zip5Codes: []string{"97211", "97212"},
+			},
+		},
+		{
+			name: "Fips",
+			in: &SelectMarketStatsInput{
+				Fips: "41051",
+			},
+		},
+		{
+			name: "Polygon",
+			in: &SelectMarketStatsInput{
+				Polygon: []geom.Point{
+					{Lat: 42.151677, Lon: -88.279575},
+					{Lat: 42.150677, Lon: -88.281268},
+					{Lat: 42.149691, Lon: -88.278443},
+					{Lat: 42.151677, Lon: -88.279575},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMarketArea(tt.in)
+			if tt.errorId == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.errorId, err.(*errors.Object).Id)
+				assert.Equal(t, errors.Code_INVALID_ARGUMENT, err.(*errors.Object).Code)
+			}
+		})
+	}
+}
+
+func TestPeriodEnd(t *testing.T) {
+	start := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), periodEnd(IntervalMonth, start))
+	assert.Equal(t, time.Date(2025, 2, 7, 0, 0, 0, 0, time.UTC), periodEnd(IntervalWeek, start))
+}
+
+func TestPolygonWKT(t *testing.T) {
+	points := []geom.Point{
+		{Lat: 1, Lon: 2},
+		{Lat: 3, Lon: 4},
+		{Lat: 5, Lon: 6},
+	}
+
+	assert.Equal(
+		t,
+		"POLYGON((2.000000 1.000000, 4.000000 3.000000, 6.000000 5.000000, 2.000000 1.000000))",
+		polygonWKT(points),
+	)
+}
+
+// fakeRepository returns no market stats.
+type fakeRepository struct{}
+
+func (repo *fakeRepository) SelectMarketStatsRecords(r *arc.Request, in *SelectMarketStatsRecordsInput) (*SelectMarketStatsRecordsOutput, error) {
+	return &SelectMarketStatsRecordsOutput{}, nil
+}
+
+// fakeAuthDomain records the quota transactions.
+type fakeAuthDomain struct {
+	auth.Domain
+
+	transactions []*arc.ApiQuotaTransaction
+}
+
+func (dom *fakeAuthDomain) InsertApiQuotaTransaction(r *arc.Request, in *auth.InsertApiQuotaTransactionInput) (*auth.InsertApiQuotaTransactionOutput, error) {
+	dom.transactions = append(dom.transactions, in.Entity)
+
+	return &auth.InsertApiQuotaTransactionOutput{}, nil
+}
+
+func TestDomain_SelectMarketStats_Charge(t *testing.T) {
+	r, err := arc.NewDomain(&arc.NewDomainInput{}).CreateRequest(&arc.CreateRequestInput{
+		Id:      uuid.New(),
+		Context: context.Background(),
+		Flags:   []string{"API_MARKET_STATS_LAYOUT_ENABLED"},
+	})
+	require.NoError(t, err)
+
+	authDomain := new(fakeAuthDomain)
+	dom := NewDomain(&NewDomainInput{
+		Repository: new(fakeRepository),
+		AuthDomain: authDomain,
+	})
+
+	out, err := dom.SelectMarketStats(r, &SelectMarketStatsInput{
+		StartDate: "2025-01-01",
+		EndDate:   "2025-03-31",
+		Fips:      "41051",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, out.MarketStatsEntities)
+
+	// Empty results are charged one unit.
+	require.Len(t, authDomain.transactions, 1)
+	assert.Equal(t, int32(1), authDomain.transactions[0].MarketStatsLayoutAmount)
+}
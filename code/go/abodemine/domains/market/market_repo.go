@@ -0,0 +1,252 @@
+package market
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+	"abodemine/lib/geom"
+)
+
+type Repository interface {
+	SelectMarketStatsRecords(r *arc.Request, in *SelectMarketStatsRecordsInput) (*SelectMarketStatsRecordsOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+type SelectMarketStatsRecordsInput struct {
+	Interval  string
+	StartDate time.Time
+	EndDate   time.Time
+
+	Zip5Codes []string
+	Fips      string
+	Polygon   []geom.Point
+}
+
+type SelectMarketStatsRecordsOutput struct {
+	Records []*entities.MarketStats
+}
+
+func (repo *repository) SelectMarketStatsRecords(r *arc.Request, in *SelectMarketStatsRecordsInput) (*SelectMarketStatsRecordsOutput, error) {
+	// The whole status change history of the area, as the
+	// inventory of a period includes the listings opened
+	// before the start date.
+	listings := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Question).
+		Select(
+			"ad_df_listing.attom_id",
+			"ad_df_listing.mls_listing_id",
+			"ad_df_listing.listing_status",
+			"ad_df_listing.status_change_date",
+			"ad_df_listing.am_updated_at",
+			"ad_df_listing.latest_listing_price",
+			"ad_df_listing.living_area_square_feet",
+			"ad_df_listing.days_on_market",
+			"ad_df_listing.mls_sold_price",
+		).
+		Column(squirrel.Expr("date_trunc(?, ad_df_listing.status_change_date)::date as period_start", in.Interval)).
+		From("ad_df_listing").
+		// The end date is inclusive.
+		Where("ad_df_listing.status_change_date < ?", in.EndDate.AddDate(0, 0, 1))
+
+	switch {
+	case len(in.Zip5Codes) > 0:
+		listings = listings.Where(
+			"exists (select 1 from properties join addresses on addresses.id = properties.address_id where properties.ad_attom_id = ad_df_listing.attom_id and addresses.zip5 = any(?))",
+			in.Zip5Codes,
+		)
+	case in.Fips != "":
+		listings = listings.Where(
+			"exists (select 1 from properties where properties.ad_attom_id = ad_df_listing.attom_id and properties.fips = ?)",
+			in.Fips,
+		)
+	case len(in.Polygon) > 0:
+		listings = listings.Where(
+			"exists (select 1 from ad_geom where ad_geom.attom_id = ad_df_listing.attom_id and ST_Within(ad_geom.location_3857, ST_Transform(ST_GeomFromText(?, 4326), 3857)))",
+			polygonWKT(in.Polygon),
+		)
+	default:
+		return nil, &errors.Object{
+			Id:     "082e9426-8543-42cb-bb14-2921b62ee0b8",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing market area.",
+		}
+	}
+
+	listingsSql, listingsArgs, err := listings.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "691647a4-c652-42f0-9e24-1fd574ef1624",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	step := "1 " + in.Interval
+
+	prefixArgs := append(listingsArgs,
+		// periods
+		step, in.Interval, in.StartDate, in.EndDate, step,
+		// events
+		in.StartDate,
+	)
+
+	// A listing is keyed by its property and MLS listing id, so
+	// the relistings of a property count separately.
+	prefix := `with listings as (` + listingsSql + `),
+		periods as (
+			select period::date as period_start, (period + ?::interval)::date as period_end
+			from generate_series(date_trunc(?, ?::timestamp), ?::timestamp, ?::interval) as period
+		),
+		events as (
+			select distinct on (l.period_start, l.attom_id, l.mls_listing_id) l.*
+			from listings l
+			where l.status_change_date >= ?
+			order by l.period_start, l.attom_id, l.mls_listing_id, l.status_change_date desc, l.am_updated_at desc
+		),
+		activity as (
+			select
+				e.period_start,
+				count(*) as listing_count,
+				` + fmt.Sprintf("count(*) filter (where e.listing_status = '%s')", listingStatusSold) + ` as sold_count,
+				percentile_cont(0.5) within group (order by e.latest_listing_price)::numeric(14, 2) as median_list_price,
+				percentile_cont(0.5) within group (order by e.latest_listing_price::numeric / nullif(e.living_area_square_feet, 0))::numeric(14, 2) as median_price_per_sq_ft,
+				percentile_cont(0.5) within group (order by e.days_on_market)::numeric(14, 2) as median_days_on_market,
+				` + fmt.Sprintf(
+		"(percentile_cont(0.5) within group (order by e.mls_sold_price) filter (where e.listing_status = '%s'))::numeric(14, 2)",
+		listingStatusSold,
+	) + ` as median_sold_price,
+				` + fmt.Sprintf(
+		"(percentile_cont(0.5) within group (order by e.mls_sold_price::numeric / nullif(e.latest_listing_price, 0)) filter (where e.listing_status = '%s'))::numeric(8, 4)",
+		listingStatusSold,
+	) + ` as median_sold_to_list_ratio
+			from events e
+			group by e.period_start
+		)`
+
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"p.period_start",
+			"coalesce(a.listing_count, 0)",
+			"coalesce(a.sold_count, 0)",
+			"a.median_list_price",
+			"a.median_price_per_sq_ft",
+			"a.median_days_on_market",
+			"a.median_sold_price",
+			"a.median_sold_to_list_ratio",
+			"coalesce(i.inventory_by_status, '{}'::jsonb)",
+		).
+		Prefix(prefix, prefixArgs...).
+		From("periods p").
+		LeftJoin("activity a on a.period_start = p.period_start").
+		// The inventory of a period is made of the open listings
+		// at its end, keyed by their status at that time.
+		JoinClause(
+			`left join lateral (
+				select jsonb_object_agg(s.listing_status, s.total) as inventory_by_status
+				from (
+					select b.listing_status, count(*) as total
+					from (
+						select distinct on (l.attom_id, l.mls_listing_id) l.listing_status
+						from listings l
+						where l.status_change_date < p.period_end
+						order by l.attom_id, l.mls_listing_id, l.status_change_date desc, l.am_updated_at desc
+					) b
+					where b.listing_status = any(?)
+					group by b.listing_status
+				) s
+			) i on true`,
+			listingStatusesOpen,
+		).
+		// Periods without any listing are left out.
+		Where("a.period_start is not null or i.inventory_by_status is not null").
+		OrderBy("p.period_start")
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "4b049fa9-3714-47c6-9060-eaac2e7fdf61",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresDatapipe, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "c3a3ea0a-9b47-451e-b057-68c69bbe210c")
+	}
+	defer rows.Close()
+
+	out := &SelectMarketStatsRecordsOutput{}
+
+	for rows.Next() {
+		record := &entities.MarketStats{}
+
+		if err := rows.Scan(
+			&record.PeriodStart,
+			&record.ListingCount,
+			&record.SoldCount,
+			&record.MedianListPrice,
+			&record.MedianPricePerSqFt,
+			&record.MedianDaysOnMarket,
+			&record.MedianSoldPrice,
+			&record.MedianSoldToListRatio,
+			&record.InventoryByStatus,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "817c6601-2ef0-4365-81d3-b89e36afc72e",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to select row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "67726104-b397-4563-bcbb-9f74999ecee0",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to iterate rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+func polygonWKT(points []geom.Point) string {
+	parts := make([]string, 0, len(points)+1)
+
+	for _, p := range points {
+		parts = append(parts, fmt.Sprintf("%f %f", p.Lon, p.Lat))
+	}
+
+	// WKT polygons must be closed.
+	first, last := points[0], points[len(points)-1]
+	if first.Lat != last.Lat || first.Lon != last.Lon {
+		parts = append(parts, fmt.Sprintf("%f %f", first.Lon, first.Lat))
+	}
+
+	return fmt.Sprintf("POLYGON((%s))", strings.Join(parts, ", "))
+}
@@ -0,0 +1,135 @@
+package market
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/extutils"
+	"abodemine/lib/val"
+	"abodemine/projects/api/conf"
+)
+
+/*
+GO_TEST_COUNT=1 \
+GO_TEST_PARAMS="-v" \
+make -C ${ABODEMINE_WORKSPACE}/code/go/abodemine \
+test/abodemine/domains/market \
+RUN="TestRepository_SelectMarketStatsRecords"
+*/
+func TestRepository_SelectMarketStatsRecords(t *testing.T) {
+	if os.Getenv("RUN") != t.Name() {
+		t.Skip("This test MUST be selected manually.")
+	}
+
+	config := conf.MustResolveAndLoadOnce()
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		PgxPool: config.PGxPool,
+	})
+
+	r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+
+	pgxPool, err := r.Dom().SelectPgxPool(consts.ConfigKeyPostgresDatapipe)
+	require.NoError(t, err)
+
+	// Nothing is committed.
+	tx, err := pgxPool.Begin(r.Context())
+	require.NoError(t, err)
+	defer extutils.RollbackPgxTx(r.Context(), tx, "c489cbd9-fe9f-4daa-b5b4-04381c4a00dd")
+
+	r = r.Clone(arc.CloneRequestWithPgxTx(consts.ConfigKeyPostgresDatapipe, tx))
+
+	// A county code without any real property.
+	fips := "99999"
+	now := time.Now()
+
+	// Negative ids do not collide with the partner ids.
+	relistedAttomId := -now.UnixNano()
+	otherAttomId := relistedAttomId - 1
+
+	for _, attomId := range []int64{relistedAttomId, otherAttomId} {
+		_, err := extutils.PgxExec(r, consts.ConfigKeyPostgresDatapipe,
+			"insert into properties (id, created_at, updated_at, ad_attom_id, fips) values ($1, $2, $2, $3, $4)",
+			[]any{uuid.New(), now, attomId, fips},
+		)
+		require.NoError(t, err)
+	}
+
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.DateOnly, s)
+		require.NoError(t, err)
+		return d
+	}
+
+	events := []struct {
+		attomId      int64
+		listingId    int64
+		status       string
+		date         string
+		listPrice    int64
+		soldPrice    *int64
+		livingSqFeet int64
+	}{
+		// Listed before the start date, sold, then relisted.
+		{relistedAttomId, 1, "Active", "2024-12-10", 300000, nil, 1500},
+		{relistedAttomId, 1, "Pending", "2025-01-15", 300000, nil, 1500},
+		{relistedAttomId, 1, listingStatusSold, "2025-02-20", 300000, val.PtrRef(int64(310000)), 1500},
+		{relistedAttomId, 2, "Active", "2025-03-05", 350000, nil, 1500},
+		{otherAttomId, 3, "Active", "2025-02-03", 200000, nil, 1000},
+	}
+
+	for _, e := range events {
+		_, err := extutils.PgxExec(r, consts.ConfigKeyPostgresDatapipe,
+			`insert into ad_df_listing (
+				am_id, am_created_at, am_updated_at, attom_id, mls_record_id, mls_listing_id,
+				status_change_date, listing_status, latest_listing_price, mls_sold_price, living_area_square_feet
+			) values ($1, $2, $2, $3, $4, $4, $5, $6, $7, $8, $9)`,
+			[]any{uuid.New(), now, e.attomId, e.listingId, date(e.date), e.status, e.listPrice, e.soldPrice, e.livingSqFeet},
+		)
+		require.NoError(t, err)
+	}
+
+	out, err := NewRepository().SelectMarketStatsRecords(r, &SelectMarketStatsRecordsInput{
+		Interval:  IntervalMonth,
+		StartDate: date("2025-01-01"),
+		EndDate:   date("2025-03-31"),
+		Fips:      fips,
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Records, 3)
+
+	jan, feb, mar := out.Records[0], out.Records[1], out.Records[2]
+
+	// The event before the start date is only part of the
+	// inventory.
+	assert.True(t, date("2025-01-01").Equal(jan.PeriodStart))
+	assert.Equal(t, int64(1), jan.ListingCount)
+	assert.Equal(t, int64(0), jan.SoldCount)
+	assert.Equal(t, map[string]int64{"Pending": 1}, jan.InventoryByStatus)
+
+	// Sold listings leave the inventory.
+	assert.True(t, date("2025-02-01").Equal(feb.PeriodStart))
+	assert.Equal(t, int64(2), feb.ListingCount)
+	assert.Equal(t, int64(1), feb.SoldCount)
+	require.NotNil(t, feb.MedianSoldPrice)
+	assert.Equal(t, "310000", feb.MedianSoldPrice.String())
+	assert.Equal(t, map[string]int64{"Active": 1}, feb.InventoryByStatus)
+
+	// The relisting counts besides the listing of the other
+	// property.
+	assert.True(t, date("2025-03-01").Equal(mar.PeriodStart))
+	assert.Equal(t, int64(1), mar.ListingCount)
+	assert.Equal(t, int64(0), mar.SoldCount)
+	assert.Equal(t, map[string]int64{"Active": 2}, mar.InventoryByStatus)
+}
@@ -0,0 +1,120 @@
+package property
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/propertycache"
+	"abodemine/entities"
+)
+
+// layoutCache holds the cached layouts of a property while it
+// is selected, and collects the layouts read from the database.
+type layoutCache struct {
+	hits map[string]*propertycache.Layout
+
+	// generation is only known when the select succeeded,
+	// otherwise the fills are not cached.
+	generation string
+	selected   bool
+
+	mu       sync.Mutex
+	fills    map[string]any
+	cachedAt time.Time
+}
+
+// selectLayoutCache never fails, since the cache
+// must not break requests the database can serve.
+func (dom *domain) selectLayoutCache(r *arc.Request, aupid uuid.UUID, layouts []string) *layoutCache {
+	c := &layoutCache{
+		fills: make(map[string]any),
+	}
+
+	out, err := dom.cacheDomain.SelectLayouts(r, &propertycache.SelectLayoutsInput{
+		Aupid:   aupid,
+		Layouts: layouts,
+	})
+	if err != nil {
+		r.Logger().Error().
+			Err(err).
+			Str("id", "3b48c4e0-be6f-4e06-b160-4db3f654e634").
+			Str("aupid", aupid.String()).
+			Msg("Failed to select cached property layouts.")
+
+		return c
+	}
+
+	c.hits = out.Layouts
+	c.generation = out.Generation
+	c.selected = true
+
+	return c
+}
+
+func (dom *domain) putLayoutCache(r *arc.Request, aupid uuid.UUID, c *layoutCache) {
+	if !c.selected || len(c.fills) == 0 {
+		return
+	}
+
+	if _, err := dom.cacheDomain.PutLayouts(r, &propertycache.PutLayoutsInput{
+		Aupid:      aupid,
+		Generation: c.generation,
+		Layouts:    c.fills,
+	}); err != nil {
+		r.Logger().Error().
+			Err(err).
+			Str("id", "6ae27f7c-e92c-4e61-bf90-d232e7cfc861").
+			Str("aupid", aupid.String()).
+			Msg("Failed to put cached property layouts.")
+	}
+}
+
+// meta returns nil when no layout came from the cache.
+func (c *layoutCache) meta() *entities.PropertyMeta {
+	if c.cachedAt.IsZero() {
+		return nil
+	}
+
+	cachedAt := c.cachedAt
+	cacheAge := int64(time.Since(cachedAt).Seconds())
+
+	return &entities.PropertyMeta{
+		CachedAt: &cachedAt,
+		CacheAge: &cacheAge,
+	}
+}
+
+// loadLayout returns the cached entity of the layout, or calls
+// fetch and queues the result to be cached. It is safe to call
+// from concurrent goroutines.
+func loadLayout[T any](c *layoutCache, name string, fetch func() (T, error)) (T, error) {
+	if layout, ok := c.hits[name]; ok {
+		var v T
+
+		// Undecodable entries are fetched again and overwritten.
+		if err := json.Unmarshal(layout.Data, &v); err == nil {
+			c.mu.Lock()
+			if c.cachedAt.IsZero() || layout.CachedAt.Before(c.cachedAt) {
+				c.cachedAt = layout.CachedAt
+			}
+			c.mu.Unlock()
+
+			return v, nil
+		}
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+
+	c.mu.Lock()
+	c.fills[name] = v
+	c.mu.Unlock()
+
+	return v, nil
+}
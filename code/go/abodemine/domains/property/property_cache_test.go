@@ -0,0 +1,160 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/propertycache"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+)
+
+// fakeCacheDomain records the puts of the layouts.
+type fakeCacheDomain struct {
+	propertycache.Domain
+
+	selectErr error
+	puts      []*propertycache.PutLayoutsInput
+}
+
+func (d *fakeCacheDomain) SelectLayouts(r *arc.Request, in *propertycache.SelectLayoutsInput) (*propertycache.SelectLayoutsOutput, error) {
+	if d.selectErr != nil {
+		return nil, d.selectErr
+	}
+
+	return &propertycache.SelectLayoutsOutput{
+		Layouts:    map[string]*propertycache.Layout{},
+		Generation: "3",
+	}, nil
+}
+
+func (d *fakeCacheDomain) PutLayouts(r *arc.Request, in *propertycache.PutLayoutsInput) (*propertycache.PutLayoutsOutput, error) {
+	d.puts = append(d.puts, in)
+
+	return &propertycache.PutLayoutsOutput{}, nil
+}
+
+func TestLoadLayout(t *testing.T) {
+	older := time.Now().Add(-2 * time.Hour).UTC()
+	newer := time.Now().Add(-time.Hour).UTC()
+
+	c := &layoutCache{
+		hits: map[string]*propertycache.Layout{
+			propertycache.LayoutAssessor: {
+				CachedAt: newer,
+				Data:     json.RawMessage(`{"fips": "41051"}`),
+			},
+			propertycache.LayoutRentEstimate: {
+				CachedAt: older,
+				Data:     json.RawMessage(`null`),
+			},
+			propertycache.LayoutSaleEstimate: {
+				CachedAt: older,
+				Data:     json.RawMessage(`[1, 2]`),
+			},
+		},
+		fills: make(map[string]any),
+	}
+
+	fetched := 0
+
+	assessor, err := loadLayout(c, propertycache.LayoutAssessor, func() (*entities.Assessor, error) {
+		fetched++
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, assessor)
+	assert.Equal(t, "41051", *assessor.Fips)
+
+	// Cached nil layouts are hits too.
+	rentEstimate, err := loadLayout(c, propertycache.LayoutRentEstimate, func() (*entities.RentalAvm, error) {
+		fetched++
+		return &entities.RentalAvm{}, nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, rentEstimate)
+	assert.Equal(t, 0, fetched)
+
+	// Undecodable entries are fetched again.
+	saleEstimate, err := loadLayout(c, propertycache.LayoutSaleEstimate, func() (*entities.SaleAvm, error) {
+		fetched++
+		return &entities.SaleAvm{}, nil
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, saleEstimate)
+
+	_, err = loadLayout(c, propertycache.LayoutListing, func() ([]*entities.Listing, error) {
+		fetched++
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, fetched)
+	assert.Contains(t, c.fills, propertycache.LayoutSaleEstimate)
+	assert.Contains(t, c.fills, propertycache.LayoutListing)
+	assert.NotContains(t, c.fills, propertycache.LayoutAssessor)
+
+	meta := c.meta()
+	require.NotNil(t, meta)
+	assert.True(t, older.Equal(*meta.CachedAt))
+	assert.GreaterOrEqual(t, *meta.CacheAge, int64(2*time.Hour/time.Second))
+}
+
+func TestLayoutCache_Meta(t *testing.T) {
+	c := &layoutCache{fills: make(map[string]any)}
+
+	_, err := loadLayout(c, propertycache.LayoutAddress, func() (*entities.PropertyAddress, error) {
+		return &entities.PropertyAddress{}, nil
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, c.meta())
+}
+
+func TestLayoutCache_Generation(t *testing.T) {
+	r, err := arc.NewDomain(&arc.NewDomainInput{}).CreateRequest(&arc.CreateRequestInput{
+		Id:      uuid.New(),
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+
+	cacheDomain := new(fakeCacheDomain)
+	dom := &domain{cacheDomain: cacheDomain}
+	aupid := uuid.New()
+
+	fill := func(c *layoutCache) {
+		_, err := loadLayout(c, propertycache.LayoutAddress, func() (*entities.PropertyAddress, error) {
+			return &entities.PropertyAddress{}, nil
+		})
+		require.NoError(t, err)
+	}
+
+	// The layouts are put with the generation they were selected at.
+	c := dom.selectLayoutCache(r, aupid, []string{propertycache.LayoutAddress})
+	fill(c)
+	dom.putLayoutCache(r, aupid, c)
+
+	require.Len(t, cacheDomain.puts, 1)
+	assert.Equal(t, "3", cacheDomain.puts[0].Generation)
+
+	// Without a generation, the layouts could overwrite an
+	// invalidation, so they are not cached.
+	cacheDomain.selectErr = &errors.Object{
+		Id:     "2b8a4c4e-6a4e-4fd4-9f0e-34b7f1c9bb9c",
+		Code:   errors.Code_UNAVAILABLE,
+		Detail: "Connection refused.",
+	}
+
+	c = dom.selectLayoutCache(r, aupid, []string{propertycache.LayoutAddress})
+	fill(c)
+	dom.putLayoutCache(r, aupid, c)
+
+	assert.Len(t, cacheDomain.puts, 1)
+}
@@ -11,9 +11,12 @@ import (
 	"abodemine/domains/assessor"
 	"abodemine/domains/avm"
 	"abodemine/domains/listings"
+	"abodemine/domains/propertycache"
 	"abodemine/domains/recorder"
+	"abodemine/domains/timeline"
 	"abodemine/entities"
 	"abodemine/lib/errors"
+	"abodemine/lib/val"
 )
 
 type Domain interface {
@@ -24,16 +27,20 @@ type domain struct {
 	addressDomain  address.Domain
 	assessorDomain assessor.Domain
 	avmDomain      avm.Domain
+	cacheDomain    propertycache.Domain
 	listingDomain  listings.Domain
 	recorderDomain recorder.Domain
+	timelineDomain timeline.Domain
 }
 
 type NewDomainInput struct {
 	AddressDomain  address.Domain
 	AssessorDomain assessor.Domain
 	AvmDomain      avm.Domain
+	CacheDomain    propertycache.Domain
 	ListingDomain  listings.Domain
 	RecorderDomain recorder.Domain
+	TimelineDomain timeline.Domain
 }
 
 func NewDomain(in *NewDomainInput) Domain {
@@ -41,8 +48,14 @@ func NewDomain(in *NewDomainInput) Domain {
 		addressDomain:  in.AddressDomain,
 		assessorDomain: in.AssessorDomain,
 		avmDomain:      in.AvmDomain,
+		cacheDomain: val.Ternary(
+			in.CacheDomain == nil,
+			propertycache.NewDomain(&propertycache.NewDomainInput{}),
+			in.CacheDomain,
+		),
 		listingDomain:  in.ListingDomain,
 		recorderDomain: in.RecorderDomain,
+		timelineDomain: in.TimelineDomain,
 	}
 }
 
@@ -56,6 +69,7 @@ type SelectPropertyInput struct {
 	IncludeRecorder     bool
 	IncludeSaleEstimate bool
 	IncludeRentEstimate bool
+	IncludeTimeline     bool
 }
 
 type SelectPropertyOutput struct {
@@ -68,6 +82,7 @@ type SelectPropertyOutput struct {
 	RecorderLayoutSum  int32
 	RentalAvmLayoutSum int32
 	SaleAvmLayoutSum   int32
+	TimelineLayoutSum  int32
 }
 
 func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*SelectPropertyOutput, error) {
@@ -97,6 +112,7 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 	var listingEnts []*entities.Listing
 	var recorderEnts []*entities.Recorder
 	var rentalAvmEnt *entities.RentalAvm
+	var timelineEnts []*entities.TimelineEvent
 
 	var addressLayoutSum atomic.Int32
 	var assessorLayoutSum atomic.Int32
@@ -105,21 +121,37 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 	var recorderLayoutSum atomic.Int32
 	var rentalAvmLayoutSum atomic.Int32
 	var saleAvmLayoutSum atomic.Int32
+	var timelineLayoutSum atomic.Int32
+
+	// Layouts are read through the cache, which
+	// is filled with the misses once all are done.
+	cache := dom.selectLayoutCache(r, *aupid, cachedLayouts(in))
 
 	// Run 4 goroutines at most.
 	g.SetLimit(4)
 
 	if in.IncludeAddress {
 		g.Go(func() error {
-			selectPropertyAddressOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
-				Aupid: aupid,
+			ent, err := loadLayout(cache, propertycache.LayoutAddress, func() (*entities.PropertyAddress, error) {
+				selectPropertyAddressOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "32128858-ef02-447f-9fca-189c315f44ee")
+				}
+
+				if len(selectPropertyAddressOut.AddressEntities) == 0 {
+					return nil, nil
+				}
+
+				return selectPropertyAddressOut.AddressEntities[0], nil
 			})
 			if err != nil {
-				return errors.Forward(err, "32128858-ef02-447f-9fca-189c315f44ee")
+				return errors.Forward(err, "af1d2b23-0ae1-4e78-b414-f5e7a37e0726")
 			}
 
-			if len(selectPropertyAddressOut.AddressEntities) > 0 {
-				propertyAddressEnt = selectPropertyAddressOut.AddressEntities[0]
+			if ent != nil {
+				propertyAddressEnt = ent
 				addressLayoutSum.Add(1)
 			}
 
@@ -129,15 +161,26 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 
 	if in.IncludeAssessor {
 		g.Go(func() error {
-			selectAssessorOut, err := dom.assessorDomain.SelectAssessor(r, &assessor.SelectAssessorInput{
-				Aupid: aupid,
+			ent, err := loadLayout(cache, propertycache.LayoutAssessor, func() (*entities.Assessor, error) {
+				selectAssessorOut, err := dom.assessorDomain.SelectAssessor(r, &assessor.SelectAssessorInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "58dbb05f-e8ac-4f7e-8088-bea354ede0cf")
+				}
+
+				if len(selectAssessorOut.AssessorEntities) == 0 {
+					return nil, nil
+				}
+
+				return selectAssessorOut.AssessorEntities[0], nil
 			})
 			if err != nil {
-				return errors.Forward(err, "58dbb05f-e8ac-4f7e-8088-bea354ede0cf")
+				return errors.Forward(err, "494e82ed-d516-40b2-aa7c-aef06c2247f5")
 			}
 
-			if len(selectAssessorOut.AssessorEntities) > 0 {
-				assessorEnt = selectAssessorOut.AssessorEntities[0]
+			if ent != nil {
+				assessorEnt = ent
 				assessorLayoutSum.Add(1)
 			}
 
@@ -147,14 +190,21 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 
 	if in.IncludeListing {
 		g.Go(func() error {
-			selectListingOut, err := dom.listingDomain.SelectListing(r, &listings.SelectListingInput{
-				Aupid: aupid,
+			ents, err := loadLayout(cache, propertycache.LayoutListing, func() ([]*entities.Listing, error) {
+				selectListingOut, err := dom.listingDomain.SelectListing(r, &listings.SelectListingInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "f837bba5-dfd2-41f0-9567-0e197911fa56")
+				}
+
+				return selectListingOut.ListingEntities, nil
 			})
 			if err != nil {
-				return errors.Forward(err, "f837bba5-dfd2-41f0-9567-0e197911fa56")
+				return errors.Forward(err, "cb7574ab-de10-4f93-b064-71aefb07ca92")
 			}
 
-			listingEnts = selectListingOut.ListingEntities
+			listingEnts = ents
 			listingLayoutSum.Add(int32(len(listingEnts)))
 
 			return nil
@@ -163,14 +213,21 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 
 	if in.IncludeRecorder {
 		g.Go(func() error {
-			selectRecorderOut, err := dom.recorderDomain.SelectRecorder(r, &recorder.SelectRecorderInput{
-				Aupid: aupid,
+			ents, err := loadLayout(cache, propertycache.LayoutRecorder, func() ([]*entities.Recorder, error) {
+				selectRecorderOut, err := dom.recorderDomain.SelectRecorder(r, &recorder.SelectRecorderInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "98dbb429-5532-4790-8310-9379577689cf")
+				}
+
+				return selectRecorderOut.RecorderEntities, nil
 			})
 			if err != nil {
-				return errors.Forward(err, "98dbb429-5532-4790-8310-9379577689cf")
+				return errors.Forward(err, "cf5dd03f-d5c0-4d0c-ab40-83f7d0afcf28")
 			}
 
-			recorderEnts = selectRecorderOut.RecorderEntities
+			recorderEnts = ents
 			recorderLayoutSum.Add(int32(len(recorderEnts)))
 
 			return nil
@@ -179,18 +236,28 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 
 	if in.IncludeComps || in.IncludeSaleEstimate {
 		g.Go(func() error {
-			selectSaleAvmOut, err := dom.avmDomain.SelectSaleAvm(r, &avm.SelectSaleAvmInput{
-				Aupid: aupid,
+			saleAvmEntity, err := loadLayout(cache, propertycache.LayoutSaleEstimate, func() (*entities.SaleAvm, error) {
+				selectSaleAvmOut, err := dom.avmDomain.SelectSaleAvm(r, &avm.SelectSaleAvmInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "f71d43aa-8e03-4a51-be56-3363c567e8d5")
+				}
+
+				if len(selectSaleAvmOut.SaleAvmEntities) == 0 {
+					return nil, nil
+				}
+
+				return selectSaleAvmOut.SaleAvmEntities[0], nil
 			})
 			if err != nil {
-				return errors.Forward(err, "f71d43aa-8e03-4a51-be56-3363c567e8d5")
+				return errors.Forward(err, "38803451-2040-4b9a-952b-790c633e99ea")
 			}
 
-			if len(selectSaleAvmOut.SaleAvmEntities) == 0 {
+			if saleAvmEntity == nil {
 				return nil
 			}
 
-			saleAvmEntity := selectSaleAvmOut.SaleAvmEntities[0]
 			saleAvmLayoutSum.Add(1)
 
 			if in.IncludeComps {
@@ -224,15 +291,26 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 
 	if in.IncludeRentEstimate {
 		g.Go(func() error {
-			selectRentalAvmOut, err := dom.avmDomain.SelectRentalAvm(r, &avm.SelectRentalAvmInput{
-				Aupid: aupid,
+			ent, err := loadLayout(cache, propertycache.LayoutRentEstimate, func() (*entities.RentalAvm, error) {
+				selectRentalAvmOut, err := dom.avmDomain.SelectRentalAvm(r, &avm.SelectRentalAvmInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "28905ed8-0ef6-4033-a407-3a2ef7eb6268")
+				}
+
+				if len(selectRentalAvmOut.RentalAvmEntities) == 0 {
+					return nil, nil
+				}
+
+				return selectRentalAvmOut.RentalAvmEntities[0], nil
 			})
 			if err != nil {
-				return errors.Forward(err, "28905ed8-0ef6-4033-a407-3a2ef7eb6268")
+				return errors.Forward(err, "9aa3224c-6b2d-4709-87d3-a94d97267b93")
 			}
 
-			if len(selectRentalAvmOut.RentalAvmEntities) > 0 {
-				rentalAvmEnt = selectRentalAvmOut.RentalAvmEntities[0]
+			if ent != nil {
+				rentalAvmEnt = ent
 				rentalAvmLayoutSum.Add(1)
 			}
 
@@ -240,10 +318,37 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 		})
 	}
 
+	if in.IncludeTimeline {
+		g.Go(func() error {
+			ents, err := loadLayout(cache, propertycache.LayoutTimeline, func() ([]*entities.TimelineEvent, error) {
+				selectTimelineOut, err := dom.timelineDomain.SelectTimeline(r, &timeline.SelectTimelineInput{
+					Aupid: aupid,
+				})
+				if err != nil {
+					return nil, errors.Forward(err, "b1fe0022-10cc-4cd3-9a30-7c4fc5b96fdf")
+				}
+
+				return selectTimelineOut.TimelineEvents, nil
+			})
+			if err != nil {
+				return errors.Forward(err, "2f05048d-f25c-49d2-b7ab-5af4148ca012")
+			}
+
+			if len(ents) > 0 {
+				timelineEnts = ents
+				timelineLayoutSum.Add(1)
+			}
+
+			return nil
+		})
+	}
+
 	if err := g.Wait(); err != nil {
 		return nil, errors.Forward(err, "a39e8145-5644-46bf-a59b-6a8dc0b5a5ce")
 	}
 
+	dom.putLayoutCache(r, *aupid, cache)
+
 	var properties []*entities.Property
 
 	anyLayoutFound := propertyAddressEnt != nil ||
@@ -252,7 +357,8 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 		len(listingEnts) > 0 ||
 		len(recorderEnts) > 0 ||
 		rentalAvmEnt != nil ||
-		saleAvmEnt != nil
+		saleAvmEnt != nil ||
+		len(timelineEnts) > 0
 
 	if anyLayoutFound {
 		properties = []*entities.Property{{
@@ -264,6 +370,8 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 			Recorder: recorderEnts,
 			Rental:   rentalAvmEnt,
 			Sale:     saleAvmEnt,
+			Timeline: timelineEnts,
+			Meta:     cache.meta(),
 		}}
 	}
 
@@ -277,7 +385,43 @@ func (dom *domain) SelectProperty(r *arc.Request, in *SelectPropertyInput) (*Sel
 		RecorderLayoutSum:  recorderLayoutSum.Load(),
 		RentalAvmLayoutSum: rentalAvmLayoutSum.Load(),
 		SaleAvmLayoutSum:   saleAvmLayoutSum.Load(),
+		TimelineLayoutSum:  timelineLayoutSum.Load(),
 	}
 
 	return out, nil
 }
+
+// cachedLayouts returns the cache fields of the requested layouts.
+func cachedLayouts(in *SelectPropertyInput) []string {
+	var layouts []string
+
+	if in.IncludeAddress {
+		layouts = append(layouts, propertycache.LayoutAddress)
+	}
+
+	if in.IncludeAssessor {
+		layouts = append(layouts, propertycache.LayoutAssessor)
+	}
+
+	if in.IncludeListing {
+		layouts = append(layouts, propertycache.LayoutListing)
+	}
+
+	if in.IncludeRecorder {
+		layouts = append(layouts, propertycache.LayoutRecorder)
+	}
+
+	if in.IncludeComps || in.IncludeSaleEstimate {
+		layouts = append(layouts, propertycache.LayoutSaleEstimate)
+	}
+
+	if in.IncludeRentEstimate {
+		layouts = append(layouts, propertycache.LayoutRentEstimate)
+	}
+
+	if in.IncludeTimeline {
+		layouts = append(layouts, propertycache.LayoutTimeline)
+	}
+
+	return layouts
+}
@@ -0,0 +1,309 @@
+package propertycache
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+)
+
+// Layouts cached per property. Comps are not cached
+// themselves, as they are built from the sale estimates
+// of other properties, which are cached.
+const (
+	LayoutAddress      = "address"
+	LayoutAssessor     = "assessor"
+	LayoutListing      = "listing"
+	LayoutRecorder     = "recorder"
+	LayoutRentEstimate = "rentEstimate"
+	LayoutSaleEstimate = "saleEstimate"
+	LayoutTimeline     = "timeline"
+)
+
+// Used when the property_cache_ttl duration is not configured.
+// Loaders invalidate the properties they touch, so the TTL only
+// bounds how long a missed invalidation can serve stale data.
+const defaultTtl = time.Hour
+
+// generationTtl MUST outlive any request between the select and
+// the put of the layouts of a property, or a put could compare
+// equal to a generation that expired since.
+const generationTtl = 24 * time.Hour
+
+// Domain is a read-through cache of property layouts in Valkey,
+// keyed by AUPID. All methods are no-ops when the cache Valkey
+// is not configured.
+type Domain interface {
+	SelectLayouts(r *arc.Request, in *SelectLayoutsInput) (*SelectLayoutsOutput, error)
+	PutLayouts(r *arc.Request, in *PutLayoutsInput) (*PutLayoutsOutput, error)
+	DeleteProperties(r *arc.Request, in *DeletePropertiesInput) (*DeletePropertiesOutput, error)
+}
+
+type domain struct{}
+
+type NewDomainInput struct{}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{}
+}
+
+// Layout is a cached layout, where Data is the JSON encoded
+// entity, or null when the property has no such layout.
+type Layout struct {
+	CachedAt time.Time       `json:"t"`
+	Data     json.RawMessage `json:"d"`
+}
+
+// propertyKeys returns the key of the layouts of the property
+// and the key of its generation, which invalidations bump. They
+// share the hash slot of the property, so all its layouts live
+// on the same node.
+func propertyKeys(aupid uuid.UUID) []string {
+	slot := base64.StdEncoding.EncodeToString(aupid[:])[:22]
+
+	return []string{
+		fmt.Sprintf("{%s}:prop", slot),
+		fmt.Sprintf("{%s}:gen", slot),
+	}
+}
+
+// selectValkey returns a nil client when the cache is not configured.
+func selectValkey(r *arc.Request) valkey.Client {
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyCache)
+	if err != nil {
+		return nil
+	}
+
+	return valkeyCli
+}
+
+type SelectLayoutsInput struct {
+	Aupid   uuid.UUID
+	Layouts []string
+}
+
+type SelectLayoutsOutput struct {
+	// Layouts found in the cache, by name.
+	Layouts map[string]*Layout
+
+	// Generation of the property when the layouts were selected,
+	// to put the layouts read from the database with.
+	Generation string
+}
+
+func (dom *domain) SelectLayouts(r *arc.Request, in *SelectLayoutsInput) (*SelectLayoutsOutput, error) {
+	out := &SelectLayoutsOutput{
+		Layouts: make(map[string]*Layout),
+	}
+
+	valkeyCli := selectValkey(r)
+	if valkeyCli == nil || len(in.Layouts) == 0 {
+		return out, nil
+	}
+
+	selectScript, err := r.Dom().SelectValkeyScript("select-property-layouts")
+	if err != nil {
+		return nil, errors.Forward(err, "bcf6512a-94af-426d-83fd-70599175fe18")
+	}
+
+	values, err := selectScript.Exec(
+		r.Context(),
+		valkeyCli,
+		propertyKeys(in.Aupid),
+		in.Layouts,
+	).ToArray()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "b5783010-074d-4cba-9f55-88cf7cd44912",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	for i, value := range values {
+		if i > len(in.Layouts) {
+			break
+		}
+
+		s, err := value.ToString()
+		if err != nil {
+			if valkey.IsValkeyNil(err) {
+				continue
+			}
+
+			return nil, &errors.Object{
+				Id:     "9165b610-bf0f-481a-8aa0-3c0dbe129b1e",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse script output.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if i == len(in.Layouts) {
+			out.Generation = s
+			continue
+		}
+
+		layout := new(Layout)
+
+		// Entries that can't be decoded, e.g. written by an older
+		// version, are treated as misses and overwritten.
+		if err := json.Unmarshal([]byte(s), layout); err != nil {
+			continue
+		}
+
+		out.Layouts[in.Layouts[i]] = layout
+	}
+
+	return out, nil
+}
+
+type PutLayoutsInput struct {
+	Aupid uuid.UUID
+
+	// Generation returned by SelectLayouts. The layouts are not
+	// cached if the property was invalidated since, as they may
+	// have been read before the invalidation.
+	Generation string
+
+	// Entities to cache by layout name. Nil entities are
+	// cached too, so properties without a layout don't hit
+	// the database on every request.
+	Layouts map[string]any
+}
+
+type PutLayoutsOutput struct{}
+
+func (dom *domain) PutLayouts(r *arc.Request, in *PutLayoutsInput) (*PutLayoutsOutput, error) {
+	out := &PutLayoutsOutput{}
+
+	valkeyCli := selectValkey(r)
+	if valkeyCli == nil || len(in.Layouts) == 0 {
+		return out, nil
+	}
+
+	putScript, err := r.Dom().SelectValkeyScript("put-property-layouts")
+	if err != nil {
+		return nil, errors.Forward(err, "4edd4829-a3f7-4075-ac17-e35c8ff00ffb")
+	}
+
+	ttl, err := r.Dom().SelectDuration(consts.ConfigKeyDurationPropertyCacheTtl)
+	if err != nil {
+		ttl = defaultTtl
+	}
+
+	now := time.Now()
+	args := []string{strconv.Itoa(int(ttl.Seconds())), in.Generation}
+
+	for name, entity := range in.Layouts {
+		data, err := json.Marshal(entity)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "fe6c9bb2-ef02-4bed-9aff-07b1ec54b73d",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to encode layout.",
+				Cause:  err.Error(),
+				Err:    err,
+				Meta: map[string]any{
+					"layout": name,
+				},
+			}
+		}
+
+		value, err := json.Marshal(&Layout{
+			CachedAt: now,
+			Data:     data,
+		})
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "164c5471-052c-4b2c-ae83-4276b6c90eec",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to encode layout.",
+				Cause:  err.Error(),
+				Err:    err,
+				Meta: map[string]any{
+					"layout": name,
+				},
+			}
+		}
+
+		args = append(args, name, string(value))
+	}
+
+	if err := putScript.Exec(
+		r.Context(),
+		valkeyCli,
+		propertyKeys(in.Aupid),
+		args,
+	).Error(); err != nil {
+		return nil, &errors.Object{
+			Id:     "dba9cfb7-0f22-41e3-96bd-a53dcc4a36f6",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+type DeletePropertiesInput struct {
+	Aupids []uuid.UUID
+}
+
+type DeletePropertiesOutput struct{}
+
+// DeleteProperties invalidates all the cached layouts of the
+// properties, e.g. after a loader touched their rows. It bumps
+// their generation, so that the layouts read before are not
+// cached by requests still in flight.
+func (dom *domain) DeleteProperties(r *arc.Request, in *DeletePropertiesInput) (*DeletePropertiesOutput, error) {
+	out := &DeletePropertiesOutput{}
+
+	valkeyCli := selectValkey(r)
+	if valkeyCli == nil || len(in.Aupids) == 0 {
+		return out, nil
+	}
+
+	deleteScript, err := r.Dom().SelectValkeyScript("delete-property")
+	if err != nil {
+		return nil, errors.Forward(err, "6c002266-df5a-4da8-9176-7775d4b00dfc")
+	}
+
+	// One call per property, since keys of different
+	// properties may live on different nodes.
+	multi := make([]valkey.LuaExec, len(in.Aupids))
+
+	for i, aupid := range in.Aupids {
+		multi[i] = valkey.LuaExec{
+			Keys: propertyKeys(aupid),
+			Args: []string{strconv.Itoa(int(generationTtl.Seconds()))},
+		}
+	}
+
+	for _, result := range deleteScript.ExecMulti(r.Context(), valkeyCli, multi...) {
+		if err := result.Error(); err != nil {
+			return nil, &errors.Object{
+				Id:     "bcef0fbe-a87f-4a16-bb5c-9b910e051b00",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to execute script.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+	}
+
+	return out, nil
+}
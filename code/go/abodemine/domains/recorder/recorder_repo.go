@@ -281,6 +281,7 @@ func (repo *repository) SelectRecorderRecord(r *arc.Request, in *SelectRecorderR
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -537,6 +538,7 @@ func (repo *repository) SelectRecorderRecord(r *arc.Request, in *SelectRecorderR
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
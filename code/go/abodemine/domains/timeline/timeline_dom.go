@@ -0,0 +1,340 @@
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/listings"
+	"abodemine/domains/recorder"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+)
+
+type Domain interface {
+	SelectTimeline(r *arc.Request, in *SelectTimelineInput) (*SelectTimelineOutput, error)
+}
+
+type domain struct {
+	repository Repository
+
+	listingDomain  listings.Domain
+	recorderDomain recorder.Domain
+}
+
+type NewDomainInput struct {
+	Repository Repository
+
+	ListingDomain  listings.Domain
+	RecorderDomain recorder.Domain
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+		listingDomain:  in.ListingDomain,
+		recorderDomain: in.RecorderDomain,
+	}
+}
+
+type SelectTimelineInput struct {
+	Aupid *uuid.UUID
+}
+
+type SelectTimelineOutput struct {
+	// Events are sorted in chronological order, oldest first.
+	TimelineEvents []*entities.TimelineEvent
+}
+
+func (dom *domain) SelectTimeline(r *arc.Request, in *SelectTimelineInput) (*SelectTimelineOutput, error) {
+	if in == nil || in.Aupid == nil {
+		return nil, &errors.Object{
+			Id:     "83542a64-98da-4d2c-b2e1-878a95895d76",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Aupid is required.",
+		}
+	}
+
+	var g errgroup.Group
+	var recorderEvents []*entities.TimelineEvent
+	var listingEvents []*entities.TimelineEvent
+	var assessmentEvents []*entities.TimelineEvent
+
+	g.Go(func() error {
+		selectRecorderOut, err := dom.recorderDomain.SelectRecorder(r, &recorder.SelectRecorderInput{
+			Aupid: in.Aupid,
+		})
+		if err != nil {
+			return errors.Forward(err, "20eba41c-9fad-4af2-ac36-8cdde1d3d764")
+		}
+
+		recorderEvents = buildRecorderEvents(selectRecorderOut.RecorderEntities)
+
+		return nil
+	})
+
+	g.Go(func() error {
+		selectListingOut, err := dom.listingDomain.SelectListing(r, &listings.SelectListingInput{
+			Aupid: in.Aupid,
+		})
+		if err != nil {
+			return errors.Forward(err, "73332f03-5fab-4cc5-84e1-c1e2fd3b771b")
+		}
+
+		listingEvents = buildListingEvents(selectListingOut.ListingEntities)
+
+		return nil
+	})
+
+	g.Go(func() error {
+		selectAssessmentRecordsOut, err := dom.repository.SelectAssessmentRecords(r, &SelectAssessmentRecordsInput{
+			Aupid: in.Aupid,
+		})
+		if err != nil {
+			return errors.Forward(err, "08407873-183d-4efe-a794-7ff5122f4c13")
+		}
+
+		assessmentEvents = buildAssessmentEvents(selectAssessmentRecordsOut.Records)
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, errors.Forward(err, "ef973752-0fc5-46d2-86fc-dd8ea430e099")
+	}
+
+	out := &SelectTimelineOutput{
+		TimelineEvents: mergeEvents(recorderEvents, listingEvents, assessmentEvents),
+	}
+
+	return out, nil
+}
+
+// buildRecorderEvents classifies each recorded document as a sale
+// (a transfer with a disclosed price), a refinance (a mortgage without
+// a change of ownership) or a plain transfer of ownership.
+func buildRecorderEvents(records []*entities.Recorder) []*entities.TimelineEvent {
+	var events []*entities.TimelineEvent
+
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+
+		date := val.Coalesce(record.RecordingDate, record.InstrumentDate)
+		if date == nil {
+			continue
+		}
+
+		event := &entities.TimelineEvent{
+			Source: entities.TimelineEventSourceRecorder,
+			Date:   *date,
+			References: &entities.TimelineEventReferences{
+				TransactionId:    record.TransactionId,
+				DocumentTypeCode: record.DocumentTypeCode,
+				DocumentNumber:   record.DocumentNumber,
+				InstrumentNumber: record.InstrumentNumber,
+				Book:             record.Book,
+				Page:             record.Page,
+			},
+		}
+
+		hasGrantor := record.Grantor1FullName != nil && *record.Grantor1FullName != ""
+		hasMortgage := record.Mortgage1Amount != nil && *record.Mortgage1Amount > 0
+
+		switch {
+		case record.TransferAmount != nil && record.TransferAmount.IsPositive():
+			event.EventType = entities.TimelineEventTypeSale
+			event.Amount = record.TransferAmount
+		case hasMortgage && !hasGrantor:
+			event.EventType = entities.TimelineEventTypeRefinance
+			event.Amount = intDecimal(record.Mortgage1Amount)
+
+			if record.Mortgage1RecordingDate != nil {
+				event.Date = *record.Mortgage1RecordingDate
+			}
+
+			event.References.DocumentNumber = val.Coalesce(record.Mortgage1DocumentNumber, record.DocumentNumber)
+			event.References.InstrumentNumber = val.Coalesce(record.Mortgage1InstrumentNumber, record.InstrumentNumber)
+			event.References.Book = val.Coalesce(record.Mortgage1Book, record.Book)
+			event.References.Page = val.Coalesce(record.Mortgage1Page, record.Page)
+		default:
+			event.EventType = entities.TimelineEventTypeTransfer
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// buildListingEvents emits an event every time the status of a listing
+// changes, plus one for each distinct price change reported by the MLS.
+func buildListingEvents(records []*entities.Listing) []*entities.TimelineEvent {
+	sorted := make([]*entities.Listing, 0, len(records))
+
+	for _, record := range records {
+		if record != nil && record.StatusChangeDate != nil {
+			sorted = append(sorted, record)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StatusChangeDate.Before(*sorted[j].StatusChangeDate)
+	})
+
+	var events []*entities.TimelineEvent
+	lastStatus := make(map[string]string)
+	seenPriceChanges := make(map[string]bool)
+
+	for _, record := range sorted {
+		key := listingKey(record)
+
+		references := &entities.TimelineEventReferences{
+			MlsNumber:    record.MlsNumber,
+			MlsListingId: record.MlsListingId,
+			MlsRecordId:  record.MlsRecordId,
+		}
+
+		status := val.PtrDeref(record.ListingStatus)
+
+		if prev, ok := lastStatus[key]; !ok || prev != status {
+			lastStatus[key] = status
+
+			amount := record.LatestListingPrice
+			if record.MlsSoldPrice != nil && record.MlsSoldDate != nil {
+				amount = record.MlsSoldPrice
+			}
+
+			events = append(events, &entities.TimelineEvent{
+				EventType:     entities.TimelineEventTypeListingStatusChange,
+				Source:        entities.TimelineEventSourceListing,
+				Date:          *record.StatusChangeDate,
+				Amount:        intDecimal(amount),
+				ListingStatus: record.ListingStatus,
+				References:    references,
+			})
+		}
+
+		if record.LatestPriceChangeDate == nil ||
+			record.LatestListingPrice == nil ||
+			record.PreviousListingPrice == nil ||
+			*record.LatestListingPrice == *record.PreviousListingPrice {
+			continue
+		}
+
+		priceChangeKey := fmt.Sprintf("%s:%s:%d", key, record.LatestPriceChangeDate.Format(time.DateOnly), *record.LatestListingPrice)
+		if seenPriceChanges[priceChangeKey] {
+			continue
+		}
+		seenPriceChanges[priceChangeKey] = true
+
+		events = append(events, &entities.TimelineEvent{
+			EventType:      entities.TimelineEventTypeListingPriceChange,
+			Source:         entities.TimelineEventSourceListing,
+			Date:           *record.LatestPriceChangeDate,
+			Amount:         intDecimal(record.LatestListingPrice),
+			PreviousAmount: intDecimal(record.PreviousListingPrice),
+			ListingStatus:  record.ListingStatus,
+			References:     references,
+		})
+	}
+
+	return events
+}
+
+// buildAssessmentEvents expects records sorted by tax year and emits
+// an event for every tax year where the total assessed value changed.
+func buildAssessmentEvents(records []*AssessmentRecord) []*entities.TimelineEvent {
+	// Keep a single record per tax year, the last one wins.
+	byYear := make(map[int]*AssessmentRecord)
+	var years []int
+
+	for _, record := range records {
+		if record == nil || record.TaxYear == nil || record.AssessedValueTotal == nil {
+			continue
+		}
+
+		if _, ok := byYear[*record.TaxYear]; !ok {
+			years = append(years, *record.TaxYear)
+		}
+
+		byYear[*record.TaxYear] = record
+	}
+
+	sort.Ints(years)
+
+	var events []*entities.TimelineEvent
+	var previous *int
+
+	for _, year := range years {
+		record := byYear[year]
+
+		if previous != nil && *previous == *record.AssessedValueTotal {
+			continue
+		}
+
+		events = append(events, &entities.TimelineEvent{
+			EventType:      entities.TimelineEventTypeAssessedValueChange,
+			Source:         entities.TimelineEventSourceAssessor,
+			Date:           time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Amount:         intDecimal(record.AssessedValueTotal),
+			PreviousAmount: intDecimal(previous),
+			References: &entities.TimelineEventReferences{
+				TaxYear: record.TaxYear,
+			},
+		})
+
+		previous = record.AssessedValueTotal
+	}
+
+	return events
+}
+
+// mergeEvents merges the events of all sources into a single
+// chronologically ordered stream. Events on the same date keep
+// the order of the sources as given.
+func mergeEvents(sources ...[]*entities.TimelineEvent) []*entities.TimelineEvent {
+	var events []*entities.TimelineEvent
+
+	for _, source := range sources {
+		events = append(events, source...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+
+	return events
+}
+
+func listingKey(record *entities.Listing) string {
+	switch {
+	case record.MlsListingId != nil:
+		return fmt.Sprintf("id:%d", *record.MlsListingId)
+	case record.MlsNumber != nil:
+		return "number:" + *record.MlsNumber
+	default:
+		return ""
+	}
+}
+
+func intDecimal(v *int) *decimal.Decimal {
+	if v == nil {
+		return nil
+	}
+
+	d := decimal.NewFromInt(int64(*v))
+
+	return &d
+}
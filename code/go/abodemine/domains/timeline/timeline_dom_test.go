@@ -0,0 +1,160 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"abodemine/entities"
+	"abodemine/lib/val"
+)
+
+func date(y int, m time.Month, d int) *time.Time {
+	return val.PtrRef(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+func TestBuildRecorderEvents(t *testing.T) {
+	records := []*entities.Recorder{
+		{
+			TransactionId:  val.PtrRef(int64(1)),
+			RecordingDate:  date(2015, 6, 1),
+			TransferAmount: val.PtrRef(decimal.NewFromInt(350000)),
+			DocumentNumber: val.PtrRef("2015-001"),
+		},
+		{
+			TransactionId:           val.PtrRef(int64(2)),
+			RecordingDate:           date(2020, 3, 1),
+			Mortgage1Amount:         val.PtrRef(280000),
+			Mortgage1RecordingDate:  date(2020, 3, 2),
+			Mortgage1DocumentNumber: val.PtrRef("2020-777"),
+			DocumentNumber:          val.PtrRef("2020-001"),
+		},
+		{
+			TransactionId:    val.PtrRef(int64(3)),
+			InstrumentDate:   date(2021, 1, 1),
+			Grantor1FullName: val.PtrRef("JOHN DOE"),
+		},
+		{
+			// No date, skipped.
+			TransactionId: val.PtrRef(int64(4)),
+		},
+	}
+
+	events := buildRecorderEvents(records)
+
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, entities.TimelineEventTypeSale, events[0].EventType)
+		assert.Equal(t, "350000", events[0].Amount.String())
+		assert.Equal(t, "2015-001", *events[0].References.DocumentNumber)
+
+		assert.Equal(t, entities.TimelineEventTypeRefinance, events[1].EventType)
+		assert.Equal(t, "280000", events[1].Amount.String())
+		assert.Equal(t, *date(2020, 3, 2), events[1].Date)
+		assert.Equal(t, "2020-777", *events[1].References.DocumentNumber)
+
+		assert.Equal(t, entities.TimelineEventTypeTransfer, events[2].EventType)
+		assert.Nil(t, events[2].Amount)
+		assert.Equal(t, *date(2021, 1, 1), events[2].Date)
+	}
+}
+
+func TestBuildListingEvents(t *testing.T) {
+	listingId := val.PtrRef(int64(10))
+
+	records := []*entities.Listing{
+		{
+			MlsListingId:          listingId,
+			StatusChangeDate:      date(2024, 5, 10),
+			ListingStatus:         val.PtrRef("Pending"),
+			LatestListingPrice:    val.PtrRef(480000),
+			PreviousListingPrice:  val.PtrRef(500000),
+			LatestPriceChangeDate: date(2024, 4, 20),
+		},
+		{
+			MlsListingId:       listingId,
+			StatusChangeDate:   date(2024, 4, 1),
+			ListingStatus:      val.PtrRef("Active"),
+			LatestListingPrice: val.PtrRef(500000),
+		},
+		{
+			MlsListingId:          listingId,
+			StatusChangeDate:      date(2024, 5, 12),
+			ListingStatus:         val.PtrRef("Pending"),
+			LatestListingPrice:    val.PtrRef(480000),
+			PreviousListingPrice:  val.PtrRef(500000),
+			LatestPriceChangeDate: date(2024, 4, 20),
+		},
+		{
+			MlsListingId:       listingId,
+			StatusChangeDate:   date(2024, 6, 1),
+			ListingStatus:      val.PtrRef("Sold"),
+			LatestListingPrice: val.PtrRef(480000),
+			MlsSoldDate:        date(2024, 6, 1),
+			MlsSoldPrice:       val.PtrRef(475000),
+		},
+	}
+
+	events := buildListingEvents(records)
+
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, entities.TimelineEventTypeListingStatusChange, events[0].EventType)
+		assert.Equal(t, "Active", *events[0].ListingStatus)
+
+		assert.Equal(t, entities.TimelineEventTypeListingStatusChange, events[1].EventType)
+		assert.Equal(t, "Pending", *events[1].ListingStatus)
+
+		assert.Equal(t, entities.TimelineEventTypeListingPriceChange, events[2].EventType)
+		assert.Equal(t, "480000", events[2].Amount.String())
+		assert.Equal(t, "500000", events[2].PreviousAmount.String())
+
+		assert.Equal(t, entities.TimelineEventTypeListingStatusChange, events[3].EventType)
+		assert.Equal(t, "475000", events[3].Amount.String())
+	}
+}
+
+func TestBuildAssessmentEvents(t *testing.T) {
+	records := []*AssessmentRecord{
+		{TaxYear: val.PtrRef(2021), AssessedValueTotal: val.PtrRef(200000)},
+		{TaxYear: val.PtrRef(2022), AssessedValueTotal: val.PtrRef(200000)},
+		{TaxYear: val.PtrRef(2023), AssessedValueTotal: val.PtrRef(210000)},
+		// Republished, the last record of a year wins.
+		{TaxYear: val.PtrRef(2023), AssessedValueTotal: val.PtrRef(215000)},
+		{TaxYear: val.PtrRef(2024)},
+	}
+
+	events := buildAssessmentEvents(records)
+
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, *date(2021, 1, 1), events[0].Date)
+		assert.Nil(t, events[0].PreviousAmount)
+
+		assert.Equal(t, *date(2023, 1, 1), events[1].Date)
+		assert.Equal(t, "215000", events[1].Amount.String())
+		assert.Equal(t, "200000", events[1].PreviousAmount.String())
+		assert.Equal(t, 2023, *events[1].References.TaxYear)
+	}
+}
+
+func TestMergeEvents(t *testing.T) {
+	events := mergeEvents(
+		[]*entities.TimelineEvent{
+			{Source: entities.TimelineEventSourceRecorder, Date: *date(2020, 1, 1)},
+			{Source: entities.TimelineEventSourceRecorder, Date: *date(2010, 1, 1)},
+		},
+		[]*entities.TimelineEvent{
+			{Source: entities.TimelineEventSourceListing, Date: *date(2015, 1, 1)},
+		},
+		[]*entities.TimelineEvent{
+			{Source: entities.TimelineEventSourceAssessor, Date: *date(2020, 1, 1)},
+		},
+	)
+
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, *date(2010, 1, 1), events[0].Date)
+		assert.Equal(t, *date(2015, 1, 1), events[1].Date)
+		assert.Equal(t, entities.TimelineEventSourceRecorder, events[2].Source)
+		assert.Equal(t, entities.TimelineEventSourceAssessor, events[3].Source)
+	}
+}
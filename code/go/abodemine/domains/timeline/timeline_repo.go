@@ -0,0 +1,127 @@
+package timeline
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+)
+
+type Repository interface {
+	SelectAssessmentRecords(r *arc.Request, in *SelectAssessmentRecordsInput) (*SelectAssessmentRecordsOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// AssessmentRecord is a single assessed tax year of a property,
+// taken either from the current assessor data or its history.
+type AssessmentRecord struct {
+	TaxYear         *int
+	PublicationDate *time.Time
+
+	AssessedValueTotal *int
+	MarketValueTotal   *int
+}
+
+type SelectAssessmentRecordsInput struct {
+	Aupid *uuid.UUID
+}
+
+type SelectAssessmentRecordsOutput struct {
+	Records []*AssessmentRecord
+}
+
+func (repo *repository) SelectAssessmentRecords(r *arc.Request, in *SelectAssessmentRecordsInput) (*SelectAssessmentRecordsOutput, error) {
+	columns := []string{
+		"tax_year_assessed",
+		"publication_date",
+		"tax_assessed_value_total",
+		"tax_market_value_total",
+	}
+
+	history := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Question).
+		Select(columns...).
+		From("ad_assessor_history").
+		Where("ad_assessor_history.attomid = (select ad_attom_id from properties where id = ?)", in.Aupid)
+
+	historySql, historyArgs, err := history.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "39c67200-ea93-4fe2-8f43-a2585f60ccd3",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(columns...).
+		From("ad_df_assessor").
+		Where("ad_df_assessor.attomid = (select ad_attom_id from properties where id = ?)", in.Aupid).
+		Suffix("union all "+historySql, historyArgs...).
+		Suffix("order by 1, 2")
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "8be4a7fd-9c9d-4948-b34f-2d0a41548a7d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresDatapipe, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "cd22fdc7-4384-4ef3-8a15-4d7af01749aa")
+	}
+	defer rows.Close()
+
+	out := &SelectAssessmentRecordsOutput{}
+
+	for rows.Next() {
+		record := &AssessmentRecord{}
+
+		if err := rows.Scan(
+			&record.TaxYear,
+			&record.PublicationDate,
+			&record.AssessedValueTotal,
+			&record.MarketValueTotal,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "9a5cca55-f796-453a-bd2b-1a4ded1b5813",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to select row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "fd70d0f5-1401-47f2-8892-a460ec5001b7",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to iterate rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
@@ -19,12 +19,17 @@ const (
 	TypeSaasServerSession
 	TypeTokenExchange
 	TypeOneTimePassword
+	TypeESOWhiteLabelServerSession
+	TypeOidcState
 )
 
 var validTokenTypes = map[uint16]struct{}{
 	TypeSaasServerSession: {},
 	TypeTokenExchange:     {},
 	TypeOneTimePassword:   {},
+
+	TypeESOWhiteLabelServerSession: {},
+	TypeOidcState:                  {},
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -14,6 +14,7 @@ import (
 	"abodemine/domains/arc"
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
 	"abodemine/lib/val"
 )
 
@@ -35,6 +36,9 @@ func NewDomain(in *NewDomainInput) Domain {
 }
 
 type CreateTokenInput struct {
+	// Generated when nil.
+	Id uuid.UUID
+
 	OrganizationId uuid.UUID
 	TokenType      uint16
 	Body           []byte
@@ -81,12 +85,21 @@ func (dom *domain) CreateToken(r *arc.Request, in *CreateTokenInput) (*CreateTok
 		}
 	}
 
-	id, err := val.NewUUID4()
-	if err != nil {
-		return nil, errors.Forward(err, "4d059d57-a2cc-40c8-8654-72b24d1120fb")
+	id := in.Id
+
+	if id == uuid.Nil {
+		newId, err := val.NewUUID4()
+		if err != nil {
+			return nil, errors.Forward(err, "4d059d57-a2cc-40c8-8654-72b24d1120fb")
+		}
+
+		id = newId
 	}
 
-	var body []byte
+	var (
+		body []byte
+		err  error
+	)
 
 	if in.Value == nil {
 		body = in.Body
@@ -98,6 +111,7 @@ func (dom *domain) CreateToken(r *arc.Request, in *CreateTokenInput) (*CreateTok
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to marshal token value",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -179,6 +193,7 @@ func (dom *domain) CreateToken(r *arc.Request, in *CreateTokenInput) (*CreateTok
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute script.",
 			Cause:  scriptOut.Error().Error(),
+			Err:    scriptOut.Error(),
 		}
 	}
 
@@ -189,6 +204,7 @@ func (dom *domain) CreateToken(r *arc.Request, in *CreateTokenInput) (*CreateTok
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -288,6 +304,7 @@ func (dom *domain) SelectToken(r *arc.Request, in *SelectTokenInput) (Token, err
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to execute script.",
 				Cause:  scriptOut.Error().Error(),
+				Err:    scriptOut.Error(),
 			}
 		}
 	}
@@ -299,6 +316,7 @@ func (dom *domain) SelectToken(r *arc.Request, in *SelectTokenInput) (Token, err
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -317,6 +335,7 @@ func (dom *domain) SelectToken(r *arc.Request, in *SelectTokenInput) (Token, err
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output[0].",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -330,6 +349,7 @@ func (dom *domain) SelectToken(r *arc.Request, in *SelectTokenInput) (Token, err
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to parse script output[1].",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -406,6 +426,7 @@ func (dom *domain) DeleteToken(r *arc.Request, in *DeleteTokenInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute script.",
 			Cause:  scriptOut.Error().Error(),
+			Err:    scriptOut.Error(),
 		}
 	}
 
@@ -497,6 +518,10 @@ func (dom *domain) EncodeToken(r *arc.Request, in *EncodeTokenInput) (*EncodeTok
 			}
 		}
 
+		// Tokens carry the id of their key, so they can be
+		// verified after the signing key is rotated.
+		token.SetFooter(pasetoConfig.Footer())
+
 		// Remove paseto header.
 		encoded.WriteString(strings.TrimPrefix(
 			token.V4Encrypt(pasetoConfig.V4SymmetricKey, nil),
@@ -556,6 +581,7 @@ func (dom *domain) DecodeToken(r *arc.Request, in *DecodeTokenInput) (*DecodeTok
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Invalid version.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -568,13 +594,35 @@ func (dom *domain) DecodeToken(r *arc.Request, in *DecodeTokenInput) (*DecodeTok
 
 		tokenStr := "v4.local." + in.Value[3:]
 
-		token, err := pasetoConfig.Parser.ParseV4Local(pasetoConfig.V4SymmetricKey, tokenStr, nil)
+		footer, err := pasetoConfig.Parser.UnsafeParseFooter(paseto.V4Local, tokenStr)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "cd063da4-3707-45a9-8f90-f80589fc4fdd",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid auth token.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		keyId, err := gconf.ParseFooterKeyId(footer)
+		if err != nil {
+			return nil, errors.Forward(err, "fc68fb9b-d80a-4cc5-ac06-dfe4720b2085")
+		}
+
+		key, err := pasetoConfig.SelectVerificationKey(keyId, time.Now())
+		if err != nil {
+			return nil, errors.Forward(err, "ec1ee77a-f959-441b-9abd-e4c62230588e")
+		}
+
+		token, err := pasetoConfig.Parser.ParseV4Local(key.V4SymmetricKey, tokenStr, nil)
 		if err != nil {
 			return nil, &errors.Object{
 				Id:     "c126b63e-bec6-4ea9-9ead-800fb556577a",
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Invalid auth token.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -585,6 +633,7 @@ func (dom *domain) DecodeToken(r *arc.Request, in *DecodeTokenInput) (*DecodeTok
 				Code:   errors.Code_INTERNAL,
 				Detail: "Token type not found.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -609,6 +658,7 @@ func (dom *domain) DecodeToken(r *arc.Request, in *DecodeTokenInput) (*DecodeTok
 				Code:   errors.Code_INTERNAL,
 				Detail: "Token body not found.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
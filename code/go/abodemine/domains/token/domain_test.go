@@ -6,11 +6,14 @@ import (
 	"testing"
 	"time"
 
+	"aidanwoods.dev/go-paseto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"abodemine/domains/arc"
 	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
 	"abodemine/lib/must"
 	"abodemine/lib/val"
 	"abodemine/projects/ci/conf"
@@ -460,3 +463,90 @@ func TestDomain_DeleteToken(t *testing.T) {
 		})
 	}
 }
+
+func TestDomain_DecodeToken_KeyRotation(t *testing.T) {
+	oldKey := paseto.NewV4SymmetricKey().ExportHex()
+	newKey := paseto.NewV4SymmetricKey().ExportHex()
+
+	newRequest := func(config *gconf.Paseto) *arc.Request {
+		item, err := gconf.LoadPaseto(config)
+		require.NoError(t, err)
+
+		pasetoCache := val.NewCache[string, *gconf.PasetoCacheItem]()
+		pasetoCache.Set("test", item)
+
+		r, err := arc.NewDomain(&arc.NewDomainInput{
+			Paseto: pasetoCache,
+		}).CreateRequest(&arc.CreateRequestInput{
+			Context: context.Background(),
+		})
+		require.NoError(t, err)
+
+		return r
+	}
+
+	encode := func(r *arc.Request) string {
+		out, err := NewDomain(nil).EncodeToken(r, &EncodeTokenInput{
+			Id:               uuid.New(),
+			TokenType:        TypeTokenExchange,
+			Expire:           time.Minute,
+			Version:          VersionPasetoV4Local,
+			PasetoConfigName: "test",
+		})
+		require.NoError(t, err)
+
+		return out.Value
+	}
+
+	decode := func(r *arc.Request, value string) error {
+		_, err := NewDomain(nil).DecodeToken(r, &DecodeTokenInput{
+			Value:            value,
+			TokenType:        TypeTokenExchange,
+			PasetoConfigName: "test",
+		})
+
+		return err
+	}
+
+	// Tokens signed before key ids were introduced have no footer.
+	legacyRequest := newRequest(&gconf.Paseto{Key: oldKey})
+	legacyToken := encode(legacyRequest)
+	require.NoError(t, decode(legacyRequest, legacyToken))
+
+	rotatedRequest := newRequest(&gconf.Paseto{
+		KeyId: "k2",
+		Key:   newKey,
+		VerificationKeys: []*gconf.PasetoKey{
+			{Key: oldKey, ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	})
+
+	newToken := encode(rotatedRequest)
+	assert.NoError(t, decode(rotatedRequest, newToken))
+	assert.NoError(t, decode(rotatedRequest, legacyToken))
+
+	// The legacy verifier doesn't know the new key id.
+	assert.Error(t, decode(legacyRequest, newToken))
+
+	// Tokens of expired keys are rejected.
+	expiredRequest := newRequest(&gconf.Paseto{
+		KeyId: "k2",
+		Key:   newKey,
+		VerificationKeys: []*gconf.PasetoKey{
+			{Key: oldKey, ExpiresAt: time.Now().Add(-time.Second)},
+		},
+	})
+
+	assert.NoError(t, decode(expiredRequest, newToken))
+	assert.Error(t, decode(expiredRequest, legacyToken))
+
+	// Duplicate key ids are rejected.
+	_, err := gconf.LoadPaseto(&gconf.Paseto{
+		KeyId: "k2",
+		Key:   newKey,
+		VerificationKeys: []*gconf.PasetoKey{
+			{KeyId: "k2", Key: oldKey},
+		},
+	})
+	assert.Error(t, err)
+}
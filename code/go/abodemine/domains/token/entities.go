@@ -79,6 +79,7 @@ func (c *tokenContainer) MarshalBinary() ([]byte, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to marshal.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -96,6 +97,7 @@ func (c *tokenContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to create zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -112,6 +114,7 @@ func (c *tokenContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to write to zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -121,6 +124,7 @@ func (c *tokenContainer) MarshalBinary() ([]byte, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to close zstd writer.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -152,6 +156,7 @@ func (c *tokenContainer) UnmarshalBinary(data []byte) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to create zstd reader.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -164,6 +169,7 @@ func (c *tokenContainer) UnmarshalBinary(data []byte) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to read from zstd reader.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -176,6 +182,7 @@ func (c *tokenContainer) UnmarshalBinary(data []byte) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to unmarshal server session.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
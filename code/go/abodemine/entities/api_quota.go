@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApiQuota is the api provisioning of an organization.
+type ApiQuota struct {
+	OrganizationId uuid.UUID `json:"organizationId"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+
+	DailyQuota   int64 `json:"dailyQuota"`
+	MonthlyQuota int64 `json:"monthlyQuota"`
+
+	// Enabled state by usage layout name. The base
+	// layout is always enabled and isn't listed.
+	Layouts map[string]bool `json:"layouts"`
+
+	// Nil when the quota never expires.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ApiSession is the cached session of an api key.
+type ApiSession struct {
+	ApiKeyId   uuid.UUID  `json:"apiKeyId"`
+	Name       string     `json:"name,omitempty"`
+	KeyStatus  string     `json:"keyStatus"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// False when the key has no cached session. The other
+	// fields below are only set for cached sessions.
+	Cached bool `json:"cached"`
+
+	Invalid        bool       `json:"invalid,omitempty"`
+	QuotaExhausted string     `json:"quotaExhausted,omitempty"`
+	EnabledLayouts []string   `json:"enabledLayouts,omitempty"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+}
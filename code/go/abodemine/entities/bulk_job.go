@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/lib/errors"
+)
+
+const (
+	BulkJobStatusPending   = "PENDING"
+	BulkJobStatusRunning   = "RUNNING"
+	BulkJobStatusSucceeded = "SUCCEEDED"
+	BulkJobStatusFailed    = "FAILED"
+
+	BulkJobFormatCsv    = "CSV"
+	BulkJobFormatNdjson = "NDJSON"
+)
+
+// BulkJob enriches an uploaded file of addresses or AUPIDs
+// asynchronously. The results are delivered as an NDJSON file
+// of BulkJobResult lines through the secure-download endpoint.
+type BulkJob struct {
+	Id uuid.UUID `json:"id"`
+
+	Status  string   `json:"status"`
+	Format  string   `json:"format"`
+	Layouts []string `json:"layouts"`
+
+	TotalRows    int `json:"totalRows"`
+	EnrichedRows int `json:"enrichedRows"`
+	FailedRows   int `json:"failedRows"`
+
+	Error *string `json:"error,omitempty"`
+
+	Download *BulkJobDownload `json:"download,omitempty"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// BulkJobDownload is redeemed at the secure-download endpoint,
+// sending Token as a bearer token and Object in the
+// X-AbodeMine-S3-Object header.
+type BulkJobDownload struct {
+	Token     string    `json:"token"`
+	Object    string    `json:"object"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// BulkJobResult is one line of the result file, in input order.
+type BulkJobResult struct {
+	// Row is the 1-based position of the row in the input,
+	// excluding the CSV header.
+	Row int `json:"row"`
+
+	// Id is the optional reference sent with the row.
+	Id string `json:"id,omitempty"`
+
+	Properties []*Property `json:"properties"`
+
+	Error *errors.Object `json:"error,omitempty"`
+}
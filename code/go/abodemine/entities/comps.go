@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	CompSaleSourceRecorder = "RECORDER"
+	CompSaleSourceListing  = "LISTING"
+)
+
+// CompSale is a recent arm's-length sale selected as comparable
+// to a subject property, along with its similarity score and
+// the price adjustments towards the subject characteristics.
+type CompSale struct {
+	Aupid *uuid.UUID `json:"aupid,omitempty"`
+
+	Source    string          `json:"source"`
+	SaleDate  time.Time       `json:"saleDate"`
+	SalePrice decimal.Decimal `json:"salePrice"`
+
+	DistanceMiles float64 `json:"distanceMiles"`
+
+	LivingAreaSqFt *int             `json:"livingAreaSqFt,omitempty"`
+	Bedrooms       *int             `json:"bedrooms,omitempty"`
+	Bathrooms      *decimal.Decimal `json:"bathrooms,omitempty"`
+	YearBuilt      *int             `json:"yearBuilt,omitempty"`
+	LotSizeSqFt    *decimal.Decimal `json:"lotSizeSqFt,omitempty"`
+
+	// SimilarityScore ranges from 0 (dissimilar) to 1 (identical).
+	SimilarityScore float64 `json:"similarityScore"`
+
+	Adjustments   *CompAdjustments `json:"adjustments,omitempty"`
+	AdjustedPrice decimal.Decimal  `json:"adjustedPrice"`
+}
+
+// CompAdjustments are the amounts added to the sale price of a comp
+// to account for its differences from the subject property.
+type CompAdjustments struct {
+	LivingArea decimal.Decimal `json:"livingArea"`
+	Bedrooms   decimal.Decimal `json:"bedrooms"`
+	Bathrooms  decimal.Decimal `json:"bathrooms"`
+	YearBuilt  decimal.Decimal `json:"yearBuilt"`
+	LotSize    decimal.Decimal `json:"lotSize"`
+	Total      decimal.Decimal `json:"total"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MarketStats holds the listing aggregates for a single
+// time bucket (week or month) of a market area.
+type MarketStats struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+
+	ListingCount int64 `json:"listingCount"`
+	SoldCount    int64 `json:"soldCount"`
+
+	MedianListPrice       *decimal.Decimal `json:"medianListPrice,omitempty"`
+	MedianPricePerSqFt    *decimal.Decimal `json:"medianPricePerSqFt,omitempty"`
+	MedianDaysOnMarket    *decimal.Decimal `json:"medianDaysOnMarket,omitempty"`
+	MedianSoldPrice       *decimal.Decimal `json:"medianSoldPrice,omitempty"`
+	MedianSoldToListRatio *decimal.Decimal `json:"medianSoldToListRatio,omitempty"`
+
+	// Open listing counts at the end of the period, keyed by
+	// their ListingStatus at that time.
+	InventoryByStatus map[string]int64 `json:"inventoryByStatus"`
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Property struct {
 	Aupid *uuid.UUID `json:"aupid,omitempty"`
@@ -12,6 +16,20 @@ type Property struct {
 	Recorder []*Recorder      `json:"recorder,omitempty"`
 	Rental   *RentalAvm       `json:"rentEstimate,omitempty"`
 	Sale     *SaleAvm         `json:"saleEstimate,omitempty"`
+	Timeline []*TimelineEvent `json:"timeline,omitempty"`
+
+	Meta *PropertyMeta `json:"meta,omitempty"`
+}
+
+// PropertyMeta describes how the property was served.
+// It is only set when some layouts came from the cache.
+type PropertyMeta struct {
+	// CachedAt is when the oldest cached layout was read
+	// from the database.
+	CachedAt *time.Time `json:"cachedAt,omitempty"`
+
+	// CacheAge is the age of the oldest cached layout, in seconds.
+	CacheAge *int64 `json:"cacheAge,omitempty"`
 }
 
 // PropertyRef holds references to a given
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TimelineEventTypeSale                = "SALE"
+	TimelineEventTypeTransfer            = "TRANSFER"
+	TimelineEventTypeRefinance           = "REFINANCE"
+	TimelineEventTypeListingStatusChange = "LISTING_STATUS_CHANGE"
+	TimelineEventTypeListingPriceChange  = "LISTING_PRICE_CHANGE"
+	TimelineEventTypeAssessedValueChange = "ASSESSED_VALUE_CHANGE"
+
+	TimelineEventSourceRecorder = "RECORDER"
+	TimelineEventSourceListing  = "LISTING"
+	TimelineEventSourceAssessor = "ASSESSOR"
+)
+
+// TimelineEvent is a single dated event in the history of a property,
+// derived from the recorder, listing or assessor data.
+type TimelineEvent struct {
+	EventType string    `json:"eventType"`
+	Source    string    `json:"source"`
+	Date      time.Time `json:"date"`
+
+	Amount         *decimal.Decimal `json:"amount,omitempty"`
+	PreviousAmount *decimal.Decimal `json:"previousAmount,omitempty"`
+
+	// Set for listing events.
+	ListingStatus *string `json:"listingStatus,omitempty"`
+
+	References *TimelineEventReferences `json:"references,omitempty"`
+}
+
+// TimelineEventReferences holds the identifiers that allow
+// a timeline event to be traced back to its source document.
+type TimelineEventReferences struct {
+	// Recorder.
+	TransactionId    *int64  `json:"transactionId,string,omitempty"`
+	DocumentTypeCode *string `json:"documentTypeCode,omitempty"`
+	DocumentNumber   *string `json:"documentNumber,omitempty"`
+	InstrumentNumber *string `json:"instrumentNumber,omitempty"`
+	Book             *string `json:"book,omitempty"`
+	Page             *string `json:"page,omitempty"`
+
+	// Listing.
+	MlsNumber    *string `json:"mlsNumber,omitempty"`
+	MlsListingId *int64  `json:"mlsListingId,string,omitempty"`
+	MlsRecordId  *int64  `json:"mlsRecordId,string,omitempty"`
+
+	// Assessor.
+	TaxYear *int `json:"taxYear,omitempty"`
+}
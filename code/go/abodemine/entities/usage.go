@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Layout names of usage amounts. Requests without layouts
+// are counted as one base request.
+const (
+	UsageLayoutBase         = "base"
+	UsageLayoutAddress      = "address"
+	UsageLayoutAssessor     = "assessor"
+	UsageLayoutComps        = "comps"
+	UsageLayoutListing      = "listing"
+	UsageLayoutRecorder     = "recorder"
+	UsageLayoutRentEstimate = "rentEstimate"
+	UsageLayoutSaleEstimate = "saleEstimate"
+	UsageLayoutMarketStats  = "marketStats"
+	UsageLayoutTimeline     = "timeline"
+
+	UsagePeriodDaily   = "DAILY"
+	UsagePeriodMonthly = "MONTHLY"
+)
+
+// UsageLayouts lists the usage layouts in reporting order.
+var UsageLayouts = []string{
+	UsageLayoutBase,
+	UsageLayoutAddress,
+	UsageLayoutAssessor,
+	UsageLayoutComps,
+	UsageLayoutListing,
+	UsageLayoutRecorder,
+	UsageLayoutRentEstimate,
+	UsageLayoutSaleEstimate,
+	UsageLayoutMarketStats,
+	UsageLayoutTimeline,
+}
+
+// Usage is the quota consumption of an organization
+// for a day and the month of that day.
+type Usage struct {
+	Daily   *UsagePeriod `json:"daily"`
+	Monthly *UsagePeriod `json:"monthly"`
+
+	// Quota thresholds crossed during the month, oldest first.
+	Notifications []*UsageNotification `json:"notifications"`
+}
+
+type UsagePeriod struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+
+	// Zero when the organization has no quota.
+	Quota    int64 `json:"quota"`
+	Used     int64 `json:"used"`
+	Requests int64 `json:"requests"`
+
+	ByLayout map[string]int64 `json:"byLayout"`
+	ByApiKey []*UsageByApiKey `json:"byApiKey"`
+}
+
+type UsageByApiKey struct {
+	// Nil for usage not tied to an api key.
+	ApiKeyId *uuid.UUID `json:"apiKeyId,omitempty"`
+
+	Used     int64            `json:"used"`
+	Requests int64            `json:"requests"`
+	ByLayout map[string]int64 `json:"byLayout"`
+}
+
+type UsageNotification struct {
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"periodStart"`
+	Percent     int16     `json:"percent"`
+	Quota       int64     `json:"quota"`
+	Usage       int64     `json:"usage"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// BillingStatement is the usage of an organization for a month.
+type BillingStatement struct {
+	OrganizationId uuid.UUID `json:"organizationId"`
+
+	*UsagePeriod
+}
@@ -7,6 +7,8 @@ package consts
 const (
 	ConfigKeyCasbinApiDefault  = "api/default"
 	ConfigKeyCasbinSaasDefault = "saas/default"
+
+	ConfigKeyCasbinSaasWhitelabel = "saas/whitelabel"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -16,6 +18,8 @@ const (
 const (
 	ConfigKeyDurationApiTokenExchangeTtl = "api_token_exchange_ttl"
 
+	ConfigKeyDurationPropertyCacheTtl = "property_cache_ttl"
+
 	ConfigKeyDurationSaasSession = "saas_session"
 
 	ConfigKeyDurationSaasWhitelabelSessionDefault = "saas_whitelabel_session_default"
@@ -58,6 +62,7 @@ const (
 
 const (
 	ConfigKeyValkeyApi     = "api"
+	ConfigKeyValkeyCache   = "cache"
 	ConfigKeyValkeySession = "session"
 	ConfigKeyValkeyToken   = "token"
 )
@@ -12,6 +12,12 @@ import (
 
 const (
 	CookieAbodeMineSaasWebSession = "am-sws"
+
+	// Default of white-label tenants.
+	CookieAbodeMineESOWebSession = "am-ews"
+
+	// Holds the state token of an OIDC login in progress.
+	CookieAbodeMineOidcState = "am-oidc"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -45,6 +51,10 @@ func AbodeMineBotUserId() uuid.UUID {
 ////////////////////////////////////////////////////////////////////////////////
 
 const (
+	RoleRoot                = "root"
+	RoleApiAdminUser        = "api_admin_user"
+	RoleSaasAdminUser       = "saas_admin_user"
+	RoleSaasUser            = "saas_user"
 	RoleSaasWhitelabelUser  = "saas_whitelabel_user"
 	RoleSystemAuthCheckUser = "system_auth_check_user"
 )
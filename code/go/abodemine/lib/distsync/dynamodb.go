@@ -80,6 +80,7 @@ func (l *DynamoDB) Extend(ctx context.Context) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create expression.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"lock_type": l.lock.Type,
 			},
@@ -101,6 +102,7 @@ func (l *DynamoDB) Extend(ctx context.Context) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update lock item.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -138,6 +140,7 @@ func (l *DynamoDB) Lock(ctx context.Context, lock *Lock) error {
 				Code:   errors.Code_CANCELED,
 				Detail: "Context was canceled.",
 				Cause:  ctx.Err().Error(),
+				Err:    ctx.Err(),
 			}
 		default:
 			acquired, err := l.tryAcquire(ctx)
@@ -225,6 +228,7 @@ func (l *DynamoDB) tryAcquire(ctx context.Context) (bool, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create expression.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"lock_type": l.lock.Type,
 			},
@@ -255,6 +259,7 @@ func (l *DynamoDB) tryAcquire(ctx context.Context) (bool, error) {
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Lock condition failed.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -266,6 +271,7 @@ func (l *DynamoDB) tryAcquire(ctx context.Context) (bool, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update lock item.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -296,6 +302,7 @@ WAIT_READERS:
 				Code:   errors.Code_CANCELED,
 				Detail: "Context was canceled.",
 				Cause:  ctx.Err().Error(),
+				Err:    ctx.Err(),
 			}
 		default:
 			getItemOut, err := l.Client.GetItem(ctx, &dynamodb.GetItemInput{
@@ -311,6 +318,7 @@ WAIT_READERS:
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to get lock item.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -340,6 +348,7 @@ WAIT_READERS:
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to parse reader_count.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -369,6 +378,7 @@ func (l *DynamoDB) Status(ctx context.Context, id string) (*LockStatus, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get lock item.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -420,6 +430,7 @@ func (l *DynamoDB) Status(ctx context.Context, id string) (*LockStatus, error) {
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to parse writer_expires_at.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -462,6 +473,7 @@ func (l *DynamoDB) Status(ctx context.Context, id string) (*LockStatus, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse reader_count.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -497,6 +509,7 @@ func (l *DynamoDB) Status(ctx context.Context, id string) (*LockStatus, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse reader_expires_at.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -558,6 +571,7 @@ func (l *DynamoDB) Unlock(ctx context.Context) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create expression.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"lock_type": l.lock.Type,
 			},
@@ -579,6 +593,7 @@ func (l *DynamoDB) Unlock(ctx context.Context) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update lock item.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -0,0 +1,357 @@
+// Package errcatalog collects the ids of the errors.Object of the
+// module, to generate the error catalog of lib/errors and to lint it.
+package errcatalog
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GeneratedFile is the file of lib/errors holding the catalog.
+const GeneratedFile = "catalog_gen.go"
+
+// RetryableDirective overrides the retryability of the error
+// declared on the next line, e.g. //errcatalog:retryable=false.
+const RetryableDirective = "//errcatalog:retryable="
+
+// CodeDefault is what the errors of a code mean to the clients,
+// unless their declaration overrides it.
+type CodeDefault struct {
+	Title     string
+	Retryable bool
+}
+
+// CodeDefaults are keyed by the names of the code constants of
+// lib/errors.
+var CodeDefaults = map[string]CodeDefault{
+	"Code_CANCELED":            {"Request canceled", true},
+	"Code_UNKNOWN":             {"Unknown error", false},
+	"Code_INVALID_ARGUMENT":    {"Invalid argument", false},
+	"Code_DEADLINE_EXCEEDED":   {"Deadline exceeded", true},
+	"Code_NOT_FOUND":           {"Not found", false},
+	"Code_ALREADY_EXISTS":      {"Already exists", false},
+	"Code_PERMISSION_DENIED":   {"Permission denied", false},
+	"Code_RESOURCE_EXHAUSTED":  {"Resource exhausted", true},
+	"Code_FAILED_PRECONDITION": {"Failed precondition", false},
+	"Code_ABORTED":             {"Aborted", true},
+	"Code_OUT_OF_RANGE":        {"Out of range", false},
+	"Code_UNIMPLEMENTED":       {"Not implemented", false},
+	"Code_INTERNAL":            {"Internal error", false},
+	"Code_UNAVAILABLE":         {"Service unavailable", true},
+	"Code_DATA_LOSS":           {"Data loss", false},
+	"Code_UNAUTHENTICATED":     {"Unauthenticated", false},
+}
+
+// Declaration is an error id found in the source.
+type Declaration struct {
+	Id string
+
+	// Code is the expression of the code of the error, such as
+	// Code_NOT_FOUND, or empty if it is not a constant.
+	Code string
+
+	// Title is the title of the error, if set in the source.
+	Title string
+
+	// Retryable is set by a RetryableDirective in the source.
+	Retryable *bool
+
+	// Origin is true for the ids of the errors a chain starts
+	// from, and false for the ids of errors.Forward.
+	Origin bool
+
+	Pos token.Position
+}
+
+type Result struct {
+	Declarations []*Declaration
+}
+
+// Origins returns the declarations of the errors a chain starts
+// from, which are the ones the clients see.
+func (r *Result) Origins() []*Declaration {
+	var out []*Declaration
+
+	for _, d := range r.Declarations {
+		if d.Origin {
+			out = append(out, d)
+		}
+	}
+
+	return out
+}
+
+// Duplicates returns the declarations of the ids declared more than
+// once, by id.
+func (r *Result) Duplicates() map[string][]*Declaration {
+	byId := make(map[string][]*Declaration)
+
+	for _, d := range r.Declarations {
+		byId[d.Id] = append(byId[d.Id], d)
+	}
+
+	out := make(map[string][]*Declaration)
+
+	for id, declarations := range byId {
+		if len(declarations) > 1 {
+			out[id] = declarations
+		}
+	}
+
+	return out
+}
+
+// Scan parses the non-test Go files under root.
+func Scan(root string) (*Result, error) {
+	result := new(Result)
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			name := entry.Name()
+
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		name := entry.Name()
+
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == GeneratedFile {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+
+		// The package declaring the errors refers to them unqualified.
+		qualifier := "errors"
+
+		if file.Name.Name == "errors" && filepath.Base(filepath.Dir(path)) == "errors" {
+			qualifier = ""
+		}
+
+		declarations, err := scanFile(fset, file, qualifier)
+		if err != nil {
+			return err
+		}
+
+		result.Declarations = append(result.Declarations, declarations...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result.Declarations, func(i, j int) bool {
+		return result.Declarations[i].Id < result.Declarations[j].Id
+	})
+
+	return result, nil
+}
+
+func scanFile(fset *token.FileSet, file *ast.File, qualifier string) ([]*Declaration, error) {
+	var out []*Declaration
+
+	// The retryability directives, by line.
+	directives := make(map[int]bool)
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			v, ok := strings.CutPrefix(comment.Text, RetryableDirective)
+			if !ok {
+				continue
+			}
+
+			retryable, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid directive at %s: %w", fset.Position(comment.Pos()), err)
+			}
+
+			directives[fset.Position(comment.Pos()).Line] = retryable
+		}
+	}
+
+	retryableAt := func(pos token.Position) *bool {
+		if v, ok := directives[pos.Line-1]; ok {
+			return &v
+		}
+
+		return nil
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if !isName(n.Type, qualifier, "Object") {
+				return true
+			}
+
+			d := &Declaration{
+				Origin: true,
+				Pos:    fset.Position(n.Pos()),
+			}
+
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				switch key.Name {
+				case "Id":
+					d.Id = stringLit(kv.Value)
+				case "Code":
+					d.Code = codeExpr(kv.Value, qualifier)
+				case "Title":
+					d.Title = stringLit(kv.Value)
+				}
+			}
+
+			if d.Id != "" {
+				d.Retryable = retryableAt(d.Pos)
+				out = append(out, d)
+			}
+		case *ast.CallExpr:
+			var d *Declaration
+
+			switch {
+			case isName(n.Fun, qualifier, "Forward") && len(n.Args) == 2:
+				d = &Declaration{Id: stringLit(n.Args[1])}
+			case isName(n.Fun, qualifier, "Unauthenticated") && len(n.Args) == 2:
+				d = &Declaration{Id: stringLit(n.Args[1]), Code: "Code_UNAUTHENTICATED", Origin: true}
+			case isName(n.Fun, qualifier, "Internal") && len(n.Args) == 1:
+				d = &Declaration{Id: stringLit(n.Args[0]), Code: "Code_INTERNAL", Title: "Internal error", Origin: true}
+			}
+
+			if d != nil && d.Id != "" {
+				d.Pos = fset.Position(n.Pos())
+
+				if d.Origin {
+					d.Retryable = retryableAt(d.Pos)
+				}
+
+				out = append(out, d)
+			}
+		}
+
+		return true
+	})
+
+	return out, nil
+}
+
+func isName(expr ast.Expr, qualifier, name string) bool {
+	if qualifier == "" {
+		ident, ok := expr.(*ast.Ident)
+		return ok && ident.Name == name
+	}
+
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+
+	x, ok := sel.X.(*ast.Ident)
+
+	return ok && x.Name == qualifier
+}
+
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+
+	return s
+}
+
+func codeExpr(expr ast.Expr, qualifier string) string {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if v.Kind == token.INT {
+			return v.Value
+		}
+	case *ast.Ident:
+		if qualifier == "" && strings.HasPrefix(v.Name, "Code_") {
+			return v.Name
+		}
+	case *ast.SelectorExpr:
+		if x, ok := v.X.(*ast.Ident); ok && x.Name == qualifier && strings.HasPrefix(v.Sel.Name, "Code_") {
+			return v.Sel.Name
+		}
+	}
+
+	return ""
+}
+
+// Generate returns the source of the catalog of the origins. The
+// titles and the retryability not declared in the source are the
+// defaults of their code.
+func Generate(result *Result) ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	b.WriteString("// Code generated by tools/errcatalog. DO NOT EDIT.\n\n")
+	b.WriteString("package errors\n\n")
+	b.WriteString("var catalogEntries = []*CatalogEntry{\n")
+
+	for _, d := range result.Origins() {
+		fmt.Fprintf(b, "\t{Id: %q", d.Id)
+
+		if d.Code != "" {
+			fmt.Fprintf(b, ", Code: %s", d.Code)
+		}
+
+		codeDefault := CodeDefaults[d.Code]
+
+		title := d.Title
+		if title == "" {
+			title = codeDefault.Title
+		}
+
+		if title != "" {
+			fmt.Fprintf(b, ", Title: %q", title)
+		}
+
+		retryable := codeDefault.Retryable
+		if d.Retryable != nil {
+			retryable = *d.Retryable
+		}
+
+		fmt.Fprintf(b, ", Retryable: %t", retryable)
+
+		b.WriteString("},\n")
+	}
+
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:generate go run ../../tools/errcatalog --root ../..
+
+// ProblemTypeBase prefixes the slugs of the errors into the type
+// URI of their problem details.
+const ProblemTypeBase = "https://docs.abodemine.com/docs/errors/"
+
+// CatalogEntry declares what an error Id means to the clients.
+type CatalogEntry struct {
+	Id        string `json:"id"`
+	Code      int    `json:"code"`
+	Status    int    `json:"status"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Retryable bool   `json:"retryable"`
+}
+
+type codeInfo struct {
+	title     string
+	slug      string
+	retryable bool
+}
+
+// codeInfos derive the entries of the Ids missing from the catalog.
+// Declared entries only take their slug from them. They must match
+// the CodeDefaults of lib/errcatalog.
+var codeInfos = map[int]codeInfo{
+	Code_CANCELED:            {"Request canceled", "canceled", true},
+	Code_UNKNOWN:             {"Unknown error", "unknown", false},
+	Code_INVALID_ARGUMENT:    {"Invalid argument", "invalid-argument", false},
+	Code_DEADLINE_EXCEEDED:   {"Deadline exceeded", "deadline-exceeded", true},
+	Code_NOT_FOUND:           {"Not found", "not-found", false},
+	Code_ALREADY_EXISTS:      {"Already exists", "already-exists", false},
+	Code_PERMISSION_DENIED:   {"Permission denied", "permission-denied", false},
+	Code_RESOURCE_EXHAUSTED:  {"Resource exhausted", "resource-exhausted", true},
+	Code_FAILED_PRECONDITION: {"Failed precondition", "failed-precondition", false},
+	Code_ABORTED:             {"Aborted", "aborted", true},
+	Code_OUT_OF_RANGE:        {"Out of range", "out-of-range", false},
+	Code_UNIMPLEMENTED:       {"Not implemented", "unimplemented", false},
+	Code_INTERNAL:            {"Internal error", "internal", false},
+	Code_UNAVAILABLE:         {"Service unavailable", "unavailable", true},
+	Code_DATA_LOSS:           {"Data loss", "data-loss", false},
+	Code_UNAUTHENTICATED:     {"Unauthenticated", "unauthenticated", false},
+}
+
+// catalog holds the entries of catalog_gen.go, with the status
+// and the slug of their code.
+var catalog = func() map[string]*CatalogEntry {
+	out := make(map[string]*CatalogEntry, len(catalogEntries))
+
+	for _, entry := range catalogEntries {
+		out[entry.Id] = completeEntry(entry)
+	}
+
+	return out
+}()
+
+func completeEntry(entry *CatalogEntry) *CatalogEntry {
+	v := *entry
+
+	if v.Status == 0 {
+		v.Status = (&Object{Code: v.Code}).HTTPStatusCode()
+	}
+
+	if v.Title == "" {
+		v.Title = http.StatusText(v.Status)
+	}
+
+	if v.Slug == "" {
+		v.Slug = codeInfos[v.Code].slug
+	}
+
+	if v.Slug == "" {
+		v.Slug = strings.ToLower(strings.ReplaceAll(v.Title, " ", "-"))
+	}
+
+	return &v
+}
+
+// defaultEntry derives the entry of an undeclared Id from its code.
+func defaultEntry(id string, code int, title string) *CatalogEntry {
+	info := codeInfos[code]
+
+	if title == "" {
+		title = info.title
+	}
+
+	return completeEntry(&CatalogEntry{
+		Id:        id,
+		Code:      code,
+		Title:     title,
+		Retryable: info.retryable,
+	})
+}
+
+// LookupCatalog returns the entry of an error Id.
+func LookupCatalog(id string) (*CatalogEntry, bool) {
+	entry, ok := catalog[id]
+	return entry, ok
+}
+
+// Catalog returns the entries of all the error Ids, sorted by Id.
+func Catalog() []*CatalogEntry {
+	out := make([]*CatalogEntry, 0, len(catalog))
+
+	for _, entry := range catalog {
+		out = append(out, entry)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Id < out[j].Id
+	})
+
+	return out
+}
+
+// catalogEntryOf returns the entry of the object, completed from its
+// code when its Id is not declared.
+func catalogEntryOf(o *Object) *CatalogEntry {
+	if entry, ok := catalog[o.Id]; ok && entry.Code == o.Code {
+		return entry
+	}
+
+	return defaultEntry(o.Id, o.Code, o.Title)
+}
@@ -0,0 +1,1057 @@
+// Code generated by tools/errcatalog. DO NOT EDIT.
+
+package errors
+
+var catalogEntries = []*CatalogEntry{
+	{Id: "004b8aeb-27e1-4a48-a8cb-4a1e96c1f8f5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "00a7392e-de39-49d7-ad5f-df51c6e8d91a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "00a9013b-21ff-40bc-8f14-f0a8523c0c17", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "00e05b52-95c9-44eb-a7f1-f2314410f204", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "011063ec-a546-4f91-8945-2b21d81c79f5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "01299479-2cf0-462b-bea7-7360e1530016", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "015f9c91-b56f-4e28-a752-b0e4704bb154", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0197d777-5880-4582-ac8e-7553a9abe81a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "020a5a4d-f59b-4676-9957-493fe12fd722", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "022454bb-eb9c-44f6-b438-111b5fe59ff8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0287e078-88f0-4f43-976c-e0159f0fce41", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "02939888-9ac3-47ca-ac0e-7f934da25f93", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0393744f-d002-45d9-b400-29f0d23d7b4a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "03d8cfca-12fa-439b-ba46-0f0128fae567", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0494141b-b146-4e3c-9dd2-85f4452eb2ba", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "04ba6bbb-150c-4ab4-968a-ac8cfcc8603a", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "04e434a7-0b37-4b3a-955e-2a63fa32747a", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "04f801b4-e364-4f33-93ee-fe26795b7f2a", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "04fb238b-9be4-4a6d-b229-059b8515ce92", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "05396b5d-6b66-4f00-8621-f6e272f8d0be", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "05a61e46-56c5-4829-97b9-b1c3006f5e3a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "05b80090-d2bc-4eee-a100-a44a7297b1a8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "05b82600-2b03-4066-8930-edd68b7502a4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "05c86642-3f86-40a6-ba04-8d1cd07084dd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "05d1d689-74c3-48f9-83ea-6dc36bc6a773", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "062635b8-ca7f-42e1-8f80-52ebeed7a8e4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "06438a85-f0e7-4d9c-86ab-363878045489", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "066f09b9-039e-4fca-9edf-64f328996d8f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "06a235e5-533d-4fb9-b332-8589ae8ddca1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "06e2db52-631b-4a6d-8425-91f05f30de1e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "07061ccc-4aa6-4845-819e-087840cff2e6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0711fa9f-0864-4adc-9ac0-bef5f9356f85", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0789711f-71a6-4406-86b6-b3b895971195", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "07e56510-6f33-41fa-b1d3-ff263ed0e8af", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0812546d-6159-4cff-a400-ee3e2a1a4b72", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "082e9426-8543-42cb-bb14-2921b62ee0b8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "08b96192-4464-4416-8b89-228baa3ba88f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "097fae72-0459-4dd6-b69d-95d0ca815920", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "09deb0c0-3786-4c90-9285-1f7ee9cec12e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "09f6a85a-32ed-4dd7-8cc3-f9b4ed330ce6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0a29f5db-5d92-48a2-aa74-78fc65bf739f", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "0a3d3a29-ba73-49e7-9466-28e1bb1c5499", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0a5982e2-db38-4f37-9b94-d1868b2588f8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0a659f6c-302c-4ac6-9b3b-5a26161da808", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "0ae73580-53af-47fa-8860-5f3ff99ebeaa", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0b2ac439-8072-4149-a364-1ecd39288139", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0b2ae43b-6fd5-428e-8c8e-13f204707032", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0b2cb580-f57d-431c-a889-313898623b0b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0b78b5b5-bfc2-44d1-b663-581fbe02f51b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0b891402-f613-4fde-bdbe-923349ff6fc3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0d34b6d6-58d3-4292-a309-21b45575370c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0d829e48-6eda-4f05-978d-ede9cf991ff2", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0dad30a3-4c5e-404a-a000-098f8b293a3e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0f161658-deb1-425b-9d42-faf7c77c5a96", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0f366e8e-e5cf-4e87-ac51-d2cfd645c998", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0f5243f4-540d-4b49-98ac-562c9f3f35a6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "0f889387-e353-4bb4-b2f0-e93a80191c3e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "0fc5d005-5059-4af6-8756-ad815996871a", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "10d6402c-8e48-4fa9-ac33-5beadeb484d8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "10e7477b-3929-4f93-a8fa-7f36cc149e40", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1186a8fe-ba73-4ac1-8bbc-e704770b659f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "11b1ac94-acfb-4f70-bb53-870ee9ea8697", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "11bdd1aa-e58b-43cb-877e-d040e3567c96", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1216129d-8e24-468b-b14a-a56e003a4833", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "122dc423-34ad-4abd-b1e9-79df874926d9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "12fb1bf9-de13-4280-b3c9-dbf36cdd80ce", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "13ae1af8-1cab-4792-bb27-09275c5615d1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "13b3c532-4f53-43f0-969f-05ec7454c4d1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "13ed66fd-e228-430f-bca1-b5abf3f3f3c0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "14480d90-bf71-44b9-8649-2535ecd52fb8", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "14769607-e3be-4f46-8235-3331351c4394", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "14c24d1d-0b58-489e-8d20-c5c5af18dbed", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "155e0e33-59a2-4f01-8fd6-eb67a14de1ae", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1579e17a-cc80-4a90-8137-a7aa46defd21", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "158cf44e-3b9d-49b7-bcb8-7397631f726b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1592167c-59a8-4581-b188-b7cb937252e6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "15a761b1-1326-4407-adc2-c2aad14e82cb", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "1612fe20-b290-4c6f-9a24-b3c11332f356", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "164c5471-052c-4b2c-ae83-4276b6c90eec", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "16685ef5-0da7-4726-877d-bbcb0e70e488", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "16daa5bd-0a76-492e-9518-7dca679a1452", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "16e646f6-3116-4d4c-bb35-9653092bf08d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "16e71479-aa7f-4db5-9411-c7865b5a6483", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "16f0b0e7-ebc2-4052-81dd-92466c0464f1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1760a2a2-1af8-4545-8ead-032d159b97fe", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1771ed14-d571-4256-9907-ffba3f3cc682", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "17b96bde-005a-4ddf-9783-0bc0a232a015", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "17fb0929-de13-48ba-825e-b652f8567cbe", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "18017cd6-3be2-4032-9b82-8b9c7e51ac36", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "18194b1a-2450-491f-a800-8cd3ba1b4564", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "186f675a-d1d4-4947-9d7b-a7dfc902f0e1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "188f0129-412d-4797-a39e-8734900d5e9e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "18d3f538-7b90-4534-8f75-ab10c23d17c1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "199801f2-4f1a-4846-85fc-fbf7a72adc80", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "19c57ac4-d323-4eec-a919-fe3b204fb5ef", Code: Code_CANCELED, Title: "Request canceled", Retryable: true},
+	{Id: "19e8d46b-fe69-420f-9998-36de9fde7e36", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1a7d5186-ee92-43d8-90a9-cac3ca877c0d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1b16d187-3020-41df-ab42-ed493e3968cb", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1b4ca908-0121-469e-828b-87eedd6d3177", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1b4cf7fe-3ab1-4a5a-8f13-fcf55c94439b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1b6f2ee6-cc5a-4afd-9984-80be8547a213", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1bc949a9-1a39-4595-b857-5d8484870fb2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1bcb4e9d-86f3-4ef2-9122-df6172925da9", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "1bf77705-f061-4ff4-9b4f-c2f93241833d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1cc5ed49-0094-493a-a98e-8a829bdf0643", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "1cf777d7-ba7c-495c-8c13-243d90411217", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1d20f6ff-68a1-4f80-8154-6f1223c6a477", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1d420a37-8372-4e12-9f72-104e8a51432e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1d4b1ee4-2db9-4cba-a8db-9fbe2972e502", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1dc5a35c-2a23-4f8b-90ab-de01535a61e7", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1e2c5cfa-f1d6-4ecb-b968-2e219b3a5b62", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1e44df38-b834-4d7a-885c-e0bfdf19d460", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1e8b0a62-3889-46a9-81e8-c668217152c4", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "1ea795dd-0500-4359-95e2-1a855373ac48", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1ec65fb1-dcce-4084-aba2-800a92aeebf8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1ed76331-0390-4cc7-b3d0-66ff32f04869", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "1eee0dc7-0ffd-4fe4-871e-bbfeeb6fd60d", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "1f10e839-d501-4a89-b81a-b604dbc5c911", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1f1a2c75-8088-4f7b-9a60-c9ad1afc9306", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1f2ad831-bbd1-4330-bf17-a1a8ac54fcdd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1fac62f0-15c1-4327-b9fd-9213c580d446", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "1fd70b97-b2ec-455a-9099-a8f16a385b91", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "2089d39a-1b87-47d5-81b8-eb97b74f3a0c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "209c0342-321f-424f-88ab-43428382f385", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "20fba024-7496-46a7-93c5-ad14e84d598a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "21aaa962-3eec-48e9-afb4-76f22d823a00", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "21ce7d6c-ebb6-4503-a3af-163113c1c42f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "21ed994f-95ad-450c-ab59-43599d113872", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "222d21ad-c873-41c3-a4e1-d0a10e39acd6", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "22561116-04dd-43b8-9c96-09537c4ebe97", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "226f2cc9-1a37-4e2b-94a6-1c18f4837622", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2306b28f-a628-41ba-b008-acb2819446cc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "230af5a5-e6df-4241-8894-b92a85527656", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "23115865-839a-4372-8718-61995de9439b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "232b6ab9-7b56-4a1c-a258-72a517106d14", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2340d40f-a214-46c2-930b-e8a42bc67ee7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "23cf8b2f-620f-4089-92a9-6bd9474ef3ce", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "24724930-ea4f-4fe0-8e64-7a01c62b5964", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "24c3b97e-d16f-43cd-97b5-26da39992cb9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "24d4acbf-9991-4de5-83f9-bbb528d52c24", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "24e3ea13-cd51-481f-b370-177d045192c2", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2504816e-d352-45e4-a264-22a7dfe10854", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "25867a84-7cdb-44c5-ac0e-5203220da30c", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "25e55ece-0e67-47c5-b4ae-7fed4127ae15", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "263513a2-9ef0-4132-bb9f-d7ef37db2334", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2683beb9-b5fa-40e6-a9c6-a4820e4af81c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "26abbc60-f42e-4ff9-a4eb-122b33a9df50", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "26dac94d-7087-49dc-bc9a-7bf2131d7c86", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "27bf569b-90b4-4e29-8157-0979ae45ee31", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "27c97eb5-0a3e-4576-962e-514c6d5310d9", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "28434294-cfe2-4de8-a2d9-0ba04a95ad45", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "28d327d8-0286-495f-bbd7-45895844667b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "299fcdbc-a179-40a0-89a0-d6b1a4eb3742", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "29c1eb3e-8a28-4bda-85b1-04f8eeeff9f9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "29d1ed69-8fa8-46ab-8518-e68ca70bc6cf", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "29e5342b-4051-4374-8bc1-fc79e65fac7c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2a193d4f-f30c-4a5d-a854-01f1962e93d2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2a3b4c5d-6e7f-8g9h-0i1j-2k3l4m5n6o7p", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2a57d955-4083-4f2a-b3e0-7adffd1940df", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2aa71b6b-1dc8-4a42-beb0-0c86f5bbc716", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "2aa99a49-82b0-490a-8832-a705175899e7", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2adbbc2c-239e-4c28-a668-c21c7d19f21f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2bb65958-56e7-478c-b3e4-44e76c628af9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2bec7aaf-2b20-4a59-a585-ce63a7faa05d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2c0b0af4-e31a-4e03-9de0-022997569624", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2c3a4f12-f10e-4d66-85e2-5a88d980fce9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2c4dc6dd-6844-4110-b99e-49ba1cf14e5c", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "2c5f4208-f291-4639-bd69-757f0b483eea", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2c97d0ef-e279-464b-b08a-84f88b924747", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2ca5bedb-5179-44a5-9178-6796a56bcd0a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2d1247f5-6919-4b06-9641-fc338345ce81", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "2d533a6c-7466-4694-9574-5e135412bef1", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "2e112c9b-5adb-462f-b991-3ce95952d1bf", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2e4d6f8a-1c3b-4e5d-9a7f-8b2c5e4d6f8a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2e7b0167-5cc1-4566-b21b-ee855c8ad109", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2e85140b-1333-4c57-951f-c9f15db11ac2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2eb0199d-d78d-4318-b5d4-ac48d9530776", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2edee4c8-0423-4f8c-a93e-74e9805db9f3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2ee2bc78-9a9d-4143-a3ba-570040ad2089", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2ef3d6ad-6a30-4356-a793-7fe6bee468db", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2f4347c2-dee7-436e-b6f1-6f68e2d01f9f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2f63170e-74a3-4cb6-b5ba-7f7519138634", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2f8cca0d-2a42-4b84-aea6-38fa65121c0da", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2f9c0b17-4242-49db-a645-bfee6fd63c3a", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "2fa565f5-b91b-4808-afe5-4ada09043c48", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "2fb4e492-7aee-41c3-b1d2-f883dcfa4870", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "2fd06784-43a6-4301-8df2-d051466df9e2", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "30386bfd-76de-4d66-ae02-605cc4388e77", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "3070ba5c-9376-40dc-9854-f00723447d9e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "30a445da-77f5-4d83-9e6d-b72219f53009", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "30b53d34-0523-4933-9705-6edcb3aececc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "30fadc87-7706-4425-93be-2781c292b469", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "312316e8-d3ff-4048-a06c-4b6eb3700dde", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "314df57f-9d95-47aa-8c1d-ca9ef06a7efa", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "31569522-749d-4915-9738-5827a9cbe8c1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "31c846e1-8783-4211-aaea-219b1da0e9fa", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "31c862d4-8fe8-44fe-8317-1d9c73cf6150", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "31e864d4-e321-41d9-9bfb-7eefe144c2da", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "31ec67d4-7af8-40d2-8bd6-b08cb9f95841", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "320b3aec-e44b-430f-b0b7-bb007c866efa", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3227b36a-0a98-47a5-bdd3-2b73e31ddb2b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3239fe51-2e21-4811-916b-b790f6279e4a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "32a297c0-87ee-4ea2-92ba-d6c11a6faba3", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "3336b67b-7fcc-4ac5-9953-bd0861c06640", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "337f5502-ef53-40dd-a87e-09f02d7e01a9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "33f3f4ab-e652-4629-8eb3-2aebc823109b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "345dde22-83da-42a5-a213-00d404fa5d63", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "3495a66e-45be-4fb2-ae74-90d459d63267", Code: Code_ABORTED, Title: "Aborted", Retryable: true},
+	{Id: "34bd3a18-8ddc-4fe4-8b89-45d237990199", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "34d52b60-9d01-4748-88f5-03191cce3614", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "34e1686e-b2ef-4321-b9d5-a1644dd8f0ed", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "35103cf4-0750-4048-bc0b-f4f12631f494", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "35674f9d-8a96-4306-b0e5-98c159bd0278", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "365a1cd8-1b55-48fe-aa6f-a531361593ca", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "3664e880-a5fe-4cad-9e29-14425c502757", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3665cbec-88e5-47af-b40f-c0e6f004dbae", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3676e689-43a0-4831-bf6d-bfbdcf7fef49", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "36b9989c-44a1-4f23-b828-dfa467867365", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "37006f10-57aa-41bd-9197-94cb2ce04371", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "37071ffd-3bf1-4ad5-932d-a8a0e0e9d824", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "376ed856-623d-48e4-944f-23dd75577436", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "378b3f42-c6e0-461c-bdb3-8bd95d1ad858", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "378e4fa1-9d5a-401e-a795-93125d1326e9", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "38892a84-1343-498a-8827-b1a0e1ef338d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "38899655-e414-4c18-aeff-ccdfdb04ee62", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "389e76a2-7b33-4c49-a7d6-546ca8e12e86", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3986b394-da2b-4ad1-9278-2a67325b4d27", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "39b45f44-ea70-44be-818b-a51f328e3f31", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "39c67200-ea93-4fe2-8f43-a2585f60ccd3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "39d9e831-3fad-4bd9-a895-3a88b643be1a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3a05e783-68ae-48ad-993d-205f398dc27d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3a0f23c2-8735-41a5-b193-d7fdaeabd0c4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3a3a71cf-0a35-4154-a518-70d84f0968b2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3a4acd65-39cc-4264-a35c-eddb8727e20d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3a4b5c6d-7e8f-9g0h-1i2j-3k4l5m6n7o8p", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3a7894b8-a8c1-427a-b5b3-36565328c5ec", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3a8a1280-4a86-4f20-9bb2-40f627033759", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "3ab10dbe-70d7-427f-9a43-924e6780528b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3ac3e3b9-c7a6-4bbe-b2f5-591413a7faab", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3b008eaa-f350-4a65-bb16-ff02652d81ec", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3b5c6702-e11e-4574-b097-6416ec840c6a", Code: Code_UNIMPLEMENTED, Title: "Not implemented", Retryable: false},
+	{Id: "3b84c711-c3b2-4773-8dfe-423de9d1272b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3bf4b6fb-f445-401a-9509-58ec91e79a41", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "3c02de9f-7015-4701-b230-57c694d17cf2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3c06d800-5ff5-4db2-bd32-34eebb94ff17", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3c7b1c73-507b-4694-b5e8-62251a392ec9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3c8767a1-0c66-4f08-a6d4-a97b856812f7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3c8bc274-21c0-4524-bd59-7d49f6beb627", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3ce91d63-51e4-4647-98fe-5c30e75a73c7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3d36fe4f-3fc4-45a2-81e9-8c128997eafd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3e039731-ee8b-462e-8557-0327f0c5dabe", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "3e95f0f9-f2e0-4dcb-a814-5ea3e9fe4286", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3f6b1f2d-70b1-4eb0-a01e-3a0edc83e80a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "3f80be4b-fb35-48a5-9623-6bcd938f25fd", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "40071c67-8f0e-47b5-9930-36f226e720ca", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "400911a4-dc88-43bf-a92b-0ac1df493d3d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4048e58d-a41c-4ca5-bdf4-c632c1f52570", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "4092c815-9d43-446c-b226-893580cf207f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "40d34bbc-f3a2-4058-81d9-fa3cfcc618c4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4116c93a-8a89-4060-8d71-0d0efef583a3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "411a86f7-13ab-414e-aa53-1bfb6e954d3c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "414b0b7b-e850-4c12-b30d-8e426f4cdf59", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "41aa7625-d7e4-4136-95ce-02c40afaf5a3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "420a2285-2f8a-4380-a0a4-49dbfe0af053", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "420d0b9f-5e27-4851-ae48-0fda8a2aa3b5", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "42497ebf-76bf-42d0-a78a-ca5d3f041a93", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "425eb3ad-916b-4f5b-8d21-bccb2cca0f8a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "43e398ef-e725-43f0-ae26-6ca121a30954", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: false},
+	{Id: "447b138e-ce27-4926-b820-f930533ee1d5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "449167d4-0078-4ec4-bdfa-8c2ef2990d5f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "451d5e44-3ae4-4f65-9513-7311c39bb1d6", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "4528c3ee-f7c9-4a54-9f0d-f97569c0df93", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "453ba10e-788c-4aa3-a7f9-c936cb9611eb", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "45690cc3-1057-4001-a216-e32b342bf113", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "462b2721-2d99-4474-a140-0b76e862f084", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "4725ba87-a3da-4bb1-93c9-7942c2b47945", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "482edf82-d56d-4508-afa5-9c1ba74f7de7", Code: Code_ABORTED, Title: "Aborted", Retryable: true},
+	{Id: "48c408fc-ece3-41f8-a686-ea85ea9b40cb", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "48eba997-9683-4226-95cf-c59687930400", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4901d82a-5bdf-4bdc-bd32-671a50cc7252", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "49137f44-a998-4899-ac01-1ddee4e37144", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4973e13e-5de0-4fb6-8a19-a572c9d441bc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "49b4d4aa-e98f-4b49-994e-43ff6948462d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4a047b1a-b6f4-47c5-8d86-b313e2c3f8c0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4adefb6c-3587-400e-9bcc-99951841e822", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4b049fa9-3714-47c6-9060-eaac2e7fdf61", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4b242d18-38d6-442d-aa92-fe9a175e3cb9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4bc74386-db1a-4925-b859-8791b777eea4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4cfe9efd-080d-49eb-be48-96162c8164ae", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4d0eab4b-53db-4361-81e3-6679b11973f4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4d66482b-ace2-43b3-bccb-3af795b2caa0", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "4d9a5562-fb3c-4ee9-8575-8ff7f925a9d1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4df3b53e-bace-435a-bba9-905ecd0c5d7f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4dfd2fff-f61e-4f7c-8300-19c7fe6607b8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4e02fd0e-78cc-41e7-aad1-482deb849980", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4e0395be-ee74-4af3-85bb-17583f9d8d53", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4e2f398d-be79-4b3e-9419-abfbd575a7a2", Code: Code_UNIMPLEMENTED, Title: "Not implemented", Retryable: false},
+	{Id: "4e3b148e-785b-4303-9007-6421fd5cf5d4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4ed83449-5aa3-4af0-a116-343ae1c1fbd5", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4f2fb8d2-e499-414c-a87f-687d9a98607d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4f43dcf0-1022-455b-894c-04f1a8cc1ce2", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "4f561f30-b053-461a-91bf-cf4f54e0edd9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "4f5f1860-e21f-4e53-ae25-d2cb583021db", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "4fc36f39-8ef3-4541-bb7c-16c7a634ee32", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "4ff48721-11bc-45c2-a544-2859562e23c8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "50408fe8-51ad-4875-b99c-e9c9e2c265bc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "505b6a3f-25db-48e6-ab40-6e54a98c9973", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "505c4f34-436c-4df1-adec-d34fe8f5d406", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "50865764-a081-4391-8b13-d7120b633d67", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "5087e9c3-6431-49c8-bf52-f6562b591267", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "509af6f7-7b0a-44c3-b6cc-d3b47783fd03", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "50a317d8-314b-4f44-86f5-d51fd4de3e5f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "50df55ec-68c5-43a3-881d-ebb5ecec9177", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "51018313-7dcf-41fa-a5c9-75e0664ca20c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "514b4322-7f39-4447-8b8f-36cf5a0e369b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "517e8fdb-fe74-4fec-add8-ab07a79fc005", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "51b6678b-3bc9-4d95-81b5-56732abf8d59", Code: Code_ABORTED, Title: "Aborted", Retryable: true},
+	{Id: "51bb7a42-745e-4689-b552-a58c75636e8a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "51cf760e-aa5f-4c8e-98f5-1a7b9d530880", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "51d08672-96dd-464b-81c6-d9a7d0755665", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "51e5e4f0-450c-42f3-8b34-1e7b8e8c4fc0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "51e73ab3-7ec5-4f93-82db-314f38f7deb7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "51f1e6e4-82f2-448c-9338-a367b86eb417", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "5217e4b2-1f59-417b-b425-52732cb71600", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "52284eec-4e5b-4f34-89b6-c930a9d7e6a9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "52ab2941-0df0-40c7-868e-2b73cb722417", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "52d4ee3a-2d83-4478-a185-65a6a080cc49", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "53c9ae35-8bc3-4188-9a52-d81e5753e09a", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "543a277e-13f3-48a0-8769-f63bd397b11a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "547aa980-9361-4385-bed6-f85ceecbaf85", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "547c4032-ed8c-4bd4-ae00-b085d613d7a6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5490d1c2-f250-41f8-a9a1-580af654a129", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "54e3391f-f63c-44d5-81f1-e3ebf1b1dc44", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "552a5ad9-3b3c-40cd-a4bc-f34185251299", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "555afde8-4146-4f26-9206-392c0d5cfbaa", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "55601033-e9dc-43c1-b41b-4c1fa5a857fc", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "5581ea9a-4ab2-4877-8d29-bfdf4efd0c56", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "55d8cd41-1b8d-45dd-a0d6-64e9a0c1d4d3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "55efa13e-4787-41c3-976a-a437f3fa6e65", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5603dbee-573a-4362-b58b-88e54ea61d99", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "56080192-3fc6-4ac6-ac2e-2c86c4c3e50c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "566e107b-dfb4-460a-8c3f-c93195beb630", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "56a9b7fa-5183-421d-bf08-e9a685faee58", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "571d3afa-e2d3-4447-84e3-17020ba869a4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "577b5ce5-d09b-4b42-b1f0-b1254249aed4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5788d35e-cf30-49a3-bf82-0b0f3531d777", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "57cba8bf-45f2-4690-8a56-3c0ba1c46335", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "57efa963-56b3-4b32-85c3-2c436bb1f783", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "581d50e2-5cfa-4143-a53a-e4871d5f14b7", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5889e22b-4b49-4cc2-a542-ef67c92b1a02", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "58979bf8-711a-4d8f-a1f7-097de1a029de", Code: Code_ABORTED, Title: "Aborted", Retryable: true},
+	{Id: "5897e73e-098e-4710-8dd1-eb012b527e7d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "589c2d8d-6078-44b3-abc4-f35550536f98", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "58cbecaa-23cd-48b1-9cfd-e5e75584c728", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "58d53fe4-3a80-42f7-987c-398ac9ed856d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "58ec8280-b1cd-4f93-a2ad-a484160171c4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5915278c-3ad1-476d-b26e-206037283409", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "592fbeee-e093-4cb7-85c9-9bbd20a38987", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "59fe7212-c078-4718-85bd-94a3a9b4f633", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5a73874c-b1ff-4523-b6b8-8efd39bf6c65", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5ac8ad38-7693-4558-b189-b4e6d730d97c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5b3c9f35-90ce-447e-a452-a4c07b3c3626", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5b730ccd-6834-493f-8910-03cb7cff9e5e", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "5be8c03e-785d-4e20-8264-ac676dd9825a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5c2057ab-236d-460b-8b62-764120c88578", Code: Code_DATA_LOSS, Title: "Data loss", Retryable: false},
+	{Id: "5c2079c8-b46c-4beb-80f7-0b3168cb448a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5c4cccb7-347d-410e-bf9c-8b0004b8ea81", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5c9fbc97-fd3e-44e0-ac2d-18991c994b50", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "5cbdde73-41c2-4051-9804-a8e4e1d0402c", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "5ccab1f5-b23a-41d5-889d-b419806c31ff", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "5cdcd68b-2719-4a46-9102-4b5347ced445", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5e117393-03a2-49a1-9143-365423d48eea", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "5e162577-e4da-4ff4-afa0-f0087d9dc839", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5e2fbceb-e62b-4df4-8131-d3dce2947a9e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "5e376d72-7d2d-4a0b-a731-421d45ba5b75", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "5e5723db-cb4a-4d25-afde-7917a3a505e6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5e869d41-6088-4295-b5ee-88e285eae2b4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5ecc482a-cebe-4d3e-bec9-a766a4ed761b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5ef02783-993d-44c1-aa2b-ab337c7e4bf5", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "5f055938-a3ae-4695-b3a6-61df739c4363", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5f226933-94db-420b-bfeb-0febc50c751d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "5fb4d8ea-b196-4475-8691-1dbde841296b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6004e19f-6c48-4ff5-a486-04f91e633b9c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "602b0f63-a2f1-4d20-8545-fdc942a0aee4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "603fcde5-057d-4cd2-b295-78d3dd854480", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "604935dd-65ba-41cd-bb73-1c99fe73c5f5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "614a17ce-78d5-4f02-841e-3387d17a2317", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "616b2a40-219e-4408-aa76-42aa621074a1", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "617baf1d-f2cb-4888-8f60-2bd8fd55faf4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "618ab168-b36b-48ed-9042-89ee82c88edc", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "61b966bb-3f09-41a2-939b-db59239ee46d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "62208df3-5381-4843-94ca-22919beafc53", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6244d443-5de2-4ee0-aa93-e10da4d935bc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "62a07d9f-1516-4b26-b96b-42e40627e74f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "630d0f11-9e57-4b7f-a8c6-f13eedd9bcfc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "63186515-cad8-453e-9d6f-0b3ef885d224", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "63612935-b2b3-4ba5-bab3-06b60bb31da9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "63a5c819-7b66-4512-a085-390e6a572f1a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "63bb09f1-ef2c-43ce-b1ab-728839876862", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "63be4367-455e-4c96-937e-bd927f7c07de", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "63c72efd-3791-4be7-9ec2-68104ff2dcfc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "63e88aec-dafd-4592-9f48-718babf7c082", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "63fc0315-6d87-452e-976d-7b45ee0ba1b0", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "63feecfb-69bb-4562-a926-e58935094001", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "641bb72b-08a3-4779-86cc-418ff393426c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "641d4d53-993c-4923-8dfd-dfe17975cc6d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "641d5822-bd75-4289-b5f2-e8ca88f94363", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "644b4e90-0f15-4aa0-a898-a0a5fd5c4a56", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "64b8cc3b-b76b-4d97-b91c-54a039f6f77d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "64df81e2-d60d-4555-a688-799cbe54198f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6500d892-1ce6-430e-9a24-166eb8c6bd56", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "650b6075-e7b6-4eb4-96a0-a72f0c2b897d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "657b17ed-6569-4ad9-887c-b25500074f04", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "658b3897-fdfc-4c5c-a243-1f93cfc0e1be", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "65b921e0-a716-433d-ab54-f0caf0ea71a4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "66909f9e-93a6-49be-aca3-e8d46838654f", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "66c4eef2-fa56-43e5-8eda-1471bb8bd1cd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "66f539e3-ea62-4ab4-8a83-fcf3dafec3c1", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "66ff2f68-fb78-482b-9cdf-24352f958b89", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "670613dd-9c07-4f86-a6bc-8d176044cded", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "671a1ca5-3cf7-46ea-a4f1-09320c1f6c78", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "67726104-b397-4563-bcbb-9f74999ecee0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "67b2bc15-d3c4-4eb1-9eff-6c77d1571c28", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "68617f09-380c-4d55-850b-74ce83126a7d", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "687a3d35-56e1-4be9-a652-a5933b60c778", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "687e268e-fa4f-4d7b-8630-01588f702047", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "691647a4-c652-42f0-9e24-1fd574ef1624", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "698916de-63c0-45e6-bd14-b96949444acc", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "69b653cc-2a9f-4d74-b4d0-e03508035261", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6a1b2c3d-4e5f-6g7h-8i9j-0k1l2m3n4o5p", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6a277573-50a0-434b-bc99-61f19b9695d8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6a7b8c9d-0e1f-2g3h-4i5j-6k7l8m9n0o1p", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6abda17f-f7f8-461e-929e-019379fa9e24", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "6b07d29d-6c08-477b-9ff0-07c81c3c9a1f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6b978168-c82c-4fb8-aec2-1113129be96d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6b9b55f9-2757-440e-b310-c807df574968", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6ba98b5e-d870-474c-a02f-0f2a85594527", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "6cbf60fb-712a-4a3b-a70b-0e7f40598a3a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6cce0c15-0677-4d44-a4d0-61cda447013f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6d1496c7-7d07-49fd-9ce8-cb908e7dd518", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6db5336c-c235-49ee-9038-49c5546a3f67", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6db96430-d33c-499f-8214-c8f0cd4e59ca", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6ded960f-e6f1-4fd5-94e4-db21e38d6e29", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6e398189-1d37-4a7f-8d79-dc997e316dca", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6e3cb928-8e31-4951-bfec-83b01cbf1eac", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6eda6efc-d666-467f-8e60-695ad1b4d8c8", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "6f1ab605-0954-49fa-be71-b6b13f80acba", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6f23ebdd-b0b1-40d2-ad73-3aa5d24cd1df", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "6f3e487a-c4ea-4af9-8e72-1ca13d2304d8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6f482fac-7ed8-438b-9017-a531ee2e6157", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6f8acc2c-567b-4b44-86bd-20ba59ff2849", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "6fa1d56f-a6bd-48b6-ac8d-c26979fd2b80", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "6fb03b82-f922-4bc0-93af-88ff9ae31876", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "7003d14a-35bf-47ca-85ab-e0b0b8c848c3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "702471b9-bb6e-496d-9ac1-0bda68469242", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "70307a5c-518b-42a6-8c89-de3b26abf08b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7049e23f-25ab-4ae0-8257-79119aa14b7f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "709b7a03-ea64-476c-95e0-3effa7aff4b8", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "71095010-ded3-42e5-8949-c62e5cc6f368", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7146b32d-602e-4099-8129-51aabf3a5b51", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "716ad845-497a-4708-a748-2e2815c5c55a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "717103ef-d9eb-473c-96dc-62b9fe4187a3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "717d8c81-0e12-47b8-9777-74909d6250a8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "71c5884f-0ae7-4df6-99db-5f6a64022b63", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "71e14796-f2b9-4e8d-bf5a-fdfacf756da9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "723ee5c3-f835-4443-ae8b-e08a519d18f1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "724b623f-50d4-4c0f-86e0-a9f163650536", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7275c8fc-6827-4696-8146-a756c08802e0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "72aa1c83-d3d3-453d-b7bf-d3f7e12529bd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "72cb20f4-680f-4450-b3d6-269aa8a58235", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "736dbdd9-c4a4-4cb4-b263-12a5438eb17c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "73920644-ce4e-4433-9f21-cffd6ea36485", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "73ed814e-409b-4222-b818-b9b585228640", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "742d4110-30ee-47d8-9135-da50a035ccd8", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "7459abfc-d6f7-4369-8dac-6da3ebdaaf99", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "747b2ffb-575a-4e62-a868-172507a913ae", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "74830d26-d19c-4ef9-9a1e-7dc2c8b15f3b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7513d16e-ccec-4d9e-bc42-c1e86ba5ba3f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7520f0a1-7f4a-4aba-b314-936008e9e2ca", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "75670630-44f3-4616-a980-821d59e10ee7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7576f061-5828-4b28-8cf7-5db7f66d0c33", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "75997f75-2faa-4699-afcf-31f71499d647", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "75d134b5-8f44-4fc5-b35c-aba1850ad9ec", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7611188c-6e6c-465b-a003-c6329c7b6f5b", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "76451bbb-49ad-48a6-a946-52d2b80261fc", Code: Code_CANCELED, Title: "Request canceled", Retryable: true},
+	{Id: "764b042f-f062-4fc5-822a-0ad0df0260ce", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "76b06b33-0945-47b7-9bb2-5040863f6ae9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7704d81e-957b-4827-9d0f-4a252912656e", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "77175de8-869c-4393-a46f-f303f4eaa046", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "77922016-66a7-422e-a8f4-7a98bf2e9692", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "77fb076c-8186-47dd-bc30-1a0f512d4c34", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "783f5704-1c80-4d82-84fb-fa995a28eaca", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "78741220-72f9-4f98-85a0-83424563245d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7945fc9e-c3ce-41eb-9500-5741b24b0e7c", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "79493e50-9067-4c33-9b66-09ca80f77c01", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "795abed7-da3d-4318-9aae-449e81a93f37", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7a908ed0-8785-4535-b364-43ea273fbe3a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7a9b05ac-dc36-4089-b392-f7c045ceaf47", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7aa742cf-0862-413e-b369-bd50636a580c", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "7aaaacc2-f13d-4988-8624-3ca1a66314ee", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7b024d1e-fe83-4999-ba04-16ce8dd62657", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7b2aeafd-f4cc-4c27-9e26-dcb213de8bef", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7b696611-77e7-4190-81e8-20df0a498972", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7bb78787-4884-49b5-8bf9-d369e06bcbc4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7c10e640-c00b-4881-9832-059c8a786fcb", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "7c1a4c58-a4ae-46bc-9005-44c7fcbef2d3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7c2e768b-771b-4850-a529-3dcd601ce6a9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7c4e89c3-04a1-4115-b7f8-6a7eec072276", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7c543b36-018b-4d1e-a3c8-260d89e3e4ff", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "7cb7020b-d014-42d1-9552-9af9ba25f515", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7cd47182-5b32-4e50-bf5a-da8c3906d704", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7d16eb4f-1484-423b-ab4c-f262d8843ca4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7d2c45e1-1493-419a-b445-00ad74eb539f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7d2f4c23-5572-4348-9f8e-b57ba7f6e851", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7d9e5f3a-2b1c-4d8e-9f6a-3c8b4d5e6f7a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7dadaf1d-3c41-453d-a69d-4ed6b8e067d4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7e3a9703-9152-4e78-b95f-928fb7756328", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "7e6aa757-687e-4d46-b365-5c2612803313", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7eda426f-6b41-4fa4-a224-590e705fd6f1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7edefa50-ae71-444d-8204-0e9678f8e9a4", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "7ee88808-c73d-4392-9c58-e354180b870e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7f0adcd5-af31-4a6b-bed9-8fc4db6e43ce", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "7f27014c-2eca-4fcc-bb72-01942efe3db4", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "7f5082d5-1018-46bb-8c23-68047a281850", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7f5ee5ce-afeb-4a24-827e-2c6ecba61e1c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7fa8b943-6831-4e51-9b85-db028b422cf5", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "7fdffd95-280f-4f68-ae58-4b8db3688919", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7fe46238-4637-498f-b333-03f831f616ab", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "7ff2b7fc-c837-4a3c-8602-74c4f9824519", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "7ffb1cf2-1b35-441d-a569-b981de02284e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "805556fa-f894-4b6c-a85d-938570ae75db", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "80705d81-b497-44cd-8123-da4430452a37", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "80d44621-aa24-4ecd-959f-20944377a716", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "817c6601-2ef0-4365-81d3-b89e36afc72e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "81b7254f-d0dd-4286-9811-398a53ef30bf", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "81b99c83-1c44-43c6-8769-9149987cebf4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "82152906-460a-46d4-abf8-8ad0a2af7807", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "8226ba5e-9804-442a-9a5d-d582ab3765ca", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "826b33a3-5c42-40cc-8b7c-d6bb1ffd36b4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "82bf1474-57c0-491e-bf9b-927ff4a259c6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "82e7d6a4-83e8-40c3-bfce-a06305ed426d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "83542a64-98da-4d2c-b2e1-878a95895d76", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "83704a88-7d5d-4513-9af7-ac4d6ca34502", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "83823559-7879-49b8-a6c7-2c553bf248bc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "83bf18c0-6037-407e-b9ca-d98b537f1a17", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "83fa34fd-366a-4435-8ca6-557fa2021f86", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "840023bf-d709-4811-894b-db5e0f8572ea", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "842747a2-158e-4f59-a720-63ed2ec726d1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "84280b27-91c5-49b3-a2d3-afadacfcec85", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "845a74a2-5ab3-41e4-a5c2-583798a98af4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "84beffd0-af11-40cd-b587-c5dfd725dc3b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "84d2ad91-2b61-4823-a683-114520dfa7c4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "85260d20-3be4-441a-896f-12f0acd68207", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "856ad47e-2eb4-4718-ac4a-11003fe06e7e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "856d32fb-7afe-476d-aae4-de8e185a4e04", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: false},
+	{Id: "857a50a4-b2b7-453f-b3c2-ae233e7cefdd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8667cc0a-d5ed-41ba-924b-94432c082ab8", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "86b09b27-4eb5-426a-97c3-8b39b30dc5e0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "87912f47-dc5f-4833-9ad1-09a164d24016", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "8824f35a-8d33-4889-b156-58bde51c9dda", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8825638c-f76d-46b6-99c9-20bdd1628a66", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8826e710-2a99-4366-9559-9ad9f1fef1e3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "882d2ded-2430-4199-89e9-0ce6c955f760", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "88a6437d-9e26-4c11-b54e-812c324f9b9e", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "891a8daf-4b5e-4de1-8676-59301586244d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "89d413cc-7320-446b-84d0-60fba8b2eda0", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "8a74bc6e-3eb1-462d-a0d3-1ad53c985670", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8a9b0c1d-2e3f-4g5h-6i7j-8k9l0m1n2o3p", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8b4582f5-3c3c-49fb-805a-d8c8d5116c61", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "8b6a8ec9-1f30-4583-aa08-d24c04406b58", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "8b6b0ff4-36a6-4e17-9509-df944b8b5140", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8bc4207b-850d-485a-80c3-7dd0fa758362", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8be4a7fd-9c9d-4948-b34f-2d0a41548a7d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8bf09ed6-a987-4840-8439-1bb4a23bcb42", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8bf7d35b-a20e-43f0-9601-39e934be1279", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8c8566ba-17ca-42f0-8d4c-6122a151fb52", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8d4f33af-c9af-4bec-a64c-9a9fadea4309", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8d666621-2194-491b-9e31-d6ae8e248206", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8d775b8a-177f-4ce0-8f7e-b03b54a14642", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8db16a44-2b71-4379-84fd-3821467cb4c4", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "8dd62b05-b834-4584-910a-76c819418d62", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "8df08523-995a-4be9-afaa-a00592c09475", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "8e7d87a3-e714-44a8-8483-e6f0cdbad0d6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8e9c2ca8-086e-414d-958f-2347f8b32225", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8eb7369a-a721-408b-a86c-fc69be17393b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8ec5e308-589c-4175-a3be-227856aa502e", Code: Code_CANCELED, Title: "Request canceled", Retryable: true},
+	{Id: "8ed19877-a746-40be-adb0-f52725e8f365", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8f405162-772b-43bd-b2df-b235b0c2969d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8f65bdc6-3f80-4248-b4e7-ee65c886b95b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8f7a9b2d-6c3e-4d5a-b1f8-e9d2c4f3a5b6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8f7ecbb6-dd86-4844-a23a-f2a473d209be", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "8f8ba2db-bcb0-4abb-ab79-4623360cff11", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "8fc52ab6-2c79-405c-a811-9bd0b4cd1432", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "902270e4-245f-4e2b-ad6f-40fecec418d7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9027bdf0-b9b9-4c29-a7f8-88acca8df98d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "907e8d95-1127-4a8f-af37-026a9741fe73", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "90927502-478b-41d2-96a2-86df1d385172", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "909fb694-62d5-4007-bacf-dfb11785ff98", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "90e5955e-dbdd-4714-adc8-f3fad60f65f2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9110ac3b-704d-4955-8d8f-d9147cb9b5c2", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9165b610-bf0f-481a-8aa0-3c0dbe129b1e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "91935818-dfb0-4680-a326-fa78f5a54c4f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "92d220c0-67be-436c-936c-96267f320f36", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "93a343a6-404b-499b-9f83-8e7e8f16581c", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "93aee2ec-887a-49ee-9be7-07594b0e675e", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "93cfaca7-1b12-47a9-a907-b0387c33ab96", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "93d4e101-5390-492c-b307-f73b108b8332", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9401408e-d145-4db4-aa86-3b1bff858cf3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "940cd490-7982-42ce-a88d-1ea5c54801fb", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "94158f28-c5d1-482c-8b5d-6cac67f406f9", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "942f7b6e-196f-4425-a1e0-51088b5f2ac1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "946b036f-72e0-4e58-a8d1-edeb2e32ace0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "947a932a-3ed0-4c85-aa22-f3617167a095", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9487c077-ab80-48fd-86d0-d22483cd410b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "95145a17-80fe-4deb-a4ae-26425d34d7c0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9549854d-9e25-47f9-b133-61ca6186f015", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "957a63ed-4771-498b-8d53-8ed4bc13964c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9588da0c-b448-4959-9491-a08202c9efe3", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "95e795c5-2693-4d61-b4c4-db8a7a16cd16", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "95f4750e-430a-460b-8687-1116216b1660", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "961054c6-9364-40c3-ba03-f9062fd4c27f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "964feb15-034e-46f6-b155-6fb6e98322fb", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "96de201e-e2bf-47be-919a-0fc425bbfb80", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "96ff690e-619d-4314-8088-0cd882399a06", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "97183cb2-83eb-42f6-9137-e3092fd7d07e", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "973a9506-222e-4a10-b6aa-45fe1780354d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "97406b51-3981-492f-98ab-f4c6d197f27f", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "97782e5e-5508-4053-83a9-2c0c5a38a1cb", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "978bcf0d-aab8-4376-8a4e-b01c9a4d16ea", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "97a02772-faea-4dbd-bf12-da7428cb2125", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "97d0fadf-a8bb-44ba-8e00-f71aa9adb719", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "97e255d7-90df-4e9d-a27a-35627f5609f0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "97e3f946-4b7d-4eb6-a08e-b00ac1c55ce3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "982e2ffe-f1f9-4c65-9c64-bb76dd6b0e32", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "984f4a78-1c79-4055-85a6-eaf2e6954756", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "98572dcd-4b2e-44bc-ba38-e1024d5fbd65", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "986587d0-09be-4ce7-a18c-8a65f0d4c7aa", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "991fc278-abe7-4f14-84a1-f0c62dea0572", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9a5cca55-f796-453a-bd2b-1a4ded1b5813", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9aa08800-1d37-4dfe-b0eb-12494cdc938f", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "9ad2907f-63e6-4fc6-8549-1afd79ca5739", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9b313bcb-7a51-4cc9-b6ff-702ada9e607d", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "9ba5c7b4-465a-4f44-929d-b43b60ed8af3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9c017ea2-ed23-47b5-8599-3bfb6876bcf3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9c0c782b-79be-4576-8b78-e8894cec2432", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9cd6055c-3a0e-48c4-a8fa-531e8e05feb0", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "9ce9bd93-c1e2-47de-851f-9ab0601a0625", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9e0787ac-9cd1-4ab5-9ae5-aff721b701c6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9e1788c4-d6a4-42cd-9a55-6479602fc7c0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9e289d64-63b8-4f18-a201-00dda33ff9ea", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9e5af2a6-7704-479f-86ee-771a0f72ea00", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9eae6ac2-b182-4248-bac8-56378cb514dd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9f01b149-c492-4344-8dec-a9766d6b6de8", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "9f129873-0536-44cb-b922-f94127d26339", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9f19a3a3-10ff-4039-a819-f044ea46c89b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9f46d105-cee2-4012-8f26-b698cdaa5ebc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9f4db5e0-dffc-4339-a16d-13c577bdc60b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9f7d82f2-8b6e-4251-9eb1-90ff5c0c752d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "9f996efd-f71e-4470-9688-faa45da3dec6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "9fbac405-21c0-4abb-bd39-825476a6e7db", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a0158f12-5773-4127-b8be-5cae91aec360", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "a020f270-6367-41e2-b5b6-849c7e7ab332", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "a0f2b1c4-5d3e-4b8e-9f7c-5a6d3f8b1c2e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a119420f-9ac2-4c33-ba71-99bb045bebef", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a16895b3-585b-49cf-90b0-3cd2cefac071", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a18fdfa3-8c83-4f0c-9436-8455f42459ba", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a1c2032d-cc5e-4b75-8bc4-369e09c0a9ed", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "a235629e-3f27-4744-83f0-9e4433bb68c8", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "a247c55a-39d8-4dae-bd68-bd6f34c66dc6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a2759977-fb6f-4e55-99e1-77b08b89e7b7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a29d9894-6e4d-4eb5-85fa-7e4f27d9ce2f", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "a2e5f9fa-22d8-4ef4-b29d-54ac3d8ab294", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "a3354f84-1ff1-4ea8-8fa5-1c2b13a91600", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a3428d48-31f0-427c-bf18-a0d59cfc563b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a3d44407-330d-46f1-b568-c9c4284b4ff1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a3e400da-f405-42e1-b338-5ebd84e6cf8b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a3f082a7-a3a2-4a6b-b01b-cbccbf4c9cb3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a3f9e521-3901-4f1d-a3e5-8c1fc3cc564e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a4099b2e-354c-454a-994f-8a13bd63d432", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a447a9d2-900b-4a03-bd6a-62ca20579421", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a44ad686-cf36-4e11-8e67-37c2cf250cc9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a4d95e32-eaf3-4fe2-a8da-b8e5b064bdd9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a4e5dd2e-6300-4695-9cee-8278a47d78fa", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a4e97370-8208-4b74-9660-25db2e26437c", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "a54cb4c3-3926-4bc9-b55b-e742ae5963af", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a587bf52-1cfc-46da-9bfa-5bfbbea4c319", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a5f0b506-7c74-48a9-b126-bac3d8c59306", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a65584f6-61bf-44b6-ac74-75a9af4dac5c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a678b920-31ed-43cc-960a-ee43c4e99a7c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a68600b6-2850-4e45-8558-105c716d09a5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a6b3c4c6-5f1f-4f7a-bb8f-2f6d4e9b3f6b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a6b72469-9df4-4127-b176-68ee86da731e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a6c6ab6c-1d14-4dab-8a9a-261080901432", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "a6ef22d0-e124-440a-85eb-ccd4c7bf1ac9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a7020dea-97ce-4447-bba1-f7087be2d637", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a7576940-82cf-464a-bfdd-7b0fd10418a5", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a76a0175-79f2-4920-b1d1-b5dcde74ce35", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "a776cc98-24b1-4cae-bec1-c14952ba0d4a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a7eba120-6416-4631-9c24-eecb16e2a3a5", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "a8480de8-83a1-4983-9097-3604bc804d36", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a84a2463-5000-4ec7-9646-1fbec9a93d95", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "a85d39f6-d106-43c7-a9e7-b4cd927d84a9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a8a80671-7163-4acb-a1b8-357740fffbf4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a91bd3bf-3413-4a79-96ae-0086ecf77966", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a9890862-9947-4e21-95da-77b5c69d3d07", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "a992e428-4915-40da-baec-774b362e754f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "a9aeef93-b3da-472f-befe-245eeec795c4", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "aa019848-686d-4e9d-ba56-24693a897bb7", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "aa84de54-9ad2-4180-aabf-739d199c4bbc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "aa8f0c82-7924-44a1-9b60-a265cd59b8fa", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "aac2b658-c74a-48b8-9192-96a689e04cfe", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "aaf8bf02-2190-4fc6-b08c-3a96616ce0aa", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ab0c39d7-dbd0-4f63-8c65-48433e9fad7f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ab919728-e9df-4b9f-b6a8-736b53cb2628", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "abc3988c-98ab-4a76-a8c4-91de1ab05a7e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ac5289fb-8e35-4566-9f83-865e9349069e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ad11c19f-e22f-431b-bd3a-b2eddba9a55d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ad2de446-4af5-423a-9d5f-87ef3e65bb6f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ad460ed3-9e4e-48b8-8a20-7986bc44d459", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "adc89bdd-7e41-4ff9-b7ec-962422a9cd1d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "adf1984d-d800-4560-8d6f-34138cd49395", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ae2ddbf4-b8b6-47a9-a38b-13356c71f35f", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "aeec2365-abb7-4c12-95b0-8a46025cbb23", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "af340b55-39e7-4574-8356-3c6e600ed0ad", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "af8c2304-d1b2-4055-9529-a75798bc0517", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "af97bfc9-34a5-4fe3-96cb-a217b3274562", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "afb17ed4-9ce6-4f3e-a984-5bd0c455af97", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "afe73139-a37e-4740-a289-595be5f52658", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b0a7a9b5-7b4d-4f3f-8f0c-9c9c1f4a5b0d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b0c1f2a4-3d8e-4b8e-9f79-ebf9def3e131", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "b0f3c4a1-5d8e-4c2b-8f6d-7e9a2f3b5c1e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b1400349-337a-476b-ba9b-2ce108d11994", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "b1782dfd-51ac-4bb0-8c1b-49858a455a19", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "b1824044-1c40-4573-b909-f690209e4ebe", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b22927bc-ca0d-49ce-93fe-34c337ae339e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b2516dfc-9598-418a-bd8e-7d5d3019f76a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b253a828-082a-4c47-b52d-7cbf636afbaf", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b29e33b6-8a9c-473d-b66c-9edfc73c4347", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b2b5f524-219e-4eb3-bb21-68be9922e8ee", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b2f5c4ad-a1fc-4286-a2a9-c3274a5cb24a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b418998f-0e50-4bc6-ad6e-fa6affd1a908", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b47c426d-4a6e-44ad-8be5-f74fb1b2df44", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b5212926-88e0-45a3-ac61-eedec4bb7b76", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b52821f0-3127-4595-9927-70147f482472", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "b5440ef0-0458-4f75-9069-3b3c53505042", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b5783010-074d-4cba-9f55-88cf7cd44912", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b5873e52-b1ef-4fac-9e58-2cb1ba7bed93", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b5c915d1-68ec-4c88-80bf-d657146ac86e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b5d2d2d9-b576-4658-837c-ac9e9e1cb242", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b5fe0827-a7c1-4df7-a7c7-b67910cacf8e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b621b600-33db-457c-a3aa-53360a5ad623", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "b6be2a89-62a8-4cb3-a525-eae0971c4243", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b6caf04e-942a-4e81-8758-723833242a5e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b6f00b97-ea85-46d7-8725-6787e46a3d36", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "b70cd6bf-c0e3-41c9-8ea2-eeb18ca4861e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b82dbd58-7ce9-4c6d-9ba9-91bfa35b8fde", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b873ae27-50d7-43c4-b0e5-40feb8540deb", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b8a2c454-597f-42ce-8686-404ed81b1772", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "b8a56e2b-a15d-42fd-98d9-7ab6faa70378", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "b8ca956f-ea2d-4c6e-883c-913d50743f41", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b8f2034c-9551-46a9-9529-7100ba325d8f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "b9422b42-1d54-4869-8483-f6029b7d63a4", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: false},
+	{Id: "b9bd71ed-763c-4c30-9eb3-4f49912103d2", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "b9eafcbd-b0e7-4265-969b-187cc9aa1d9f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ba849718-c79d-46b7-8eef-dec39fb5e0d6", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "bafd8e03-9ed8-4b5d-9836-bd9b3aa1c7ac", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "bb27bb7a-498f-45e1-a33b-be16dcf8362b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bba8f576-2c9a-49f9-8a7d-4336688ff691", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "bbadc4f8-ffd6-47aa-8ea4-f7d78975bca7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bc3c0a51-c82d-410f-b317-2daece0504a3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bc635a0f-6a06-4487-a32b-37626b0156a0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bc9d4790-5fd9-41e6-9c29-83b2e1994fdc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bc9ff9a3-5b12-4905-b8bd-c58e15caf02b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bcb618db-bed9-4aa9-9e6f-890f57d49fcb", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bcef0fbe-a87f-4a16-bb5c-9b910e051b00", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bd124fe1-b211-4756-94d8-606591960392", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bd33a4a8-bcd6-4a56-ac61-1bc86d7fb97d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bd5237b0-2b41-4c81-8706-dabe0e49efa3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bdc94a77-031c-4427-94f2-0d91562bd091", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "bdfde7f0-8b6b-4c1c-9b22-4dee5b94d4e4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "be8e7a25-bbf5-46f3-aa6d-4cf285ec156f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bf13fc5c-52b9-487b-88a7-ed5a452ab445", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bfa0fccc-6d5e-4ebf-aeff-656338fd781a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bfd1e7d1-d8ca-48d3-b917-1d17e5ff55f7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "bfe399c0-e208-44d8-8a2d-d949d3075aa3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c0435cf9-b55d-4dcc-8cbf-1afb2e5fd814", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c0a66e0c-025a-4426-9419-b45195c6945a", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "c0aa0235-fec8-4b83-b30c-eb818e58bc2d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c0baa1b8-b4bf-4655-a33d-db3cc1df693a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c0f1d8b2-3a4e-4f5c-9b6d-7e0f1c2b3d4e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c0f2a1b4-3d5e-4b8f-9c7d-6a0e2f3b5c7d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c126b63e-bec6-4ea9-9ead-800fb556577a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c1d90b60-7c58-4602-ad7f-b2ed14037f35", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c1f1cef0-b0af-4c5d-b3db-3071dd8385ea", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c2976214-464e-4f96-98c8-eba4394fdeb4", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c2a27e96-04df-40d8-818c-d8febdb3fb48", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c2ab919d-0c69-4267-91c1-2629bf406ba5", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c2c303a9-7f4b-45c5-b76b-15a6d3a861e0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c2c81d59-9cae-429e-83e6-76a528a169e0", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c32b779e-fccb-49b9-bebc-92363b1a7f20", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c337f246-c820-4711-86e9-7b729de782d3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c35b3f63-fad3-4cd0-9ec1-889f33294929", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c36b77e6-91d4-4240-b1ad-818642889c0c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c3895751-5c3b-44e8-977d-94a7908c2a3d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c3bc848f-25c6-4290-a7b0-0d9ebb8d9f9c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c3beb2b1-a073-4d3b-87a1-ae7a4de6a526", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c3d4617b-345d-42d7-b85a-9a8b6c5630a5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c458bf35-9caa-471a-ad64-bce22001966d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c4e0d3df-aff3-4c02-88d1-3d0e2a54d55f", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "c4f2a1b3-5d8e-4c2b-8f6d-7e9a2f3b5c1e", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "c4fbab7a-9d37-4959-ac95-c6a7c84fd813", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c56f82c1-2efb-4708-a01a-39307af6c8c9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c5757be4-06a0-4fe9-a1b6-bed2b862f6ee", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c59b8fac-7d93-4a26-8f0a-4630adee1ebf", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c5a3f5a1-d608-437f-8c26-5a3a3c83fe84", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "c5ad6b0e-bfee-4617-8fae-672c0a93e0a2", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c5bd88bb-d8c4-4c41-9765-40e1ade1a501", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c5e47c6e-4c69-4b2b-8593-5cd60573035e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c5f0b9c8-7e4a-4d3d-b8a1-6c2b2f0a5e3f", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "c69e54af-5bdd-42a7-86ed-58ed438d0f08", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c7a0b695-9739-47a1-b6ef-49f41a316563", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c81e81fa-5e37-47dc-8639-1d12aed82760", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c8385e58-a81e-434b-b660-cd5c7e267c95", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c847970d-f142-46da-a70d-37cb2441ad74", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c89b4065-29e9-4b2c-9a78-82f97bfc6577", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "c91bca2b-c342-4549-9bf6-c2190c3da492", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c99d2a3a-b076-4934-b691-915ea75764bc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "c9bbbc6c-092d-42f5-97a7-bc58e49c4e38", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ca110768-3a53-426f-8bc6-ab7f9da717dc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ca97340c-eb56-4fc8-8743-43e9296ae1c6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "caec416c-de2d-4618-b295-baa385b801d5", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cb2bf439-9917-49df-94f4-937400e73f5e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cb38f47c-fb51-4d04-a1eb-bb96475c4a47", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cb7a075a-4656-4d82-b7d4-8a9b8bb3c8f1", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cc0d1648-a11b-44b3-87d7-4e30bd9ffff2", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cc1ff6a0-4cba-4ab1-b020-01d6a9788434", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ccc2ccea-381b-4fe8-b9c9-d7afe3070897", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cce383bf-e68f-463c-a242-2711da7465a3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cd063da4-3707-45a9-8f90-f80589fc4fdd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cd37d2d5-ab8b-43e5-8e05-35ef8a20021d", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "cd6c46c3-4718-4c51-be09-77c5d989c38a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cdcbbc8c-acf6-4818-8301-3eecd4e4589c", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "ce37747f-fb6c-499a-b14b-13c9fb95c7dc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cec7f313-05c2-420a-b94e-b5a9e00c41dc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "cf195972-41fa-477e-b3c7-728a3849783c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cf351613-15ca-4743-bafa-c3e1553fe91e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "cfb46088-d42b-419f-81cb-a3a6962a4257", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "cfb75479-358d-4464-8850-66dc2c10cb20", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "cfd0366d-7906-4aa2-9cb2-a1c54ee66891", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d015250f-23aa-49e7-914f-90394445c9fc", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d06ca9d6-4cf2-491a-89e0-64fc97f1ec09", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "d0c7ae33-e8dd-4603-88ce-d83a821e4b36", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "d1338fb4-281d-466b-9228-5b51f277ea6e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d1928846-7e7d-4576-86e0-4dce02db4b1c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d1bc40c3-0a0d-4057-8b97-dba17dd8dd81", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d1c10dd7-5bd7-4ec2-82df-40b47bca5e0a", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "d21fcc9e-b0dd-4a5c-a896-711599b3b62e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d2db073a-9269-47e3-809c-62557c839c02", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d3850f71-a34d-477e-b666-3ca160b1f3d3", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d38e21fb-717b-4f8e-b3a7-8e3cd05a83ec", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d3ce6c56-3885-4b8e-9f79-ebf9def3e131", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d3f9e8c5-5b6f-4a3e-8b3e-3c6b5f8b9e3d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d425b7c6-9768-4a8a-b6f6-f64cc79c4759", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "d471bb1a-c2c2-4678-8fac-a8ec7b2cb072", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "d48e3097-f939-41db-a241-c92889890203", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d4c4354c-dc64-490e-8202-eb49ea06f204", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d4dc8995-520d-4238-a4fc-8ba046227c4d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d4faf4a4-efd3-4fc9-83c3-836300325513", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d503de37-87c5-4dc7-9136-3c5a8b0af59a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d54f30a4-7063-4f6b-aee0-d3675de4b10b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d57b1b31-3c58-453c-8ef1-9227765e0b45", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d58ef19c-4d6d-4f23-854f-1703b32d6e22", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d5b9a71a-ebd0-4c6c-93ee-e3935e88ceb8", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d5f93dd5-8c74-46d0-8bda-39451db19b27", Code: Code_UNAVAILABLE, Title: "Service unavailable", Retryable: true},
+	{Id: "d6474ab3-44fd-4c4d-b8c0-5ddc77a32a5d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d6f662f9-0da3-40ca-8867-3994110f355b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d731dbca-c8c2-4ae2-8e45-53cc858cf273", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d78bdffe-ee1b-41ac-967a-bf9b6d51fe7e", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "d7d79fc7-61e9-459b-943c-383f8aec7838", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d8153b71-390b-449d-94b4-e018cf52c47a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d815c177-862e-4fe3-a40b-679ac17a93d4", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "d827753c-f482-4b33-b96e-3371bcd1a32f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d833ed02-ce0f-4d4c-a59b-e6d8d6c0768f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d8ad64e6-13dd-4049-a89e-8230095e41a1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d91cb395-4e1d-4604-a973-00633006d475", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "d92bdf54-e982-4f8d-80a6-23dbaa5a2300", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "d95d14fa-74ff-4bfd-8a21-d3b0a6726fdc", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "d99e3d64-a98c-4f05-b1b9-47d84e903ac4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d9a132ed-07b2-410c-9297-b0e52d0e74bd", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "d9bbb307-da4a-47ff-96f5-7a4bf440239d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "d9d51222-adc4-4b0e-bf2b-ad14b772f901", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "da7d0e82-c5bd-46cf-b467-8a7faa3e572a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dab723ad-b2fc-4654-9f7b-70a506f7cd54", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "db1bd049-47be-4db1-8499-92572c8bc038", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "db499ba7-a19e-4a10-9cb8-eab9c592ffd3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "db633267-d38a-4117-8930-68e80813ed78", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "db647235-6e83-4cd7-8691-cd843e9bdcec", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "db74b226-98da-4082-837b-d6efcf57bf40", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "dba9cfb7-0f22-41e3-96bd-a53dcc4a36f6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dbbba877-e40f-4510-a424-2c63d4e2ff3e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dcb24299-a257-4e6e-84d2-17ebd550ef5c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dcb6e952-ec1f-4add-97cd-b28cac9f319f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ddb84e09-857f-473a-83ef-764b583ea26a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "de5cda7b-bd83-44de-8ced-9722cf821858", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "de7b7a03-a0da-4193-bf4c-07d854ed6d35", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "de8e74ca-9f78-4ef5-97ff-f5b452afb813", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "debda895-e4c1-461d-89e5-982e91d6fd15", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dee16348-0e42-405b-8723-6acf4af4e040", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "df0a45e7-4741-4d34-9ccd-386d7f2d3d32", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "df581a02-301e-4df7-8882-d1e585f24a24", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "dfe782d7-74a4-425f-8740-eb64197a133a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e039212b-bf7b-4dcc-ac5e-7154c74089fe", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "e04918b4-52af-4f11-beaa-8a4e8b7a924d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e0782fe1-744b-4d9a-8f4f-a43194825f8a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e08a27f3-f0c0-40b4-9398-7194c6003c09", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e0ab3439-0995-4a47-a204-cc7456a1bdc5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e0cd7bdf-361e-4a12-8ab6-a6772feb8fb5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e0d0c176-dc79-4814-b15b-97a3904654af", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "e2262439-7c81-4655-bd45-5b368a0f6069", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e2546eef-c066-4204-a38e-bd1714014c10", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e259d370-bab3-411f-a193-8572ce5bd16f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e3405626-5d7a-435c-97af-545b7f099e8c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e3879cac-087f-466c-813b-1d4c05666711", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e387a1f4-afea-48d2-817c-1bfd8e3ace85", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e3e29f66-165d-4b99-ae96-0fee220ba45b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e3e4ded9-9b1a-483c-a23b-60e743f95ab5", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "e42ed97e-4973-4b91-b8ed-763f4ff5dd0c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e462543a-e8e6-4123-a304-745e9b0c5499", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e468eec8-7db4-47c2-bd75-47ac98124758", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e47c2194-905b-468b-b233-7123cf72da9c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e492a98b-925f-4489-990a-5ccb76d1a6f1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e49bf628-b053-41d7-a7a9-2ae8e09ab56d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e4bf0f9d-ee15-4729-a853-51c3c6bd4744", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "e4f9fc98-39eb-47f8-88d4-bac53610ee5a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e5504d88-f065-4221-89b1-f2bfdf41653a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e559079e-e7e6-46da-bd1e-f945e8cb2f48", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "e5773e18-ebe4-4e6c-b6ca-fa7787dca1db", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e5807733-785e-428d-b943-b543931e41ec", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "e5af6231-c8c1-4dd5-a304-fe8b41d871e6", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e5c5fa0a-f9ae-41f8-a045-14d7db3addc3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e5c7d0b3-9d4f-4f8e-8a2b-7a6b1a4c2c5f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e5cf1b2c-712d-41ae-8c75-8a3c5d73782c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e60f94eb-abde-4f3e-a98f-b641ab37c81e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e61355e3-0667-45ec-ac28-6ae8e95ac7dd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e61d5e4e-dc2d-419e-bdc3-c488059ca8e3a", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e728e6f4-0e69-4bf0-95e7-a5794d8aa3a6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e792db31-cda4-45f7-8e75-8e54a0375e90", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e8660186-fe03-40f4-a46f-5621bc20f1be", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e8b2db78-b1c0-4b9f-becd-aab2f19f3614", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e8bd67a5-331c-4ae7-8434-cd1a1f319f5a", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "e8bd83c1-efb7-4fc3-a045-f76bcd10e8df", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e8d63290-5389-4948-9d07-2362ad490c6e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e8e49c05-e0c0-41d7-ad4f-bf6cd9f60f2e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e94c0a56-674c-44f6-877c-92ca1cb6cbb6", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e9ac81ec-253f-4181-956c-cbf0b923c45a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "e9b05d9f-0fb1-40f0-849e-630086eb234c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "e9fcfdfa-e462-4451-80cc-7535d0c20df3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ea0de2e8-89dd-44fc-adbd-06f7381f42ed", Code: Code_ALREADY_EXISTS, Title: "Already exists", Retryable: false},
+	{Id: "ea1c2af5-46ef-4f99-b444-06da417d6a64", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "ea36b334-9f92-443b-9200-0c7f0cc24be7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ea4dd8ea-c733-4dc9-a011-4cdac03067e6", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "ea504258-bec7-4f95-b069-2061f6eec546", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "eae7b3b2-7a89-4afe-bd27-a10ca6a0a657", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "eb205831-fde3-4431-ac52-60194a3daa9b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "eb2e058d-5c81-4814-b03d-eb02d9cf8290", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "eb554767-0681-4b60-b4ab-fcc01ca0399a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "eba5a2fc-27dc-4909-8b5b-fb217d7382d5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ebba165b-571d-4f72-a4d5-b59d7cc012c5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ec16af85-e492-40b0-9bf0-820e3056bee0", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ec67047c-c40e-460e-afe0-787767dd2fce", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "edbfdfbb-5717-44df-9e19-c10ae6dacfc1", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ee5cc689-545b-4e7a-8590-b1e10881d9de", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ef040e1f-95ff-42fb-b91f-0648a8e75edc", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ef97a143-e121-4847-8c73-f3fe0bdf09a0", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: true},
+	{Id: "ef9f9f40-6e5c-4703-ab7c-b502f67d0f42", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "efc554f7-9a49-48e4-99a3-8781d0663a4f", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f00c9540-5388-4899-9394-5bc2e03713e3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f058761f-0b8b-4329-bd4b-417c8843dd16", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f05e06fe-972a-4e40-9eba-5b0ff87a5cdd", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "f0a22612-91f5-40a0-985c-f85858c01406", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "f0a2b1c4-5d3e-4b8c-9f7d-6e1a2f3b4c5d", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f0df0ebd-4c20-4fee-a33a-b0fb795eb2a8", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "f1302542-5386-43af-bb01-4b1e95225d88", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f15279e5-e181-483e-9a44-ca9251247782", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "f155b2f8-0852-4857-a1c1-be5b8b869892", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f174413e-cded-4045-9f7d-9770ceb13b8d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f1910c46-cc6d-461e-b9fd-a203cad6ad88", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f1b828d1-2cc7-4ede-a785-1941ec7d7efa", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f1e744c6-e49f-4713-8887-80a8117664ac", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f20e1698-0e6a-43fb-bdbd-38e57e16d834", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f2125f68-8d0e-47f5-bb43-38b63a3a6381", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f24a26f5-5e27-493b-a9f7-9e65e22fa601", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f25c9156-5f93-4588-95f0-29b832e17f35", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f2a9c7d1-890d-4fda-af90-8404a8f53505", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f2d861f5-1f6d-4661-bc8e-4a0bce46bbe7", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "f2e39945-d256-420a-96a5-646cac2cdcba", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f36a8e24-c71e-4ea4-acde-c6823ccbe717", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "f3b0c4a1-2d5e-4b8c-9f6d-7a0e1f2f3b8c", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f46e84e4-40b4-4a59-8350-46d074a214a4", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f47c9041-021a-4e89-b0da-6e51879f6c24", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f4c4a05a-6898-4249-9cb5-01869d258cdd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f5113e4c-9108-413d-a511-32d3d0034198", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f642f63d-a526-417a-9ece-140c86ab50c9", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f64be2a2-9ded-4c3c-bedd-12db15326f89", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f65d986a-7a01-4442-8f3c-d68df0793b9b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f67e95e6-d741-477e-8f12-d249c00c480a", Code: Code_NOT_FOUND, Title: "Not found", Retryable: false},
+	{Id: "f6840ef6-0f8f-434d-87ea-b4d9cf1dfa15", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f6cc1bde-f39b-4960-982d-a99b702eba10", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f6d6ec5e-591c-4c56-86ff-a61e9249fa2b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f78eef5e-9b31-4190-971f-3b08fc7d02ff", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f79b4e65-4713-432c-b0f1-b3872ea2e19b", Code: Code_RESOURCE_EXHAUSTED, Title: "Resource exhausted", Retryable: false},
+	{Id: "f7c40c28-b649-4b0d-95fa-24e04b931054", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f7d42e9c-8b51-4d3c-a8f6-9e4f3b2c1a5d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f8a0b1c4-2d3e-4c5b-9f6d-7a0e5f3f2b8c", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f8d0d9d6-d878-410b-974b-c270070f4c01", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f8e3a5d2-1c0c-4f3b-8a9d-5c2b2f0a5e3f", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f9034cdd-2409-4098-bb19-0c392da5ae51", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f913924a-4d3b-489c-9641-c4f9df9069a1", Code: Code_CANCELED, Title: "Request canceled", Retryable: true},
+	{Id: "f93c97fa-709a-4f04-ba0a-678144f4d5c7", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f9549361-7a1e-4078-9577-8716757ae23b", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "f964fe6d-4d58-406e-93fc-a77b40a47fcd", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f9a6d5d8-cf3b-45bb-87ae-847a2cf81de9", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "f9c12746-0671-46e5-9256-f688ec7d5ba8", Code: Code_PERMISSION_DENIED, Title: "Permission denied", Retryable: false},
+	{Id: "f9fc8e3a-3291-4e3c-94e6-d3ec4de191c3", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fa7dbd29-72ea-4ea3-b937-5756127da02b", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fac5f8b3-00e1-46fd-8496-1a2e03c0e4f5", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fad8c384-2c70-4f95-bf1a-084c51899606", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "faf16ca0-c6a2-4f26-a153-061688eb9976", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fb038aab-696d-4b22-800a-3939cf6358fe", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "fb61c5d4-e618-4860-baae-4bcacab2296e", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fb6c6305-554d-4c63-aca0-22e306605743", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fba420a4-ac73-485b-bc63-0b1449e5b0ff", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fbe457d6-f58c-4293-bf2a-fc49358e24dd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fbe72913-b4dd-45d4-b355-88f35d441de6", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "fbf80ed1-f787-4f67-8cc7-3853f7e8c7fd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fc3bbb0f-113f-4b49-a739-080901c93513", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fc6e6fc6-834d-4719-b963-1120e819770a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fcf4d839-6574-4ebd-b234-70313f350a40", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fd1f4c0a-a257-47d0-b1f6-eb6aa1efa0bd", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fd6964fd-5170-469c-a2c4-698c212b3ffe", Code: Code_INTERNAL, Title: "Internal error", Retryable: false},
+	{Id: "fd70d0f5-1401-47f2-8892-a460ec5001b7", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fdcf8340-e8b7-4ab5-96d4-73ecc2991e08", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fe051d40-0a2c-4ed6-9d1a-0d9a9468a844", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "fe2a125d-f581-4d9b-9742-b0243250147a", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "fe6c9bb2-ef02-4bed-9aff-07b1ec54b73d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ff1edd92-578c-49f4-a191-2cbe8b7f33b3", Code: Code_FAILED_PRECONDITION, Title: "Failed precondition", Retryable: false},
+	{Id: "ffb8bc98-6f92-4f31-a120-786d1596be2d", Code: Code_UNAUTHENTICATED, Title: "Unauthenticated", Retryable: false},
+	{Id: "ffbf320d-2633-42a0-8d3a-16593ad68837", Code: Code_INVALID_ARGUMENT, Title: "Invalid argument", Retryable: false},
+	{Id: "ffc9b6a5-110b-418c-bfed-3c71202dc88d", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+	{Id: "ffcacfbc-da2b-427d-a76b-2f8eedf59a6e", Code: Code_UNKNOWN, Title: "Unknown error", Retryable: false},
+}
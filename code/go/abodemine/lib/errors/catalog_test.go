@@ -0,0 +1,134 @@
+package errors
+
+import (
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errcatalog"
+)
+
+// TestCatalog fails when an error Id is declared twice, or when the
+// catalog misses Ids of the source. Run go generate ./lib/errors to
+// update the catalog.
+func TestCatalog(t *testing.T) {
+	result, err := errcatalog.Scan("../..")
+	require.NoError(t, err)
+
+	var duplicates []string
+
+	for id, declarations := range result.Duplicates() {
+		for _, d := range declarations {
+			duplicates = append(duplicates, id+" "+d.Pos.String())
+		}
+	}
+
+	sort.Strings(duplicates)
+	assert.Empty(t, duplicates, "duplicate error ids")
+
+	var undeclared []string
+
+	for _, d := range result.Origins() {
+		if _, ok := LookupCatalog(d.Id); !ok {
+			undeclared = append(undeclared, d.Id+" "+d.Pos.String())
+		}
+	}
+
+	assert.Empty(t, undeclared, "undeclared error ids, run go generate ./lib/errors")
+
+	b, err := errcatalog.Generate(result)
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile(errcatalog.GeneratedFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(b), string(generated), "stale catalog, run go generate ./lib/errors")
+}
+
+func TestNewProblem(t *testing.T) {
+	problem := NewProblem(Sanitize(Forward(&Object{
+		Id:     "132db314-d9b5-44c0-a2ab-d3b91d94d62b",
+		Code:   Code_RESOURCE_EXHAUSTED,
+		Detail: "Daily quota exhausted.",
+	}, "fb66293b-0d08-406e-b3d4-bbdd19b08238"), false), "0195b6a4-6a1e-7c3e-9b0d-6c8e4f7a2b10")
+
+	assert.Equal(t, ProblemTypeBase+"resource-exhausted", problem.Type)
+	assert.Equal(t, "Resource exhausted", problem.Title)
+	assert.Equal(t, 429, problem.Status)
+	assert.Equal(t, "Daily quota exhausted.", problem.Detail)
+	assert.Equal(t, "urn:uuid:0195b6a4-6a1e-7c3e-9b0d-6c8e4f7a2b10", problem.Instance)
+	assert.Equal(t, "132db314-d9b5-44c0-a2ab-d3b91d94d62b", problem.Id)
+	assert.True(t, problem.Retryable)
+	require.Len(t, problem.Errors, 1)
+
+	// Internal errors are never detailed.
+	problem = NewProblem(Sanitize(&Object{
+		Id:    "b3040922-6758-4468-98c7-44b1ec3551e0",
+		Code:  Code_UNKNOWN,
+		Cause: "connection reset",
+	}, false), "")
+
+	assert.Equal(t, 500, problem.Status)
+	assert.Equal(t, "Internal error", problem.Title)
+	assert.Empty(t, problem.Instance)
+
+	entry, ok := LookupCatalog(problem.Id)
+	require.True(t, ok)
+	assert.Equal(t, Code_INTERNAL, entry.Code)
+}
+
+// TestCodeDefaults fails when the defaults the catalog is generated
+// with differ from the ones of the Ids missing from it.
+func TestCodeDefaults(t *testing.T) {
+	codes := map[string]int{
+		"Code_CANCELED":            Code_CANCELED,
+		"Code_UNKNOWN":             Code_UNKNOWN,
+		"Code_INVALID_ARGUMENT":    Code_INVALID_ARGUMENT,
+		"Code_DEADLINE_EXCEEDED":   Code_DEADLINE_EXCEEDED,
+		"Code_NOT_FOUND":           Code_NOT_FOUND,
+		"Code_ALREADY_EXISTS":      Code_ALREADY_EXISTS,
+		"Code_PERMISSION_DENIED":   Code_PERMISSION_DENIED,
+		"Code_RESOURCE_EXHAUSTED":  Code_RESOURCE_EXHAUSTED,
+		"Code_FAILED_PRECONDITION": Code_FAILED_PRECONDITION,
+		"Code_ABORTED":             Code_ABORTED,
+		"Code_OUT_OF_RANGE":        Code_OUT_OF_RANGE,
+		"Code_UNIMPLEMENTED":       Code_UNIMPLEMENTED,
+		"Code_INTERNAL":            Code_INTERNAL,
+		"Code_UNAVAILABLE":         Code_UNAVAILABLE,
+		"Code_DATA_LOSS":           Code_DATA_LOSS,
+		"Code_UNAUTHENTICATED":     Code_UNAUTHENTICATED,
+	}
+
+	require.Len(t, errcatalog.CodeDefaults, len(codeInfos))
+
+	for name, codeDefault := range errcatalog.CodeDefaults {
+		info, ok := codeInfos[codes[name]]
+		require.True(t, ok, name)
+
+		assert.Equal(t, info.title, codeDefault.Title, name)
+		assert.Equal(t, info.retryable, codeDefault.Retryable, name)
+	}
+}
+
+func TestLookupCatalog(t *testing.T) {
+	// Declared retryability overrides the one of the code.
+	entry, ok := LookupCatalog("43e398ef-e725-43f0-ae26-6ca121a30954")
+	require.True(t, ok)
+	assert.Equal(t, Code_RESOURCE_EXHAUSTED, entry.Code)
+	assert.Equal(t, 429, entry.Status)
+	assert.Equal(t, "resource-exhausted", entry.Slug)
+	assert.False(t, entry.Retryable)
+
+	entry, ok = LookupCatalog("4f43dcf0-1022-455b-894c-04f1a8cc1ce2")
+	require.True(t, ok)
+	assert.True(t, entry.Retryable)
+
+	// Undeclared Ids are derived from their code.
+	entry = catalogEntryOf(&Object{Id: "c1b7f0b4-8a5e-4d4b-9b38-0b0b5c3f8e21", Code: Code_UNAVAILABLE})
+	assert.Equal(t, "Service unavailable", entry.Title)
+	assert.Equal(t, 503, entry.Status)
+	assert.True(t, entry.Retryable)
+}
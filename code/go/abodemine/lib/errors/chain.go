@@ -66,6 +66,25 @@ func (c *Chain) Error() string {
 	return s.String()
 }
 
+// Unwrap returns the objects of the chain, the last one first, so
+// that errors.Is and errors.As walk the chain down to the original
+// errors. errors.As with an *Object target finds the last object.
+func (c *Chain) Unwrap() []error {
+	if c == nil {
+		return nil
+	}
+
+	out := make([]error, 0, len(c.Objects))
+
+	for i := len(c.Objects) - 1; i >= 0; i-- {
+		if o := c.Objects[i]; o != nil {
+			out = append(out, o)
+		}
+	}
+
+	return out
+}
+
 func AsChain(err error) *Chain {
 	switch v := err.(type) {
 	case *Object:
@@ -77,6 +96,7 @@ func AsChain(err error) *Chain {
 			Id:    "f65d986a-7a01-4442-8f3c-d68df0793b9b",
 			Code:  Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 		})
 	}
 }
@@ -95,6 +115,7 @@ func Forward(err error, id string) *Chain {
 			Id:    "7b024d1e-fe83-4999-ba04-16ce8dd62657",
 			Code:  Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 		})
 	}
 
@@ -119,6 +140,7 @@ func Wrap(err error, wrapper *Object) *Chain {
 			Id:    "3a7894b8-a8c1-427a-b5b3-36565328c5ec",
 			Code:  Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 		})
 	}
 
@@ -148,6 +170,7 @@ func Sanitize(e error, debug bool) *Chain {
 			Id:    "dfe782d7-74a4-425f-8740-eb64197a133a",
 			Code:  Code_UNKNOWN,
 			Cause: e.Error(),
+			Err:   e,
 		})
 	}
 
@@ -182,6 +205,7 @@ func Sanitize(e error, debug bool) *Chain {
 
 		if !debug {
 			objectCopy.Cause = ""
+			objectCopy.Err = nil
 
 			if isInternalError {
 				continue
@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSentinel = New("sentinel")
+
+type typedError struct {
+	Field string
+}
+
+func (e *typedError) Error() string {
+	return "typed error on " + e.Field
+}
+
+// buildChain wraps the origin once per step: with Forward when the
+// step is false, and with Wrap of an object of the code when true.
+// It returns the ids of the chain and the code of its last wrapper.
+func buildChain(origin error, steps []bool, code int) (error, []string, int) {
+	err := origin
+	var ids []string
+	lastCode := Code_OK
+
+	for _, wrap := range steps {
+		id := uuid.NewString()
+		ids = append(ids, id)
+
+		if wrap {
+			err = Wrap(err, &Object{
+				Id:     id,
+				Code:   code,
+				Detail: "Wrapped.",
+			})
+
+			lastCode = code
+		} else {
+			err = Forward(err, id)
+		}
+	}
+
+	return err, ids, lastCode
+}
+
+func quickCode(c uint8) int {
+	// Skip OK and UNKNOWN, which CodeOf looks past.
+	return int(c)%(Code_UNAUTHENTICATED-Code_UNKNOWN) + Code_UNKNOWN + 1
+}
+
+func TestChain_Is(t *testing.T) {
+	property := func(steps []bool, c uint8) bool {
+		origin := &Object{
+			Id:    uuid.NewString(),
+			Code:  Code_UNKNOWN,
+			Cause: errSentinel.Error(),
+			Err:   fmt.Errorf("select: %w", errSentinel),
+		}
+
+		err, ids, _ := buildChain(origin, steps, quickCode(c))
+
+		if !Is(err, errSentinel) {
+			return false
+		}
+
+		if !Is(err, &Object{Id: origin.Id}) || !Is(err, &Object{Id: origin.Id, Code: Code_UNKNOWN}) {
+			return false
+		}
+
+		for _, id := range ids {
+			if !Is(err, &Object{Id: id}) {
+				return false
+			}
+		}
+
+		return !Is(err, &Object{Id: uuid.NewString()}) &&
+			!Is(err, &Object{Id: origin.Id, Code: Code_NOT_FOUND}) &&
+			!Is(err, context.Canceled)
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestChain_As(t *testing.T) {
+	property := func(steps []bool, c uint8, field string) bool {
+		err, _, _ := buildChain(Forward(&typedError{Field: field}, uuid.NewString()), steps, quickCode(c))
+
+		var typed *typedError
+		if !As(err, &typed) || typed.Field != field {
+			return false
+		}
+
+		var chain *Chain
+		return As(err, &chain) && chain.First().Err == typed
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestCodeOf(t *testing.T) {
+	property := func(steps []bool, c, o uint8) bool {
+		originCode := quickCode(o)
+		err, _, lastCode := buildChain(&Object{
+			Id:   uuid.NewString(),
+			Code: originCode,
+		}, steps, quickCode(c))
+
+		if lastCode == Code_OK {
+			return CodeOf(err) == originCode
+		}
+
+		return CodeOf(err) == lastCode
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+
+	// The context errors keep their code once forwarded.
+	property = func(steps []bool, _, _ uint8) bool {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err, _, lastCode := buildChain(Forward(ctx.Err(), uuid.NewString()), steps, quickCode(0))
+
+		return lastCode != Code_OK || CodeOf(err) == Code_CANCELED
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+
+	assert.Equal(t, Code_OK, CodeOf(nil))
+	assert.Equal(t, Code_UNKNOWN, CodeOf(errSentinel))
+	assert.Equal(t, Code_DEADLINE_EXCEEDED, CodeOf(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+	assert.Equal(t, Code_NOT_FOUND, CodeOf(&Object{Code: Code_NOT_FOUND}))
+}
+
+func TestSanitize_DropsErr(t *testing.T) {
+	chain := Sanitize(Forward(&Object{
+		Id:     "1c916cfa-8e0c-41ce-af11-b756d81e9492",
+		Code:   Code_NOT_FOUND,
+		Detail: "Not found.",
+		Cause:  errSentinel.Error(),
+		Err:    errSentinel,
+	}, "58c4ed6d-f753-4b53-b6ff-3f235c49187b"), false)
+
+	assert.False(t, Is(chain, errSentinel))
+	assert.Equal(t, Code_NOT_FOUND, CodeOf(chain))
+}
@@ -36,6 +36,10 @@ type Object struct {
 	// The original error message, from stdlib or external libraries/packages.
 	Cause string `json:"cause,omitempty"`
 
+	// The original error, from stdlib or external libraries/packages.
+	// It is never serialized, but kept for errors.Is and errors.As.
+	Err error `json:"-"`
+
 	// Additional metadata about the error.
 	Meta map[string]any `json:"meta,omitempty"`
 }
@@ -80,6 +84,34 @@ func (o *Object) Error() string {
 	return b.String()
 }
 
+// Unwrap returns the original error, if any.
+func (o *Object) Unwrap() error {
+	if o == nil {
+		return nil
+	}
+
+	return o.Err
+}
+
+// Is reports whether the target is an *Object matching the Id and
+// the Code of the object, where an empty Id or a zero Code of the
+// target matches any.
+//
+//	errors.Is(err, &errors.Object{Code: errors.Code_NOT_FOUND})
+func (o *Object) Is(target error) bool {
+	t, ok := target.(*Object)
+	if !ok || o == nil || t == nil {
+		return false
+	}
+
+	if t.Id == "" && t.Code == 0 {
+		return o == t
+	}
+
+	return (t.Id == "" || t.Id == o.Id) &&
+		(t.Code == 0 || t.Code == o.Code)
+}
+
 // HTTPStatusCode returns the HTTP status code for this error.
 func (o *Object) HTTPStatusCode() int {
 	if o == nil {
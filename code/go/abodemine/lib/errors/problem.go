@@ -0,0 +1,61 @@
+package errors
+
+// ProblemContentType is the media type of the problem details of
+// RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of the error responses, following RFC 7807.
+// The members after Instance are extensions.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Id is the Id of the error, to look up in the catalog.
+	Id        string `json:"id,omitempty"`
+	Code      int    `json:"code,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Retryable bool   `json:"retryable"`
+	RequestId string `json:"requestId,omitempty"`
+
+	// Errors is the sanitized chain, kept for the clients reading
+	// the errors of the former response shape.
+	Errors []*Object `json:"errors,omitempty"`
+}
+
+// NewProblem returns the problem details of a sanitized chain, whose
+// first object is the error shown to the client.
+func NewProblem(chain *Chain, requestId string) *Problem {
+	first := chain.First()
+
+	if first == nil {
+		first = Internal("40071c67-8f0e-47b5-9930-36f226e720ca")
+	}
+
+	entry := catalogEntryOf(first)
+
+	problem := &Problem{
+		Type:      ProblemTypeBase + entry.Slug,
+		Title:     entry.Title,
+		Status:    first.HTTPStatusCode(),
+		Detail:    first.Detail,
+		Id:        first.Id,
+		Code:      first.Code,
+		Path:      first.Path,
+		Retryable: entry.Retryable,
+		RequestId: requestId,
+		Errors:    chain.Objects,
+	}
+
+	if first.Title != "" {
+		problem.Title = first.Title
+	}
+
+	if requestId != "" {
+		problem.Instance = "urn:uuid:" + requestId
+	}
+
+	return problem
+}
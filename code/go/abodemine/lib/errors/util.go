@@ -1,12 +1,14 @@
 package errors
 
 import (
+	"context"
 	std_errors "errors"
 )
 
 var As = std_errors.As
 var Is = std_errors.Is
 var New = std_errors.New
+var Unwrap = std_errors.Unwrap
 
 func Callback(err error, f func(error)) {
 	if err != nil {
@@ -37,3 +39,40 @@ func Last(err error) *Object {
 
 	return new(Chain).Last()
 }
+
+// CodeOf returns the code of the error, so that the callers need not
+// find the object holding it:
+//   - the code of the last object of the chain with a known code,
+//   - Code_CANCELED or Code_DEADLINE_EXCEEDED for the errors of a
+//     context, even once wrapped,
+//   - Code_UNKNOWN otherwise, and Code_OK for nil.
+func CodeOf(err error) int {
+	if err == nil {
+		return Code_OK
+	}
+
+	var chain *Chain
+	var object *Object
+
+	switch {
+	case As(err, &chain):
+		for i := len(chain.Objects) - 1; i >= 0; i-- {
+			if o := chain.Objects[i]; o != nil && o.Code != Code_OK && o.Code != Code_UNKNOWN {
+				return o.Code
+			}
+		}
+	case As(err, &object):
+		if object.Code != Code_OK && object.Code != Code_UNKNOWN {
+			return object.Code
+		}
+	}
+
+	switch {
+	case Is(err, context.Canceled):
+		return Code_CANCELED
+	case Is(err, context.DeadlineExceeded):
+		return Code_DEADLINE_EXCEEDED
+	}
+
+	return Code_UNKNOWN
+}
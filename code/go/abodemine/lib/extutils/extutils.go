@@ -29,6 +29,7 @@ func PgxExec(r *arc.Request, key string, sql string, args []any) (*pgconn.Comman
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query tx.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -43,6 +44,7 @@ func PgxExec(r *arc.Request, key string, sql string, args []any) (*pgconn.Comman
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query pool.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -65,6 +67,7 @@ func PgxQuery(r *arc.Request, key string, sql string, args []any) (pgx.Rows, err
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query tx.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -79,6 +82,7 @@ func PgxQuery(r *arc.Request, key string, sql string, args []any) (pgx.Rows, err
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query pool.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -49,6 +49,8 @@ const (
 	ApiRecorderLayoutEnabled
 	ApiRentEstimateLayoutEnabled
 	ApiSaleEstimateLayoutEnabled
+	ApiMarketStatsLayoutEnabled
+	ApiTimelineLayoutEnabled
 )
 
 var flagByName = map[string]uint{
@@ -63,4 +65,6 @@ var flagByName = map[string]uint{
 	"API_RECORDER_LAYOUT_ENABLED":      ApiRecorderLayoutEnabled,
 	"API_RENT_ESTIMATE_LAYOUT_ENABLED": ApiRentEstimateLayoutEnabled,
 	"API_SALE_ESTIMATE_LAYOUT_ENABLED": ApiSaleEstimateLayoutEnabled,
+	"API_MARKET_STATS_LAYOUT_ENABLED":  ApiMarketStatsLayoutEnabled,
+	"API_TIMELINE_LAYOUT_ENABLED":      ApiTimelineLayoutEnabled,
 }
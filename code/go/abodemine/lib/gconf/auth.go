@@ -7,8 +7,8 @@ import (
 )
 
 type Casbin struct {
-	Model  string `json:"model,omitempty" yaml:"model,omitempty"`
-	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+	Model  string `json:"model,omitempty" yaml:"model,omitempty" conf:"required"`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty" conf:"required"`
 }
 
 func LoadCasbin(config *Casbin) (*casbin.Enforcer, error) {
@@ -27,6 +27,7 @@ func LoadCasbin(config *Casbin) (*casbin.Enforcer, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create enforcer.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
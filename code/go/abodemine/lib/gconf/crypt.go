@@ -0,0 +1,583 @@
+package gconf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"gopkg.in/yaml.v3"
+
+	"abodemine/lib/errors"
+)
+
+// EncryptHeaderPrefix starts the first line of the config files
+// encrypted by EncryptConfig, which records the parameters of the
+// encryption, e.g.:
+//
+//	# gconf-encrypted: v=1 mode=fields iter=600000 salt=<base64>
+//
+// The files without it are decrypted with DecryptConfig.
+const EncryptHeaderPrefix = "# gconf-encrypted:"
+
+const (
+	// EncryptVersion_1 derives the key with PBKDF2-HMAC-SHA256, and
+	// encrypts with AES-256-GCM.
+	EncryptVersion_1 = 1
+
+	EncryptVersion = EncryptVersion_1
+)
+
+const (
+	// EncryptMode_FILE encrypts the whole body of the file.
+	EncryptMode_FILE = "file"
+
+	// EncryptMode_FIELDS encrypts each value of a yaml file, keeping
+	// its keys readable, so that a diff shows the changed keys.
+	EncryptMode_FIELDS = "fields"
+)
+
+// DefaultEncryptIter is the PBKDF2 iteration count of the new files.
+const DefaultEncryptIter = 600_000
+
+const (
+	encryptSaltSize  = 16
+	encryptKeySize   = 32
+	encryptLineWidth = 76
+
+	encryptedFieldPrefix = "ENC["
+	encryptedFieldSuffix = "]"
+)
+
+// EncryptHeader holds the parameters of an encrypted config file.
+type EncryptHeader struct {
+	Version int
+	Mode    string
+	Iter    int
+	Salt    []byte
+}
+
+func (h *EncryptHeader) String() string {
+	return EncryptHeaderPrefix +
+		" v=" + strconv.Itoa(h.Version) +
+		" mode=" + h.Mode +
+		" iter=" + strconv.Itoa(h.Iter) +
+		" salt=" + base64.StdEncoding.EncodeToString(h.Salt)
+}
+
+// ParseEncryptHeader parses the first line of an encrypted config
+// file. It returns nil if the body has no header.
+func ParseEncryptHeader(body []byte) (*EncryptHeader, []byte, error) {
+	if !bytes.HasPrefix(body, []byte(EncryptHeaderPrefix)) {
+		return nil, body, nil
+	}
+
+	line, rest, _ := bytes.Cut(body, []byte("\n"))
+
+	h := new(EncryptHeader)
+
+	for _, field := range strings.Fields(strings.TrimPrefix(string(line), EncryptHeaderPrefix)) {
+		k, v, _ := strings.Cut(field, "=")
+
+		var err error
+
+		switch k {
+		case "v":
+			h.Version, err = strconv.Atoi(v)
+		case "mode":
+			h.Mode = v
+		case "iter":
+			h.Iter, err = strconv.Atoi(v)
+		case "salt":
+			h.Salt, err = base64.StdEncoding.DecodeString(v)
+		}
+
+		if err != nil {
+			return nil, nil, &errors.Object{
+				Id:     "36b9989c-44a1-4f23-b828-dfa467867365",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Failed to parse encryption header.",
+				Cause:  err.Error(),
+				Err:    err,
+				Meta: map[string]any{
+					"field": k,
+				},
+			}
+		}
+	}
+
+	if h.Version != EncryptVersion_1 {
+		return nil, nil, &errors.Object{
+			Id:     "2c0b0af4-e31a-4e03-9de0-022997569624",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unsupported encryption version.",
+			Meta: map[string]any{
+				"version": h.Version,
+			},
+		}
+	}
+
+	if (h.Mode != EncryptMode_FILE && h.Mode != EncryptMode_FIELDS) || h.Iter <= 0 || len(h.Salt) == 0 {
+		return nil, nil, &errors.Object{
+			Id:     "c0baa1b8-b4bf-4655-a33d-db3cc1df693a",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid encryption header.",
+			Meta: map[string]any{
+				"mode": h.Mode,
+				"iter": h.Iter,
+			},
+		}
+	}
+
+	return h, rest, nil
+}
+
+// IsEncryptedConfig reports whether the body was encrypted by
+// EncryptConfig.
+func IsEncryptedConfig(body []byte) bool {
+	return bytes.HasPrefix(body, []byte(EncryptHeaderPrefix))
+}
+
+type EncryptConfigInput struct {
+	// Path is the path of the file, whose extension must be yaml in
+	// fields mode.
+	Path string
+
+	Body []byte
+	Pass string
+
+	// Iter defaults to DefaultEncryptIter.
+	Iter int
+
+	// Mode defaults to EncryptMode_FILE.
+	Mode string
+
+	// Previous is the encrypted file the body was decrypted from.
+	// In fields mode, its salt and the values of the unchanged
+	// fields are kept if its passphrase and iteration count are the
+	// same, so that a diff only shows the changed fields.
+	Previous []byte
+}
+
+// EncryptConfig encrypts the body of a config file with the current
+// EncryptVersion, see EncryptHeaderPrefix.
+func EncryptConfig(in *EncryptConfigInput) ([]byte, error) {
+	if in.Pass == "" {
+		return nil, &errors.Object{
+			Id:     "c8385e58-a81e-434b-b660-cd5c7e267c95",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing passphrase.",
+		}
+	}
+
+	h := &EncryptHeader{
+		Version: EncryptVersion,
+		Mode:    EncryptMode_FILE,
+		Iter:    DefaultEncryptIter,
+	}
+
+	if in.Mode != "" {
+		h.Mode = in.Mode
+	}
+
+	if in.Iter > 0 {
+		h.Iter = in.Iter
+	}
+
+	switch h.Mode {
+	case EncryptMode_FILE:
+	case EncryptMode_FIELDS:
+		switch strings.ToLower(filepath.Ext(in.Path)) {
+		case ".yaml", ".yml":
+		default:
+			return nil, &errors.Object{
+				Id:     "eae7b3b2-7a89-4afe-bd27-a10ca6a0a657",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Fields mode only supports yaml config files.",
+				Meta: map[string]any{
+					"path": in.Path,
+				},
+			}
+		}
+	default:
+		return nil, &errors.Object{
+			Id:     "eba5a2fc-27dc-4909-8b5b-fb217d7382d5",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown encryption mode.",
+			Meta: map[string]any{
+				"mode": h.Mode,
+			},
+		}
+	}
+
+	// The fields of the previous file, by path, whose ciphertexts
+	// are kept if their values did not change.
+	var previousFields map[string]*encryptedField
+
+	// Another passphrase or iteration count encrypts all the fields
+	// again, with a new salt.
+	if prev, prevBody, err := ParseEncryptHeader(in.Previous); err == nil && prev != nil && prev.Mode == EncryptMode_FIELDS && h.Mode == EncryptMode_FIELDS && prev.Iter == h.Iter {
+		if fields, err := decryptFields(prevBody, deriveEncryptKey(in.Pass, prev)); err == nil {
+			h.Salt = prev.Salt
+			previousFields = fields.byPath
+		}
+	}
+
+	if h.Salt == nil {
+		h.Salt = make([]byte, encryptSaltSize)
+
+		if _, err := rand.Read(h.Salt); err != nil {
+			return nil, &errors.Object{
+				Id:     "ec16af85-e492-40b0-9bf0-820e3056bee0",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to generate salt.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+	}
+
+	aead, err := newEncryptAEAD(deriveEncryptKey(in.Pass, h))
+	if err != nil {
+		return nil, errors.Forward(err, "d53a94b6-83cd-4051-a100-a4ae042de1f6")
+	}
+
+	out := new(bytes.Buffer)
+	out.WriteString(h.String())
+	out.WriteString("\n")
+
+	if h.Mode == EncryptMode_FILE {
+		sealed, err := sealEncrypt(aead, in.Body, []byte(h.String()))
+		if err != nil {
+			return nil, errors.Forward(err, "1673f331-04e3-47a7-a7ef-2a6b5983d4ce")
+		}
+
+		writeEncryptLines(out, sealed)
+
+		return out.Bytes(), nil
+	}
+
+	doc := new(yaml.Node)
+
+	if err := yaml.Unmarshal(in.Body, doc); err != nil {
+		return nil, &errors.Object{
+			Id:     "4901d82a-5bdf-4bdc-bd32-671a50cc7252",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to parse yaml config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	var walkErr error
+
+	walkScalars(doc, "", func(node *yaml.Node, path string) {
+		if walkErr != nil || node.ShortTag() == "!!null" {
+			return
+		}
+
+		plaintext := node.ShortTag() + "\n" + node.Value
+
+		if prev, ok := previousFields[path]; ok && prev.plaintext == plaintext {
+			node.Value = prev.value
+		} else {
+			sealed, err := sealEncrypt(aead, []byte(plaintext), []byte(path))
+			if err != nil {
+				walkErr = errors.Forward(err, "83480510-76fe-4aad-a2c4-19a077042600")
+				return
+			}
+
+			node.Value = encryptedFieldPrefix + sealed + encryptedFieldSuffix
+		}
+
+		node.Tag = "!!str"
+		node.Style = 0
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	if err := encodeYAML(out, doc); err != nil {
+		return nil, errors.Forward(err, "b7a77c81-d163-4594-b54b-aa7f56abf989")
+	}
+
+	return out.Bytes(), nil
+}
+
+// DecryptConfigFile decrypts a config file encrypted by EncryptConfig,
+// with the parameters of its header, or by openssl otherwise, with the
+// given iteration count (see DecryptConfig).
+func DecryptConfigFile(body []byte, pass string, iter int) ([]byte, error) {
+	h, rest, err := ParseEncryptHeader(body)
+	if err != nil {
+		return nil, errors.Forward(err, "2819e9e3-1356-4722-a8e3-6c0abdb01892")
+	}
+
+	if h == nil {
+		if iter <= 0 {
+			return nil, &errors.Object{
+				Id:     "805556fa-f894-4b6c-a85d-938570ae75db",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing iteration count.",
+			}
+		}
+
+		out, err := DecryptConfig(bytes.NewReader(body), pass, iter)
+		if err != nil {
+			return nil, errors.Forward(err, "66b2a59d-b83a-44f2-adb1-fc0cf28f4225")
+		}
+
+		return out, nil
+	}
+
+	key := deriveEncryptKey(pass, h)
+
+	if h.Mode == EncryptMode_FILE {
+		aead, err := newEncryptAEAD(key)
+		if err != nil {
+			return nil, errors.Forward(err, "9d208dae-d53f-4a74-9306-1f14e0cb8941")
+		}
+
+		out, err := openEncrypt(aead, string(rest), []byte(h.String()))
+		if err != nil {
+			return nil, errors.Forward(err, "f8f0a338-82ca-4c4d-a3b1-863b8a2e4ffa")
+		}
+
+		return out, nil
+	}
+
+	fields, err := decryptFields(rest, key)
+	if err != nil {
+		return nil, errors.Forward(err, "67095eab-fc3f-4c22-b957-85def836592c")
+	}
+
+	out := new(bytes.Buffer)
+
+	if err := encodeYAML(out, fields.doc); err != nil {
+		return nil, errors.Forward(err, "8788483e-42cf-42bb-9d41-ac7fc8dbb663")
+	}
+
+	return out.Bytes(), nil
+}
+
+type encryptedField struct {
+	// value is the encrypted value, ENC[<base64>].
+	value string
+
+	// plaintext is the tag and the value, separated by a newline.
+	plaintext string
+}
+
+type decryptedFields struct {
+	doc    *yaml.Node
+	byPath map[string]*encryptedField
+}
+
+// decryptFields decrypts in place the values of the yaml body
+// encrypted in fields mode.
+func decryptFields(body []byte, key []byte) (*decryptedFields, error) {
+	aead, err := newEncryptAEAD(key)
+	if err != nil {
+		return nil, errors.Forward(err, "6f6477e0-e270-4a7b-b80d-c2c1f8793aa4")
+	}
+
+	out := &decryptedFields{
+		doc:    new(yaml.Node),
+		byPath: make(map[string]*encryptedField),
+	}
+
+	if err := yaml.Unmarshal(body, out.doc); err != nil {
+		return nil, &errors.Object{
+			Id:     "603fcde5-057d-4cd2-b295-78d3dd854480",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to parse yaml config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	var walkErr error
+
+	walkScalars(out.doc, "", func(node *yaml.Node, path string) {
+		if walkErr != nil {
+			return
+		}
+
+		sealed, ok := strings.CutPrefix(node.Value, encryptedFieldPrefix)
+		if !ok || !strings.HasSuffix(sealed, encryptedFieldSuffix) {
+			return
+		}
+
+		plaintext, err := openEncrypt(aead, strings.TrimSuffix(sealed, encryptedFieldSuffix), []byte(path))
+		if err != nil {
+			walkErr = errors.Wrap(err, &errors.Object{
+				Id:     "5cdcd68b-2719-4a46-9102-4b5347ced445",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Failed to decrypt field.",
+				Path:   path,
+			})
+
+			return
+		}
+
+		out.byPath[path] = &encryptedField{
+			value:     node.Value,
+			plaintext: string(plaintext),
+		}
+
+		tag, value, _ := strings.Cut(string(plaintext), "\n")
+
+		node.Tag = tag
+		node.Value = value
+		node.Style = 0
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	return out, nil
+}
+
+// walkScalars calls f with the scalar values of the yaml node, and
+// their paths as JSON pointers. The keys of the maps are not values.
+func walkScalars(node *yaml.Node, path string, f func(node *yaml.Node, path string)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, v := range node.Content {
+			walkScalars(v, path, f)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkScalars(node.Content[i+1], path+"/"+escapePathKey(node.Content[i].Value), f)
+		}
+	case yaml.SequenceNode:
+		for i, v := range node.Content {
+			walkScalars(v, path+"/"+strconv.Itoa(i), f)
+		}
+	case yaml.ScalarNode:
+		f(node, path)
+	}
+}
+
+func encodeYAML(out *bytes.Buffer, doc *yaml.Node) error {
+	// An empty file has no document.
+	if doc.Kind == 0 {
+		return nil
+	}
+
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return &errors.Object{
+			Id:     "51cf760e-aa5f-4c8e-98f5-1a7b9d530880",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to encode yaml config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return &errors.Object{
+			Id:     "3b84c711-c3b2-4773-8dfe-423de9d1272b",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to encode yaml config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+func deriveEncryptKey(pass string, h *EncryptHeader) []byte {
+	return pbkdf2.Key([]byte(pass), h.Salt, h.Iter, encryptKeySize, sha256.New)
+}
+
+func newEncryptAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "40d34bbc-f3a2-4058-81d9-fa3cfcc618c4",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create cipher.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "77175de8-869c-4393-a46f-f303f4eaa046",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create cipher.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return aead, nil
+}
+
+// sealEncrypt returns the nonce followed by the ciphertext, base64
+// encoded. The additional data binds the ciphertext to its header or
+// to the path of its field.
+func sealEncrypt(aead cipher.AEAD, plaintext, additionalData []byte) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return "", &errors.Object{
+			Id:     "2bec7aaf-2b20-4a59-a585-ce63a7faa05d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to generate nonce.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plaintext, additionalData)), nil
+}
+
+func openEncrypt(aead cipher.AEAD, sealed string, additionalData []byte) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sealed), ""))
+	if err != nil || len(b) < aead.NonceSize() {
+		return nil, &errors.Object{
+			Id:     "e468eec8-7db4-47c2-bd75-47ac98124758",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid ciphertext.",
+		}
+	}
+
+	// The error of a wrong passphrase tells nothing more.
+	out, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "e5773e18-ebe4-4e6c-b6ca-fa7787dca1db",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to decrypt config file, wrong passphrase or altered file.",
+		}
+	}
+
+	return out, nil
+}
+
+func writeEncryptLines(out *bytes.Buffer, sealed string) {
+	for len(sealed) > encryptLineWidth {
+		out.WriteString(sealed[:encryptLineWidth])
+		out.WriteString("\n")
+		sealed = sealed[encryptLineWidth:]
+	}
+
+	out.WriteString(sealed)
+	out.WriteString("\n")
+}
@@ -0,0 +1,245 @@
+package gconf
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// The fixtures of testdata were encrypted from plain.yaml: legacy.yaml.enc
+// by openssl with testLegacyPass, and the v1 files by EncryptConfig
+// with testPass. They MUST NOT be encrypted again, so that the files
+// encrypted before a change of the format are still decrypted.
+const (
+	testPass       = "test-pass"
+	testLegacyPass = "dGVzdC1wYXNz"
+	testIter       = 1000
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return b
+}
+
+// requireSameYAML compares the values of the yaml bodies, since the
+// fields mode encodes the yaml again.
+func requireSameYAML(t *testing.T, want, got []byte) {
+	t.Helper()
+
+	var wantValue, gotValue any
+	require.NoError(t, yaml.Unmarshal(want, &wantValue))
+	require.NoError(t, yaml.Unmarshal(got, &gotValue))
+	require.Equal(t, wantValue, gotValue)
+}
+
+func TestDecryptConfigFile_Fixtures(t *testing.T) {
+	plain := readTestdata(t, "plain.yaml")
+
+	out, err := DecryptConfigFile(readTestdata(t, "v1-file.yaml.enc"), testPass, 0)
+	require.NoError(t, err)
+	assert.Equal(t, string(plain), string(out))
+
+	out, err = DecryptConfigFile(readTestdata(t, "v1-fields.yaml.enc"), testPass, 0)
+	require.NoError(t, err)
+	requireSameYAML(t, plain, out)
+
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl not found")
+	}
+
+	out, err = DecryptConfigFile(readTestdata(t, "legacy.yaml.enc"), testLegacyPass, testIter)
+	require.NoError(t, err)
+	assert.Equal(t, string(plain), string(out))
+
+	_, err = DecryptConfigFile(readTestdata(t, "legacy.yaml.enc"), testLegacyPass, 0)
+	require.Error(t, err)
+}
+
+func TestEncryptConfig_RoundTrip(t *testing.T) {
+	plain := readTestdata(t, "plain.yaml")
+
+	for _, mode := range []string{EncryptMode_FILE, EncryptMode_FIELDS} {
+		t.Run(mode, func(t *testing.T) {
+			encrypted, err := EncryptConfig(&EncryptConfigInput{
+				Path: "config.yaml",
+				Body: plain,
+				Pass: testPass,
+				Iter: testIter,
+				Mode: mode,
+			})
+			require.NoError(t, err)
+
+			h, _, err := ParseEncryptHeader(encrypted)
+			require.NoError(t, err)
+			assert.Equal(t, EncryptVersion, h.Version)
+			assert.Equal(t, mode, h.Mode)
+			assert.Equal(t, testIter, h.Iter)
+
+			assert.NotContains(t, string(encrypted), "p@ss: word")
+
+			out, err := DecryptConfigFile(encrypted, testPass, 0)
+			require.NoError(t, err)
+			requireSameYAML(t, plain, out)
+
+			_, err = DecryptConfigFile(encrypted, "wrong-pass", 0)
+			require.Error(t, err)
+
+			// The header is bound to the ciphertext.
+			altered := strings.Replace(string(encrypted), "iter=1000", "iter=1001", 1)
+			_, err = DecryptConfigFile([]byte(altered), testPass, 0)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEncryptConfig_Fields(t *testing.T) {
+	plain := readTestdata(t, "plain.yaml")
+
+	encrypted, err := EncryptConfig(&EncryptConfigInput{
+		Path: "config.yaml",
+		Body: plain,
+		Pass: testPass,
+		Iter: testIter,
+		Mode: EncryptMode_FIELDS,
+	})
+	require.NoError(t, err)
+
+	// The keys are readable, and the types of the values are kept.
+	assert.Contains(t, string(encrypted), "    password: ENC[")
+
+	out, err := DecryptConfigFile(encrypted, testPass, 0)
+	require.NoError(t, err)
+
+	var v struct {
+		Postgres map[string]*Postgres `yaml:"postgres"`
+		Flags    []string             `yaml:"flags"`
+	}
+	require.NoError(t, yaml.Unmarshal(out, &v))
+	assert.Equal(t, 5432, v.Postgres["api"].Port)
+	assert.Equal(t, []string{"true", "beta"}, v.Flags)
+
+	// Encrypting the same body again changes nothing.
+	again, err := EncryptConfig(&EncryptConfigInput{
+		Path:     "config.yaml",
+		Body:     out,
+		Pass:     testPass,
+		Iter:     testIter,
+		Mode:     EncryptMode_FIELDS,
+		Previous: encrypted,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(encrypted), string(again))
+
+	// Only the changed field differs.
+	changed, err := EncryptConfig(&EncryptConfigInput{
+		Path:     "config.yaml",
+		Body:     []byte(strings.Replace(string(out), "dbname: api", "dbname: api2", 1)),
+		Pass:     testPass,
+		Iter:     testIter,
+		Mode:     EncryptMode_FIELDS,
+		Previous: encrypted,
+	})
+	require.NoError(t, err)
+
+	wantLines := strings.Split(string(encrypted), "\n")
+	gotLines := strings.Split(string(changed), "\n")
+	require.Len(t, gotLines, len(wantLines))
+
+	var diff []string
+	for i := range wantLines {
+		if wantLines[i] != gotLines[i] {
+			diff = append(diff, strings.TrimSpace(strings.SplitAfter(gotLines[i], ":")[0]))
+		}
+	}
+	assert.Equal(t, []string{"dbname:"}, diff)
+
+	// The values are bound to their keys.
+	swapped := strings.Replace(string(encrypted), "    user: ", "    tmp: ", 1)
+	swapped = strings.Replace(swapped, "    dbname: ", "    user: ", 1)
+	swapped = strings.Replace(swapped, "    tmp: ", "    dbname: ", 1)
+	_, err = DecryptConfigFile([]byte(swapped), testPass, 0)
+	require.Error(t, err)
+
+	_, err = EncryptConfig(&EncryptConfigInput{
+		Path: "config.json",
+		Body: []byte(`{}`),
+		Pass: testPass,
+		Mode: EncryptMode_FIELDS,
+	})
+	require.Error(t, err)
+}
+
+func TestEncryptConfig_Rekey(t *testing.T) {
+	plain := readTestdata(t, "plain.yaml")
+
+	encrypted := readTestdata(t, "v1-fields.yaml.enc")
+
+	out, err := DecryptConfigFile(encrypted, testPass, 0)
+	require.NoError(t, err)
+
+	// Another passphrase does not keep the salt nor the fields.
+	rekeyed, err := EncryptConfig(&EncryptConfigInput{
+		Path:     "config.yaml",
+		Body:     out,
+		Pass:     "new-pass",
+		Iter:     testIter * 2,
+		Mode:     EncryptMode_FIELDS,
+		Previous: encrypted,
+	})
+	require.NoError(t, err)
+
+	h, _, err := ParseEncryptHeader(rekeyed)
+	require.NoError(t, err)
+	assert.Equal(t, testIter*2, h.Iter)
+
+	old, _, err := ParseEncryptHeader(encrypted)
+	require.NoError(t, err)
+	assert.NotEqual(t, old.Salt, h.Salt)
+
+	_, err = DecryptConfigFile(rekeyed, testPass, 0)
+	require.Error(t, err)
+
+	out, err = DecryptConfigFile(rekeyed, "new-pass", 0)
+	require.NoError(t, err)
+	requireSameYAML(t, plain, out)
+}
+
+func TestConfigSource_Encrypted(t *testing.T) {
+	const envKey = "GCONF_TEST_CONFIG_PATH"
+
+	file := new(struct {
+		LogLevel string               `yaml:"log_level"`
+		Postgres map[string]*Postgres `yaml:"postgres"`
+		Flags    []string             `yaml:"flags"`
+	})
+
+	for _, name := range []string{"v1-file.yaml.enc", "v1-fields.yaml.enc"} {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, readTestdata(t, name), 0o600))
+
+		t.Setenv(envKey+"_ENC_PASS", "")
+
+		source, err := NewConfigSource(path, envKey)
+		require.NoError(t, err)
+
+		// The encrypted files are not read as plain files.
+		_, err = source.Read(context.Background())
+		require.Error(t, err)
+
+		t.Setenv(envKey+"_ENC_PASS", testPass)
+
+		require.NoError(t, source.Load(context.Background(), file))
+		assert.Equal(t, "p@ss: word", file.Postgres["api"].Password)
+	}
+}
@@ -13,12 +13,13 @@ import (
 	"github.com/valkey-io/valkey-go"
 
 	"abodemine/lib/errors"
+	"abodemine/lib/tracing"
 )
 
 type OpenSearch struct {
 	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
 
-	Addresses            []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	Addresses            []string `json:"addresses,omitempty" yaml:"addresses,omitempty" conf:"required,url"`
 	Username             string   `json:"username,omitempty" yaml:"username,omitempty"`
 	Password             string   `json:"password,omitempty" yaml:"password,omitempty"`
 	CompressRequestBody  bool     `json:"compress_request_body,omitempty" yaml:"compress_request_body,omitempty"`
@@ -36,6 +37,7 @@ func LoadOpenSearch(config *OpenSearch) (*opensearch.Client, error) {
 	}
 
 	client, err := opensearch.NewClient(opensearch.Config{
+		Transport:            &tracing.Transport{Name: "opensearch"},
 		Addresses:            config.Addresses,
 		Username:             config.Username,
 		Password:             config.Password,
@@ -48,6 +50,7 @@ func LoadOpenSearch(config *OpenSearch) (*opensearch.Client, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create client.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -76,11 +79,11 @@ func (t *collectionTransport) Perform(req *http.Request) (*http.Response, error)
 type Postgres struct {
 	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
 
-	Host     string `json:"host,omitempty" yaml:"host,omitempty"`
+	Host     string `json:"host,omitempty" yaml:"host,omitempty" conf:"required"`
 	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
-	User     string `json:"user,omitempty" yaml:"user,omitempty"`
+	User     string `json:"user,omitempty" yaml:"user,omitempty" conf:"required"`
 	Password string `json:"password,omitempty" yaml:"password,omitempty"`
-	Dbname   string `json:"dbname,omitempty" yaml:"dbname,omitempty"`
+	Dbname   string `json:"dbname,omitempty" yaml:"dbname,omitempty" conf:"required"`
 	SslMode  string `json:"ssl_mode,omitempty" yaml:"ssl_mode,omitempty"`
 }
 
@@ -119,13 +122,27 @@ func LoadPostgres(config *Postgres) (*pgxpool.Pool, error) {
 
 	switch driver {
 	case "pgx":
-		pool, err := pgxpool.New(ctx, connectionString)
+		poolConfig, err := pgxpool.ParseConfig(connectionString)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "0b2ae43b-6fd5-428e-8c8e-13f204707032",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Failed to parse pgx configuration.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		poolConfig.ConnConfig.Tracer = &tracing.PgxTracer{}
+
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 		if err != nil {
 			return nil, &errors.Object{
 				Id:     "a76a0175-79f2-4920-b1d1-b5dcde74ce35",
 				Code:   errors.Code_UNAVAILABLE,
 				Detail: "Failed to open pgx connection.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -136,6 +153,7 @@ func LoadPostgres(config *Postgres) (*pgxpool.Pool, error) {
 					Code:   errors.Code_UNAVAILABLE,
 					Detail: "Failed to ping pgx connection.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 		}
@@ -151,7 +169,7 @@ func LoadPostgres(config *Postgres) (*pgxpool.Pool, error) {
 }
 
 type Valkey struct {
-	Nodes    []*ValkeyNode            `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Nodes    []*ValkeyNode            `json:"nodes,omitempty" yaml:"nodes,omitempty" conf:"required"`
 	Scripts  map[string]*ValkeyScript `json:"scripts,omitempty" yaml:"scripts,omitempty"`
 	Username string                   `json:"username,omitempty" yaml:"username,omitempty"`
 	Password string                   `json:"password,omitempty" yaml:"password,omitempty"`
@@ -159,8 +177,8 @@ type Valkey struct {
 }
 
 type ValkeyNode struct {
-	Host string `json:"host,omitempty" yaml:"host,omitempty"`
-	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+	Host string `json:"host,omitempty" yaml:"host,omitempty" conf:"required"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty" conf:"required"`
 }
 
 type ValkeyScript struct {
@@ -194,13 +212,16 @@ func LoadValkey(config *Valkey) (valkey.Client, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create client.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
-	return cli, nil
+	return tracing.NewValkeyClient(cli), nil
 }
 
-func LoadValkeyScript(config *ValkeyScript) (*valkey.Lua, error) {
+// LoadValkeyScript loads the script and names the spans of its
+// executions after it.
+func LoadValkeyScript(name string, config *ValkeyScript) (*valkey.Lua, error) {
 	if config == nil {
 		return nil, &errors.Object{
 			Id:     "716ad845-497a-4708-a748-2e2815c5c55a",
@@ -223,11 +244,14 @@ func LoadValkeyScript(config *ValkeyScript) (*valkey.Lua, error) {
 				Detail: "Failed to read file.",
 				Path:   "/file",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
 		body = string(b)
 	}
 
+	tracing.RegisterValkeyScript(name, body)
+
 	return valkey.NewLuaScript(body), nil
 }
@@ -2,6 +2,7 @@ package gconf
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,13 +22,43 @@ type ConfigFileCtxKey string
 
 // ResolveConfig resolves the config file path and parses it into the given value.
 func ResolveConfig(v any, configPath string, envKey string) error {
+	source, err := NewConfigSource(configPath, envKey)
+	if err != nil {
+		return errors.Forward(err, "e35337c2-0d19-4393-a1a5-404f9067cd93")
+	}
+
+	if err := source.Load(context.Background(), v); err != nil {
+		return errors.Forward(err, "b99b1568-6850-4d66-a323-9a03a7f4842e")
+	}
+
+	return nil
+}
+
+// ConfigSource reads a config file, decrypting it when its
+// <envKey>_ENC_PASS variable is set (see DecryptConfigFile), then expanding the environment
+// variables and the ${secret:<provider>:<path>} references of its
+// body. It is kept to read the file again on reload.
+type ConfigSource struct {
+	// Path is "-" when the config is defined elsewhere.
+	Path    string
+	EnvKey  string
+	Secrets *SecretResolver
+
+	// SkipSchema disables ValidateSchema, for the tools reading a
+	// part of a config file.
+	SkipSchema bool
+}
+
+// NewConfigSource resolves the config file path, from the variable
+// of envKey if configPath is empty. The secret providers are set up
+// from the environment, see NewEnvSecretResolver.
+func NewConfigSource(configPath string, envKey string) (*ConfigSource, error) {
 	configPath = strings.TrimSpace(configPath)
+	envKey = strings.TrimSpace(envKey)
 
 	if configPath == "" {
-		envKey = strings.TrimSpace(envKey)
-
 		if envKey == "" {
-			return &errors.Object{
+			return nil, &errors.Object{
 				Id:     "13ae1af8-1cab-4792-bb27-09275c5615d1",
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to resolve config file path.",
@@ -37,73 +68,148 @@ func ResolveConfig(v any, configPath string, envKey string) error {
 		configPath = os.Getenv(envKey)
 	}
 
+	secrets, err := NewEnvSecretResolver(envKey, filepath.Dir(configPath))
+	if err != nil {
+		return nil, errors.Forward(err, "82071d9a-8dbc-41dc-bb28-914726d5f7e0")
+	}
+
+	return &ConfigSource{
+		Path:    configPath,
+		EnvKey:  envKey,
+		Secrets: secrets,
+	}, nil
+}
+
+// Load reads the config file and parses it into the given value.
+func (s *ConfigSource) Load(ctx context.Context, v any) error {
 	// This should indicate that the config was defined elsewhere.
-	if configPath == "-" {
+	if s.Path == "-" {
 		return nil
 	}
 
-	fileBody, err := os.ReadFile(configPath)
+	body, err := s.Read(ctx)
+	if err != nil {
+		return errors.Forward(err, "899a66bb-04a9-472c-a65a-855488c817b5")
+	}
+
+	if err := s.Decode(body, v); err != nil {
+		return errors.Forward(err, "c44ca670-4ceb-4d42-8d77-4795ae19f6c6")
+	}
+
+	return nil
+}
+
+// Read returns the decrypted and expanded body of the config file.
+func (s *ConfigSource) Read(ctx context.Context) ([]byte, error) {
+	fileBody, err := os.ReadFile(s.Path)
 	if err != nil {
-		return &errors.Object{
+		return nil, &errors.Object{
 			Id:     "d57b1b31-3c58-453c-8ef1-9227765e0b45",
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Failed to read config file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
-	if passStr := os.Getenv(fmt.Sprintf("%s_ENC_PASS", envKey)); passStr != "" {
-		iterStr := os.Getenv(fmt.Sprintf("%s_ENC_ITER", envKey))
-
-		iter, err := strconv.Atoi(iterStr)
-		if err != nil {
-			return &errors.Object{
-				Id:     "a85d39f6-d106-43c7-a9e7-b4cd927d84a9",
-				Code:   errors.Code_INVALID_ARGUMENT,
-				Detail: "Failed to parse iteration count.",
-				Cause:  err.Error(),
-				Meta: map[string]any{
-					"iter": iterStr,
-				},
+	if passStr := os.Getenv(fmt.Sprintf("%s_ENC_PASS", s.EnvKey)); s.EnvKey != "" && passStr != "" {
+		// The iteration count is in the header of the files encrypted
+		// by EncryptConfig, and only needed for the older ones.
+		var iter int
+
+		if iterStr := os.Getenv(fmt.Sprintf("%s_ENC_ITER", s.EnvKey)); iterStr != "" {
+			iter, err = strconv.Atoi(iterStr)
+			if err != nil {
+				return nil, &errors.Object{
+					Id:     "a85d39f6-d106-43c7-a9e7-b4cd927d84a9",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Failed to parse iteration count.",
+					Cause:  err.Error(),
+					Err:    err,
+					Meta: map[string]any{
+						"iter": iterStr,
+					},
+				}
 			}
 		}
 
-		decryptedBody, err := DecryptConfig(bytes.NewBuffer(fileBody), passStr, iter)
+		decryptedBody, err := DecryptConfigFile(fileBody, passStr, iter)
 		if err != nil {
-			return errors.Forward(err, "3aab8a04-f594-456a-9a53-7b9afc4f4227")
+			return nil, errors.Forward(err, "3aab8a04-f594-456a-9a53-7b9afc4f4227")
 		}
 
 		fileBody = decryptedBody
+	} else if IsEncryptedConfig(fileBody) {
+		return nil, &errors.Object{
+			Id:     "63fc0315-6d87-452e-976d-7b45ee0ba1b0",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Missing passphrase of encrypted config file.",
+			Meta: map[string]any{
+				"env": s.EnvKey + "_ENC_PASS",
+			},
+		}
+	}
+
+	expandedBody, err := s.Secrets.Expand(ctx, string(fileBody))
+	if err != nil {
+		return nil, errors.Forward(err, "a7a971d8-ac51-45d2-abbd-c6010812d6b3")
 	}
 
-	expandedBody := []byte(os.ExpandEnv(string(fileBody)))
+	return []byte(expandedBody), nil
+}
+
+// Decode parses the body read from the config file into the given
+// value, by the extension of the file. The body is first checked
+// against the schema of the value, see ValidateSchema.
+func (s *ConfigSource) Decode(body []byte, v any) error {
+	if !s.SkipSchema {
+		problems, err := ValidateSchema(body, s.Path, v)
+		if err != nil {
+			return errors.Forward(err, "e16efdf0-25ae-4ce9-837f-7f9a09769bc0")
+		}
+
+		if len(problems) > 0 {
+			return errors.Wrap(problems[0], &errors.Object{
+				Id:     "fad8c384-2c70-4f95-bf1a-084c51899606",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid config file.",
+				Meta: map[string]any{
+					"path":     s.Path,
+					"problems": len(problems),
+				},
+			})
+		}
+	}
 
-	switch strings.ToLower(filepath.Ext(configPath)) {
+	switch strings.ToLower(filepath.Ext(s.Path)) {
 	case ".json":
-		if err := json.Unmarshal(expandedBody, v); err != nil {
+		if err := json.Unmarshal(body, v); err != nil {
 			return &errors.Object{
 				Id:     "6e3cb928-8e31-4951-bfec-83b01cbf1eac",
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse json config file.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	case ".toml":
-		if err := toml.Unmarshal(expandedBody, v); err != nil {
+		if err := toml.Unmarshal(body, v); err != nil {
 			return &errors.Object{
 				Id:     "7c1a4c58-a4ae-46bc-9005-44c7fcbef2d3",
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse toml config file.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	case ".yaml", ".yml":
-		if err := yaml.Unmarshal(expandedBody, v); err != nil {
+		if err := yaml.Unmarshal(body, v); err != nil {
 			return &errors.Object{
 				Id:     "0a3d3a29-ba73-49e7-9466-28e1bb1c5499",
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse yaml config file.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -111,7 +217,8 @@ func ResolveConfig(v any, configPath string, envKey string) error {
 	return nil
 }
 
-// pass must be in the form of a base64 encoded string.
+// DecryptConfig decrypts a config file encrypted by openssl, before
+// EncryptConfig. pass must be in the form of a base64 encoded string.
 func DecryptConfig(reader io.Reader, pass string, iter int) ([]byte, error) {
 	cmd := exec.Command(
 		"openssl",
@@ -134,6 +241,7 @@ func DecryptConfig(reader io.Reader, pass string, iter int) ([]byte, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to decrypt config file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
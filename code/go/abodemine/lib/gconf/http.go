@@ -48,4 +48,29 @@ type HttpServer struct {
 	Tls       *Tls         `json:"tls,omitempty" yaml:"tls,omitempty"`
 	Csrf      *Csrf        `json:"csrf,omitempty" yaml:"csrf,omitempty"`
 	Session   *HttpSession `json:"session,omitempty" yaml:"session,omitempty"`
+
+	// TrustedProxies is the number of proxies in front of the
+	// server, e.g. 1 behind the load balancer, each appending the
+	// address of its peer to X-Forwarded-For. The client address
+	// is the entry appended by the outermost of them. Defaults to
+	// none, so the address of the peer is the client address.
+	TrustedProxies int `json:"trusted_proxies,omitempty" yaml:"trusted_proxies,omitempty"`
+
+	// Admin is the plain HTTP listener serving /metrics and the
+	// health checks. It MUST NOT be reachable from the internet.
+	Admin *HttpAdmin `json:"admin,omitempty" yaml:"admin,omitempty"`
+
+	// ShutdownDelay is how long the server keeps serving after
+	// SIGTERM while reporting not ready, so that the load balancer
+	// stops routing requests to it first. Defaults to none.
+	ShutdownDelay string `json:"shutdown_delay,omitempty" yaml:"shutdown_delay,omitempty" conf:"duration"`
+
+	// ShutdownTimeout bounds the drain of the in-flight requests.
+	// Defaults to 25s, below the 30s ECS waits before SIGKILL.
+	ShutdownTimeout string `json:"shutdown_timeout,omitempty" yaml:"shutdown_timeout,omitempty" conf:"duration"`
+}
+
+type HttpAdmin struct {
+	Bind string `json:"bind,omitempty" yaml:"bind,omitempty"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
 }
@@ -20,6 +20,24 @@ type Sentry struct {
 	EnableTracing    bool    `json:"enable_tracing,omitempty" yaml:"enable_tracing,omitempty"`
 }
 
+// ErrorReporter selects where the errors of the process are
+// reported.
+type ErrorReporter struct {
+	// Sink is one of sentry, zerolog, file or memory. Defaults to
+	// sentry when Sentry is configured, zerolog otherwise.
+	Sink string `json:"sink,omitempty" yaml:"sink,omitempty"`
+
+	// File is the NDJSON file of the file sink.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// SampleRate is the ratio of the events reported. Defaults to 1.
+	SampleRate float64 `json:"sample_rate,omitempty" yaml:"sample_rate,omitempty"`
+
+	// DedupeWindow is how long the events of an error id are
+	// dropped after one was reported. Defaults to 1m.
+	DedupeWindow string `json:"dedupe_window,omitempty" yaml:"dedupe_window,omitempty" conf:"duration"`
+}
+
 func LoadZerolog(logLevel string, noLogColor bool) error {
 	zerologConfig, err := GetZerologConfig(logLevel, noLogColor)
 	if err != nil {
@@ -63,6 +81,7 @@ func GetZerologConfig(logLevel string, noLogColor bool) (*Zerolog, error) {
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Invalid zerolog level.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
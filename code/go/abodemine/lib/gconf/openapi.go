@@ -0,0 +1,56 @@
+package gconf
+
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"abodemine/lib/errors"
+)
+
+type OpenApi struct {
+	// Path to the OpenAPI spec file.
+	Spec string `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	// ValidateResponses enables response conformance checks.
+	// Responses are buffered before being written, so this
+	// should only be enabled in tests and local environments.
+	ValidateResponses bool `json:"validate_responses,omitempty" yaml:"validate_responses,omitempty"`
+}
+
+func LoadOpenApi(config *OpenApi) (*openapi3.T, error) {
+	if config == nil {
+		return nil, &errors.Object{
+			Id:     "c9bbbc6c-092d-42f5-97a7-bc58e49c4e38",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing config.",
+		}
+	}
+
+	loader := openapi3.NewLoader()
+
+	doc, err := loader.LoadFromFile(config.Spec)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "54e3391f-f63c-44d5-81f1-e3ebf1b1dc44",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to load OpenAPI spec.",
+			Path:   "/spec",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, &errors.Object{
+			Id:     "4e3b148e-785b-4303-9007-6421fd5cf5d4",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid OpenAPI spec.",
+			Path:   "/spec",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return doc, nil
+}
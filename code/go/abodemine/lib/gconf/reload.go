@@ -0,0 +1,349 @@
+package gconf
+
+import (
+	"context"
+	"crypto/sha256"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/valkey-io/valkey-go"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+)
+
+// ConfigReload enables the reload of the config file, so that the
+// credentials of the clients can rotate without a restart.
+type ConfigReload struct {
+	// Interval is how often the config file and its secrets are
+	// read again. Defaults to 1m.
+	Interval    time.Duration `json:"-" yaml:"-"`
+	IntervalStr string        `json:"interval,omitempty" yaml:"interval,omitempty" conf:"duration"`
+
+	// CloseDelay is how long the replaced clients are kept open,
+	// for the calls that selected them to complete. Defaults to 1m.
+	CloseDelay    time.Duration `json:"-" yaml:"-"`
+	CloseDelayStr string        `json:"close_delay,omitempty" yaml:"close_delay,omitempty" conf:"duration"`
+}
+
+func LoadConfigReload(config *ConfigReload) error {
+	config.Interval = time.Minute
+	config.CloseDelay = time.Minute
+
+	if config.IntervalStr != "" {
+		v, err := LoadDuration(config.IntervalStr)
+		if err != nil {
+			return errors.Forward(err, "4fa1a32c-0155-4587-a1d0-56dcfb049198")
+		}
+
+		config.Interval = v
+	}
+
+	if config.CloseDelayStr != "" {
+		v, err := LoadDuration(config.CloseDelayStr)
+		if err != nil {
+			return errors.Forward(err, "d2578467-5e30-4380-a463-c8d7e33ca46f")
+		}
+
+		config.CloseDelay = v
+	}
+
+	if config.Interval <= 0 {
+		return &errors.Object{
+			Id:     "b5fe0827-a7c1-4df7-a7c7-b67910cacf8e",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The interval must be positive.",
+			Path:   "/interval",
+		}
+	}
+
+	return nil
+}
+
+// Watcher reads the config file at an interval, and reloads it when
+// its expanded body changes, including its secrets.
+type Watcher struct {
+	source   *ConfigSource
+	interval time.Duration
+	reload   func(ctx context.Context, body []byte) error
+	sum      [sha256.Size]byte
+}
+
+type NewWatcherInput struct {
+	Source   *ConfigSource
+	Interval time.Duration
+
+	// Reload applies the new body of the config file. The body is
+	// read again at the next interval if it fails.
+	Reload func(ctx context.Context, body []byte) error
+}
+
+// NewWatcher reads the config file, whose current body is not
+// reloaded.
+func NewWatcher(ctx context.Context, in *NewWatcherInput) (*Watcher, error) {
+	if in.Source == nil || in.Source.Path == "-" || in.Reload == nil {
+		return nil, &errors.Object{
+			Id:     "e2546eef-c066-4204-a38e-bd1714014c10",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing config file source or reload.",
+		}
+	}
+
+	body, err := in.Source.Read(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "804954eb-f18e-428e-8477-5b23c212c6ca")
+	}
+
+	return &Watcher{
+		source:   in.Source,
+		interval: val.Ternary(in.Interval > 0, in.Interval, time.Minute),
+		reload:   in.Reload,
+		sum:      sha256.Sum256(body),
+	}, nil
+}
+
+// Check reads the config file, and reloads it if it changed. It
+// reports whether it was reloaded.
+func (w *Watcher) Check(ctx context.Context) (bool, error) {
+	body, err := w.source.Read(ctx)
+	if err != nil {
+		return false, errors.Forward(err, "2012890e-f21d-4f91-af5c-5b9981aa4fcc")
+	}
+
+	sum := sha256.Sum256(body)
+	if sum == w.sum {
+		return false, nil
+	}
+
+	if err := w.reload(ctx, body); err != nil {
+		return false, errors.Forward(err, "81bb4dc0-8ccf-4d47-9924-0cc4371cbad1")
+	}
+
+	w.sum = sum
+
+	return true, nil
+}
+
+// Run checks the config file at every interval until the context is
+// done. The errors are logged, the clients in use are kept.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := w.Check(ctx)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("path", w.source.Path).
+				Msg("Failed to reload config file.")
+
+			continue
+		}
+
+		if reloaded {
+			log.Info().
+				Str("path", w.source.Path).
+				Msg("Reloaded config file.")
+		}
+	}
+}
+
+// ClientCaches are the caches of the clients rebuilt on reload. They
+// are shared with arc.Domain, which selects the clients on each call.
+type ClientCaches struct {
+	OpenSearch *val.Cache[string, *opensearch.Client]
+	Paseto     *val.Cache[string, *PasetoCacheItem]
+	PGxPool    *val.Cache[string, *pgxpool.Pool]
+	Valkey     *val.Cache[string, valkey.Client]
+}
+
+// ClientConfigs are the configs of the clients rebuilt on reload.
+type ClientConfigs struct {
+	OpenSearch map[string]*OpenSearch
+	Paseto     map[string]*Paseto
+	Postgres   map[string]*Postgres
+	Valkey     map[string]*Valkey
+}
+
+// ClientReloader rebuilds the clients whose config changed, and
+// swaps them in the caches.
+type ClientReloader struct {
+	mu         sync.Mutex
+	caches     *ClientCaches
+	configs    *ClientConfigs
+	closeDelay time.Duration
+}
+
+type NewClientReloaderInput struct {
+	Caches *ClientCaches
+
+	// Configs are the configs of the clients of the caches.
+	Configs *ClientConfigs
+
+	CloseDelay time.Duration
+}
+
+func NewClientReloader(in *NewClientReloaderInput) *ClientReloader {
+	return &ClientReloader{
+		caches:     in.Caches,
+		configs:    in.Configs,
+		closeDelay: in.CloseDelay,
+	}
+}
+
+// Reload rebuilds the clients whose config changed, added or removed.
+// Either all the clients are swapped, or none if one fails to build.
+// The replaced clients are closed after the close delay.
+func (r *ClientReloader) Reload(configs *ClientConfigs) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	openSearch, err := diffClients(r.configs.OpenSearch, configs.OpenSearch, LoadOpenSearch, nil)
+	if err != nil {
+		return errors.Forward(err, "9c023252-ce29-48df-81fc-2978aec68a5f")
+	}
+
+	paseto, err := diffClients(r.configs.Paseto, configs.Paseto, LoadPaseto, nil)
+	if err != nil {
+		return errors.Forward(err, "575c0181-c77b-44c4-a33c-df10b3a88e91")
+	}
+
+	pgxPool, err := diffClients(r.configs.Postgres, configs.Postgres, LoadPostgres, func(v *pgxpool.Pool) { v.Close() })
+	if err != nil {
+		return errors.Forward(err, "24605233-5db1-4b62-a65d-a3bde3c92884")
+	}
+
+	valkeyClients, err := diffClients(r.configs.Valkey, configs.Valkey, LoadValkey, func(v valkey.Client) { v.Close() })
+	if err != nil {
+		// Do not leak the pools built before.
+		pgxPool.abort()
+
+		return errors.Forward(err, "3b4003e6-0805-49a2-ab33-9baba4f6f057")
+	}
+
+	closers := []func(){
+		swapClients(r.caches.OpenSearch, openSearch),
+		swapClients(r.caches.Paseto, paseto),
+		swapClients(r.caches.PGxPool, pgxPool),
+		swapClients(r.caches.Valkey, valkeyClients),
+	}
+
+	r.configs = configs
+
+	time.AfterFunc(r.closeDelay, func() {
+		for _, f := range closers {
+			f()
+		}
+	})
+
+	return nil
+}
+
+type clientDiff[V any] struct {
+	name    string
+	set     map[string]V
+	removed []string
+	close   func(V)
+}
+
+func (d *clientDiff[V]) abort() {
+	if d.close == nil {
+		return
+	}
+
+	for _, v := range d.set {
+		d.close(v)
+	}
+}
+
+func diffClients[C, V any](old, new map[string]C, load func(C) (V, error), closeFunc func(V)) (*clientDiff[V], error) {
+	d := &clientDiff[V]{
+		name:  reflect.TypeFor[C]().Elem().Name(),
+		set:   make(map[string]V),
+		close: closeFunc,
+	}
+
+	for k, c := range new {
+		if o, ok := old[k]; ok && reflect.DeepEqual(o, c) {
+			continue
+		}
+
+		v, err := load(c)
+		if err != nil {
+			d.abort()
+
+			return nil, &errors.Object{
+				Id:     "cfb46088-d42b-419f-81cb-a3a6962a4257",
+				Code:   errors.Code_FAILED_PRECONDITION,
+				Detail: "Failed to rebuild client.",
+				Cause:  err.Error(),
+				Err:    err,
+				Meta: map[string]any{
+					"client": d.name,
+					"key":    k,
+				},
+			}
+		}
+
+		d.set[k] = v
+	}
+
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			d.removed = append(d.removed, k)
+		}
+	}
+
+	return d, nil
+}
+
+// swapClients sets the rebuilt clients in the cache, and returns the
+// function closing the replaced ones.
+func swapClients[V any](cache *val.Cache[string, V], d *clientDiff[V]) func() {
+	var replaced []V
+
+	for k, v := range d.set {
+		if o, ok := cache.Select(k); ok {
+			replaced = append(replaced, o)
+		}
+
+		cache.Set(k, v)
+
+		log.Info().
+			Str("client", d.name).
+			Str("key", k).
+			Msg("Reloaded client.")
+	}
+
+	for _, k := range d.removed {
+		if o, ok := cache.Pop(k); ok {
+			replaced = append(replaced, o)
+		}
+
+		log.Info().
+			Str("client", d.name).
+			Str("key", k).
+			Msg("Removed client.")
+	}
+
+	return func() {
+		if d.close == nil {
+			return
+		}
+
+		for _, v := range replaced {
+			d.close(v)
+		}
+	}
+}
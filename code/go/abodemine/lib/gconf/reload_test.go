@@ -0,0 +1,110 @@
+package gconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/unique"
+	"abodemine/lib/val"
+)
+
+type reloadTestFile struct {
+	Paseto map[string]*Paseto `yaml:"paseto"`
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	configPath := filepath.Join(dir, "config.yaml")
+	keyPath := filepath.Join(dir, "paseto_key")
+
+	// The file provider reads from the directory of the config file
+	// by default.
+	require.NoError(t, os.WriteFile(configPath, []byte("paseto:\n  default:\n    key: ${secret:file:paseto_key}\n"), 0o600))
+
+	writeKey := func(key string) {
+		require.NoError(t, os.WriteFile(keyPath, []byte(key+"\n"), 0o600))
+	}
+
+	firstKey := paseto.NewV4SymmetricKey()
+	writeKey(firstKey.ExportHex())
+
+	source, err := NewConfigSource(configPath, unique.Upper(16))
+	require.NoError(t, err)
+
+	f := new(reloadTestFile)
+	require.NoError(t, source.Load(ctx, f))
+
+	item, err := LoadPaseto(f.Paseto["default"])
+	require.NoError(t, err)
+
+	cache := val.NewCache[string, *PasetoCacheItem]()
+	cache.Set("default", item)
+
+	reloader := NewClientReloader(&NewClientReloaderInput{
+		Caches: &ClientCaches{
+			Paseto: cache,
+		},
+		Configs: &ClientConfigs{
+			Paseto: f.Paseto,
+		},
+	})
+
+	watcher, err := NewWatcher(ctx, &NewWatcherInput{
+		Source: source,
+		Reload: func(ctx context.Context, body []byte) error {
+			f := new(reloadTestFile)
+
+			if err := source.Decode(body, f); err != nil {
+				return err
+			}
+
+			return reloader.Reload(&ClientConfigs{Paseto: f.Paseto})
+		},
+	})
+	require.NoError(t, err)
+
+	reloaded, err := watcher.Check(ctx)
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Same(t, item, cache.Get("default"))
+
+	// Rotate the secret.
+	secondKey := paseto.NewV4SymmetricKey()
+	writeKey(secondKey.ExportHex())
+
+	reloaded, err = watcher.Check(ctx)
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, secondKey.ExportHex(), cache.Get("default").V4SymmetricKey.ExportHex())
+
+	// An invalid secret keeps the client in use, until it is fixed.
+	writeKey("invalid")
+
+	reloaded, err = watcher.Check(ctx)
+	assert.Error(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, secondKey.ExportHex(), cache.Get("default").V4SymmetricKey.ExportHex())
+
+	writeKey(firstKey.ExportHex())
+
+	reloaded, err = watcher.Check(ctx)
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, firstKey.ExportHex(), cache.Get("default").V4SymmetricKey.ExportHex())
+
+	// A removed config removes its client.
+	require.NoError(t, os.WriteFile(configPath, []byte("paseto: {}\n"), 0o600))
+
+	reloaded, err = watcher.Check(ctx)
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.False(t, cache.Has("default"))
+}
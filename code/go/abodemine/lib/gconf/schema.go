@@ -0,0 +1,361 @@
+package gconf
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"abodemine/lib/errors"
+)
+
+// SchemaTag is the struct tag declaring the rules of a config field,
+// separated by commas:
+//   - required: the key must be set to a non empty value,
+//   - duration: the value must parse with time.ParseDuration,
+//   - url: the value must be an absolute URL.
+//
+// The rules of a list or a map apply to each of its values. The
+// time.Duration fields are checked as durations without a tag.
+const SchemaTag = "conf"
+
+const (
+	schemaRule_REQUIRED = "required"
+	schemaRule_DURATION = "duration"
+	schemaRule_URL      = "url"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	yamlUnmarshalerType = reflect.TypeFor[yaml.Unmarshaler]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// ValidateSchema checks the body of a config file against the schema
+// of v, a pointer to the struct it is parsed into, by the extension of
+// the file. It returns the problems sorted by path: the unknown keys,
+// the missing required keys, and the values breaking the rules of
+// SchemaTag.
+func ValidateSchema(body []byte, configPath string, v any) ([]*errors.Object, error) {
+	var doc any
+	var err error
+
+	tagName := "yaml"
+
+	switch strings.ToLower(filepath.Ext(configPath)) {
+	case ".json":
+		tagName = "json"
+		err = json.Unmarshal(body, &doc)
+	case ".toml":
+		var m map[string]any
+		_, err = toml.Decode(string(body), &m)
+		doc = m
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(body, &doc)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "414b0b7b-e850-4c12-b30d-8e426f4cdf59",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to parse config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	s := &schemaValidator{tagName: tagName}
+	s.validate(reflect.TypeOf(v), doc, "", nil)
+
+	sort.SliceStable(s.problems, func(i, j int) bool {
+		return s.problems[i].Path < s.problems[j].Path
+	})
+
+	return s.problems, nil
+}
+
+type schemaValidator struct {
+	tagName  string
+	problems []*errors.Object
+}
+
+type schemaField struct {
+	index []int
+	rules []string
+}
+
+func (s *schemaValidator) add(problem *errors.Object) {
+	s.problems = append(s.problems, problem)
+}
+
+func (s *schemaValidator) validate(t reflect.Type, doc any, path string, rules []string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if doc == nil {
+		return
+	}
+
+	if t == durationType {
+		rules = append(rules, schemaRule_DURATION)
+	}
+
+	s.checkRules(t, doc, path, rules)
+
+	// The types decoding themselves are not walked.
+	if isSchemaLeaf(t) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := doc.(map[string]any)
+		if !ok {
+			s.add(&errors.Object{
+				Id:     "18d3f538-7b90-4534-8f75-ab10c23d17c1",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Expected an object.",
+				Path:   pathOrRoot(path),
+			})
+
+			return
+		}
+
+		fields := s.fields(t)
+
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			field, ok := fields[k]
+			if !ok {
+				s.add(&errors.Object{
+					Id:     "3d36fe4f-3fc4-45a2-81e9-8c128997eafd",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Unknown key.",
+					Path:   path + "/" + escapePathKey(k),
+				})
+
+				continue
+			}
+
+			s.validate(t.FieldByIndex(field.index).Type, m[k], path+"/"+escapePathKey(k), field.rules)
+		}
+
+		for k, field := range fields {
+			if !hasRule(field.rules, schemaRule_REQUIRED) {
+				continue
+			}
+
+			if v, ok := m[k]; !ok || v == nil || v == "" {
+				s.add(&errors.Object{
+					Id:     "fd1f4c0a-a257-47d0-b1f6-eb6aa1efa0bd",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Missing required key.",
+					Path:   path + "/" + escapePathKey(k),
+				})
+			}
+		}
+	case reflect.Map:
+		m, ok := doc.(map[string]any)
+		if !ok {
+			s.add(&errors.Object{
+				Id:     "3a4acd65-39cc-4264-a35c-eddb8727e20d",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Expected an object.",
+				Path:   pathOrRoot(path),
+			})
+
+			return
+		}
+
+		for k, v := range m {
+			s.validate(t.Elem(), v, path+"/"+escapePathKey(k), valueRules(rules))
+		}
+	case reflect.Slice, reflect.Array:
+		// []byte fields hold strings.
+		if t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		list, ok := doc.([]any)
+		if !ok {
+			s.add(&errors.Object{
+				Id:     "c3beb2b1-a073-4d3b-87a1-ae7a4de6a526",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Expected a list.",
+				Path:   pathOrRoot(path),
+			})
+
+			return
+		}
+
+		for i, v := range list {
+			s.validate(t.Elem(), v, path+"/"+strconv.Itoa(i), valueRules(rules))
+		}
+	}
+}
+
+// checkRules checks the scalar values, the rules of the lists and
+// the maps being passed on to their values.
+func (s *schemaValidator) checkRules(t reflect.Type, doc any, path string, rules []string) {
+	switch doc.(type) {
+	case map[string]any, []any:
+		return
+	}
+
+	// The numbers are decoded as nanoseconds into time.Duration, and
+	// as strings into the other types, e.g. "10" that is no duration.
+	if t == durationType {
+		if _, ok := doc.(string); !ok {
+			return
+		}
+	}
+
+	v, _ := doc.(string)
+
+	for _, rule := range rules {
+		switch rule {
+		case schemaRule_DURATION:
+			// The error is left out, as it quotes the value, that
+			// may be a decrypted secret.
+			if _, err := time.ParseDuration(v); err != nil {
+				s.add(&errors.Object{
+					Id:     "13b3c532-4f53-43f0-969f-05ec7454c4d1",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid duration. Expected format: a number with a unit, e.g. 300ms, 10s or 1h30m.",
+					Path:   path,
+				})
+			}
+		case schemaRule_URL:
+			if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+				s.add(&errors.Object{
+					Id:     "9f7d82f2-8b6e-4251-9eb1-90ff5c0c752d",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid URL.",
+					Path:   path,
+				})
+			}
+		}
+	}
+}
+
+// fields returns the fields of the struct by key.
+func (s *schemaValidator) fields(t reflect.Type) map[string]*schemaField {
+	out := make(map[string]*schemaField)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get(s.tagName), ",")
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		inline := strings.Contains(opts, "inline") || (f.Anonymous && name == "" && s.tagName == "json")
+
+		// The fields of the embedded structs are decoded even when
+		// their type is unexported.
+		if inline && ft.Kind() == reflect.Struct {
+			for k, field := range s.fields(ft) {
+				out[k] = &schemaField{
+					index: append([]int{i}, field.index...),
+					rules: field.rules,
+				}
+			}
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		var rules []string
+		if tag := f.Tag.Get(SchemaTag); tag != "" {
+			rules = strings.Split(tag, ",")
+		}
+
+		out[name] = &schemaField{
+			index: []int{i},
+			rules: rules,
+		}
+	}
+
+	return out
+}
+
+func isSchemaLeaf(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+
+	p := reflect.PointerTo(t)
+
+	return p.Implements(textUnmarshalerType) ||
+		p.Implements(yamlUnmarshalerType) ||
+		p.Implements(jsonUnmarshalerType)
+}
+
+// valueRules returns the rules applying to the values of a list or a
+// map, which need not all be set.
+func valueRules(rules []string) []string {
+	var out []string
+
+	for _, rule := range rules {
+		if rule != schemaRule_REQUIRED {
+			out = append(out, rule)
+		}
+	}
+
+	return out
+}
+
+func hasRule(rules []string, rule string) bool {
+	for _, v := range rules {
+		if v == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// escapePathKey escapes a key as a JSON pointer token, since keys
+// such as "api/default" hold slashes.
+func escapePathKey(k string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(k, "~", "~0"), "/", "~1")
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
@@ -0,0 +1,85 @@
+package gconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+type schemaTestFile struct {
+	schemaTestBase `json:",inline" yaml:",inline"`
+
+	Paseto     map[string]*Paseto     `json:"paseto,omitempty" yaml:"paseto,omitempty"`
+	Postgres   map[string]*Postgres   `json:"postgres,omitempty" yaml:"postgres,omitempty"`
+	OpenSearch map[string]*OpenSearch `json:"opensearch,omitempty" yaml:"opensearch,omitempty"`
+}
+
+type schemaTestBase struct {
+	LogLevel string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
+}
+
+func schemaPaths(problems []*errors.Object) []string {
+	var out []string
+
+	for _, problem := range problems {
+		out = append(out, problem.Path+" "+problem.Detail)
+	}
+
+	return out
+}
+
+func TestValidateSchema(t *testing.T) {
+	body := []byte(`{
+		"log_level": "debug",
+		"paseto": {"api/default": {"expire": "2 days"}},
+		"postgres": {"api": {"host": "localhost", "user": "", "dbname": "api", "sslmode": "disable"}},
+		"opensearch": {"search": {"addresses": "https://localhost:9200"}}
+	}`)
+
+	problems, err := ValidateSchema(body, "config.json", new(schemaTestFile))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/opensearch/search/addresses Expected a list.",
+		"/paseto/api~1default/expire Invalid duration. Expected format: a number with a unit, e.g. 300ms, 10s or 1h30m.",
+		"/postgres/api/sslmode Unknown key.",
+		"/postgres/api/user Missing required key.",
+	}, schemaPaths(problems))
+
+	// The values may be decrypted secrets.
+	for _, problem := range problems {
+		assert.NotContains(t, problem.Error(), "2 days")
+		assert.NotContains(t, problem.Cause, "2 days")
+	}
+
+	problems, err = ValidateSchema([]byte("log_level: debug\nlog_levl: info\n"), "config.yml", new(schemaTestFile))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/log_levl Unknown key."}, schemaPaths(problems))
+
+	_, err = ValidateSchema([]byte("log_level: [\n"), "config.yaml", new(schemaTestFile))
+	require.Error(t, err)
+}
+
+func TestResolveConfig_Schema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log_levl: debug\n"), 0o600))
+
+	err := ResolveConfig(new(schemaTestFile), path, "")
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_INVALID_ARGUMENT, errors.CodeOf(err))
+
+	// The problem of the unknown key is in the chain.
+	assert.True(t, errors.Is(err, &errors.Object{Id: "3d36fe4f-3fc4-45a2-81e9-8c128997eafd"}))
+
+	source, err := NewConfigSource(path, "")
+	require.NoError(t, err)
+
+	source.SkipSchema = true
+	require.NoError(t, source.Load(context.Background(), new(schemaTestFile)))
+}
@@ -0,0 +1,410 @@
+package gconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"gopkg.in/yaml.v3"
+
+	"abodemine/lib/errors"
+)
+
+// SecretRefPrefix starts the references to secrets in the config
+// files, written ${secret:<provider>:<path>}.
+const SecretRefPrefix = "secret:"
+
+const (
+	SecretProvider_ENV   = "env"
+	SecretProvider_FILE  = "file"
+	SecretProvider_SSM   = "ssm"
+	SecretProvider_VAULT = "vault"
+)
+
+// SecretProvider returns the value of the secret at the path, whose
+// meaning depends on the provider. The errors MUST NOT hold the value.
+type SecretProvider interface {
+	GetSecret(ctx context.Context, path string) (string, error)
+}
+
+// SecretResolver expands the secret references of the config files
+// with the registered providers.
+type SecretResolver struct {
+	mu        sync.Mutex
+	providers map[string]SecretProvider
+}
+
+func NewSecretResolver() *SecretResolver {
+	return &SecretResolver{
+		providers: make(map[string]SecretProvider),
+	}
+}
+
+// NewEnvSecretResolver returns a resolver with the providers set up
+// from the variables prefixed with envKey:
+//   - env, always,
+//   - file, reading the files under <envKey>_SECRETS_DIR, or under
+//     dir if not set,
+//   - ssm, whose client is created on first use, with the endpoint of
+//     <envKey>_SSM_ENDPOINT if set,
+//   - vault, if <envKey>_VAULT_FILE is set, decrypted with
+//     <envKey>_VAULT_PASS, and <envKey>_VAULT_ITER if it was
+//     encrypted by openssl.
+func NewEnvSecretResolver(envKey, dir string) (*SecretResolver, error) {
+	resolver := NewSecretResolver()
+
+	getenv := func(suffix string) string {
+		if envKey == "" {
+			return ""
+		}
+
+		return strings.TrimSpace(os.Getenv(envKey + suffix))
+	}
+
+	if v := getenv("_SECRETS_DIR"); v != "" {
+		dir = v
+	}
+
+	resolver.Register(SecretProvider_ENV, new(EnvSecretProvider))
+	resolver.Register(SecretProvider_FILE, &FileSecretProvider{Dir: dir})
+	resolver.Register(SecretProvider_SSM, NewSSMSecretProvider(&NewSSMSecretProviderInput{
+		Endpoint: getenv("_SSM_ENDPOINT"),
+	}))
+
+	if file := getenv("_VAULT_FILE"); file != "" {
+		var iter int
+
+		if iterStr := getenv("_VAULT_ITER"); iterStr != "" {
+			v, err := strconv.Atoi(iterStr)
+			if err != nil {
+				return nil, &errors.Object{
+					Id:     "0b2cb580-f57d-431c-a889-313898623b0b",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Failed to parse vault iteration count.",
+					Cause:  err.Error(),
+					Err:    err,
+					Meta: map[string]any{
+						"iter": iterStr,
+					},
+				}
+			}
+
+			iter = v
+		}
+
+		resolver.Register(SecretProvider_VAULT, &VaultSecretProvider{
+			File: file,
+			Pass: getenv("_VAULT_PASS"),
+			Iter: iter,
+		})
+	}
+
+	return resolver, nil
+}
+
+// Register sets the provider of the references to name, replacing
+// the former one.
+func (s *SecretResolver) Register(name string, provider SecretProvider) {
+	s.mu.Lock()
+	s.providers[name] = provider
+	s.mu.Unlock()
+}
+
+// Resolve returns the value of a reference, without its prefix, in
+// the form <provider>:<path>.
+func (s *SecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name, path, ok := strings.Cut(ref, ":")
+	if !ok || name == "" || path == "" {
+		return "", &errors.Object{
+			Id:     "2c3a4f12-f10e-4d66-85e2-5a88d980fce9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid secret reference.",
+			Meta: map[string]any{
+				"ref": ref,
+			},
+		}
+	}
+
+	s.mu.Lock()
+	provider, ok := s.providers[name]
+	s.mu.Unlock()
+
+	if !ok {
+		return "", &errors.Object{
+			Id:     "fdcf8340-e8b7-4ab5-96d4-73ecc2991e08",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown secret provider.",
+			Meta: map[string]any{
+				"provider": name,
+			},
+		}
+	}
+
+	v, err := provider.GetSecret(ctx, path)
+	if err != nil {
+		return "", errors.Forward(err, "df4180fd-5dd4-4a63-a164-acab2a8aac9b")
+	}
+
+	return v, nil
+}
+
+// Expand replaces the ${secret:<provider>:<path>} references of the
+// body with their values, and the other variables with their value
+// in the environment, as os.ExpandEnv does. The values are inserted
+// as they are, so they must be quoted in the config file if needed.
+func (s *SecretResolver) Expand(ctx context.Context, body string) (string, error) {
+	var firstErr error
+
+	out := os.Expand(body, func(name string) string {
+		ref, ok := strings.CutPrefix(name, SecretRefPrefix)
+		if !ok {
+			return os.Getenv(name)
+		}
+
+		if firstErr != nil {
+			return ""
+		}
+
+		v, err := s.Resolve(ctx, ref)
+		if err != nil {
+			firstErr = errors.Forward(err, "293d5ce8-8b2a-4261-b5eb-ffdc9ee38ce2")
+			return ""
+		}
+
+		return v
+	})
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return out, nil
+}
+
+// EnvSecretProvider returns the value of the environment variable
+// named by the path.
+type EnvSecretProvider struct{}
+
+func (p *EnvSecretProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	v, ok := os.LookupEnv(path)
+	if !ok {
+		return "", &errors.Object{
+			Id:     "0a29f5db-5d92-48a2-aa74-78fc65bf739f",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Secret environment variable not set.",
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	return v, nil
+}
+
+// FileSecretProvider returns the content of the file at the path,
+// relative to Dir, without its trailing newlines. It suits the
+// secrets mounted as files by Docker or Kubernetes.
+type FileSecretProvider struct {
+	Dir string
+}
+
+func (p *FileSecretProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	name := filepath.Join(p.Dir, filepath.Clean("/"+path))
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return "", &errors.Object{
+			Id:     "618ab168-b36b-48ed-9042-89ee82c88edc",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Failed to read secret file.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// SSMGetParameterAPI is the part of the SSM client used by the ssm
+// provider, so that SSM compatible stores can be used.
+type SSMGetParameterAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SSMSecretProvider returns the decrypted value of the SSM parameter
+// named by the path.
+type SSMSecretProvider struct {
+	mu       sync.Mutex
+	client   SSMGetParameterAPI
+	endpoint string
+}
+
+type NewSSMSecretProviderInput struct {
+	// Client defaults to an SSM client of the default AWS config,
+	// created on first use.
+	Client SSMGetParameterAPI
+
+	// Endpoint overrides the endpoint of the default client, for
+	// SSM compatible stores.
+	Endpoint string
+}
+
+func NewSSMSecretProvider(in *NewSSMSecretProviderInput) *SSMSecretProvider {
+	return &SSMSecretProvider{
+		client:   in.Client,
+		endpoint: in.Endpoint,
+	}
+}
+
+func (p *SSMSecretProvider) getClient(ctx context.Context) (SSMGetParameterAPI, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "a247c55a-39d8-4dae-bd68-bd6f34c66dc6",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to load default AWS config.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	p.client = ssm.NewFromConfig(awsConfig, func(o *ssm.Options) {
+		if p.endpoint != "" {
+			o.BaseEndpoint = aws.String(p.endpoint)
+		}
+	})
+
+	return p.client, nil
+}
+
+func (p *SSMSecretProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return "", errors.Forward(err, "150b1452-1f6c-40af-83a4-7eecc7259fb4")
+	}
+
+	out, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(path),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", &errors.Object{
+			Id:     "a6c6ab6c-1d14-4dab-8a9a-261080901432",
+			Code:   errors.Code_UNAVAILABLE,
+			Detail: "Failed to get SSM parameter.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", &errors.Object{
+			Id:     "b1782dfd-51ac-4bb0-8c1b-49858a455a19",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "SSM parameter has no value.",
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	return *out.Parameter.Value, nil
+}
+
+// VaultSecretProvider returns the secrets of a local vault: a yaml
+// map of the paths to their values, encrypted as the config files
+// are (see DecryptConfigFile). The vault is decrypted again when the file
+// changes.
+type VaultSecretProvider struct {
+	File string
+	Pass string
+	Iter int
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	secrets map[string]string
+}
+
+func (p *VaultSecretProvider) GetSecret(ctx context.Context, path string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.File)
+	if err != nil {
+		return "", &errors.Object{
+			Id:     "5cbdde73-41c2-4051-9804-a8e4e1d0402c",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to stat vault file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if p.secrets == nil || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		body, err := os.ReadFile(p.File)
+		if err != nil {
+			return "", &errors.Object{
+				Id:     "c4e0d3df-aff3-4c02-88d1-3d0e2a54d55f",
+				Code:   errors.Code_FAILED_PRECONDITION,
+				Detail: "Failed to read vault file.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		decrypted, err := DecryptConfigFile(body, p.Pass, p.Iter)
+		if err != nil {
+			return "", errors.Forward(err, "93bc41f4-d8b5-427a-8cbe-9e01868dc2ff")
+		}
+
+		secrets := make(map[string]string)
+
+		if err := yaml.Unmarshal(decrypted, &secrets); err != nil {
+			// The cause could hold a part of the vault.
+			return "", &errors.Object{
+				Id:     "5c9fbc97-fd3e-44e0-ac2d-18991c994b50",
+				Code:   errors.Code_FAILED_PRECONDITION,
+				Detail: "Failed to parse vault file.",
+			}
+		}
+
+		p.secrets = secrets
+		p.modTime = info.ModTime()
+		p.size = info.Size()
+	}
+
+	v, ok := p.secrets[path]
+	if !ok {
+		return "", &errors.Object{
+			Id:     "30386bfd-76de-4d66-ae02-605cc4388e77",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Secret not found in vault.",
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,114 @@
+package gconf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/unique"
+)
+
+type fakeSSM map[string]string
+
+func (f fakeSSM) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	v, ok := f[aws.ToString(params.Name)]
+	if !ok {
+		return nil, &types.ParameterNotFound{}
+	}
+
+	return &ssm.GetParameterOutput{
+		Parameter: &types.Parameter{Value: aws.String(v)},
+	}, nil
+}
+
+func TestSecretResolver_Expand(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "db"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "password"), []byte("pg-secret\n"), 0o600))
+
+	envKey := unique.Upper(16)
+	t.Setenv(envKey, "env-secret")
+
+	resolver := NewSecretResolver()
+	resolver.Register(SecretProvider_ENV, new(EnvSecretProvider))
+	resolver.Register(SecretProvider_FILE, &FileSecretProvider{Dir: dir})
+	resolver.Register(SecretProvider_SSM, NewSSMSecretProvider(&NewSSMSecretProviderInput{
+		Client: fakeSSM{"/abodemine/valkey/password": "ssm-secret"},
+	}))
+
+	have, err := resolver.Expand(ctx, "a: ${secret:file:db/password}\nb: ${secret:env:"+envKey+"}\nc: ${secret:ssm:/abodemine/valkey/password}\nd: $"+envKey+"\n")
+	require.NoError(t, err)
+	assert.Equal(t, "a: pg-secret\nb: env-secret\nc: ssm-secret\nd: env-secret\n", have)
+
+	// The files stay under the directory of the provider.
+	have, err = resolver.Expand(ctx, "${secret:file:../"+filepath.Base(dir)+"/db/password}")
+	assert.Empty(t, have)
+	assert.Equal(t, errors.Code_NOT_FOUND, errors.CodeOf(err))
+
+	testCases := []*struct {
+		name string
+		body string
+		code int
+	}{
+		{name: "unknown-provider", body: "${secret:unknown:a}", code: errors.Code_INVALID_ARGUMENT},
+		{name: "missing-path", body: "${secret:file:}", code: errors.Code_INVALID_ARGUMENT},
+		{name: "missing-file", body: "${secret:file:db/user}", code: errors.Code_NOT_FOUND},
+		{name: "missing-env", body: "${secret:env:" + unique.Upper(16) + "}", code: errors.Code_NOT_FOUND},
+		{name: "missing-parameter", body: "${secret:ssm:/abodemine/none}", code: errors.Code_UNAVAILABLE},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(st *testing.T) {
+			_, err := resolver.Expand(ctx, tc.body)
+			assert.Equal(st, tc.code, errors.CodeOf(err))
+		})
+	}
+}
+
+func TestVaultSecretProvider(t *testing.T) {
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl not found")
+	}
+
+	file := filepath.Join(t.TempDir(), "vault.yaml.enc")
+
+	encrypt := func(body string) {
+		cmd := exec.Command("openssl", "enc", "-aes-256-cbc", "-pbkdf2", "-iter", "1000", "-pass", "pass:vault-pass", "-out", file)
+		cmd.Stdin = bytes.NewBufferString(body)
+		require.NoError(t, cmd.Run())
+	}
+
+	encrypt("postgres/password: first\n")
+
+	provider := &VaultSecretProvider{
+		File: file,
+		Pass: "vault-pass",
+		Iter: 1000,
+	}
+
+	have, err := provider.GetSecret(context.Background(), "postgres/password")
+	require.NoError(t, err)
+	assert.Equal(t, "first", have)
+
+	// A rotation changes the size of the file.
+	encrypt("postgres/password: second-password\n")
+
+	have, err = provider.GetSecret(context.Background(), "postgres/password")
+	require.NoError(t, err)
+	assert.Equal(t, "second-password", have)
+
+	_, err = provider.GetSecret(context.Background(), "valkey/password")
+	assert.Equal(t, errors.Code_NOT_FOUND, errors.CodeOf(err))
+}
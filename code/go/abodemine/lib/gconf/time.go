@@ -13,6 +13,7 @@ func LoadDuration(s string) (time.Duration, error) {
 			Id:    "d21fcc9e-b0dd-4a5c-a896-711599b3b62e",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 		}
 	}
 
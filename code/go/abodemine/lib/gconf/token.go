@@ -1,6 +1,8 @@
 package gconf
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"aidanwoods.dev/go-paseto"
@@ -16,16 +18,113 @@ const (
 type Paseto struct {
 	Type   int           `json:"type,omitempty" yaml:"type,omitempty"`
 	Expire time.Duration `json:"expire,omitempty" yaml:"expire,omitempty"`
-	Seed   string        `json:"seed,omitempty" yaml:"seed,omitempty"`
-	Key    string        `json:"key,omitempty" yaml:"key,omitempty"`
+
+	// Id of the signing key, written to the footer of new tokens.
+	// Tokens without a key id are verified with the key without one.
+	KeyId string `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	Seed  string `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Key   string `json:"key,omitempty" yaml:"key,omitempty"`
+
+	// Retired keys, still accepted to verify tokens until they expire.
+	VerificationKeys []*PasetoKey `json:"verification_keys,omitempty" yaml:"verification_keys,omitempty"`
+}
+
+// PasetoKey is a verification key of the same type as its Paseto config.
+type PasetoKey struct {
+	KeyId string `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	Seed  string `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Key   string `json:"key,omitempty" yaml:"key,omitempty"`
+
+	// Tokens signed with the key are rejected after ExpiresAt.
+	// It should be no earlier than the rotation plus the lifetime
+	// of the longest lived token. Zero means the key never expires.
+	ExpiresAt time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+}
+
+// PasetoFooter is the footer of tokens signed with a key id.
+type PasetoFooter struct {
+	KeyId string `json:"kid,omitempty"`
 }
 
 type PasetoCacheItem struct {
 	Parser                paseto.Parser                `json:"parser,omitempty" yaml:"parser,omitempty"`
 	Expire                time.Duration                `json:"expire,omitempty" yaml:"expire,omitempty"`
+	KeyId                 string                       `json:"key_id,omitempty" yaml:"key_id,omitempty"`
 	V4SymmetricKey        paseto.V4SymmetricKey        `json:"v4_symmetric_key,omitempty" yaml:"v4_symmetric_key,omitempty"`
 	V4AsymmetricSecretKey paseto.V4AsymmetricSecretKey `json:"v4_asymmetric_secret_key,omitempty" yaml:"v4_asymmetric_secret_key,omitempty"`
 	V4AsymmetricPublicKey paseto.V4AsymmetricPublicKey `json:"v4_asymmetric_public_key,omitempty" yaml:"v4_asymmetric_public_key,omitempty"`
+
+	// Verification keys by key id, including the signing key.
+	VerificationKeys map[string]*PasetoVerificationKey `json:"-" yaml:"-"`
+}
+
+type PasetoVerificationKey struct {
+	ExpiresAt             time.Time
+	V4SymmetricKey        paseto.V4SymmetricKey
+	V4AsymmetricPublicKey paseto.V4AsymmetricPublicKey
+}
+
+// Footer returns the footer of new tokens, or nil
+// if the signing key has no key id.
+func (item *PasetoCacheItem) Footer() []byte {
+	if item.KeyId == "" {
+		return nil
+	}
+
+	footer, _ := json.Marshal(&PasetoFooter{KeyId: item.KeyId})
+
+	return footer
+}
+
+// SelectVerificationKey returns the key to verify a token
+// with, given the key id of its footer.
+func (item *PasetoCacheItem) SelectVerificationKey(keyId string, now time.Time) (*PasetoVerificationKey, error) {
+	key, ok := item.VerificationKeys[keyId]
+	if !ok {
+		return nil, &errors.Object{
+			Id:     "a7020dea-97ce-4447-bba1-f7087be2d637",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown token key id.",
+			Meta: map[string]any{
+				"key_id": keyId,
+			},
+		}
+	}
+
+	if !key.ExpiresAt.IsZero() && now.After(key.ExpiresAt) {
+		return nil, &errors.Object{
+			Id:     "7146b32d-602e-4099-8129-51aabf3a5b51",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The token key has expired.",
+			Meta: map[string]any{
+				"key_id": keyId,
+			},
+		}
+	}
+
+	return key, nil
+}
+
+// ParseFooterKeyId returns the key id of the unverified footer
+// of the token, or an empty string if it has no footer.
+func ParseFooterKeyId(footer []byte) (string, error) {
+	if len(footer) == 0 {
+		return "", nil
+	}
+
+	f := new(PasetoFooter)
+
+	if err := json.Unmarshal(footer, f); err != nil {
+		return "", &errors.Object{
+			Id:     "97782e5e-5508-4053-83a9-2c0c5a38a1cb",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid token footer.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return f.KeyId, nil
 }
 
 func LoadPaseto(config *Paseto) (*PasetoCacheItem, error) {
@@ -39,8 +138,10 @@ func LoadPaseto(config *Paseto) (*PasetoCacheItem, error) {
 	}
 
 	item := &PasetoCacheItem{
-		Parser: paseto.NewParser(),
-		Expire: config.Expire,
+		Parser:           paseto.NewParser(),
+		Expire:           config.Expire,
+		KeyId:            config.KeyId,
+		VerificationKeys: make(map[string]*PasetoVerificationKey),
 	}
 
 	switch config.Type {
@@ -52,6 +153,7 @@ func LoadPaseto(config *Paseto) (*PasetoCacheItem, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to create symmetric key.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -64,6 +166,7 @@ func LoadPaseto(config *Paseto) (*PasetoCacheItem, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to create asymmetric key.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -78,5 +181,72 @@ func LoadPaseto(config *Paseto) (*PasetoCacheItem, error) {
 		}
 	}
 
+	item.VerificationKeys[config.KeyId] = &PasetoVerificationKey{
+		V4SymmetricKey:        item.V4SymmetricKey,
+		V4AsymmetricPublicKey: item.V4AsymmetricPublicKey,
+	}
+
+	for i, v := range config.VerificationKeys {
+		path := fmt.Sprintf("/verification_keys/%d", i)
+
+		if v == nil {
+			return nil, &errors.Object{
+				Id:     "9487c077-ab80-48fd-86d0-d22483cd410b",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing verification key.",
+				Path:   path,
+			}
+		}
+
+		if _, ok := item.VerificationKeys[v.KeyId]; ok {
+			return nil, &errors.Object{
+				Id:     "31e864d4-e321-41d9-9bfb-7eefe144c2da",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Duplicate key id.",
+				Path:   path + "/key_id",
+				Meta: map[string]any{
+					"key_id": v.KeyId,
+				},
+			}
+		}
+
+		key := &PasetoVerificationKey{
+			ExpiresAt: v.ExpiresAt,
+		}
+
+		switch config.Type {
+		case Paseto_V4_SYMMETRIC:
+			k, err := paseto.V4SymmetricKeyFromHex(v.Key)
+			if err != nil {
+				return nil, &errors.Object{
+					Id:     "7bb78787-4884-49b5-8bf9-d369e06bcbc4",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Failed to create symmetric key.",
+					Cause:  err.Error(),
+					Err:    err,
+					Path:   path + "/key",
+				}
+			}
+
+			key.V4SymmetricKey = k
+		case Paseto_V4_ASYMMETRIC:
+			k, err := paseto.NewV4AsymmetricSecretKeyFromSeed(v.Seed)
+			if err != nil {
+				return nil, &errors.Object{
+					Id:     "19e8d46b-fe69-420f-9998-36de9fde7e36",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Failed to create asymmetric key.",
+					Cause:  err.Error(),
+					Err:    err,
+					Path:   path + "/seed",
+				}
+			}
+
+			key.V4AsymmetricPublicKey = k.Public()
+		}
+
+		item.VerificationKeys[v.KeyId] = key
+	}
+
 	return item, nil
 }
@@ -0,0 +1,80 @@
+package gconf
+
+import (
+	"strings"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/tracing"
+)
+
+// Tracing represents the OpenTelemetry tracing configuration.
+type Tracing struct {
+	// Exporter is the only supported exporter, file, writing
+	// the spans to File in the OTLP JSON format.
+	Exporter string `json:"exporter,omitempty" yaml:"exporter,omitempty"`
+	File     string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// SampleRatio of the traces, between 0 and 1. Defaults to 1.
+	SampleRatio float64 `json:"sample_ratio,omitempty" yaml:"sample_ratio,omitempty"`
+
+	ServiceName string `json:"service_name,omitempty" yaml:"service_name,omitempty"`
+}
+
+// LoadTracing installs the global tracer provider. The caller
+// MUST call tracing.Shutdown before exiting to flush the spans.
+func LoadTracing(config *Tracing, defaultServiceName string) error {
+	if config == nil {
+		return &errors.Object{
+			Id:     "d7d79fc7-61e9-459b-943c-383f8aec7838",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing configuration.",
+			Path:   "/",
+		}
+	}
+
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return &errors.Object{
+			Id:     "b29e33b6-8a9c-473d-b66c-9edfc73c4347",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Sample ratio must be between 0 and 1.",
+			Path:   "/sample_ratio",
+		}
+	}
+
+	in := &tracing.InitInput{
+		ServiceName: strings.TrimSpace(config.ServiceName),
+		SampleRatio: config.SampleRatio,
+	}
+
+	if in.ServiceName == "" {
+		in.ServiceName = defaultServiceName
+	}
+
+	switch strings.ToLower(strings.TrimSpace(config.Exporter)) {
+	case "file":
+		exporter, err := tracing.NewFileExporter(&tracing.NewFileExporterInput{
+			Path: strings.TrimSpace(config.File),
+		})
+		if err != nil {
+			return errors.Forward(err, "9a22af1c-a7a8-46f7-9cbd-7cee81541524")
+		}
+
+		in.Exporter = exporter
+	default:
+		return &errors.Object{
+			Id:     "ad460ed3-9e4e-48b8-8a20-7986bc44d459",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown exporter.",
+			Path:   "/exporter",
+			Meta: map[string]any{
+				"exporter": config.Exporter,
+			},
+		}
+	}
+
+	if _, err := tracing.Init(in); err != nil {
+		return errors.Forward(err, "96ef23fc-ca8e-42ff-9666-e2d46cd95747")
+	}
+
+	return nil
+}
@@ -228,6 +228,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse bool.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		bools[k] = val
@@ -243,6 +244,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to decode base64.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		bytes[k] = val
@@ -258,6 +260,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse decimal.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		decimals[k] = val
@@ -273,6 +276,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse float64.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		floats[k] = val
@@ -288,6 +292,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse int.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		ints[k] = val
@@ -303,6 +308,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse int32.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		int32s[k] = int32(val)
@@ -318,6 +324,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse int64.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		int64s[k] = val
@@ -337,6 +344,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse time.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		times[k] = val
@@ -352,6 +360,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse uint.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		uints[k] = uint(val)
@@ -367,6 +376,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse uint32.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		uint32s[k] = uint32(val)
@@ -382,6 +392,7 @@ func LoadConfigValues(v *ConfigValues) (*Values, error) {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse uint64.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		uint64s[k] = val
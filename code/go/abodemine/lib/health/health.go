@@ -0,0 +1,254 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StatusOk           = "ok"
+	StatusFail         = "fail"
+	StatusShuttingDown = "shutting_down"
+)
+
+const (
+	// DefaultTimeout of the ping of a dependency.
+	DefaultTimeout = 2 * time.Second
+
+	// DefaultCacheTtl of the report, so that frequent probes
+	// do not ping every dependency each time.
+	DefaultCacheTtl = 3 * time.Second
+)
+
+// Dependency is a backend a server cannot serve without.
+type Dependency struct {
+	// Kind is the type of the backend, such as postgres.
+	Kind string
+
+	// Name is the key the backend is registered with.
+	Name string
+
+	Ping func(ctx context.Context) error
+}
+
+// Source lists the dependencies to check, such as arc.Domain.
+type Source interface {
+	Dependencies() []*Dependency
+}
+
+type DependencyReport struct {
+	Kind       string  `json:"kind"`
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status       string              `json:"status"`
+	Dependencies []*DependencyReport `json:"dependencies,omitempty"`
+}
+
+// Checker serves the liveness and readiness of a server.
+type Checker struct {
+	source   Source
+	timeout  time.Duration
+	cacheTtl time.Duration
+
+	shuttingDown atomic.Bool
+
+	// mu serializes the checks, so that concurrent probes
+	// share the same report.
+	mu        sync.Mutex
+	report    *Report
+	checkedAt time.Time
+}
+
+type NewCheckerInput struct {
+	Source Source
+
+	// Timeout of the ping of each dependency. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	// CacheTtl of the report. Defaults to DefaultCacheTtl.
+	CacheTtl time.Duration
+}
+
+func NewChecker(in *NewCheckerInput) *Checker {
+	timeout := in.Timeout
+
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	cacheTtl := in.CacheTtl
+
+	if cacheTtl <= 0 {
+		cacheTtl = DefaultCacheTtl
+	}
+
+	return &Checker{
+		source:   in.Source,
+		timeout:  timeout,
+		cacheTtl: cacheTtl,
+	}
+}
+
+// ShutDown marks the server not ready, so the load balancer stops
+// routing requests to it while the in-flight ones drain.
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check returns the report of the last check if it is more recent
+// than the cache ttl, or pings the dependencies concurrently. The
+// failed dependencies are logged.
+func (c *Checker) Check(ctx context.Context) *Report {
+	if c.shuttingDown.Load() {
+		return &Report{Status: StatusShuttingDown}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report != nil && time.Since(c.checkedAt) < c.cacheTtl {
+		return c.report
+	}
+
+	// The report is shared, so a probe that gives up does not
+	// fail the pings of the others.
+	report := c.check(context.WithoutCancel(ctx))
+
+	for _, dependency := range report.Dependencies {
+		if dependency.Status != StatusOk {
+			log.Error().
+				Str("kind", dependency.Kind).
+				Str("name", dependency.Name).
+				Str("error", dependency.Error).
+				Float64("duration_ms", dependency.DurationMs).
+				Msg("Dependency is not ready.")
+		}
+	}
+
+	c.report = report
+	c.checkedAt = time.Now()
+
+	return report
+}
+
+func (c *Checker) check(ctx context.Context) *Report {
+	report := &Report{Status: StatusOk}
+
+	if c.source == nil {
+		return report
+	}
+
+	dependencies := c.source.Dependencies()
+	report.Dependencies = make([]*DependencyReport, len(dependencies))
+
+	wg := new(sync.WaitGroup)
+
+	for i, dependency := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Dependencies[i] = c.ping(ctx, dependency)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, dependency := range report.Dependencies {
+		if dependency.Status != StatusOk {
+			report.Status = StatusFail
+			break
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) ping(ctx context.Context, dependency *Dependency) *DependencyReport {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// A hung dependency MUST NOT hang the probe past its timeout.
+	done := make(chan error, 1)
+
+	go func() {
+		done <- dependency.Ping(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	report := &DependencyReport{
+		Kind:       dependency.Kind,
+		Name:       dependency.Name,
+		Status:     StatusOk,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000.,
+	}
+
+	if err != nil {
+		report.Status = StatusFail
+		report.Error = err.Error()
+	}
+
+	return report
+}
+
+// Livez reports that the process serves requests. It checks no
+// dependency, so that an outage of a backend does not get every
+// server restarted.
+func (c *Checker) Livez(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeReport(w, http.StatusOK, &Report{Status: StatusOk})
+}
+
+// Readyz reports whether the server can serve requests. It only
+// writes the status, so it can be served on the public listener;
+// the failed dependencies are logged.
+func (c *Checker) Readyz(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	report := c.Check(r.Context())
+
+	writeReport(w, statusCode(report), &Report{Status: report.Status})
+}
+
+// ReadyzReport is Readyz with the status of each dependency. It
+// discloses the backends and their errors, so it MUST only be
+// served on the admin listener.
+func (c *Checker) ReadyzReport(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	report := c.Check(r.Context())
+
+	writeReport(w, statusCode(report), report)
+}
+
+func statusCode(report *Report) int {
+	if report.Status != StatusOk {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusOK
+}
+
+func writeReport(w http.ResponseWriter, statusCode int, report *Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(report)
+}
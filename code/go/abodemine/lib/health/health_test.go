@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+// fakeSource is a domain whose dependencies fail on demand.
+type fakeSource struct {
+	postgresDown atomic.Bool
+	valkeyHung   atomic.Bool
+
+	pings atomic.Int32
+}
+
+func (s *fakeSource) Dependencies() []*Dependency {
+	return []*Dependency{
+		{
+			Kind: "postgres",
+			Name: "default",
+			Ping: func(ctx context.Context) error {
+				s.pings.Add(1)
+
+				if s.postgresDown.Load() {
+					return &errors.Object{
+						Id:     "74f4d7d1-1c31-403a-a422-4c455c13c9e9",
+						Code:   errors.Code_UNAVAILABLE,
+						Detail: "Connection refused.",
+					}
+				}
+
+				return nil
+			},
+		},
+		{
+			Kind: "valkey",
+			Name: "default",
+			Ping: func(ctx context.Context) error {
+				if s.valkeyHung.Load() {
+					// Ignores the context, like a stuck client.
+					time.Sleep(time.Second)
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+func readyz(t *testing.T, handle httprouter.Handle) (int, *Report) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handle(w, httptest.NewRequest(http.MethodGet, "/readyz", nil), nil)
+
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	report := new(Report)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+
+	return w.Code, report
+}
+
+func TestChecker_Readyz(t *testing.T) {
+	source := new(fakeSource)
+
+	checker := NewChecker(&NewCheckerInput{
+		Source:   source,
+		Timeout:  50 * time.Millisecond,
+		CacheTtl: time.Nanosecond,
+	})
+
+	code, report := readyz(t, checker.ReadyzReport)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOk, report.Status)
+	require.Len(t, report.Dependencies, 2)
+	assert.Equal(t, StatusOk, report.Dependencies[0].Status)
+	assert.Equal(t, StatusOk, report.Dependencies[1].Status)
+
+	source.postgresDown.Store(true)
+
+	code, report = readyz(t, checker.ReadyzReport)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, "postgres", report.Dependencies[0].Kind)
+	assert.Equal(t, StatusFail, report.Dependencies[0].Status)
+	assert.NotEmpty(t, report.Dependencies[0].Error)
+	assert.Equal(t, StatusOk, report.Dependencies[1].Status)
+
+	// The public probe discloses the status only.
+	code, report = readyz(t, checker.Readyz)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Empty(t, report.Dependencies)
+
+	source.postgresDown.Store(false)
+	source.valkeyHung.Store(true)
+
+	start := time.Now()
+	code, report = readyz(t, checker.ReadyzReport)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusOk, report.Dependencies[0].Status)
+	assert.Equal(t, StatusFail, report.Dependencies[1].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Dependencies[1].Error)
+}
+
+func TestChecker_Cache(t *testing.T) {
+	source := new(fakeSource)
+
+	checker := NewChecker(&NewCheckerInput{
+		Source:   source,
+		CacheTtl: time.Hour,
+	})
+
+	code, _ := readyz(t, checker.Readyz)
+	assert.Equal(t, http.StatusOK, code)
+
+	source.postgresDown.Store(true)
+
+	// The cached report is served until it expires.
+	for i := 0; i < 10; i++ {
+		code, _ = readyz(t, checker.Readyz)
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	assert.EqualValues(t, 1, source.pings.Load())
+
+	// A shutdown is reported at once.
+	checker.ShutDown()
+
+	code, report := readyz(t, checker.Readyz)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusShuttingDown, report.Status)
+}
+
+func TestChecker_ShutDown(t *testing.T) {
+	checker := NewChecker(&NewCheckerInput{
+		Source: new(fakeSource),
+	})
+
+	checker.ShutDown()
+
+	code, report := readyz(t, checker.Readyz)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusShuttingDown, report.Status)
+	assert.Empty(t, report.Dependencies)
+
+	// The process is still alive while it drains.
+	w := httptest.NewRecorder()
+	checker.Livez(w, httptest.NewRequest(http.MethodGet, "/livez", nil), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,224 @@
+package logging
+
+import (
+	"context"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"abodemine/lib/errors"
+)
+
+// DefaultDedupeWindow is how long the events of an error id are
+// dropped after one was reported.
+const DefaultDedupeWindow = time.Minute
+
+// ScrubbedValue replaces the personal data of the events.
+const ScrubbedValue = "[scrubbed]"
+
+// scrubbedKeys are the substrings of the keys whose values are
+// scrubbed, whatever they hold.
+var scrubbedKeys = []string{
+	"address",
+	"api_key",
+	"apikey",
+	"authorization",
+	"cookie",
+	"email",
+	"key_hash",
+	"keyhash",
+	"password",
+	"phone",
+	"secret",
+	"token",
+}
+
+var emailRegexp = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// FilteredReporter scrubs, deduplicates and samples the events
+// before passing them to its reporter.
+type FilteredReporter struct {
+	reporter     Reporter
+	sampleRate   float64
+	dedupeWindow time.Duration
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	now      func() time.Time
+}
+
+type NewFilteredReporterInput struct {
+	Reporter Reporter
+
+	// SampleRate is the ratio of the events reported, between 0 and
+	// 1. Defaults to 1.
+	SampleRate float64
+
+	// DedupeWindow defaults to DefaultDedupeWindow. A negative
+	// window disables the deduplication.
+	DedupeWindow time.Duration
+}
+
+func NewFilteredReporter(in *NewFilteredReporterInput) *FilteredReporter {
+	sampleRate := in.SampleRate
+
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+
+	dedupeWindow := in.DedupeWindow
+
+	if dedupeWindow == 0 {
+		dedupeWindow = DefaultDedupeWindow
+	}
+
+	return &FilteredReporter{
+		reporter:     in.Reporter,
+		sampleRate:   sampleRate,
+		dedupeWindow: dedupeWindow,
+		lastSent:     make(map[string]time.Time),
+		now:          time.Now,
+	}
+}
+
+func (f *FilteredReporter) Report(ctx context.Context, event *Event) {
+	if f.duplicate(event.ErrorId()) {
+		return
+	}
+
+	if f.sampleRate < 1 && rand.Float64() >= f.sampleRate {
+		return
+	}
+
+	f.reporter.Report(ctx, Scrub(event))
+}
+
+func (f *FilteredReporter) Flush(timeout time.Duration) bool {
+	return f.reporter.Flush(timeout)
+}
+
+// duplicate reports whether an event of the error id was reported
+// within the window, and records the event otherwise.
+func (f *FilteredReporter) duplicate(errorId string) bool {
+	if errorId == "" || f.dedupeWindow < 0 {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+
+	if last, ok := f.lastSent[errorId]; ok && now.Sub(last) < f.dedupeWindow {
+		return true
+	}
+
+	f.lastSent[errorId] = now
+
+	// Keep the map from growing with the ids of past errors.
+	if len(f.lastSent) > 10_000 {
+		for k, v := range f.lastSent {
+			if now.Sub(v) >= f.dedupeWindow {
+				delete(f.lastSent, k)
+			}
+		}
+	}
+
+	return false
+}
+
+// Scrub returns a copy of the event without the personal data of
+// its extras and of the meta of its errors, such as the api keys,
+// the emails and the addresses.
+func Scrub(event *Event) *Event {
+	out := *event
+
+	if event.Extra != nil {
+		out.Extra = scrubMap(event.Extra)
+	}
+
+	if !event.Chain.Empty() {
+		out.Chain = &errors.Chain{
+			Objects: make([]*errors.Object, len(event.Chain.Objects)),
+		}
+
+		for i, object := range event.Chain.Objects {
+			if object == nil || object.Meta == nil {
+				out.Chain.Objects[i] = object
+				continue
+			}
+
+			v := *object
+			v.Meta = scrubMap(object.Meta)
+
+			out.Chain.Objects[i] = &v
+		}
+	}
+
+	return &out
+}
+
+func scrubMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+
+	for k, v := range m {
+		if scrubbedKey(k) {
+			out[k] = ScrubbedValue
+			continue
+		}
+
+		out[k] = scrubValue(v)
+	}
+
+	return out
+}
+
+func scrubValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return scrubMap(v)
+	case []any:
+		out := make([]any, len(v))
+
+		for i, item := range v {
+			out[i] = scrubValue(item)
+		}
+
+		return out
+	case []string:
+		out := make([]string, len(v))
+
+		for i, item := range v {
+			out[i] = scrubString(item)
+		}
+
+		return out
+	case string:
+		return scrubString(v)
+	default:
+		return v
+	}
+}
+
+func scrubString(s string) string {
+	// Api keys and bearer tokens.
+	if strings.HasPrefix(s, "AM.") || strings.HasPrefix(s, "Bearer ") {
+		return ScrubbedValue
+	}
+
+	return emailRegexp.ReplaceAllString(s, ScrubbedValue)
+}
+
+func scrubbedKey(k string) bool {
+	k = strings.ToLower(k)
+
+	for _, v := range scrubbedKeys {
+		if strings.Contains(k, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,229 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+	"abodemine/lib/val"
+)
+
+type InitReporterInput struct {
+	Config                *gconf.ErrorReporter
+	Sentry                *gconf.Sentry
+	DeploymentEnvironment int
+	NoLogColor            bool
+}
+
+// InitReporter sets the reporter of the process, and configures the
+// global logger to report its warnings and errors.
+func InitReporter(in *InitReporterInput) error {
+	config := val.PtrEnsure(in.Config)
+
+	sink := config.Sink
+
+	if sink == "" {
+		sink = val.Ternary(in.Sentry != nil, ReporterSink_SENTRY, ReporterSink_ZEROLOG)
+	}
+
+	// Configure zerolog to include caller information.
+	zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	zerolog.CallerSkipFrameCount = 2 // Skip frames to get to the actual caller.
+
+	// Configure how errors are marshaled when using Err(), so that
+	// the reporter writer gets the chain back.
+	zerolog.ErrorMarshalFunc = func(err error) any {
+		chain := errors.AsChain(err)
+		b, err := json.Marshal(chain)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to marshal error chain.")
+			return ""
+		}
+		return string(b)
+	}
+
+	zerologConfig, err := gconf.GetZerologConfig("", in.NoLogColor)
+	if err != nil {
+		return errors.Forward(err, "a04a834a-6bdf-42f1-89ef-b06abab81446")
+	}
+
+	var consoleWriter io.Writer
+
+	if zerologConfig.ConsoleWriter.NoColor {
+		consoleWriter = zerologConfig.ConsoleWriter.Out
+	} else {
+		consoleWriter = zerologConfig.ConsoleWriter
+	}
+
+	consoleLogger := zerolog.New(consoleWriter).
+		With().
+		Caller().
+		Timestamp().
+		Logger()
+
+	var reporter Reporter
+
+	switch sink {
+	case ReporterSink_SENTRY:
+		reporter, err = NewSentryReporter(&NewSentryReporterInput{
+			Config:                in.Sentry,
+			DeploymentEnvironment: in.DeploymentEnvironment,
+		})
+		if err != nil {
+			return errors.Forward(err, "1838d326-729c-440c-8595-5371b4429898")
+		}
+	case ReporterSink_ZEROLOG:
+		reporter = NewZerologReporter(&consoleLogger)
+	case ReporterSink_FILE:
+		reporter, err = NewFileReporter(&NewFileReporterInput{
+			Path: config.File,
+		})
+		if err != nil {
+			return errors.Forward(err, "37a25b99-bd1c-475a-aa13-e6c4fcd247c4")
+		}
+	case ReporterSink_MEMORY:
+		reporter = NewMemoryReporter()
+	default:
+		return &errors.Object{
+			Id:     "7cd47182-5b32-4e50-bf5a-da8c3906d704",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown error reporter sink.",
+			Path:   "/error_reporter/sink",
+			Meta: map[string]any{
+				"sink": sink,
+			},
+		}
+	}
+
+	var dedupeWindow time.Duration
+
+	if config.DedupeWindow != "" {
+		dedupeWindow, err = gconf.LoadDuration(config.DedupeWindow)
+		if err != nil {
+			return errors.Forward(err, "cb501be0-8553-42df-b50b-eff91dbb6f80")
+		}
+	}
+
+	SetReporter(NewFilteredReporter(&NewFilteredReporterInput{
+		Reporter:     reporter,
+		SampleRate:   config.SampleRate,
+		DedupeWindow: dedupeWindow,
+	}))
+
+	if sink == ReporterSink_ZEROLOG {
+		// The errors are logged already.
+		log.Logger = consoleLogger
+	} else {
+		log.Logger = zerolog.New(zerolog.MultiLevelWriter(
+			consoleWriter,
+			NewReporterWriter(CurrentReporter()),
+		)).
+			With().
+			Caller().
+			Timestamp().
+			Logger()
+	}
+
+	log.Info().
+		Str("sink", sink).
+		Msg("Error reporter initialized.")
+
+	return nil
+}
+
+// ReporterWriter is a zerolog writer reporting the lines of level
+// warn and above.
+type ReporterWriter struct {
+	reporter Reporter
+}
+
+func NewReporterWriter(reporter Reporter) *ReporterWriter {
+	return &ReporterWriter{reporter: reporter}
+}
+
+func (w *ReporterWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *ReporterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < zerolog.WarnLevel || level == zerolog.NoLevel || level == zerolog.Disabled {
+		return len(p), nil
+	}
+
+	var fields map[string]any
+
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return len(p), nil
+	}
+
+	event := &Event{
+		Time:  time.Now(),
+		Level: level.String(),
+	}
+
+	event.Message, _ = fields[zerolog.MessageFieldName].(string)
+	event.RequestId, _ = fields["request_id"].(string)
+
+	if s, ok := fields[zerolog.ErrorFieldName].(string); ok {
+		chain := new(errors.Chain)
+
+		if err := json.Unmarshal([]byte(s), chain); err == nil && !chain.Empty() {
+			event.Chain = chain
+			delete(fields, zerolog.ErrorFieldName)
+		}
+	}
+
+	if event.Message == "" && !event.Chain.Empty() {
+		first := event.Chain.First()
+		event.Message = val.Coalesce(
+			first.Detail,
+			first.Label,
+			first.Cause,
+			first.Id,
+		)
+	}
+
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, "request_id")
+
+	if len(fields) > 0 {
+		event.Extra = fields
+	}
+
+	w.reporter.Report(context.Background(), event)
+
+	return len(p), nil
+}
+
+// ExecuteCobraCommand executes a Cobra command and
+// ensures the reporter is flushed on exit.
+func ExecuteCobraCommand(cmd *cobra.Command) {
+	defer Flush()
+
+	if err := cmd.Execute(); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to execute command.")
+
+		// Flushing manually because on os.Exit,
+		// deferred functions are not executed.
+		Flush()
+
+		os.Exit(-1)
+	}
+}
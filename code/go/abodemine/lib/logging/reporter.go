@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+)
+
+const (
+	ReporterSink_SENTRY  = "sentry"
+	ReporterSink_ZEROLOG = "zerolog"
+	ReporterSink_FILE    = "file"
+	ReporterSink_MEMORY  = "memory"
+)
+
+// Event is an error reported to a Reporter.
+type Event struct {
+	Time      time.Time         `json:"time"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message,omitempty"`
+	RequestId string            `json:"request_id,omitempty"`
+	Chain     *errors.Chain     `json:"chain,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Extra     map[string]any    `json:"extra,omitempty"`
+}
+
+// ErrorId returns the id of the error the chain originates from,
+// which groups the events of a same error.
+func (e *Event) ErrorId() string {
+	if e.Chain.Empty() {
+		return ""
+	}
+
+	return e.Chain.First().Id
+}
+
+// SetError sets the chain of err, and the message from its first
+// error when the event has none. It returns false if err has no
+// chain.
+func (e *Event) SetError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	chain := errors.AsChain(err)
+
+	if chain.Empty() {
+		return false
+	}
+
+	first := chain.First()
+
+	e.Chain = chain
+	e.Message = val.Coalesce(
+		e.Message,
+		first.Detail,
+		first.Label,
+		first.Cause,
+		first.Id,
+	)
+
+	return true
+}
+
+// Reporter sends the events to an error sink.
+type Reporter interface {
+	Report(ctx context.Context, event *Event)
+
+	// Flush waits for the events to be sent, up to the timeout.
+	// It returns false if some were not.
+	Flush(timeout time.Duration) bool
+}
+
+var (
+	reporterMu sync.RWMutex
+	reporter   Reporter = NewZerologReporter(nil)
+)
+
+// SetReporter replaces the reporter of the process, which logs the
+// events with zerolog until set.
+func SetReporter(r Reporter) {
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+
+	reporter = r
+}
+
+func CurrentReporter() Reporter {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+
+	return reporter
+}
+
+// Report sends an event to the reporter of the process.
+func Report(ctx context.Context, event *Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	if event.Level == "" {
+		event.Level = zerolog.ErrorLevel.String()
+	}
+
+	CurrentReporter().Report(ctx, event)
+}
+
+// Flush waits for the events of the reporter of the process to be
+// sent.
+func Flush() {
+	CurrentReporter().Flush(3 * time.Second)
+}
+
+// CaptureException reports an error.
+func CaptureException(err error) {
+	event := new(Event)
+
+	if !event.SetError(err) {
+		CaptureMessage(err.Error())
+		return
+	}
+
+	Report(context.Background(), event)
+}
+
+// CaptureMessage reports a message.
+func CaptureMessage(message string) {
+	Report(context.Background(), &Event{
+		Message: message,
+	})
+}
@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+func TestFilteredReporter_Scrub(t *testing.T) {
+	memory := NewMemoryReporter()
+
+	reporter := NewFilteredReporter(&NewFilteredReporterInput{
+		Reporter: memory,
+	})
+
+	object := &errors.Object{
+		Id:     "c20b0856-c519-4958-b620-d85b4e4d87ee",
+		Code:   errors.Code_INTERNAL,
+		Detail: "Failed to send.",
+		Meta: map[string]any{
+			"key_hash": "AM.p.3f8b",
+			"to":       "Ship it to jane@example.com please.",
+			"property": map[string]any{
+				"address": "1 Main St",
+				"zip5":    "94110",
+			},
+			"attempts": 3,
+		},
+	}
+
+	reporter.Report(context.Background(), &Event{
+		Message: "Failed to send.",
+		Chain:   errors.NewChain(object),
+		Extra: map[string]any{
+			"authorization": "Bearer abc",
+			"user":          []any{"bob@example.com"},
+		},
+	})
+
+	events := memory.Events()
+	require.Len(t, events, 1)
+
+	meta := events[0].Chain.First().Meta
+	assert.Equal(t, ScrubbedValue, meta["key_hash"])
+	assert.Equal(t, "Ship it to "+ScrubbedValue+" please.", meta["to"])
+	assert.Equal(t, ScrubbedValue, meta["property"].(map[string]any)["address"])
+	assert.Equal(t, "94110", meta["property"].(map[string]any)["zip5"])
+	assert.Equal(t, 3, meta["attempts"])
+
+	assert.Equal(t, ScrubbedValue, events[0].Extra["authorization"])
+	assert.Equal(t, []any{ScrubbedValue}, events[0].Extra["user"])
+
+	// The error of the caller is left untouched.
+	assert.Equal(t, "AM.p.3f8b", object.Meta["key_hash"])
+}
+
+func TestFilteredReporter_Dedupe(t *testing.T) {
+	memory := NewMemoryReporter()
+
+	reporter := NewFilteredReporter(&NewFilteredReporterInput{
+		Reporter:     memory,
+		DedupeWindow: time.Minute,
+	})
+
+	now := time.Now()
+	reporter.now = func() time.Time { return now }
+
+	report := func(id string) {
+		reporter.Report(context.Background(), &Event{
+			Chain: errors.NewChain(&errors.Object{Id: id}),
+		})
+	}
+
+	report("0ca6eb34-bbcc-418b-a835-66b173bb77d6")
+	report("0ca6eb34-bbcc-418b-a835-66b173bb77d6")
+	report("c0243d2f-0fee-41c2-98a7-a1c23fccce0d")
+
+	// Events without an error id are never deduplicated.
+	reporter.Report(context.Background(), &Event{Message: "a"})
+	reporter.Report(context.Background(), &Event{Message: "a"})
+
+	assert.Len(t, memory.Events(), 4)
+
+	now = now.Add(time.Minute)
+	report("0ca6eb34-bbcc-418b-a835-66b173bb77d6")
+
+	assert.Len(t, memory.Events(), 5)
+}
+
+func TestReporterWriter(t *testing.T) {
+	memory := NewMemoryReporter()
+
+	errorMarshalFunc := zerolog.ErrorMarshalFunc
+	defer func() { zerolog.ErrorMarshalFunc = errorMarshalFunc }()
+
+	zerolog.ErrorMarshalFunc = func(err error) any {
+		b, _ := json.Marshal(errors.AsChain(err))
+		return string(b)
+	}
+
+	logger := zerolog.New(NewReporterWriter(memory))
+
+	logger.Info().Msg("Ignored.")
+	logger.Error().
+		Err(errors.Forward(&errors.Object{
+			Id:     "5bc0e80d-6c05-4059-9f1b-2c9a57a9b043",
+			Detail: "Boom.",
+		}, "36680ab3-c51c-4736-9b9f-ff99cea8a358")).
+		Str("request_id", "0195b6a4-6a1e-7c3e-9b0d-6c8e4f7a2b10").
+		Str("route", "/api/items/:id").
+		Send()
+
+	events := memory.Events()
+	require.Len(t, events, 1)
+
+	assert.Equal(t, zerolog.ErrorLevel.String(), events[0].Level)
+	assert.Equal(t, "Boom.", events[0].Message)
+	assert.Equal(t, "5bc0e80d-6c05-4059-9f1b-2c9a57a9b043", events[0].ErrorId())
+	assert.Equal(t, "0195b6a4-6a1e-7c3e-9b0d-6c8e4f7a2b10", events[0].RequestId)
+	assert.Equal(t, map[string]any{"route": "/api/items/:id"}, events[0].Extra)
+}
+
+func TestFileReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.ndjson")
+
+	reporter, err := NewFileReporter(&NewFileReporterInput{Path: path})
+	require.NoError(t, err)
+
+	reporter.Report(context.Background(), &Event{Level: "error", Message: "one"})
+	reporter.Report(context.Background(), &Event{Level: "warn", Message: "two"})
+	require.True(t, reporter.Flush(time.Second))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var messages []string
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		event := new(Event)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), event))
+
+		messages = append(messages, event.Message)
+	}
+
+	assert.Equal(t, []string{"one", "two"}, messages)
+}
@@ -0,0 +1,165 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"abodemine/lib/errors"
+)
+
+// ZerologReporter logs the events, so that no error leaves the
+// process.
+type ZerologReporter struct {
+	logger *zerolog.Logger
+}
+
+// NewZerologReporter returns a reporter logging with the logger, or
+// the global one if nil. The logger MUST NOT write to a reporter,
+// which would report the events again.
+func NewZerologReporter(logger *zerolog.Logger) *ZerologReporter {
+	return &ZerologReporter{logger: logger}
+}
+
+func (z *ZerologReporter) Report(ctx context.Context, event *Event) {
+	logger := z.logger
+
+	if logger == nil {
+		logger = &log.Logger
+	}
+
+	level, err := zerolog.ParseLevel(event.Level)
+	if err != nil {
+		level = zerolog.ErrorLevel
+	}
+
+	e := logger.WithLevel(level).
+		Str("reporter", ReporterSink_ZEROLOG)
+
+	if v := event.ErrorId(); v != "" {
+		e = e.Str("error_id", v)
+	}
+
+	if event.RequestId != "" {
+		e = e.Str("request_id", event.RequestId)
+	}
+
+	if !event.Chain.Empty() {
+		e = e.Interface("chain", event.Chain)
+	}
+
+	if len(event.Tags) > 0 {
+		e = e.Interface("tags", event.Tags)
+	}
+
+	if len(event.Extra) > 0 {
+		e = e.Interface("extra", event.Extra)
+	}
+
+	e.Msg(event.Message)
+}
+
+func (z *ZerologReporter) Flush(timeout time.Duration) bool {
+	return true
+}
+
+// FileReporter appends the events to a file, one JSON object per
+// line.
+type FileReporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type NewFileReporterInput struct {
+	Path string
+}
+
+func NewFileReporter(in *NewFileReporterInput) (*FileReporter, error) {
+	if in == nil || in.Path == "" {
+		return nil, &errors.Object{
+			Id:     "9f46d105-cee2-4012-8f26-b698cdaa5ebc",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing path.",
+		}
+	}
+
+	file, err := os.OpenFile(in.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "d9a132ed-07b2-410c-9297-b0e52d0e74bd",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to open file.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"path": in.Path,
+			},
+		}
+	}
+
+	return &FileReporter{file: file}, nil
+}
+
+func (f *FileReporter) Report(ctx context.Context, event *Event) {
+	b, err := json.Marshal(event)
+	if err != nil {
+		// Logging with the global logger could report the error again.
+		os.Stderr.WriteString("ERROR: Failed to marshal error event: " + err.Error() + "\n")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(b, '\n')); err != nil {
+		os.Stderr.WriteString("ERROR: Failed to write error event: " + err.Error() + "\n")
+	}
+}
+
+func (f *FileReporter) Flush(timeout time.Duration) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync() == nil
+}
+
+// MemoryReporter keeps the events, for the tests to assert on.
+type MemoryReporter struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func NewMemoryReporter() *MemoryReporter {
+	return new(MemoryReporter)
+}
+
+func (m *MemoryReporter) Report(ctx context.Context, event *Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, event)
+}
+
+func (m *MemoryReporter) Flush(timeout time.Duration) bool {
+	return true
+}
+
+// Events returns the events reported so far.
+func (m *MemoryReporter) Events() []*Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*Event(nil), m.events...)
+}
+
+func (m *MemoryReporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = nil
+}
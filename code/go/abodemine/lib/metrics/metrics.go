@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"abodemine/lib/errors"
+)
+
+// Namespace prefixes the names of the metrics of the repo.
+const Namespace = "abodemine"
+
+// NewRegistry returns a registry holding the Go runtime and
+// process collectors.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return registry
+}
+
+// Handler serves the metrics of the gatherer in the Prometheus
+// exposition format.
+func Handler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+// WriteTextfile writes the metrics of the gatherer to <dir>/<name>.prom,
+// for the textfile collector of the node exporter. The file is
+// replaced atomically so the collector never reads a partial file.
+func WriteTextfile(dir, name string, gatherer prometheus.Gatherer) error {
+	dir = strings.TrimSpace(dir)
+
+	if dir == "" {
+		return &errors.Object{
+			Id:     "2306b28f-a628-41ba-b008-acb2819446cc",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing textfile directory.",
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return &errors.Object{
+			Id:     "742d4110-30ee-47d8-9135-da50a035ccd8",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to create textfile directory.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"dir": dir,
+			},
+		}
+	}
+
+	path := filepath.Join(dir, name+".prom")
+
+	if err := prometheus.WriteToTextfile(path, gatherer); err != nil {
+		return &errors.Object{
+			Id:     "7aaaacc2-f13d-4988-8624-3ca1a66314ee",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write textfile.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"path": path,
+			},
+		}
+	}
+
+	return nil
+}
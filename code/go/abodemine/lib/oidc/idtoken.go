@@ -0,0 +1,378 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"abodemine/lib/errors"
+)
+
+// clockSkew is tolerated when checking the time claims.
+const clockSkew = time.Minute
+
+type Claims struct {
+	Issuer          string    `json:"iss"`
+	Subject         string    `json:"sub"`
+	Audience        []string  `json:"-"`
+	AuthorizedParty string    `json:"azp"`
+	ExpiresAt       time.Time `json:"-"`
+	IssuedAt        time.Time `json:"-"`
+	Nonce           string    `json:"nonce"`
+	Email           string    `json:"email"`
+	EmailVerified   bool      `json:"-"`
+	Name            string    `json:"name"`
+
+	// Raw holds every claim of the token, for provider
+	// specific claims such as groups.
+	Raw map[string]any `json:"-"`
+}
+
+// Strings returns the claim as a list of strings, accepting
+// both a single string and an array of strings.
+func (c *Claims) Strings(name string) []string {
+	return stringList(c.Raw[name])
+}
+
+type VerifyIdTokenInput struct {
+	RawIdToken string
+	ClientId   string
+	Nonce      string
+
+	// Now defaults to time.Now.
+	Now time.Time
+}
+
+// VerifyIdToken checks the signature, issuer, audience, authorized
+// party, expiry and nonce of an id token and returns its claims.
+func (p *Provider) VerifyIdToken(ctx context.Context, in *VerifyIdTokenInput) (*Claims, error) {
+	parts := strings.Split(in.RawIdToken, ".")
+
+	if len(parts) != 3 {
+		return nil, invalidIdToken("dfd5c482-227e-4fdb-9942-6049e61e755b", "Malformed id token.")
+	}
+
+	header := new(struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	})
+
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, errors.Forward(err, "707140dd-1d22-4978-88c5-e02e66b57c75")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidIdToken("74928eb6-0e76-474f-bb18-76eb12bd9cd9", "Malformed id token signature.")
+	}
+
+	key, err := p.selectKey(ctx, header.Kid)
+	if err != nil {
+		return nil, errors.Forward(err, "afaa68f6-fac7-42cb-9dd9-765ae4b7e70b")
+	}
+
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, errors.Forward(err, "796a33d0-67dd-483c-9ad3-170964f76332")
+	}
+
+	raw := make(map[string]any)
+
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, errors.Forward(err, "85afc880-1fdc-4faf-b290-7d565dbadb7b")
+	}
+
+	claims := new(Claims)
+
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, errors.Forward(err, "d028cadc-aff8-4c37-8554-783c147e1d03")
+	}
+
+	claims.Raw = raw
+	claims.Audience = stringList(raw["aud"])
+	claims.ExpiresAt = numericDate(raw["exp"])
+	claims.IssuedAt = numericDate(raw["iat"])
+
+	switch v := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified = v == "true"
+	}
+
+	now := in.Now
+
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != p.issuer:
+		return nil, invalidIdToken("b9b768ae-ca5b-4309-9d3c-bdcc784b5f45", "Unexpected id token issuer.")
+	case !slices.Contains(claims.Audience, in.ClientId):
+		return nil, invalidIdToken("95f62b58-7209-481f-9c54-00c57884caea", "Unexpected id token audience.")
+	// The token of several audiences names the one it was issued to.
+	case len(claims.Audience) > 1 && claims.AuthorizedParty == "":
+		return nil, invalidIdToken("992bfb79-b676-4923-a6fa-61c9ab9ffd9b", "Missing id token authorized party.")
+	case claims.AuthorizedParty != "" && claims.AuthorizedParty != in.ClientId:
+		return nil, invalidIdToken("f984b38a-1d2c-4512-a307-a49b51aa9780", "Unexpected id token authorized party.")
+	case claims.ExpiresAt.IsZero() || !now.Before(claims.ExpiresAt.Add(clockSkew)):
+		return nil, invalidIdToken("4d475c8e-72fd-43f2-a422-4f9946329a53", "Expired id token.")
+	case !claims.IssuedAt.IsZero() && claims.IssuedAt.After(now.Add(clockSkew)):
+		return nil, invalidIdToken("f5fe0efd-8447-4377-96a2-5689f96264db", "Id token issued in the future.")
+	case claims.Subject == "":
+		return nil, invalidIdToken("30291c3c-0ae7-4562-8144-4dbd63f85547", "Missing id token subject.")
+	case in.Nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(in.Nonce)) != 1:
+		return nil, invalidIdToken("931ea343-a653-4524-b54e-b56cdb78163f", "Unexpected id token nonce.")
+	}
+
+	return claims, nil
+}
+
+func invalidIdToken(id, detail string) error {
+	return &errors.Object{
+		Id:     id,
+		Code:   errors.Code_UNAUTHENTICATED,
+		Detail: detail,
+	}
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return &errors.Object{
+			Id:     "f15279e5-e181-483e-9a44-ca9251247782",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "Malformed id token segment.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return &errors.Object{
+			Id:     "4d66482b-ace2-43b3-bccb-3af795b2caa0",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "Malformed id token segment.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+
+		return out
+	}
+
+	return nil
+}
+
+func numericDate(v any) time.Time {
+	f, ok := v.(float64)
+	if !ok {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(f), 0)
+}
+
+func verifySignature(alg string, key any, signed, signature []byte) error {
+	digest := sha256.Sum256(signed)
+
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			break
+		}
+
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+			return invalidIdToken("a4fd9723-0097-4c10-9ff7-9f4fc14c708a", "Invalid id token signature.")
+		}
+
+		return nil
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			break
+		}
+
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return invalidIdToken("5edff611-4c5f-45e0-b2e7-9da9d8e8a4af", "Invalid id token signature.")
+		}
+
+		return nil
+	}
+
+	return &errors.Object{
+		Id:     "566e107b-dfb4-460a-8c3f-c93195beb630",
+		Code:   errors.Code_UNAUTHENTICATED,
+		Detail: "Unsupported id token algorithm.",
+		Meta: map[string]any{
+			"alg": alg,
+		},
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// selectKey returns the signing key with the given id. The keys
+// are fetched again when the id is unknown, at most once
+// per jwksRefreshInterval.
+func (p *Provider) selectKey(ctx context.Context, kid string) (any, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "89282708-b26f-4aa7-815a-6074ede371ef")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, unknownKey(kid)
+	}
+
+	set := new(struct {
+		Keys []*jsonWebKey `json:"keys"`
+	})
+
+	p.keysFetchedAt = time.Now()
+
+	if err := p.getJson(ctx, metadata.JwksUri, set); err != nil {
+		return nil, errors.Forward(err, "5e2264f3-c486-4fab-88b9-98c87fac4eba")
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if key := jwk.publicKey(); key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	p.keys = keys
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, unknownKey(kid)
+}
+
+// lookupKey falls back to the only key of the set when the
+// token has no key id.
+func (p *Provider) lookupKey(kid string) any {
+	if key, ok := p.keys[kid]; ok {
+		return key
+	}
+
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+
+	return nil
+}
+
+func unknownKey(kid string) error {
+	return &errors.Object{
+		Id:     "9cd6055c-3a0e-48c4-a8fa-531e8e05feb0",
+		Code:   errors.Code_UNAUTHENTICATED,
+		Detail: "Unknown id token key.",
+		Meta: map[string]any{
+			"kid": kid,
+		},
+	}
+}
+
+func (jwk *jsonWebKey) publicKey() any {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil
+		}
+
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil
+		}
+
+		return pub
+	}
+
+	return nil
+}
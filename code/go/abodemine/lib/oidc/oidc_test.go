@@ -0,0 +1,287 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+// fakeProvider is an in-process OpenID Connect provider that
+// issues RS256 id tokens for a single authorization code.
+type fakeProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	code      string
+	challenge string
+	nonce     string
+	claims    map[string]any
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fp := &fakeProvider{
+		t:   t,
+		key: key,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 fp.server.URL,
+			"authorization_endpoint": fp.server.URL + "/authorize",
+			"token_endpoint":         fp.server.URL + "/token",
+			"jwks_uri":               fp.server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]any{
+				{
+					"kty": "RSA",
+					"kid": "k1",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		clientId, _, _ := r.BasicAuth()
+
+		if r.PostForm.Get("code") != fp.code ||
+			clientId != "client" ||
+			CodeChallengeS256(r.PostForm.Get("code_verifier")) != fp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     fp.sign(fp.claims),
+			"expires_in":   3600,
+		})
+	})
+
+	fp.server = httptest.NewServer(mux)
+	t.Cleanup(fp.server.Close)
+
+	return fp
+}
+
+// authorize simulates the user login at the authorization endpoint.
+func (fp *fakeProvider) authorize(authUrl string) string {
+	u, err := url.Parse(authUrl)
+	require.NoError(fp.t, err)
+
+	query := u.Query()
+	require.Equal(fp.t, "S256", query.Get("code_challenge_method"))
+
+	fp.code = RandomString(16)
+	fp.challenge = query.Get("code_challenge")
+	fp.nonce = query.Get("nonce")
+	fp.claims = map[string]any{
+		"iss":            fp.server.URL,
+		"sub":            "user-1",
+		"aud":            query.Get("client_id"),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          fp.nonce,
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{"admins", "staff"},
+	}
+
+	return fp.code
+}
+
+func (fp *fakeProvider) sign(claims map[string]any) string {
+	header, err := json.Marshal(map[string]any{"alg": "RS256", "kid": "k1", "typ": "JWT"})
+	require.NoError(fp.t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(fp.t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, fp.key, crypto.SHA256, digest[:])
+	require.NoError(fp.t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestProvider_CodeFlow(t *testing.T) {
+	fp := newFakeProvider(t)
+	ctx := context.Background()
+
+	provider := NewProvider(&NewProviderInput{
+		Issuer: fp.server.URL,
+	})
+
+	verifier := NewCodeVerifier()
+	nonce := RandomString(16)
+
+	authUrl, err := provider.AuthCodeUrl(ctx, &AuthCodeUrlInput{
+		ClientId:     "client",
+		RedirectUri:  "https://app.test/callback",
+		State:        "state",
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+
+	code := fp.authorize(authUrl)
+
+	t.Run("wrong-verifier", func(t *testing.T) {
+		_, err := provider.Exchange(ctx, &ExchangeInput{
+			ClientId:     "client",
+			RedirectUri:  "https://app.test/callback",
+			Code:         code,
+			CodeVerifier: NewCodeVerifier(),
+		})
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+	})
+
+	exchangeOut, err := provider.Exchange(ctx, &ExchangeInput{
+		ClientId:     "client",
+		RedirectUri:  "https://app.test/callback",
+		Code:         code,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+
+	claims, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+		RawIdToken: exchangeOut.IdToken,
+		ClientId:   "client",
+		Nonce:      nonce,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, []string{"admins", "staff"}, claims.Strings("groups"))
+
+	t.Run("wrong-nonce", func(t *testing.T) {
+		_, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+			RawIdToken: exchangeOut.IdToken,
+			ClientId:   "client",
+			Nonce:      "other",
+		})
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+	})
+
+	t.Run("wrong-audience", func(t *testing.T) {
+		_, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+			RawIdToken: exchangeOut.IdToken,
+			ClientId:   "other",
+			Nonce:      nonce,
+		})
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+	})
+
+	t.Run("authorized-party", func(t *testing.T) {
+		verify := func(aud any, azp string) error {
+			claims := map[string]any{}
+			for k, v := range fp.claims {
+				claims[k] = v
+			}
+			claims["aud"] = aud
+
+			if azp != "" {
+				claims["azp"] = azp
+			}
+
+			_, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+				RawIdToken: fp.sign(claims),
+				ClientId:   "client",
+				Nonce:      nonce,
+			})
+
+			return err
+		}
+
+		assert.NoError(t, verify([]string{"client", "other"}, "client"))
+		assert.NoError(t, verify("client", "client"))
+
+		err := verify([]string{"client", "other"}, "")
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.CodeOf(err))
+
+		err = verify([]string{"client", "other"}, "other")
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.CodeOf(err))
+
+		err = verify("client", "other")
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.CodeOf(err))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+			RawIdToken: exchangeOut.IdToken,
+			ClientId:   "client",
+			Nonce:      nonce,
+			Now:        time.Now().Add(2 * time.Hour),
+		})
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		claims := map[string]any{}
+		for k, v := range fp.claims {
+			claims[k] = v
+		}
+		claims["sub"] = "user-2"
+
+		forged := fp.sign(claims)
+		other := fp.sign(fp.claims)
+
+		// Swap the payload of a valid token for another one.
+		tampered := forged[:len(forged)-len(signatureOf(forged))] + signatureOf(other)
+
+		_, err := provider.VerifyIdToken(ctx, &VerifyIdTokenInput{
+			RawIdToken: tampered,
+			ClientId:   "client",
+			Nonce:      nonce,
+		})
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+	})
+}
+
+func signatureOf(token string) string {
+	for i := len(token) - 1; i >= 0; i-- {
+		if token[i] == '.' {
+			return token[i+1:]
+		}
+	}
+
+	return ""
+}
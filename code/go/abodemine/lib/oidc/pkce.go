@@ -0,0 +1,29 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// RandomString returns n random bytes, base64url encoded.
+// It is used for the state, nonce and code verifier.
+func RandomString(n int) string {
+	b := make([]byte, n)
+
+	// crypto/rand.Read never returns an error.
+	rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// NewCodeVerifier returns a PKCE code verifier of 43 characters.
+func NewCodeVerifier() string {
+	return RandomString(32)
+}
+
+// CodeChallengeS256 returns the S256 PKCE challenge of the verifier.
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
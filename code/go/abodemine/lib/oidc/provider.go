@@ -0,0 +1,272 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"abodemine/lib/errors"
+)
+
+// jwksRefreshInterval limits how often unknown key ids
+// trigger a refresh of the keys of the provider.
+const jwksRefreshInterval = time.Minute
+
+// Provider is an OpenID Connect provider, discovered from
+// its issuer on first use.
+type Provider struct {
+	issuer     string
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	metadata      *providerMetadata
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+type NewProviderInput struct {
+	Issuer string
+
+	// HttpClient defaults to a client with a 10s timeout.
+	HttpClient *http.Client
+}
+
+func NewProvider(in *NewProviderInput) *Provider {
+	httpClient := in.HttpClient
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{
+		issuer:     strings.TrimSuffix(in.Issuer, "/"),
+		httpClient: httpClient,
+	}
+}
+
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+func (p *Provider) discover(ctx context.Context) (*providerMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	metadata := new(providerMetadata)
+
+	if err := p.getJson(ctx, p.issuer+"/.well-known/openid-configuration", metadata); err != nil {
+		return nil, errors.Forward(err, "5202bb7f-f16a-4d26-9cea-2f6f4f82357e")
+	}
+
+	if strings.TrimSuffix(metadata.Issuer, "/") != p.issuer {
+		return nil, &errors.Object{
+			Id:     "a29d9894-6e4d-4eb5-85fa-7e4f27d9ce2f",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "The issuer of the provider metadata does not match.",
+			Meta: map[string]any{
+				"issuer": metadata.Issuer,
+			},
+		}
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JwksUri == "" {
+		return nil, &errors.Object{
+			Id:     "b6f00b97-ea85-46d7-8725-6787e46a3d36",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Incomplete provider metadata.",
+		}
+	}
+
+	p.metadata = metadata
+
+	return metadata, nil
+}
+
+type AuthCodeUrlInput struct {
+	ClientId    string
+	RedirectUri string
+	Scopes      []string
+	State       string
+	Nonce       string
+
+	// CodeVerifier is sent as its S256 challenge.
+	CodeVerifier string
+}
+
+// AuthCodeUrl returns the url the user is redirected to, to
+// authenticate with the provider.
+func (p *Provider) AuthCodeUrl(ctx context.Context, in *AuthCodeUrlInput) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", errors.Forward(err, "888a76e6-b024-43a2-a2a8-f132745e6ca1")
+	}
+
+	scopes := in.Scopes
+
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {in.ClientId},
+		"redirect_uri":          {in.RedirectUri},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {in.State},
+		"nonce":                 {in.Nonce},
+		"code_challenge":        {CodeChallengeS256(in.CodeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return metadata.AuthorizationEndpoint + sep + query.Encode(), nil
+}
+
+type ExchangeInput struct {
+	ClientId     string
+	ClientSecret string
+	RedirectUri  string
+	Code         string
+	CodeVerifier string
+}
+
+type ExchangeOutput struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IdToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Exchange redeems an authorization code at the token endpoint.
+func (p *Provider) Exchange(ctx context.Context, in *ExchangeInput) (*ExchangeOutput, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "71272397-04d2-4bf4-b370-93d89e55a67c")
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {in.Code},
+		"redirect_uri":  {in.RedirectUri},
+		"code_verifier": {in.CodeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "56a9b7fa-5183-421d-bf08-e9a685faee58",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create token request.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(in.ClientId), url.QueryEscape(in.ClientSecret))
+
+	out := new(ExchangeOutput)
+
+	if err := p.doJson(req, out); err != nil {
+		return nil, errors.Forward(err, "2e10deda-9ab8-4209-94b4-0d0d1a699bcb")
+	}
+
+	if out.IdToken == "" {
+		return nil, &errors.Object{
+			Id:     "a4e97370-8208-4b74-9660-25db2e26437c",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "The token response has no id token.",
+		}
+	}
+
+	return out, nil
+}
+
+func (p *Provider) getJson(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return &errors.Object{
+			Id:     "795abed7-da3d-4318-9aae-449e81a93f37",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create request.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	return p.doJson(req, v)
+}
+
+func (p *Provider) doJson(req *http.Request, v any) error {
+	rsp, err := p.httpClient.Do(req)
+	if err != nil {
+		return &errors.Object{
+			Id:     "6ba98b5e-d870-474c-a02f-0f2a85594527",
+			Code:   errors.Code_UNAVAILABLE,
+			Detail: "Failed to reach the provider.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(rsp.Body, 1<<20))
+	if err != nil {
+		return &errors.Object{
+			Id:     "4f5f1860-e21f-4e53-ae25-d2cb583021db",
+			Code:   errors.Code_UNAVAILABLE,
+			Detail: "Failed to read the provider response.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return &errors.Object{
+			Id:     "bba8f576-2c9a-49f9-8a7d-4336688ff691",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "The provider rejected the request.",
+			Meta: map[string]any{
+				"status": rsp.StatusCode,
+				"url":    req.URL.String(),
+			},
+		}
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &errors.Object{
+			Id:     "84beffd0-af11-40cd-b587-c5dfd725dc3b",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to parse the provider response.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
@@ -2,9 +2,12 @@ package storage
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/rs/zerolog/log"
+
 	"abodemine/lib/errors"
 )
 
@@ -29,6 +32,67 @@ func (b *LocalBackend) PathJoin(elem ...string) string {
 }
 
 func (b *LocalBackend) Put(ctx context.Context, obj *Object) error {
+	if obj.Body == nil {
+		return &errors.Object{
+			Id:     "65b921e0-a716-433d-ab54-f0caf0ea71a4",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing object body.",
+		}
+	}
+
+	if err := os.MkdirAll(obj.Dir, 0750); err != nil {
+		return &errors.Object{
+			Id:     "6fb03b82-f922-4bc0-93af-88ff9ae31876",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to create directory.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	targetPath := filepath.Join(obj.Dir, obj.Name)
+
+	file, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return &errors.Object{
+			Id:     "3bf4b6fb-f445-401a-9509-58ec91e79a41",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to create file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	n, err := io.Copy(file, obj.Body)
+	if err != nil {
+		if err := file.Close(); err != nil {
+			log.Error().
+				Err(err).
+				Str("id", "8384e765-0f57-4342-a7cd-125ffc8d04ef").
+				Msg("Failed to close file.")
+		}
+
+		return &errors.Object{
+			Id:     "453ba10e-788c-4aa3-a7f9-c936cb9611eb",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return &errors.Object{
+			Id:     "07e56510-6f33-41fa-b1d3-ff263ed0e8af",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to close file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	obj.Size = n
+
 	return nil
 }
 
@@ -42,6 +106,7 @@ func (b *LocalBackend) Get(ctx context.Context, obj *Object) (ObjectReader, erro
 			Code:   errors.Code_FAILED_PRECONDITION,
 			Detail: "Failed to open file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -62,6 +127,7 @@ func (b *LocalBackend) List(ctx context.Context, prefix string, options *ListOpt
 			Code:   errors.Code_FAILED_PRECONDITION,
 			Detail: "Failed to list directory.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -88,6 +154,7 @@ func (b *LocalBackend) List(ctx context.Context, prefix string, options *ListOpt
 					Code:   errors.Code_FAILED_PRECONDITION,
 					Detail: "Failed to get info of entry.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -40,6 +40,42 @@ func (b *S3Backend) PathJoin(elem ...string) string {
 }
 
 func (b *S3Backend) Put(ctx context.Context, obj *Object) error {
+	if obj.Body == nil {
+		return &errors.Object{
+			Id:     "9ce9bd93-c1e2-47de-851f-9ab0601a0625",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing object body.",
+		}
+	}
+
+	s3Client := s3.NewFromConfig(b.AWS)
+	targetPath := path.Join(obj.Dir, obj.Name)
+
+	if len(targetPath) > 0 && targetPath[0] == '/' {
+		targetPath = targetPath[1:]
+	}
+
+	putObjectInput := &s3.PutObjectInput{
+		Bucket: aws.String(b.Bucket),
+		Key:    aws.String(targetPath),
+		Body:   obj.Body,
+	}
+
+	// Unseekable bodies must declare their length to be streamed.
+	if obj.Size > 0 {
+		putObjectInput.ContentLength = aws.Int64(obj.Size)
+	}
+
+	if _, err := s3Client.PutObject(ctx, putObjectInput); err != nil {
+		return &errors.Object{
+			Id:     "630d0f11-9e57-4b7f-a8c6-f13eedd9bcfc",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to put object.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
 	return nil
 }
 
@@ -63,6 +99,7 @@ func (b *S3Backend) Get(ctx context.Context, obj *Object) (ObjectReader, error)
 			Code:   errors.Code_NOT_FOUND,
 			Detail: "Failed to get object.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -120,6 +157,7 @@ func (b *S3Backend) List(ctx context.Context, prefix string, options *ListOption
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed paginate next page.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -198,6 +236,7 @@ func (r *S3Reader) Read(p []byte) (int, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to read body.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -232,6 +271,7 @@ func (r *S3Reader) Close() error {
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to remove temporary file.",
 					Cause:  err.Error(),
+					Err:    err,
 				}).
 				Send()
 		}
@@ -243,6 +283,7 @@ func (r *S3Reader) Close() error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to close body.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -270,6 +311,7 @@ READAT:
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to read body.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -290,6 +332,7 @@ READAT:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create temporary directory.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -308,6 +351,7 @@ READAT:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create temporary file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -323,6 +367,7 @@ READAT:
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to close temporary file.",
 					Cause:  err.Error(),
+					Err:    err,
 				}).
 				Send()
 		}
@@ -334,6 +379,7 @@ READAT:
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to remove temporary file.",
 					Cause:  err.Error(),
+					Err:    err,
 				}).
 				Send()
 		}
@@ -345,6 +391,7 @@ READAT:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to copy body to temporary file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -354,6 +401,7 @@ READAT:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to close body.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -364,6 +412,7 @@ READAT:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create temporary file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
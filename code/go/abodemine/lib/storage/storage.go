@@ -42,6 +42,9 @@ type Object struct {
 	// The size of the object in bytes.
 	Size int64 `json:"size,omitempty"`
 
+	// Body is the content written by Put.
+	Body io.Reader `json:"-"`
+
 	isDirectory bool
 }
 
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+// EnvCarrier carries the trace of a process into the environment
+// of the processes it launches, as TRACEPARENT and TRACESTATE.
+type EnvCarrier map[string]string
+
+func (c EnvCarrier) Get(key string) string {
+	return c[strings.ToUpper(key)]
+}
+
+func (c EnvCarrier) Set(key, value string) {
+	c[strings.ToUpper(key)] = value
+}
+
+func (c EnvCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// InjectEnv returns the environment variables propagating the
+// trace of the context.
+func InjectEnv(ctx context.Context) map[string]string {
+	carrier := make(EnvCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	return carrier
+}
+
+// ExtractEnv returns the context continuing the trace propagated
+// in the environment of the process, if any.
+func ExtractEnv(ctx context.Context) context.Context {
+	carrier := make(EnvCarrier)
+
+	for _, k := range otel.GetTextMapPropagator().Fields() {
+		if v, ok := os.LookupEnv(strings.ToUpper(k)); ok {
+			carrier.Set(k, v)
+		}
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,269 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"abodemine/lib/errors"
+)
+
+// FileExporter appends spans to a file in the OTLP JSON format,
+// one ExportTraceServiceRequest per line, as read by the file
+// receiver of the OpenTelemetry Collector.
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type NewFileExporterInput struct {
+	Path string
+}
+
+func NewFileExporter(in *NewFileExporterInput) (*FileExporter, error) {
+	if in == nil || in.Path == "" {
+		return nil, &errors.Object{
+			Id:     "902270e4-245f-4e2b-ad6f-40fecec418d7",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing path.",
+		}
+	}
+
+	file, err := os.OpenFile(in.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "ea4dd8ea-c733-4dc9-a011-4cdac03067e6",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Failed to open file.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"path": in.Path,
+			},
+		}
+	}
+
+	return &FileExporter{file: file}, nil
+}
+
+func (e *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+
+	_, err = e.file.Write(append(b, '\n'))
+
+	return err
+}
+
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+
+	err := e.file.Close()
+	e.file = nil
+
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// OTLP JSON encoding.
+////////////////////////////////////////////////////////////////////////////////
+
+type otlpTraceRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   *otlpResource     `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope *otlpScope  `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []*otlpKeyValue `json:"attributes,omitempty"`
+	Events            []*otlpEvent    `json:"events,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Name         string          `json:"name"`
+	Attributes   []*otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func otlpRequest(spans []sdktrace.ReadOnlySpan) *otlpTraceRequest {
+	req := new(otlpTraceRequest)
+	scopes := make(map[string]*otlpScopeSpans)
+
+	// All the spans of a provider share its resource.
+	rs := &otlpResourceSpans{
+		Resource: &otlpResource{
+			Attributes: otlpAttributes(spans[0].Resource().Attributes()),
+		},
+	}
+
+	req.ResourceSpans = append(req.ResourceSpans, rs)
+
+	for _, span := range spans {
+		scope := span.InstrumentationScope()
+		key := scope.Name + "@" + scope.Version
+
+		ss, ok := scopes[key]
+		if !ok {
+			ss = &otlpScopeSpans{
+				Scope: &otlpScope{
+					Name:    scope.Name,
+					Version: scope.Version,
+				},
+			}
+
+			scopes[key] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+
+		ss.Spans = append(ss.Spans, otlpSpanOf(span))
+	}
+
+	return req
+}
+
+func otlpSpanOf(span sdktrace.ReadOnlySpan) *otlpSpan {
+	out := &otlpSpan{
+		TraceId:           span.SpanContext().TraceID().String(),
+		SpanId:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        otlpAttributes(span.Attributes()),
+	}
+
+	if span.Parent().IsValid() {
+		out.ParentSpanId = span.Parent().SpanID().String()
+	}
+
+	for _, event := range span.Events() {
+		out.Events = append(out.Events, &otlpEvent{
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+
+	// The codes of OTLP differ from the ones of the sdk.
+	switch status := span.Status(); status.Code {
+	case codes.Ok:
+		out.Status = &otlpStatus{Code: 1}
+	case codes.Error:
+		out.Status = &otlpStatus{Code: 2, Message: status.Description}
+	}
+
+	return out
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []*otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	out := make([]*otlpKeyValue, 0, len(attrs))
+
+	for _, attr := range attrs {
+		out = append(out, &otlpKeyValue{
+			Key:   string(attr.Key),
+			Value: otlpValue(attr.Value),
+		})
+	}
+
+	return out
+}
+
+func otlpValue(v attribute.Value) map[string]any {
+	switch v.Type() {
+	case attribute.BOOL:
+		return map[string]any{"boolValue": v.AsBool()}
+	case attribute.INT64:
+		// 64-bit integers are strings in the JSON encoding of protobuf.
+		return map[string]any{"intValue": strconv.FormatInt(v.AsInt64(), 10)}
+	case attribute.FLOAT64:
+		return map[string]any{"doubleValue": v.AsFloat64()}
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+		var values []map[string]any
+
+		switch v.Type() {
+		case attribute.BOOLSLICE:
+			for _, item := range v.AsBoolSlice() {
+				values = append(values, otlpValue(attribute.BoolValue(item)))
+			}
+		case attribute.INT64SLICE:
+			for _, item := range v.AsInt64Slice() {
+				values = append(values, otlpValue(attribute.Int64Value(item)))
+			}
+		case attribute.FLOAT64SLICE:
+			for _, item := range v.AsFloat64Slice() {
+				values = append(values, otlpValue(attribute.Float64Value(item)))
+			}
+		default:
+			for _, item := range v.AsStringSlice() {
+				values = append(values, otlpValue(attribute.StringValue(item)))
+			}
+		}
+
+		return map[string]any{"arrayValue": map[string]any{"values": values}}
+	}
+
+	return map[string]any{"stringValue": v.Emit()}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport creates a client span for each request sent with a
+// context holding a span, and propagates the trace in its headers.
+type Transport struct {
+	// Base defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// Name is the prefix of the names of the spans.
+	Name string
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx := req.Context()
+
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return base.RoundTrip(req)
+	}
+
+	ctx, span := Tracer().Start(ctx, t.Name+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// RoundTrippers MUST NOT modify the request.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		RecordError(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+
+	if res.StatusCode >= 500 {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type pgxSpanKey struct{}
+
+// PgxTracer creates a span for each query run with a context
+// holding a span, and is set as the Tracer of pgx.ConnConfig.
+type PgxTracer struct{}
+
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	// Queries outside of a traced request, such as the ones
+	// of the pool health checks, are not worth a root span.
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	ctx, span := Tracer().Start(ctx, "postgres "+sqlOperation(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+		),
+	)
+
+	return context.WithValue(ctx, pgxSpanKey{}, span)
+}
+
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(pgxSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	RecordError(span, data.Err)
+	span.End()
+}
+
+// sqlOperation returns the first keyword of the statement.
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+
+	return strings.ToUpper(strings.TrimLeft(fields[0], "("))
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"abodemine/lib/app"
+	"abodemine/lib/errors"
+)
+
+// TracerName is the instrumentation scope of the spans of the repo.
+const TracerName = "abodemine"
+
+var (
+	providerMu sync.Mutex
+	provider   *sdktrace.TracerProvider
+)
+
+// Tracer returns the tracer of the global provider, which is a
+// no-op tracer until Init is called.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+type InitInput struct {
+	ServiceName string
+	Exporter    sdktrace.SpanExporter
+
+	// SampleRatio of the root spans. Child spans follow the
+	// decision of their parent. Defaults to 1.
+	SampleRatio float64
+
+	// Sync exports spans as they end, instead of in batches.
+	// It SHOULD only be used in tests.
+	Sync bool
+}
+
+// Init installs the global tracer provider and the W3C trace
+// context propagator. Spans are flushed by Shutdown.
+func Init(in *InitInput) (*sdktrace.TracerProvider, error) {
+	if in == nil || in.Exporter == nil {
+		return nil, &errors.Object{
+			Id:     "420a2285-2f8a-4380-a0a4-49dbfe0af053",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing exporter.",
+		}
+	}
+
+	sampleRatio := in.SampleRatio
+
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", in.ServiceName),
+		attribute.String("service.version", app.BuildVersion()),
+		attribute.String("abodemine.build_id", app.BuildId()),
+	)
+
+	processor := sdktrace.NewBatchSpanProcessor(in.Exporter)
+
+	if in.Sync {
+		processor = sdktrace.NewSimpleSpanProcessor(in.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithSpanProcessor(processor),
+	)
+
+	providerMu.Lock()
+	previous := provider
+	provider = tp
+	providerMu.Unlock()
+
+	if previous != nil {
+		previous.Shutdown(context.Background())
+	}
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp, nil
+}
+
+// Shutdown flushes the spans of the provider installed by Init,
+// and MUST be called before the process exits.
+func Shutdown(ctx context.Context) error {
+	providerMu.Lock()
+	tp := provider
+	provider = nil
+	providerMu.Unlock()
+
+	if tp == nil {
+		return nil
+	}
+
+	if err := tp.Shutdown(ctx); err != nil {
+		return &errors.Object{
+			Id:     "37006f10-57aa-41bd-9197-94cb2ce04371",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to shutdown tracer provider.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+// NewMemoryExporter returns an exporter keeping the spans in
+// memory, for tests.
+func NewMemoryExporter() *tracetest.InMemoryExporter {
+	return tracetest.NewInMemoryExporter()
+}
+
+// RecordError marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func initMemory(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := NewMemoryExporter()
+
+	_, err := Init(&InitInput{
+		ServiceName: "test",
+		Exporter:    exporter,
+		Sync:        true,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func TestPgxTracer(t *testing.T) {
+	exporter := initMemory(t)
+	tracer := &PgxTracer{}
+
+	// Queries without a parent span are not traced.
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Empty(t, exporter.GetSpans())
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+
+	queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n  update users set x = 1"})
+	tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("UPDATE 3"),
+	})
+
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	query := spans[0]
+	assert.Equal(t, "postgres UPDATE", query.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+	assert.Contains(t, query.Attributes, attribute.Int64("db.rows_affected", 3))
+}
+
+func TestValkeyScriptName(t *testing.T) {
+	body := "return redis.call('GET', KEYS[1])"
+	RegisterValkeyScript("select-thing", body)
+
+	sum := sha1.Sum([]byte(body))
+	sha := hex.EncodeToString(sum[:])
+
+	assert.Equal(t, "select-thing", valkeyScriptName([]string{"EVAL", body, "1", "k"}))
+	assert.Equal(t, "select-thing", valkeyScriptName([]string{"EVALSHA", sha, "1", "k"}))
+	assert.Equal(t, "", valkeyScriptName([]string{"EVALSHA", "0000", "1", "k"}))
+	assert.Equal(t, "", valkeyScriptName([]string{"GET", "k"}))
+	assert.Equal(t, "", valkeyScriptName(nil))
+}
+
+func TestEnvPropagation(t *testing.T) {
+	initMemory(t)
+
+	ctx, span := Tracer().Start(context.Background(), "launcher")
+	defer span.End()
+
+	env := InjectEnv(ctx)
+	require.Contains(t, env, "TRACEPARENT")
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	extracted := ExtractEnv(context.Background())
+
+	_, child := Tracer().Start(extracted, "task")
+	defer child.End()
+
+	assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+
+	exporter, err := NewFileExporter(&NewFileExporterInput{Path: path})
+	require.NoError(t, err)
+
+	_, err = Init(&InitInput{
+		ServiceName: "test",
+		Exporter:    exporter,
+		Sync:        true,
+	})
+	require.NoError(t, err)
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	_, child := Tracer().Start(ctx, "child")
+	RecordError(child, os.ErrNotExist)
+	child.End()
+	parent.End()
+
+	require.NoError(t, Shutdown(context.Background()))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := make(map[string]any)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+
+	span := lines[0]["resourceSpans"].([]any)[0].(map[string]any)["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)[0].(map[string]any)
+
+	assert.Equal(t, "child", span["name"])
+	assert.Equal(t, parent.SpanContext().SpanID().String(), span["parentSpanId"])
+	assert.Equal(t, float64(2), span["status"].(map[string]any)["code"])
+}
@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var valkeyScripts sync.Map // map[sha1]name
+
+// RegisterValkeyScript names the spans of the EVAL and EVALSHA
+// commands running the script body.
+func RegisterValkeyScript(name, body string) {
+	sum := sha1.Sum([]byte(body))
+	valkeyScripts.Store(hex.EncodeToString(sum[:]), name)
+}
+
+func valkeyScriptName(commands []string) string {
+	if len(commands) < 2 {
+		return ""
+	}
+
+	var sha string
+
+	switch strings.ToUpper(commands[0]) {
+	case "EVALSHA", "EVALSHA_RO":
+		sha = strings.ToLower(commands[1])
+	case "EVAL", "EVAL_RO":
+		sum := sha1.Sum([]byte(commands[1]))
+		sha = hex.EncodeToString(sum[:])
+	default:
+		return ""
+	}
+
+	if v, ok := valkeyScripts.Load(sha); ok {
+		return v.(string)
+	}
+
+	return ""
+}
+
+type valkeyClient struct {
+	valkey.Client
+}
+
+// NewValkeyClient wraps the client so that its commands create
+// spans, as children of the span of their context.
+func NewValkeyClient(cli valkey.Client) valkey.Client {
+	return &valkeyClient{Client: cli}
+}
+
+func (c *valkeyClient) start(ctx context.Context, commands []string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+
+	var operation string
+
+	if len(commands) > 0 {
+		operation = strings.ToUpper(commands[0])
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "valkey"),
+		attribute.String("db.operation", operation),
+	}
+
+	name := "valkey " + operation
+
+	if script := valkeyScriptName(commands); script != "" {
+		name = "valkey " + script
+		attrs = append(attrs, attribute.String("valkey.script", script))
+	}
+
+	return Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func (c *valkeyClient) end(span trace.Span, resp valkey.ValkeyResult) {
+	if span == nil {
+		return
+	}
+
+	// Nil replies are results, not failures.
+	if err := resp.Error(); err != nil && !valkey.IsValkeyNil(err) {
+		RecordError(span, err)
+	}
+
+	span.End()
+}
+
+func (c *valkeyClient) Do(ctx context.Context, cmd valkey.Completed) valkey.ValkeyResult {
+	ctx, span := c.start(ctx, cmd.Commands())
+	resp := c.Client.Do(ctx, cmd)
+	c.end(span, resp)
+
+	return resp
+}
+
+func (c *valkeyClient) DoCache(ctx context.Context, cmd valkey.Cacheable, ttl time.Duration) valkey.ValkeyResult {
+	ctx, span := c.start(ctx, cmd.Commands())
+	resp := c.Client.DoCache(ctx, cmd, ttl)
+	c.end(span, resp)
+
+	return resp
+}
+
+func (c *valkeyClient) DoMulti(ctx context.Context, multi ...valkey.Completed) []valkey.ValkeyResult {
+	if len(multi) == 0 {
+		return c.Client.DoMulti(ctx, multi...)
+	}
+
+	ctx, span := c.start(ctx, multi[0].Commands())
+	if span != nil {
+		span.SetAttributes(attribute.Int("valkey.commands", len(multi)))
+	}
+
+	resp := c.Client.DoMulti(ctx, multi...)
+
+	if span != nil {
+		for _, v := range resp {
+			if err := v.Error(); err != nil && !valkey.IsValkeyNil(err) {
+				RecordError(span, err)
+				break
+			}
+		}
+
+		span.End()
+	}
+
+	return resp
+}
+
+func (c *valkeyClient) DoMultiCache(ctx context.Context, multi ...valkey.CacheableTTL) []valkey.ValkeyResult {
+	if len(multi) == 0 {
+		return c.Client.DoMultiCache(ctx, multi...)
+	}
+
+	ctx, span := c.start(ctx, multi[0].Cmd.Commands())
+	if span != nil {
+		span.SetAttributes(attribute.Int("valkey.commands", len(multi)))
+	}
+
+	resp := c.Client.DoMultiCache(ctx, multi...)
+
+	if span != nil {
+		for _, v := range resp {
+			if err := v.Error(); err != nil && !valkey.IsValkeyNil(err) {
+				RecordError(span, err)
+				break
+			}
+		}
+
+		span.End()
+	}
+
+	return resp
+}
@@ -21,6 +21,7 @@ func NewUUID4() (uuid.UUID, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to generate UUIDv4.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -35,6 +36,7 @@ func NewUUID7() (uuid.UUID, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to generate UUIDv7.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -49,6 +51,7 @@ func Uint16FromRawBase64(s string) (uint16, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to decode base64 string.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -63,6 +66,7 @@ func Uint16FromRawUrlSafeBase64(s string) (uint16, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to decode base64 string.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -91,6 +95,7 @@ func UUIDFromBytes(b []byte) (uuid.UUID, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to generate UUID from bytes.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -105,6 +110,7 @@ func UUIDFromString(s string) (uuid.UUID, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to generate UUID from string.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
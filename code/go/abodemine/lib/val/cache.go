@@ -77,3 +77,17 @@ func (c *Cache[K, V]) Del(k K) {
 	delete(c.items, k)
 	c.mu.Unlock()
 }
+
+// Keys returns the keys of the cache, in no particular order.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, len(c.items))
+
+	for k := range c.items {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -99,6 +99,7 @@ func BoolPtrFromStringIfNonZero(s string) (*bool, error) {
 			Id:    "4973e13e-5de0-4fb6-8a19-a572c9d441bc",
 			Code:  errors.Code_INVALID_ARGUMENT,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -134,6 +135,7 @@ func DecimalPtrFromString(s string) (*decimal.Decimal, error) {
 			Id:    "d827753c-f482-4b33-b96e-3371bcd1a32f",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -162,6 +164,7 @@ func Float64PtrFromStringIfNonZero(s string) (*float64, error) {
 			Id:    "ea504258-bec7-4f95-b069-2061f6eec546",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -182,6 +185,7 @@ func IntPtrFromStringIfNonZero(s string) (*int, error) {
 			Id:    "226f2cc9-1a37-4e2b-94a6-1c18f4837622",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -202,6 +206,7 @@ func IntPtrFromFloat64StringIfNonZero(s string) (*int, error) {
 			Id:    "dbbba877-e40f-4510-a424-2c63d4e2ff3e",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -220,6 +225,7 @@ func Int64PtrFromString(s string) (*int64, error) {
 			Id:    "22561116-04dd-43b8-9c96-09537c4ebe97",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -251,6 +257,7 @@ func TimePtrFromString(layout, s string) (*time.Time, error) {
 			Id:    "e9b05d9f-0fb1-40f0-849e-630086eb234c",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"layout": layout,
 				"value":  s,
@@ -280,6 +287,7 @@ func UUIDPtrFromStringIfNonZero(s string) (*uuid.UUID, error) {
 			Id:    "e5cf1b2c-712d-41ae-8c75-8a3c5d73782c",
 			Code:  errors.Code_UNKNOWN,
 			Cause: err.Error(),
+			Err:   err,
 			Meta: map[string]any{
 				"value": s,
 			},
@@ -0,0 +1,634 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+	"github.com/valkey-io/valkey-go"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength   = 255
+	DefaultIdempotencyTtl     = 24 * time.Hour
+	DefaultIdempotencyLockTtl = time.Minute
+
+	// DefaultIdempotencyMaxBodySize bounds the request bodies read
+	// to fingerprint the requests.
+	DefaultIdempotencyMaxBodySize = 1 << 20
+
+	// statusClientClosedRequest is the status of the responses
+	// to canceled requests.
+	statusClientClosedRequest = 499
+)
+
+// Idempotency stores the responses of requests sent with an
+// Idempotency-Key header, and replays them when the request is
+// retried with the same key, so that retries are not charged
+// again. Concurrent requests with the same key are serialized.
+//
+// Keys are scoped to the Authorization header and the route.
+// Only final 2xx and 4xx responses are stored. Responses that
+// depend on transient state, e.g. credentials, quotas, rate
+// limits or a canceled request, are not, so the request can be
+// retried with the same key.
+type Idempotency struct {
+	arcDomain arc.Domain
+
+	ttl          time.Duration
+	lockTtl      time.Duration
+	pollInterval time.Duration
+	maxBodySize  int64
+}
+
+type NewIdempotencyInput struct {
+	ArcDomain arc.Domain
+
+	// How long responses are stored. Defaults to 24 hours.
+	Ttl time.Duration
+
+	// How long a request holds its key before concurrent requests
+	// with the same key give up waiting. Defaults to one minute.
+	LockTtl time.Duration
+
+	// How often concurrent requests check whether the request
+	// holding their key completed. Defaults to 100ms.
+	PollInterval time.Duration
+
+	// Maximum size of the request bodies, in bytes.
+	// Defaults to DefaultIdempotencyMaxBodySize.
+	MaxBodySize int64
+}
+
+func NewIdempotency(in *NewIdempotencyInput) *Idempotency {
+	m := &Idempotency{
+		arcDomain:    in.ArcDomain,
+		ttl:          in.Ttl,
+		lockTtl:      in.LockTtl,
+		pollInterval: in.PollInterval,
+		maxBodySize:  in.MaxBodySize,
+	}
+
+	if m.ttl <= 0 {
+		m.ttl = DefaultIdempotencyTtl
+	}
+
+	if m.lockTtl <= 0 {
+		m.lockTtl = DefaultIdempotencyLockTtl
+	}
+
+	if m.pollInterval <= 0 {
+		m.pollInterval = 100 * time.Millisecond
+	}
+
+	if m.maxBodySize <= 0 {
+		m.maxBodySize = DefaultIdempotencyMaxBodySize
+	}
+
+	return m
+}
+
+// idempotentResponse is a response stored for replays.
+type idempotentResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+func (m *Idempotency) Handler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r, ps)
+			return
+		}
+
+		if err := validateIdempotencyKey(key); err != nil {
+			arc.HttpApiErrorResponse(m.arcDomain, w, r, "", errors.Forward(err, "c4bd3c4c-a163-4a03-a162-5a4bc4b8cbfb"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.maxBodySize))
+		if err != nil {
+			if _, ok := err.(*http.MaxBytesError); ok {
+				arc.HttpApiErrorResponse(m.arcDomain, w, r, "", &errors.Object{
+					Id:     "25e55ece-0e67-47c5-b4ae-7fed4127ae15",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Request body is too large.",
+					Meta: map[string]any{
+						"max_size": m.maxBodySize,
+					},
+				})
+				return
+			}
+
+			arc.HttpApiErrorResponse(m.arcDomain, w, r, "", &errors.Object{
+				Id:     "f8d0d9d6-d878-410b-974b-c270070f4c01",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Failed to read request body.",
+				Cause:  err.Error(),
+				Err:    err,
+			})
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := idempotencyStoreKey(r, key)
+		fingerprint := idempotencyFingerprint(r, body)
+		owner := uuid.NewString()
+
+		replay, err := m.begin(r.Context(), storeKey, fingerprint, owner)
+		if err != nil {
+			arc.HttpApiErrorResponse(m.arcDomain, w, r, "", errors.Forward(err, "ac9dc3ba-6501-43ac-b6a6-e932f280d294"))
+			return
+		}
+
+		if replay != nil {
+			for k, values := range replay.Header {
+				w.Header()[k] = values
+			}
+
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(replay.Status)
+
+			if _, err := w.Write(replay.Body); err != nil {
+				log.Error().
+					Str("id", "f8e9cb36-1f5d-480f-9a62-00a11ff00dd7").
+					Err(err).
+					Msg("Failed to write replayed response.")
+			}
+
+			return
+		}
+
+		call := &arc.IdempotentCall{Key: key}
+
+		rw := &bufferedResponseWriter{
+			header: make(http.Header),
+			status: http.StatusOK,
+		}
+
+		// Extend the lock while the request runs, so that a slow
+		// request does not let a retry run concurrently. If the
+		// lock is lost anyway, the request is canceled.
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+		heartbeatDone := make(chan struct{})
+
+		go func() {
+			defer close(heartbeatDone)
+			m.heartbeat(heartbeatCtx, storeKey, owner, cancel)
+		}()
+
+		next(rw, r.WithContext(arc.WithIdempotentCall(ctx, call)), ps)
+
+		stopHeartbeat()
+		<-heartbeatDone
+
+		// The response is written even if it could not be stored.
+		// Use a context that outlives a canceled request, so the
+		// key is not left locked until its lock expires.
+		ctx = context.WithoutCancel(r.Context())
+
+		if isStorableIdempotentStatus(rw.status) {
+			err = m.complete(ctx, storeKey, owner, call, &idempotentResponse{
+				Status: rw.status,
+				Header: rw.header,
+				Body:   rw.body.Bytes(),
+			})
+		} else {
+			err = m.release(ctx, storeKey, owner)
+		}
+
+		if err != nil {
+			log.Error().
+				Str("id", "6a90be13-9fcb-44c1-8811-9ccf8000bc8b").
+				Err(err).
+				Str("path", r.URL.Path).
+				Msg("Failed to store idempotent response.")
+		}
+
+		for k, values := range rw.header {
+			w.Header()[k] = values
+		}
+
+		w.WriteHeader(rw.status)
+
+		if _, err := w.Write(rw.body.Bytes()); err != nil {
+			log.Error().
+				Str("id", "beb46032-74c5-4add-8063-03500ddd3b20").
+				Err(err).
+				Msg("Failed to write response.")
+		}
+	}
+}
+
+// begin acquires the key. It returns the stored response if the
+// request was already completed, or nil if the caller now owns the
+// key. When another request owns the key, begin waits until it
+// completes or its lock expires.
+func (m *Idempotency) begin(ctx context.Context, storeKey, fingerprint, owner string) (*idempotentResponse, error) {
+	valkeyCli, err := m.arcDomain.SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return nil, errors.Forward(err, "f5740884-7ff2-4f1f-a3a7-fb7153f07b70")
+	}
+
+	script, err := m.arcDomain.SelectValkeyScript("begin-idempotent-request")
+	if err != nil {
+		return nil, errors.Forward(err, "eb3af21c-e8f1-4659-9c41-e192ff458704")
+	}
+
+	args := []string{
+		fingerprint,
+		owner,
+		strconv.FormatInt(m.lockTtl.Milliseconds(), 10),
+	}
+
+	deadline := time.Now().Add(m.lockTtl)
+
+	for {
+		values, err := script.Exec(ctx, valkeyCli, []string{storeKey}, args).ToArray()
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "a4e5dd2e-6300-4695-9cee-8278a47d78fa",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to execute script.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		state, err := valkeyString(values, 0)
+		if err != nil {
+			return nil, errors.Forward(err, "8d2bd9de-82eb-4d9e-b5a0-cdebc951c37a")
+		}
+
+		switch state {
+		case "STARTED":
+			return nil, nil
+		case "MISMATCH":
+			return nil, &errors.Object{
+				Id:     "6db96430-d33c-499f-8214-c8f0cd4e59ca",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Label:  "IDEMPOTENCY_KEY_REUSED",
+				Detail: "The idempotency key was already used for another request.",
+				Path:   "/header/" + IdempotencyKeyHeader,
+			}
+		case "IN_PROGRESS":
+			if time.Now().After(deadline) {
+				return nil, &errors.Object{
+					Id:     "3495a66e-45be-4fb2-ae74-90d459d63267",
+					Code:   errors.Code_ABORTED,
+					Label:  "IDEMPOTENCY_KEY_IN_USE",
+					Detail: "A request with the same idempotency key is still in progress.",
+					Path:   "/header/" + IdempotencyKeyHeader,
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, &errors.Object{
+					Id:     "76451bbb-49ad-48a6-a946-52d2b80261fc",
+					Code:   errors.Code_CANCELED,
+					Detail: "Request canceled.",
+					Cause:  ctx.Err().Error(),
+					Err:    ctx.Err(),
+				}
+			case <-time.After(m.pollInterval):
+			}
+		case "DONE":
+			return parseIdempotentResponse(values)
+		default:
+			return nil, &errors.Object{
+				Id:     "d78bdffe-ee1b-41ac-967a-bf9b6d51fe7e",
+				Code:   errors.Code_INTERNAL,
+				Detail: "Unexpected script output.",
+				Meta: map[string]any{
+					"state": state,
+				},
+			}
+		}
+	}
+}
+
+// heartbeat extends the lock of the key every third of its TTL,
+// until ctx is done. It calls lost if the caller no longer owns
+// the key.
+func (m *Idempotency) heartbeat(ctx context.Context, storeKey, owner string, lost context.CancelFunc) {
+	ticker := time.NewTicker(m.lockTtl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		extended, err := m.extend(ctx, storeKey, owner)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Retry on the next tick, before the lock expires.
+			log.Error().
+				Str("id", "ec26c39d-428c-4382-bd59-c76c7aada42b").
+				Err(err).
+				Msg("Failed to extend idempotency key lock.")
+
+			continue
+		}
+
+		if !extended {
+			log.Warn().
+				Str("id", "5052830e-e4f9-44f3-bd13-02a834638216").
+				Msg("Lost idempotency key lock.")
+
+			lost()
+
+			return
+		}
+	}
+}
+
+// extend extends the lock of the key. It returns false if the
+// caller no longer owns the key.
+func (m *Idempotency) extend(ctx context.Context, storeKey, owner string) (bool, error) {
+	valkeyCli, err := m.arcDomain.SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return false, errors.Forward(err, "ceca8780-4e07-4972-a99c-49fe9fc9bfc5")
+	}
+
+	script, err := m.arcDomain.SelectValkeyScript("extend-idempotent-request")
+	if err != nil {
+		return false, errors.Forward(err, "6491517a-3417-4cd6-9861-a62c5c9bba3b")
+	}
+
+	extended, err := script.Exec(ctx, valkeyCli, []string{storeKey}, []string{
+		owner,
+		strconv.FormatInt(m.lockTtl.Milliseconds(), 10),
+	}).AsInt64()
+	if err != nil {
+		return false, &errors.Object{
+			Id:     "fb6c6305-554d-4c63-aca0-22e306605743",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return extended == 1, nil
+}
+
+// complete stores the response and the quota transactions of the call.
+func (m *Idempotency) complete(ctx context.Context, storeKey, owner string, call *arc.IdempotentCall, response *idempotentResponse) error {
+	valkeyCli, err := m.arcDomain.SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return errors.Forward(err, "7b059304-6c97-4038-8bd0-7ab5fade3d54")
+	}
+
+	script, err := m.arcDomain.SelectValkeyScript("complete-idempotent-request")
+	if err != nil {
+		return errors.Forward(err, "e6448200-3608-42f6-9f9c-8ba92b9ff3b8")
+	}
+
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return &errors.Object{
+			Id:     "14480d90-bf71-44b9-8649-2535ecd52fb8",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Failed to encode response headers.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	transactionIds, err := json.Marshal(call.TransactionIds())
+	if err != nil {
+		return &errors.Object{
+			Id:     "1e8b0a62-3889-46a9-81e8-c668217152c4",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Failed to encode transaction ids.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	stored, err := script.Exec(ctx, valkeyCli, []string{storeKey}, []string{
+		owner,
+		strconv.Itoa(response.Status),
+		string(header),
+		string(response.Body),
+		string(transactionIds),
+		strconv.FormatInt(m.ttl.Milliseconds(), 10),
+	}).AsInt64()
+	if err != nil {
+		return &errors.Object{
+			Id:     "449167d4-0078-4ec4-bdfa-8c2ef2990d5f",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if stored == 0 {
+		return &errors.Object{
+			Id:     "51b6678b-3bc9-4d95-81b5-56732abf8d59",
+			Code:   errors.Code_ABORTED,
+			Detail: "The idempotency key lock expired before the response was stored.",
+		}
+	}
+
+	return nil
+}
+
+// release deletes the key, so the request can be retried.
+func (m *Idempotency) release(ctx context.Context, storeKey, owner string) error {
+	valkeyCli, err := m.arcDomain.SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return errors.Forward(err, "d7e2f082-455f-4bbf-b97e-8f45bfe7a0f8")
+	}
+
+	script, err := m.arcDomain.SelectValkeyScript("release-idempotent-request")
+	if err != nil {
+		return errors.Forward(err, "293ea3b0-7bfb-4ba3-8072-f1b8d106785a")
+	}
+
+	if err := script.Exec(ctx, valkeyCli, []string{storeKey}, []string{owner}).Error(); err != nil {
+		return &errors.Object{
+			Id:     "16685ef5-0da7-4726-877d-bbcb0e70e488",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+func validateIdempotencyKey(key string) error {
+	if len(key) > MaxIdempotencyKeyLength {
+		return &errors.Object{
+			Id:     "747b2ffb-575a-4e62-a868-172507a913ae",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Idempotency key is too long.",
+			Path:   "/header/" + IdempotencyKeyHeader,
+			Meta: map[string]any{
+				"max_length": MaxIdempotencyKeyLength,
+			},
+		}
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return &errors.Object{
+				Id:     "957a63ed-4771-498b-8d53-8ed4bc13964c",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Idempotency key must only contain printable ASCII characters.",
+				Path:   "/header/" + IdempotencyKeyHeader,
+			}
+		}
+	}
+
+	return nil
+}
+
+// idempotencyStoreKey scopes the key to the credentials and the route,
+// so that keys of different clients or endpoints never collide.
+func idempotencyStoreKey(r *http.Request, key string) string {
+	h := sha256.New()
+
+	for _, v := range []string{
+		r.Header.Get("Authorization"),
+		r.Method,
+		r.URL.Path,
+		key,
+	} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return "idem:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyFingerprint identifies the request sent with a key,
+// to reject the reuse of the key for another request.
+func idempotencyFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+
+	h.Write([]byte(r.URL.RawQuery))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isStorableIdempotentStatus reports whether responses with the
+// status are stored. Only final 2xx and 4xx responses are stored.
+// Authentication and permission failures, including exhausted
+// quotas, conflicts, timeouts, rate limits and canceled requests
+// depend on transient state.
+func isStorableIdempotentStatus(status int) bool {
+	switch status {
+	case http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusRequestTimeout,
+		http.StatusConflict,
+		http.StatusLocked,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests,
+		statusClientClosedRequest:
+		return false
+	}
+
+	return (status >= 200 && status < 300) || (status >= 400 && status < 500)
+}
+
+func parseIdempotentResponse(values []valkey.ValkeyMessage) (*idempotentResponse, error) {
+	status, err := valkeyString(values, 1)
+	if err != nil {
+		return nil, errors.Forward(err, "f3d44492-f630-496f-940f-1abe43d75742")
+	}
+
+	header, err := valkeyString(values, 2)
+	if err != nil {
+		return nil, errors.Forward(err, "60ff0466-e12f-4b35-abb5-5f657369f6d0")
+	}
+
+	body, err := valkeyString(values, 3)
+	if err != nil {
+		return nil, errors.Forward(err, "50845db2-d9dc-4da4-9e0a-e543f26fd49e")
+	}
+
+	out := &idempotentResponse{
+		Body: []byte(body),
+	}
+
+	out.Status, err = strconv.Atoi(status)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "66f539e3-ea62-4ab4-8a83-fcf3dafec3c1",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Invalid stored response status.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := json.Unmarshal([]byte(header), &out.Header); err != nil {
+		return nil, &errors.Object{
+			Id:     "555afde8-4146-4f26-9206-392c0d5cfbaa",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Invalid stored response headers.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+func valkeyString(values []valkey.ValkeyMessage, i int) (string, error) {
+	if i >= len(values) {
+		return "", &errors.Object{
+			Id:     "020a5a4d-f59b-4676-9957-493fe12fd722",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Script output length mismatch.",
+		}
+	}
+
+	v, err := values[i].ToString()
+	if err != nil {
+		return "", &errors.Object{
+			Id:     "0a659f6c-302c-4ac6-9b3b-5a26161da808",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Invalid script output.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+
+	"abodemine/domains/arc"
+	"abodemine/projects/api/conf"
+)
+
+func TestIdempotencyStoreKey(t *testing.T) {
+	newRequest := func(auth, path string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, path, nil)
+		r.Header.Set("Authorization", auth)
+		return r
+	}
+
+	key := idempotencyStoreKey(newRequest("Bearer a", "/api/v3/search"), "k1")
+
+	assert.True(t, strings.HasPrefix(key, "idem:"))
+	assert.Equal(t, key, idempotencyStoreKey(newRequest("Bearer a", "/api/v3/search"), "k1"))
+
+	// Keys are scoped to the credentials and the route.
+	assert.NotEqual(t, key, idempotencyStoreKey(newRequest("Bearer b", "/api/v3/search"), "k1"))
+	assert.NotEqual(t, key, idempotencyStoreKey(newRequest("Bearer a", "/api/v3/listings"), "k1"))
+	assert.NotEqual(t, key, idempotencyStoreKey(newRequest("Bearer a", "/api/v3/search"), "k2"))
+}
+
+func TestIdempotencyFingerprint(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v3/search?limit=1", nil)
+
+	fingerprint := idempotencyFingerprint(r, []byte(`{"aupid":"a"}`))
+
+	assert.Equal(t, fingerprint, idempotencyFingerprint(r, []byte(`{"aupid":"a"}`)))
+	assert.NotEqual(t, fingerprint, idempotencyFingerprint(r, []byte(`{"aupid":"b"}`)))
+
+	r = httptest.NewRequest(http.MethodPost, "/api/v3/search?limit=2", nil)
+	assert.NotEqual(t, fingerprint, idempotencyFingerprint(r, []byte(`{"aupid":"a"}`)))
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	assert.NoError(t, validateIdempotencyKey(uuid.NewString()))
+	assert.NoError(t, validateIdempotencyKey(strings.Repeat("a", MaxIdempotencyKeyLength)))
+	assert.Error(t, validateIdempotencyKey(strings.Repeat("a", MaxIdempotencyKeyLength+1)))
+	assert.Error(t, validateIdempotencyKey("key\x00"))
+	assert.Error(t, validateIdempotencyKey("clé"))
+}
+
+func TestIsStorableIdempotentStatus(t *testing.T) {
+	for status, expected := range map[int]bool{
+		http.StatusOK:                  true,
+		http.StatusCreated:             true,
+		http.StatusBadRequest:          true,
+		http.StatusNotFound:            true,
+		http.StatusFound:               false,
+		http.StatusUnauthorized:        false,
+		http.StatusForbidden:           false,
+		http.StatusConflict:            false,
+		http.StatusTooManyRequests:     false,
+		statusClientClosedRequest:      false,
+		http.StatusInternalServerError: false,
+		http.StatusServiceUnavailable:  false,
+	} {
+		assert.Equal(t, expected, isStorableIdempotentStatus(status), status)
+	}
+}
+
+func TestIdempotency_Handler_MaxBodySize(t *testing.T) {
+	m := NewIdempotency(&NewIdempotencyInput{
+		ArcDomain:   arc.NewDomain(&arc.NewDomainInput{}),
+		MaxBodySize: 8,
+	})
+
+	handler := m.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		t.Error("The handler must not be called.")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v3/search", strings.NewReader(`{"aupid":"a"}`))
+	r.Header.Set(IdempotencyKeyHeader, uuid.NewString())
+
+	w := httptest.NewRecorder()
+	handler(w, r, nil)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Request body is too large.")
+}
+
+/*
+GO_TEST_COUNT=1 \
+GO_TEST_PARAMS="-v" \
+make -C ${ABODEMINE_WORKSPACE}/code/go/abodemine \
+test/abodemine/middleware \
+RUN="TestIdempotency_Handler"
+*/
+func TestIdempotency_Handler(t *testing.T) {
+	if os.Getenv("RUN") != t.Name() {
+		t.Skip("This test MUST be selected manually.")
+	}
+
+	config := conf.MustResolveAndLoadOnce()
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		Valkey:       config.Valkey,
+		ValkeyScript: config.ValkeyScript,
+	})
+
+	m := NewIdempotency(&NewIdempotencyInput{
+		ArcDomain:    arcDomain,
+		Ttl:          time.Minute,
+		LockTtl:      5 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+	})
+
+	var calls atomic.Int32
+
+	handler := m.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		calls.Add(1)
+
+		call := arc.IdempotentCallFromContext(r.Context())
+		if assert.NotNil(t, call) {
+			call.AddTransaction(uuid.New())
+		}
+
+		// Hold the key so concurrent requests must wait.
+		time.Sleep(100 * time.Millisecond)
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+
+	// New keys on every run, so no state is left from previous runs.
+	auth := "Bearer " + uuid.NewString()
+	key := uuid.NewString()
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v3/search", strings.NewReader(body))
+		r.Header.Set("Authorization", auth)
+		r.Header.Set(IdempotencyKeyHeader, key)
+
+		w := httptest.NewRecorder()
+		handler(w, r, nil)
+
+		return w
+	}
+
+	var wg sync.WaitGroup
+
+	responses := make([]*httptest.ResponseRecorder, 3)
+
+	for i := range responses {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			responses[i] = send(key, `{"q":1}`)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	replayed := 0
+
+	for _, w := range responses {
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `{"q":1}`, w.Body.String())
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		if w.Header().Get(IdempotentReplayedHeader) == "true" {
+			replayed++
+		}
+	}
+
+	assert.Equal(t, 2, replayed)
+
+	// The key can't be reused for another request.
+	w := send(key, `{"q":2}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Requests without a key are not stored.
+	send("", `{"q":1}`)
+	send("", `{"q":1}`)
+	assert.Equal(t, int32(3), calls.Load())
+}
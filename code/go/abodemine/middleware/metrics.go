@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/metrics"
+)
+
+// Metrics records the calls of a server as Prometheus metrics
+// and zerolog access lines.
+type Metrics struct {
+	server string
+
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	responseBytes *prometheus.HistogramVec
+	quotaCharge   *prometheus.CounterVec
+}
+
+type NewMetricsInput struct {
+	// Server is the value of the server label, such as api or saas.
+	Server     string
+	Registerer prometheus.Registerer
+}
+
+func NewMetrics(in *NewMetricsInput) *Metrics {
+	labels := []string{"server", "method", "route", "status"}
+
+	m := &Metrics{
+		server: in.Server,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the HTTP requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		responseBytes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "response_bytes",
+			Help:      "Size of the HTTP responses.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 8),
+		}, labels),
+		// The organization is not a label, its cardinality is unbounded.
+		quotaCharge: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "quota_charge_total",
+			Help:      "Quota charged to the api keys.",
+		}, []string{"server", "route"}),
+	}
+
+	in.Registerer.MustRegister(
+		m.requests,
+		m.duration,
+		m.responseBytes,
+		m.quotaCharge,
+	)
+
+	return m
+}
+
+// accessWriter records the status and size of a response.
+type accessWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+	bytes      int64
+}
+
+func (w *accessWriter) WriteHeader(code int) {
+	if w.statusCode == 0 {
+		w.statusCode = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *accessWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+
+	return n, err
+}
+
+// Handler wraps an httprouter.Handle to record its calls. It
+// SHOULD wrap GzipHandler so the sizes are the ones sent.
+func (m *Metrics) Handler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		start := time.Now()
+		record := new(arc.AccessRecord)
+		aw := &accessWriter{ResponseWriter: w}
+
+		next(aw, r.WithContext(arc.WithAccessRecord(r.Context(), record)), ps)
+
+		duration := time.Since(start)
+		route := routeOf(r.URL.Path, ps)
+
+		if aw.statusCode == 0 {
+			aw.statusCode = http.StatusOK
+		}
+
+		status := strconv.Itoa(aw.statusCode)
+
+		m.requests.WithLabelValues(m.server, r.Method, route, status).Inc()
+		m.duration.WithLabelValues(m.server, r.Method, route, status).Observe(duration.Seconds())
+		m.responseBytes.WithLabelValues(m.server, r.Method, route, status).Observe(float64(aw.bytes))
+
+		quotaCharge := record.QuotaCharge()
+
+		if quotaCharge > 0 {
+			m.quotaCharge.WithLabelValues(m.server, route).Add(float64(quotaCharge))
+		}
+
+		event := log.Info()
+
+		if aw.statusCode >= 500 {
+			event = log.Error()
+		}
+
+		event = event.
+			Str("server", m.server).
+			Str("method", r.Method).
+			Str("route", route).
+			Str("path", r.URL.Path).
+			Int("status", aw.statusCode).
+			Int64("bytes", aw.bytes).
+			Float64("duration_ms", float64(duration.Microseconds())/1000.).
+			Str("remote_addr", r.RemoteAddr)
+
+		if v := arc.RequestIdFromContext(r.Context()); v != uuid.Nil {
+			event = event.Str("request_id", v.String())
+		}
+
+		if v := record.OrganizationId(); v != uuid.Nil {
+			event = event.Str("organization_id", v.String())
+		}
+
+		if v := record.KeyId(); v != uuid.Nil {
+			event = event.Str("api_key_id", v.String())
+		}
+
+		if quotaCharge > 0 {
+			event = event.Int64("quota_charge", quotaCharge)
+		}
+
+		event.Msg("Access.")
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+)
+
+func TestRouteOf(t *testing.T) {
+	ps := httprouter.Params{
+		{Key: "userId", Value: "42"},
+		{Key: "sessionId", Value: "abc"},
+	}
+
+	assert.Equal(t, "/api/admin/users/:userId/sessions/:sessionId", routeOf("/api/admin/users/42/sessions/abc", ps))
+	assert.Equal(t, "/api/listings", routeOf("/api/listings", nil))
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	m := NewMetrics(&NewMetricsInput{
+		Server:     "api",
+		Registerer: registry,
+	})
+
+	handler := m.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		record := arc.AccessRecordFromContext(r.Context())
+		require.NotNil(t, record)
+
+		record.AddQuotaCharge(3)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})
+
+	for range 2 {
+		handler(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/api/items/7", nil),
+			httprouter.Params{{Key: "id", Value: "7"}},
+		)
+	}
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	byName := make(map[string]*dto.MetricFamily)
+
+	for _, family := range families {
+		byName[family.GetName()] = family
+	}
+
+	requests := byName["abodemine_http_requests_total"]
+	require.NotNil(t, requests)
+	require.Len(t, requests.Metric, 1)
+	assert.Equal(t, float64(2), requests.Metric[0].GetCounter().GetValue())
+
+	labels := make(map[string]string)
+
+	for _, label := range requests.Metric[0].Label {
+		labels[label.GetName()] = label.GetValue()
+	}
+
+	assert.Equal(t, map[string]string{
+		"server": "api",
+		"method": http.MethodGet,
+		"route":  "/api/items/:id",
+		"status": "201",
+	}, labels)
+
+	responseBytes := byName["abodemine_http_response_bytes"]
+	require.NotNil(t, responseBytes)
+	assert.Equal(t, float64(10), responseBytes.Metric[0].GetHistogram().GetSampleSum())
+
+	quotaCharge := byName["abodemine_http_quota_charge_total"]
+	require.NotNil(t, quotaCharge)
+	assert.Equal(t, float64(6), quotaCharge.Metric[0].GetCounter().GetValue())
+}
@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+)
+
+func init() {
+	// Uploaded files are validated as opaque strings and parsed by
+	// the handlers. Parts with types kin-openapi does not know, e.g.
+	// NDJSON files, would otherwise be rejected, and its CSV decoder
+	// is stricter than ours about quotes and ragged rows.
+	for _, contentType := range []string{
+		"application/jsonl",
+		"application/vnd.ms-excel",
+		"application/x-ndjson",
+		"text/csv",
+	} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+// OpenApiValidator validates requests, and optionally responses,
+// against an OpenAPI spec before they reach the wrapped handlers.
+type OpenApiValidator struct {
+	arcDomain arc.Domain
+	router    routers.Router
+
+	validateResponses bool
+	onResponseError   func(r *http.Request, err error)
+}
+
+type NewOpenApiValidatorInput struct {
+	ArcDomain arc.Domain
+
+	// Requests are passed through unchanged when Spec is nil.
+	Spec *openapi3.T
+
+	// ValidateResponses enables the test mode, where responses
+	// are buffered and checked against the declared schemas.
+	ValidateResponses bool
+
+	// OnResponseError is called for every response that does not
+	// match the spec. Defaults to logging the error.
+	OnResponseError func(r *http.Request, err error)
+}
+
+func NewOpenApiValidator(in *NewOpenApiValidatorInput) (*OpenApiValidator, error) {
+	v := &OpenApiValidator{
+		arcDomain:         in.ArcDomain,
+		validateResponses: in.ValidateResponses,
+		onResponseError:   in.OnResponseError,
+	}
+
+	if v.onResponseError == nil {
+		v.onResponseError = func(r *http.Request, err error) {
+			log.Error().
+				Err(err).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Msg("Response does not match the OpenAPI spec.")
+		}
+	}
+
+	if in.Spec == nil {
+		return v, nil
+	}
+
+	// Match routes by path only, since the scheme and host
+	// seen by the server differ from the public ones.
+	spec := *in.Spec
+	spec.Servers = nil
+
+	for _, server := range in.Spec.Servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "f6cc1bde-f39b-4960-982d-a99b702eba10",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid OpenAPI server url.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		spec.Servers = append(spec.Servers, &openapi3.Server{URL: u.Path})
+	}
+
+	router, err := gorillamux.NewRouter(&spec)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "e9ac81ec-253f-4181-956c-cbf0b923c45a",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to create OpenAPI router.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	v.router = router
+
+	return v, nil
+}
+
+func (v *OpenApiValidator) Handler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if v.router == nil {
+			next(w, r, ps)
+			return
+		}
+
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			// Routes missing from the spec are not validated.
+			next(w, r, ps)
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// Authentication is handled by the handlers.
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				// Handlers must see the request as sent.
+				SkipSettingDefaults: true,
+			},
+		}
+
+		// The request body is restored by the validator.
+		if err := openapi3filter.ValidateRequest(r.Context(), requestInput); err != nil {
+			arc.HttpApiErrorResponse(v.arcDomain, w, r, "", requestValidationError(err))
+			return
+		}
+
+		if !v.validateResponses {
+			next(w, r, ps)
+			return
+		}
+
+		rw := &bufferedResponseWriter{
+			header: make(http.Header),
+			status: http.StatusOK,
+		}
+
+		next(rw, r, ps)
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 rw.status,
+			Header:                 rw.header,
+			Body:                   io.NopCloser(bytes.NewReader(rw.body.Bytes())),
+			Options: &openapi3filter.Options{
+				IncludeResponseStatus: true,
+			},
+		}
+
+		if err := openapi3filter.ValidateResponse(r.Context(), responseInput); err != nil {
+			v.onResponseError(r, err)
+		}
+
+		for k, values := range rw.header {
+			w.Header()[k] = values
+		}
+
+		w.WriteHeader(rw.status)
+
+		if _, err := w.Write(rw.body.Bytes()); err != nil {
+			log.Error().
+				Str("id", "8f1c7e65-f1be-4def-a9f3-8f948a22a7e1").
+				Err(err).
+				Msg("Failed to write buffered response.")
+		}
+	}
+}
+
+// requestValidationError maps a kin-openapi validation error to
+// an INVALID_ARGUMENT error, where Path is a JSON pointer to the
+// offending body field, or /<in>/<name> for parameters.
+func requestValidationError(err error) error {
+	out := &errors.Object{
+		Id:     "641bb72b-08a3-4779-86cc-418ff393426c",
+		Code:   errors.Code_INVALID_ARGUMENT,
+		Detail: "Invalid request.",
+		Cause:  err.Error(),
+		Err:    err,
+	}
+
+	requestErr, ok := err.(*openapi3filter.RequestError)
+	if !ok {
+		return out
+	}
+
+	out.Detail = requestErr.Reason
+
+	var pointer []string
+
+	if schemaErr, ok := requestErr.Err.(*openapi3.SchemaError); ok {
+		pointer = schemaErr.JSONPointer()
+		out.Detail = schemaErr.Reason
+	}
+
+	if requestErr.Parameter != nil {
+		pointer = append([]string{requestErr.Parameter.In, requestErr.Parameter.Name}, pointer...)
+
+		if out.Detail == "" {
+			out.Detail = "Invalid parameter."
+		}
+	}
+
+	if out.Detail == "" {
+		out.Detail = "Invalid request body."
+	}
+
+	out.Path = jsonPointer(pointer)
+
+	return out
+}
+
+// jsonPointer builds an RFC 6901 JSON pointer from its tokens.
+func jsonPointer(tokens []string) string {
+	if len(tokens) == 0 {
+		return "/"
+	}
+
+	b := new(strings.Builder)
+
+	for _, token := range tokens {
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+
+		b.WriteString("/" + token)
+	}
+
+	return b.String()
+}
+
+// bufferedResponseWriter keeps the response in memory
+// so it can be validated before being written.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *bufferedResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return rw.body.Write(b)
+}
+
+func (rw *bufferedResponseWriter) WriteHeader(code int) {
+	rw.status = code
+}
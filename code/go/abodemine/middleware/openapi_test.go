@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+)
+
+const testOpenApiSpec = `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/api/v3
+paths:
+  /items:
+    post:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 10
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required:
+                - name
+              properties:
+                name:
+                  type: string
+                tags:
+                  type: array
+                  items:
+                    type: string
+                    maxLength: 3
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required:
+                  - data
+                properties:
+                  data:
+                    type: object
+                    required:
+                      - id
+                    properties:
+                      id:
+                        type: string
+`
+
+type testApiResponse struct {
+	Errors []*errors.Object `json:"errors"`
+}
+
+func newTestOpenApiValidator(t *testing.T, onResponseError func(r *http.Request, err error)) *OpenApiValidator {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(testOpenApiSpec))
+	require.NoError(t, err)
+
+	v, err := NewOpenApiValidator(&NewOpenApiValidatorInput{
+		ArcDomain:         arc.NewDomain(&arc.NewDomainInput{}),
+		Spec:              spec,
+		ValidateResponses: onResponseError != nil,
+		OnResponseError:   onResponseError,
+	})
+	require.NoError(t, err)
+
+	return v
+}
+
+func serveTestRequest(handle httprouter.Handle, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handle(w, r, nil)
+
+	return w
+}
+
+func TestOpenApiValidator_Request(t *testing.T) {
+	v := newTestOpenApiValidator(t, nil)
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		path   string
+	}{
+		{
+			name:   "Valid",
+			target: "/api/v3/items?limit=5",
+			body:   `{"name": "a", "tags": ["abc"]}`,
+		},
+		{
+			name:   "Missing Required Property",
+			target: "/api/v3/items",
+			body:   `{"tags": []}`,
+			path:   "/name",
+		},
+		{
+			name:   "Invalid Nested Property",
+			target: "/api/v3/items",
+			body:   `{"name": "a", "tags": ["abc", "abcd"]}`,
+			path:   "/tags/1",
+		},
+		{
+			name:   "Invalid Query Parameter",
+			target: "/api/v3/items?limit=50",
+			body:   `{"name": "a"}`,
+			path:   "/query/limit",
+		},
+		{
+			name:   "Malformed Body",
+			target: "/api/v3/items",
+			body:   `{"name":`,
+			path:   "/",
+		},
+		{
+			name:   "Route Not In Spec",
+			target: "/api/v3/other",
+			body:   `{"name":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+
+			handle := v.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+				called = true
+
+				// Handlers must still be able to read the body.
+				b, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.body, string(b))
+
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			w := serveTestRequest(handle, tt.target, tt.body)
+
+			if tt.path == "" {
+				assert.True(t, called)
+				assert.Equal(t, http.StatusNoContent, w.Code)
+				return
+			}
+
+			assert.False(t, called)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			out := &testApiResponse{}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(out))
+			require.Len(t, out.Errors, 1)
+			assert.Equal(t, errors.Code_INVALID_ARGUMENT, out.Errors[0].Code)
+			assert.Equal(t, tt.path, out.Errors[0].Path)
+		})
+	}
+}
+
+func TestOpenApiValidator_Response(t *testing.T) {
+	var responseErrs []error
+
+	v := newTestOpenApiValidator(t, func(_ *http.Request, err error) {
+		responseErrs = append(responseErrs, err)
+	})
+
+	tests := []struct {
+		name    string
+		data    string
+		isValid bool
+	}{
+		{
+			name:    "Valid",
+			data:    `{"data": {"id": "1"}}`,
+			isValid: true,
+		},
+		{
+			name: "Missing Required Property",
+			data: `{"data": {}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseErrs = nil
+
+			handle := v.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.data))
+			})
+
+			w := serveTestRequest(handle, "/api/v3/items", `{"name": "a"}`)
+
+			// The response is always written through unchanged.
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.data, w.Body.String())
+			assert.Equal(t, tt.isValid, len(responseErrs) == 0)
+		})
+	}
+}
+
+func TestOpenApiValidator_PublishedSpec(t *testing.T) {
+	spec, err := gconf.LoadOpenApi(&gconf.OpenApi{
+		Spec: "../../../../docs/projects/api/abodemine-openapi-3.0.0.yaml",
+	})
+	require.NoError(t, err)
+
+	v, err := NewOpenApiValidator(&NewOpenApiValidatorInput{
+		ArcDomain: arc.NewDomain(&arc.NewDomainInput{}),
+		Spec:      spec,
+	})
+	require.NoError(t, err)
+
+	handle := v.Handler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	w := serveTestRequest(handle, "/api/v3/market-stats", `{"interval": "year", "startDate": "2025-01-01", "endDate": "2025-02-01"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	out := &testApiResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(out))
+	require.Len(t, out.Errors, 1)
+	assert.Equal(t, "/interval", out.Errors[0].Path)
+
+	// Uploaded files are accepted whatever their content type.
+	uploads := map[string]string{
+		"text/csv":                 "aupid,id\n\"0195f3c4-1b2a-7c3d-8e4f-5a6b7c8d9e0f\"\n",
+		"application/x-ndjson":     "{\"aupid\": \"0195f3c4-1b2a-7c3d-8e4f-5a6b7c8d9e0f\"}\n",
+		"application/octet-stream": "{\"aupid\": \"0195f3c4-1b2a-7c3d-8e4f-5a6b7c8d9e0f\"}\n",
+	}
+
+	for contentType, data := range uploads {
+		body := new(bytes.Buffer)
+		mw := multipart.NewWriter(body)
+
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {`form-data; name="file"; filename="input"`},
+			"Content-Type":        {contentType},
+		})
+		require.NoError(t, err)
+
+		_, err = part.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, mw.WriteField("layouts", "address,assessor"))
+		require.NoError(t, mw.Close())
+
+		r := httptest.NewRequest(http.MethodPost, "/api/v3/bulk-jobs", body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		w := httptest.NewRecorder()
+		handle(w, r, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, contentType)
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/logging"
+)
+
+// ErrorReportHandler wraps an httprouter.Handle
+// to report its 5xx responses to the error reporter,
+// with the error recorded by arc.HttpApiErrorResponse.
+func ErrorReportHandler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		startTime := time.Now()
+
+		record := new(arc.ErrorRecord)
+		r = r.WithContext(arc.WithErrorRecord(r.Context(), record))
+
+		rw := &ResposeWriter{
+			StatusCode:     http.StatusOK,
+			ResponseWriter: w,
+		}
+
+		next(rw, r, ps)
+
+		if rw.StatusCode < 500 {
+			return
+		}
+
+		// Calculate the duration in milliseconds, using
+		// float64 to avoid rounded results.
+		durationMs := float64(time.Since(startTime).Microseconds()) / 1000.
+
+		event := &logging.Event{
+			Tags: map[string]string{
+				"http.method": r.Method,
+				"http.route":  routeOf(r.URL.Path, ps),
+			},
+			Extra: map[string]any{
+				"status_code": rw.StatusCode,
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"duration_ms": durationMs,
+			},
+		}
+
+		if id := arc.RequestIdFromContext(r.Context()); id != uuid.Nil {
+			event.RequestId = id.String()
+		}
+
+		// The chain groups the events of a same error, and its
+		// meta is scrubbed by the filtered reporter.
+		event.SetError(record.Err())
+
+		if event.Message == "" {
+			event.Message = http.StatusText(rw.StatusCode)
+		}
+
+		logging.Report(r.Context(), event)
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/lib/logging"
+)
+
+func TestErrorReportHandler(t *testing.T) {
+	memory := logging.NewMemoryReporter()
+
+	previous := logging.CurrentReporter()
+	logging.SetReporter(logging.NewFilteredReporter(&logging.NewFilteredReporterInput{
+		Reporter: memory,
+	}))
+	t.Cleanup(func() { logging.SetReporter(previous) })
+
+	handler := ErrorReportHandler(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		arc.HttpApiErrorResponse(nil, w, r, "", errors.Forward(&errors.Object{
+			Id:     "b216637c-bb17-4bdc-80c1-b8f483a764c8",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Failed to send.",
+			Cause:  "connection reset",
+			Meta: map[string]any{
+				"email": "jane@example.com",
+			},
+		}, "77968879-e8fd-44c9-8007-caececf8882a"))
+	})
+
+	// The second error of the same id is dropped.
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/api/items/7", nil), httprouter.Params{{Key: "id", Value: "7"}})
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	}
+
+	events := memory.Events()
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, "b216637c-bb17-4bdc-80c1-b8f483a764c8", event.ErrorId())
+	assert.Equal(t, "Failed to send.", event.Message)
+	assert.Equal(t, "/api/items/:id", event.Tags["http.route"])
+
+	first := event.Chain.First()
+	assert.Equal(t, "connection reset", first.Cause)
+	assert.Equal(t, logging.ScrubbedValue, first.Meta["email"])
+}
+
+func TestErrorReportHandler_NotReported(t *testing.T) {
+	memory := logging.NewMemoryReporter()
+
+	previous := logging.CurrentReporter()
+	logging.SetReporter(memory)
+	t.Cleanup(func() { logging.SetReporter(previous) })
+
+	handler := ErrorReportHandler(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		arc.HttpApiErrorResponse(nil, w, r, "", &errors.Object{
+			Id:     "b9576f6e-2c48-43bc-b828-574870b5fbf3",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Not found.",
+		})
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/items/7", nil), nil)
+
+	assert.Empty(t, memory.Events())
+}
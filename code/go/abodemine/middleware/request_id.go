@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/val"
+)
+
+// RequestIdHandler wraps an httprouter.Handle to give the call a
+// request id, which the arc.Request of the call, its log lines, its
+// reported errors and its error payloads carry. The id of the
+// X-Request-Id header is kept if it is a UUID, so that the calls of
+// a client can be correlated, and echoed in the response.
+// It MUST be the outermost middleware.
+func RequestIdHandler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		id, err := uuid.Parse(r.Header.Get(arc.RequestIdHeader))
+		if err != nil || id == uuid.Nil {
+			id, err = val.NewUUID7()
+			if err != nil {
+				log.Error().
+					Err(err).
+					Msg("Failed to generate request id.")
+
+				// The request id is not worth failing the call.
+				next(w, r, ps)
+				return
+			}
+		}
+
+		w.Header().Set(arc.RequestIdHeader, id.String())
+
+		ctx := arc.WithRequestId(r.Context(), id)
+		ctx = arc.WithRoute(ctx, routeOf(r.URL.Path, ps))
+
+		next(w, r.WithContext(ctx), ps)
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+)
+
+func TestRequestIdHandler(t *testing.T) {
+	var (
+		gotId    uuid.UUID
+		gotRoute string
+	)
+
+	handler := RequestIdHandler(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		gotId = arc.RequestIdFromContext(r.Context())
+		gotRoute = arc.RouteFromContext(r.Context())
+	})
+
+	ps := httprouter.Params{{Key: "id", Value: "7"}}
+
+	// A new UUIDv7 is set when the client sends none.
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/items/7", nil), ps)
+
+	require.NotEqual(t, uuid.Nil, gotId)
+	assert.Equal(t, uuid.Version(7), gotId.Version())
+	assert.Equal(t, gotId.String(), w.Header().Get(arc.RequestIdHeader))
+	assert.Equal(t, "/api/items/:id", gotRoute)
+
+	// The id of the client is kept.
+	clientId := uuid.New()
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/items/7", nil)
+	r.Header.Set(arc.RequestIdHeader, clientId.String())
+	handler(w, r, ps)
+
+	assert.Equal(t, clientId, gotId)
+	assert.Equal(t, clientId.String(), w.Header().Get(arc.RequestIdHeader))
+
+	// An invalid id is replaced.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/api/items/7", nil)
+	r.Header.Set(arc.RequestIdHeader, "not-a-uuid")
+	handler(w, r, ps)
+
+	assert.NotEqual(t, uuid.Nil, gotId)
+	assert.Equal(t, gotId.String(), w.Header().Get(arc.RequestIdHeader))
+
+	// The requests of the call share the id.
+	req, err := arc.NewDomain(&arc.NewDomainInput{}).CreateRequest(&arc.CreateRequestInput{
+		Context: arc.WithRequestId(r.Context(), clientId),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, clientId, req.Id())
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"abodemine/lib/tracing"
+)
+
+// TracingHandler wraps an httprouter.Handle to run it in a server
+// span, continuing the trace of the traceparent header if any.
+// It SHOULD wrap every middleware but RequestIdHandler so the span
+// covers the others.
+func TracingHandler(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		route := routeOf(r.URL.Path, ps)
+
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("server.address", r.Host),
+				attribute.String("user_agent.original", r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		rw := &ResposeWriter{
+			StatusCode:     http.StatusOK,
+			ResponseWriter: w,
+		}
+
+		next(rw, r.WithContext(ctx), ps)
+
+		span.SetAttributes(attribute.Int("http.response.status_code", rw.StatusCode))
+
+		if rw.StatusCode >= 500 {
+			span.SetStatus(codes.Error, http.StatusText(rw.StatusCode))
+		}
+	}
+}
+
+// routeOf rebuilds the route of the path from its params, so that
+// the spans of a route share a name whatever the param values.
+func routeOf(path string, ps httprouter.Params) string {
+	if len(ps) == 0 {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+
+	for _, p := range ps {
+		for i, segment := range segments {
+			if segment != "" && segment == p.Value {
+				segments[i] = ":" + p.Key
+				break
+			}
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,37 @@
+package conf
+
+import "time"
+
+type BulkJobs struct {
+	// Bucket stores uploads and results. It must be the bucket
+	// served by the packer secure-download lambda.
+	Bucket string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+
+	// Dir is used instead of Bucket for local development.
+	Dir string `json:"dir,omitempty" yaml:"dir,omitempty"`
+
+	// DynamodbTable is the packer secure-download table,
+	// where download tokens are registered.
+	DynamodbTable string `json:"dynamodb_table,omitempty" yaml:"dynamodb_table,omitempty"`
+
+	// DownloadTokenTtl defaults to 7 days.
+	DownloadTokenTtl    time.Duration `json:"-" yaml:"-"`
+	DownloadTokenTtlStr string        `json:"download_token_ttl,omitempty" yaml:"download_token_ttl,omitempty" conf:"duration"`
+
+	// MaxRows defaults to 100,000.
+	MaxRows int `json:"max_rows,omitempty" yaml:"max_rows,omitempty"`
+
+	// Concurrency is the number of rows enriched in parallel by
+	// the worker. Defaults to 8.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+
+	// LeaseTtl is how long a job stays claimed by a worker that
+	// stopped refreshing it, before another worker claims it.
+	// Defaults to 5 minutes.
+	LeaseTtl    time.Duration `json:"-" yaml:"-"`
+	LeaseTtlStr string        `json:"lease_ttl,omitempty" yaml:"lease_ttl,omitempty" conf:"duration"`
+
+	// MaxAttempts is the number of claims of a job before it
+	// is failed. Defaults to 3.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+}
@@ -1,12 +1,17 @@
 package conf
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/casbin/casbin/v2"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 	"github.com/valkey-io/valkey-go"
 
@@ -14,6 +19,8 @@ import (
 	"abodemine/lib/flags"
 	"abodemine/lib/gconf"
 	"abodemine/lib/logging"
+	"abodemine/lib/metrics"
+	"abodemine/lib/storage"
 	"abodemine/lib/val"
 )
 
@@ -22,6 +29,12 @@ type Config struct {
 
 	Values *gconf.Values
 
+	OpenApi *openapi3.T
+
+	// BulkJobsStorage is nil when bulk jobs are not configured.
+	BulkJobsStorage storage.Backend
+
+	AWS          *val.Cache[string, aws.Config]
 	Casbin       *val.Cache[string, *casbin.Enforcer]
 	Duration     *val.Cache[string, time.Duration]
 	OpenSearch   *val.Cache[string, *opensearch.Client]
@@ -29,6 +42,15 @@ type Config struct {
 	PGxPool      *val.Cache[string, *pgxpool.Pool]
 	Valkey       *val.Cache[string, valkey.Client]
 	ValkeyScript *val.Cache[string, *valkey.Lua]
+
+	// Metrics is served on the admin listener of the http server.
+	Metrics *prometheus.Registry
+
+	// Source reads the config file again on reload.
+	Source *gconf.ConfigSource
+
+	// Clients is nil unless the reload of the config file is enabled.
+	Clients *gconf.ClientReloader
 }
 
 type File struct {
@@ -38,15 +60,22 @@ type File struct {
 	Values                   *gconf.ConfigValues `json:"values,omitempty" yaml:"values,omitempty"`
 
 	HttpServer *gconf.HttpServer `json:"http_server,omitempty" yaml:"http_server,omitempty"`
+	OpenApi    *gconf.OpenApi    `json:"openapi,omitempty" yaml:"openapi,omitempty"`
+
+	BulkJobs *BulkJobs `json:"bulk_jobs,omitempty" yaml:"bulk_jobs,omitempty"`
 
 	Casbin     map[string]*gconf.Casbin     `json:"casbin,omitempty" yaml:"casbin,omitempty"`
-	Duration   map[string]string            `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Duration   map[string]string            `json:"duration,omitempty" yaml:"duration,omitempty" conf:"duration"`
 	OpenSearch map[string]*gconf.OpenSearch `json:"opensearch,omitempty" yaml:"opensearch,omitempty"`
 	Paseto     map[string]*gconf.Paseto     `json:"paseto,omitempty" yaml:"paseto,omitempty"`
 	Postgres   map[string]*gconf.Postgres   `json:"postgres,omitempty" yaml:"postgres,omitempty"`
 	Valkey     map[string]*gconf.Valkey     `json:"valkey,omitempty" yaml:"valkey,omitempty"`
 
-	Sentry *gconf.Sentry `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	Sentry        *gconf.Sentry        `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	ErrorReporter *gconf.ErrorReporter `json:"error_reporter,omitempty" yaml:"error_reporter,omitempty"`
+	Tracing       *gconf.Tracing       `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+
+	Reload *gconf.ConfigReload `json:"reload,omitempty" yaml:"reload,omitempty"`
 
 	LogLevel   string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
 	NoLogColor bool   `json:"no_log_color,omitempty" yaml:"no_log_color,omitempty"`
@@ -57,7 +86,12 @@ func Resolve(configPath string) (*Config, error) {
 
 	f := new(File)
 
-	if err := gconf.ResolveConfig(f, configPath, envKey); err != nil {
+	source, err := gconf.NewConfigSource(configPath, envKey)
+	if err != nil {
+		return nil, errors.Forward(err, "dec97b6e-8b2f-41b0-bc29-3776305c8598")
+	}
+
+	if err := source.Load(context.Background(), f); err != nil {
 		return nil, errors.Forward(err, "5b1a8983-20a5-4e65-94b5-e647ea01dc4e")
 	}
 
@@ -66,7 +100,8 @@ func Resolve(configPath string) (*Config, error) {
 	}
 
 	return &Config{
-		File: f,
+		File:   f,
+		Source: source,
 	}, nil
 }
 
@@ -85,13 +120,28 @@ func Load(config *Config) error {
 	file.DeploymentEnvironment = gconf.DeploymentEnvironmentFromString(file.DeploymentEnvironmentStr)
 	log.Info().Str("deployment_environment", gconf.DeploymentEnvironmentToString(file.DeploymentEnvironment)).Send()
 
-	// Load first to ensure config errors go to Sentry.
-	if file.Sentry != nil {
-		if _, err := logging.InitSentry(file.Sentry, file.DeploymentEnvironment, file.NoLogColor); err != nil {
+	// Load first to ensure config errors are reported.
+	if file.Sentry != nil || file.ErrorReporter != nil {
+		if err := logging.InitReporter(&logging.InitReporterInput{
+			Config:                file.ErrorReporter,
+			Sentry:                file.Sentry,
+			DeploymentEnvironment: file.DeploymentEnvironment,
+			NoLogColor:            file.NoLogColor,
+		}); err != nil {
 			return errors.Forward(err, "dc32e994-aa59-4d8e-b313-8ceda908ab3a")
 		}
 	}
 
+	if file.Tracing != nil {
+		if err := gconf.LoadTracing(file.Tracing, "api"); err != nil {
+			return errors.Forward(err, "cb41380d-6b35-49a5-b27e-1aebd81b1c41")
+		}
+
+		log.Info().Msg("Loaded Tracing configuration.")
+	}
+
+	config.Metrics = metrics.NewRegistry()
+
 	if err := flags.ValidateMany(file.Flags); err != nil {
 		return errors.Forward(err, "10763bdf-5535-4de3-ad9e-6df0e3318209")
 	}
@@ -106,6 +156,76 @@ func Load(config *Config) error {
 	}
 
 	config.Values = values
+
+	if file.OpenApi != nil {
+		doc, err := gconf.LoadOpenApi(file.OpenApi)
+		if err != nil {
+			return errors.Forward(err, "e8bf6570-46a4-45bc-b21b-4f30b63b0294")
+		}
+
+		config.OpenApi = doc
+		log.Info().Str("spec", file.OpenApi.Spec).Msg("Loaded OpenApi configuration.")
+	}
+
+	config.AWS = val.NewCache[string, aws.Config]()
+
+	if bulkJobs := file.BulkJobs; bulkJobs != nil {
+		if bulkJobs.DownloadTokenTtlStr != "" {
+			ttl, err := gconf.LoadDuration(bulkJobs.DownloadTokenTtlStr)
+			if err != nil {
+				return errors.Forward(err, "8e20d921-7275-449e-88b4-d3e60c0fe80c")
+			}
+
+			bulkJobs.DownloadTokenTtl = ttl
+		}
+
+		if bulkJobs.LeaseTtlStr != "" {
+			ttl, err := gconf.LoadDuration(bulkJobs.LeaseTtlStr)
+			if err != nil {
+				return errors.Forward(err, "2c44a72f-977a-4c8e-8cbd-50a77a66779e")
+			}
+
+			bulkJobs.LeaseTtl = ttl
+		}
+
+		switch {
+		case bulkJobs.Bucket != "":
+			awsConfig, err := awsconfig.LoadDefaultConfig(context.Background())
+			if err != nil {
+				return &errors.Object{
+					Id:     "3a3a71cf-0a35-4154-a518-70d84f0968b2",
+					Code:   errors.Code_UNKNOWN,
+					Detail: "Failed to load default AWS config.",
+					Cause:  err.Error(),
+					Err:    err,
+				}
+			}
+
+			config.AWS.Set("default", awsConfig)
+
+			config.BulkJobsStorage = &storage.S3Backend{
+				AWS:    awsConfig,
+				Bucket: bulkJobs.Bucket,
+			}
+		case bulkJobs.Dir != "":
+			config.BulkJobsStorage = &storage.LocalBackend{
+				FilesystemPath: bulkJobs.Dir,
+			}
+		default:
+			return &errors.Object{
+				Id:     "687e268e-fa4f-4d7b-8630-01588f702047",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "One of bucket or dir is required.",
+				Path:   "/bulk_jobs",
+			}
+		}
+
+		log.Info().
+			Str("bucket", bulkJobs.Bucket).
+			Str("dir", bulkJobs.Dir).
+			Msg("Loaded BulkJobs configuration.")
+	}
+
 	config.Casbin = val.NewCache[string, *casbin.Enforcer]()
 
 	for k, v := range file.Casbin {
@@ -187,7 +307,7 @@ func Load(config *Config) error {
 				}
 			}
 
-			item, err := gconf.LoadValkeyScript(w)
+			item, err := gconf.LoadValkeyScript(l, w)
 			if err != nil {
 				return errors.Forward(err, "bbdafbf1-9a70-40a1-8238-e143941903ec")
 			}
@@ -197,9 +317,81 @@ func Load(config *Config) error {
 		}
 	}
 
+	if file.Reload != nil {
+		if err := gconf.LoadConfigReload(file.Reload); err != nil {
+			return errors.Forward(err, "ce0f830e-db0b-42b1-af42-0bb3607cf758")
+		}
+
+		config.Clients = gconf.NewClientReloader(&gconf.NewClientReloaderInput{
+			Caches: &gconf.ClientCaches{
+				OpenSearch: config.OpenSearch,
+				Paseto:     config.Paseto,
+				PGxPool:    config.PGxPool,
+				Valkey:     config.Valkey,
+			},
+			Configs:    clientConfigs(file),
+			CloseDelay: file.Reload.CloseDelay,
+		})
+
+		log.Info().
+			Dur("interval", file.Reload.Interval).
+			Msg("Loaded Reload configuration.")
+	}
+
+	return nil
+}
+
+func clientConfigs(file *File) *gconf.ClientConfigs {
+	return &gconf.ClientConfigs{
+		OpenSearch: file.OpenSearch,
+		Paseto:     file.Paseto,
+		Postgres:   file.Postgres,
+		Valkey:     file.Valkey,
+	}
+}
+
+// Reload rebuilds the OpenSearch, Paseto, Postgres and Valkey clients
+// whose config changed in the body of the config file. The other
+// changes of the file are ignored until a restart.
+func (c *Config) Reload(ctx context.Context, body []byte) error {
+	if c.Clients == nil {
+		return &errors.Object{
+			Id:     "05d1d689-74c3-48f9-83ea-6dc36bc6a773",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Reload is not enabled.",
+			Path:   "/reload",
+		}
+	}
+
+	f := new(File)
+
+	if err := c.Source.Decode(body, f); err != nil {
+		return errors.Forward(err, "9633a34c-e527-4a77-acc3-9af440363aaa")
+	}
+
+	if err := c.Clients.Reload(clientConfigs(f)); err != nil {
+		return errors.Forward(err, "caf90037-b215-4ba6-a074-c28253abcdc9")
+	}
+
 	return nil
 }
 
+// Close closes the Postgres pools and the Valkey clients. It must be
+// called only once the in-flight requests are drained.
+func (c *Config) Close() {
+	if c.PGxPool != nil {
+		for _, k := range c.PGxPool.Keys() {
+			c.PGxPool.Get(k).Close()
+		}
+	}
+
+	if c.Valkey != nil {
+		for _, k := range c.Valkey.Keys() {
+			c.Valkey.Get(k).Close()
+		}
+	}
+}
+
 func ResolveAndLoad(path string) (*Config, error) {
 	config, err := Resolve(path)
 	if err != nil {
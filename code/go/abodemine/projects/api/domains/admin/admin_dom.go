@@ -0,0 +1,532 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+	"abodemine/projects/api/domains/auth"
+)
+
+// Domain provisions the api access of organizations. All
+// methods are restricted to admins by the casbin policy.
+type Domain interface {
+	SelectApiQuota(r *arc.Request, in *SelectApiQuotaInput) (*SelectApiQuotaOutput, error)
+	UpdateApiQuota(r *arc.Request, in *UpdateApiQuotaInput) (*UpdateApiQuotaOutput, error)
+
+	SelectApiSessions(r *arc.Request, in *SelectApiSessionsInput) (*SelectApiSessionsOutput, error)
+	DeleteApiSessions(r *arc.Request, in *DeleteApiSessionsInput) (*DeleteApiSessionsOutput, error)
+}
+
+type domain struct {
+	repository Repository
+}
+
+type NewDomainInput struct {
+	Repository Repository
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+	}
+}
+
+var apiKeyStatusNames = map[auth.ApiKeyStatus]string{
+	auth.ApiKeyStatusActive:  "ACTIVE",
+	auth.ApiKeyStatusExpired: "EXPIRED",
+	auth.ApiKeyStatusRevoked: "REVOKED",
+}
+
+func newApiQuotaEntity(record *arc.ApiQuota) *entities.ApiQuota {
+	entity := &entities.ApiQuota{
+		OrganizationId: record.OrganizationId,
+		UpdatedAt:      record.UpdatedAt,
+		DailyQuota:     record.DailyQuota,
+		MonthlyQuota:   record.MonthlyQuota,
+		Layouts:        make(map[string]bool, len(apiQuotaLayoutColumns)),
+	}
+
+	for i, field := range layoutFields(record) {
+		entity.Layouts[apiQuotaLayoutColumns[i].Layout] = *field
+	}
+
+	if !record.ExpiresAt.IsZero() {
+		expiresAt := record.ExpiresAt
+		entity.ExpiresAt = &expiresAt
+	}
+
+	return entity
+}
+
+type SelectApiQuotaInput struct {
+	OrganizationId uuid.UUID
+}
+
+type SelectApiQuotaOutput struct {
+	Entity *entities.ApiQuota
+}
+
+func (dom *domain) SelectApiQuota(r *arc.Request, in *SelectApiQuotaInput) (*SelectApiQuotaOutput, error) {
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinApiDefault,
+		"/admin/quotas",
+		"read",
+	); err != nil {
+		return nil, errors.Forward(err, "18db8329-9101-42e3-ae48-2c7c657aef8b")
+	}
+
+	if in == nil || in.OrganizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "96de201e-e2bf-47be-919a-0fc425bbfb80",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing organization id.",
+			Path:   "/organizationId",
+		}
+	}
+
+	selectOut, err := dom.repository.SelectApiQuotaRecord(r, &SelectApiQuotaRecordInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "78e955cc-aa64-40a1-bcc4-269f660029ce")
+	}
+
+	if selectOut.Record == nil {
+		return nil, &errors.Object{
+			Id:     "8667cc0a-d5ed-41ba-924b-94432c082ab8",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Api quota not found.",
+			Meta: map[string]any{
+				"organizationId": in.OrganizationId,
+			},
+		}
+	}
+
+	out := &SelectApiQuotaOutput{
+		Entity: newApiQuotaEntity(selectOut.Record),
+	}
+
+	return out, nil
+}
+
+type UpdateApiQuotaInput struct {
+	OrganizationId uuid.UUID
+
+	// Nil quotas are left as they are. Both are
+	// required to provision a new organization.
+	DailyQuota   *int64
+	MonthlyQuota *int64
+
+	// Enabled state by layout name. Missing
+	// layouts are left as they are.
+	Layouts map[string]bool
+
+	// Nil leaves the expiry as it is, and a
+	// zero time removes it.
+	ExpiresAt *time.Time
+}
+
+type UpdateApiQuotaOutput struct {
+	Entity *entities.ApiQuota
+
+	// Number of cached sessions deleted so the
+	// changes apply to the next request.
+	DeletedSessions int64
+}
+
+// UpdateApiQuota provisions the organization, or updates its quota,
+// and deletes the cached sessions of all of its api keys.
+func (dom *domain) UpdateApiQuota(r *arc.Request, in *UpdateApiQuotaInput) (*UpdateApiQuotaOutput, error) {
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinApiDefault,
+		"/admin/quotas",
+		"write",
+	); err != nil {
+		return nil, errors.Forward(err, "efff973f-9b89-4bdb-a66e-d71b556ab53a")
+	}
+
+	if in == nil || in.OrganizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "21ce7d6c-ebb6-4503-a3af-163113c1c42f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing organization id.",
+			Path:   "/organizationId",
+		}
+	}
+
+	if in.DailyQuota != nil && *in.DailyQuota < 0 {
+		return nil, &errors.Object{
+			Id:     "29c1eb3e-8a28-4bda-85b1-04f8eeeff9f9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Daily quota must be nonnegative.",
+			Path:   "/dailyQuota",
+		}
+	}
+
+	if in.MonthlyQuota != nil && *in.MonthlyQuota < 0 {
+		return nil, &errors.Object{
+			Id:     "fac5f8b3-00e1-46fd-8496-1a2e03c0e4f5",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Monthly quota must be nonnegative.",
+			Path:   "/monthlyQuota",
+		}
+	}
+
+	for layout := range in.Layouts {
+		found := false
+
+		for _, c := range apiQuotaLayoutColumns {
+			if c.Layout == layout {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, &errors.Object{
+				Id:     "81b7254f-d0dd-4286-9811-398a53ef30bf",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Unsupported layout.",
+				Path:   "/layouts/" + layout,
+			}
+		}
+	}
+
+	selectOut, err := dom.repository.SelectApiQuotaRecord(r, &SelectApiQuotaRecordInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "b26d5fdd-cf12-4814-b62c-4cb033a9550a")
+	}
+
+	record := selectOut.Record
+
+	if record == nil {
+		if in.DailyQuota == nil || in.MonthlyQuota == nil {
+			return nil, &errors.Object{
+				Id:     "56080192-3fc6-4ac6-ac2e-2c86c4c3e50c",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Daily and monthly quotas are required to provision an organization.",
+			}
+		}
+
+		id, err := val.NewUUID7()
+		if err != nil {
+			return nil, errors.Forward(err, "8bc87ad0-b5b1-4c17-9d70-71fca2e95325")
+		}
+
+		record = &arc.ApiQuota{
+			Id:             id,
+			OrganizationId: in.OrganizationId,
+		}
+	}
+
+	if in.DailyQuota != nil {
+		record.DailyQuota = *in.DailyQuota
+	}
+
+	if in.MonthlyQuota != nil {
+		record.MonthlyQuota = *in.MonthlyQuota
+	}
+
+	for i, field := range layoutFields(record) {
+		if enabled, ok := in.Layouts[apiQuotaLayoutColumns[i].Layout]; ok {
+			*field = enabled
+		}
+	}
+
+	if in.ExpiresAt != nil {
+		record.ExpiresAt = *in.ExpiresAt
+	}
+
+	upsertOut, err := dom.repository.UpsertApiQuotaRecord(r, &UpsertApiQuotaRecordInput{
+		Record: record,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "fd68b077-f6b8-4c09-86d3-733395074f17")
+	}
+
+	deleteOut, err := dom.DeleteApiSessions(r, &DeleteApiSessionsInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "9da129ad-95e5-45e9-b167-b06ab8209433")
+	}
+
+	out := &UpdateApiQuotaOutput{
+		Entity:          newApiQuotaEntity(upsertOut.Record),
+		DeletedSessions: deleteOut.Deleted,
+	}
+
+	return out, nil
+}
+
+type SelectApiSessionsInput struct {
+	OrganizationId uuid.UUID
+}
+
+type SelectApiSessionsOutput struct {
+	// One per api key of the organization, oldest key first.
+	Entities []*entities.ApiSession
+}
+
+func (dom *domain) SelectApiSessions(r *arc.Request, in *SelectApiSessionsInput) (*SelectApiSessionsOutput, error) {
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinApiDefault,
+		"/admin/sessions",
+		"read",
+	); err != nil {
+		return nil, errors.Forward(err, "b2c742e6-0a3d-474c-96da-c64ea8d9e009")
+	}
+
+	if in == nil || in.OrganizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "2fd06784-43a6-4301-8df2-d051466df9e2",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing organization id.",
+			Path:   "/organizationId",
+		}
+	}
+
+	keysOut, err := dom.repository.SelectApiKeyRecords(r, &SelectApiKeyRecordsInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "13ab36e4-c08e-43bb-b7b8-b64448746838")
+	}
+
+	out := &SelectApiSessionsOutput{
+		Entities: make([]*entities.ApiSession, 0, len(keysOut.Records)),
+	}
+
+	if len(keysOut.Records) == 0 {
+		return out, nil
+	}
+
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return nil, errors.Forward(err, "d55cd9f4-f91d-4a53-9ab6-b35b3399cb6e")
+	}
+
+	inspectScript, err := r.Dom().SelectValkeyScript("inspect-api-session")
+	if err != nil {
+		return nil, errors.Forward(err, "b9e4bb16-9e15-4d8e-984a-6003f12499f5")
+	}
+
+	// One call per key, since keys may live on different nodes.
+	multi := make([]valkey.LuaExec, len(keysOut.Records))
+
+	for i, record := range keysOut.Records {
+		multi[i] = valkey.LuaExec{
+			Keys: []string{auth.ApiSessionKey(record.KeyType, record.KeyHash)},
+		}
+	}
+
+	now := time.Now()
+
+	for i, result := range inspectScript.ExecMulti(r.Context(), valkeyCli, multi...) {
+		record := keysOut.Records[i]
+
+		entity := &entities.ApiSession{
+			ApiKeyId:  record.Id,
+			Name:      record.Name,
+			KeyStatus: apiKeyStatusNames[record.KeyStatus],
+		}
+
+		if !record.LastUsedAt.IsZero() {
+			lastUsedAt := record.LastUsedAt
+			entity.LastUsedAt = &lastUsedAt
+		}
+
+		if err := parseApiSession(entity, record, result, now); err != nil {
+			return nil, errors.Forward(err, "f30cef75-5093-438f-baf5-5ec1230b3d7e")
+		}
+
+		out.Entities = append(out.Entities, entity)
+	}
+
+	return out, nil
+}
+
+// parseApiSession sets the cached state of the session
+// from the output of the inspect-api-session script.
+func parseApiSession(entity *entities.ApiSession, record *auth.ApiKey, result valkey.ValkeyResult, now time.Time) error {
+	if err := result.Error(); err != nil {
+		return &errors.Object{
+			Id:     "52284eec-4e5b-4f34-89b6-c930a9d7e6a9",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	values, err := result.ToArray()
+	if err != nil {
+		return &errors.Object{
+			Id:     "c56f82c1-2efb-4708-a01a-39307af6c8c9",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to parse script output.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if len(values) == 0 {
+		return &errors.Object{
+			Id:     "4048e58d-a41c-4ca5-bdf4-c632c1f52570",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Script output length mismatch.",
+		}
+	}
+
+	pttl, err := values[0].AsInt64()
+	if err != nil {
+		return &errors.Object{
+			Id:     "cc0d1648-a11b-44b3-87d7-4e30bd9ffff2",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to parse script output[0].",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	// The key doesn't exist.
+	if pttl == -2 || len(values) < 4 {
+		return nil
+	}
+
+	entity.Cached = true
+
+	if pttl > 0 {
+		expiresAt := now.Add(time.Duration(pttl) * time.Millisecond)
+		entity.ExpiresAt = &expiresAt
+	}
+
+	fields := make([]string, 3)
+
+	for i := range fields {
+		v, err := values[i+1].ToString()
+		if err != nil && !valkey.IsValkeyNil(err) {
+			return &errors.Object{
+				Id:     "3676e689-43a0-4831-bf6d-bfbdcf7fef49",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse script output field.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		fields[i] = v
+	}
+
+	entity.Invalid = fields[0] == "true"
+	entity.QuotaExhausted = fields[1]
+
+	if fields[2] == "" {
+		return nil
+	}
+
+	session, err := arc.NewServerSessionFromBytes(&arc.NewServerSessionFromBytesInput{
+		Data:    []byte(fields[2]),
+		KeyHash: record.KeyHash,
+		KeyType: int16(record.KeyType),
+	})
+	if err != nil {
+		return errors.Forward(err, "e8d94d95-6530-4902-9f45-59f330857b07")
+	}
+
+	entity.EnabledLayouts = session.Flags()
+
+	return nil
+}
+
+type DeleteApiSessionsInput struct {
+	OrganizationId uuid.UUID
+}
+
+type DeleteApiSessionsOutput struct {
+	Deleted int64
+}
+
+// DeleteApiSessions deletes the cached sessions of all the api keys
+// of the organization. Their next request reloads the quota and the
+// enabled layouts from the database.
+func (dom *domain) DeleteApiSessions(r *arc.Request, in *DeleteApiSessionsInput) (*DeleteApiSessionsOutput, error) {
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinApiDefault,
+		"/admin/sessions",
+		"write",
+	); err != nil {
+		return nil, errors.Forward(err, "5bc1a596-9062-478b-984e-a3ba653875a1")
+	}
+
+	if in == nil || in.OrganizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "2eb0199d-d78d-4318-b5d4-ac48d9530776",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing organization id.",
+			Path:   "/organizationId",
+		}
+	}
+
+	keysOut, err := dom.repository.SelectApiKeyRecords(r, &SelectApiKeyRecordsInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "58ccd2a5-e548-48c9-97af-812fb77b967d")
+	}
+
+	out := &DeleteApiSessionsOutput{}
+
+	if len(keysOut.Records) == 0 {
+		return out, nil
+	}
+
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return nil, errors.Forward(err, "15c73725-6f7e-4379-90dd-5ad2f230160c")
+	}
+
+	deleteScript, err := r.Dom().SelectValkeyScript("delete-api-session")
+	if err != nil {
+		return nil, errors.Forward(err, "d25c206a-fd7c-4c9a-b0d6-ab86e19572a0")
+	}
+
+	// One call per key, since keys may live on different nodes.
+	multi := make([]valkey.LuaExec, len(keysOut.Records))
+
+	for i, record := range keysOut.Records {
+		multi[i] = valkey.LuaExec{
+			Keys: []string{auth.ApiSessionKey(record.KeyType, record.KeyHash)},
+		}
+	}
+
+	for _, result := range deleteScript.ExecMulti(r.Context(), valkeyCli, multi...) {
+		deleted, err := result.AsInt64()
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "2adbbc2c-239e-4c28-a668-c21c7d19f21f",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to execute script.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Deleted += deleted
+	}
+
+	return out, nil
+}
@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+	"abodemine/lib/ptr"
+	"abodemine/lib/val"
+)
+
+type mockRepository struct {
+	records map[uuid.UUID]*arc.ApiQuota
+}
+
+func (m *mockRepository) SelectApiQuotaRecord(r *arc.Request, in *SelectApiQuotaRecordInput) (*SelectApiQuotaRecordOutput, error) {
+	out := &SelectApiQuotaRecordOutput{}
+
+	if record, ok := m.records[in.OrganizationId]; ok {
+		copied := *record
+		out.Record = &copied
+	}
+
+	return out, nil
+}
+
+func (m *mockRepository) UpsertApiQuotaRecord(r *arc.Request, in *UpsertApiQuotaRecordInput) (*UpsertApiQuotaRecordOutput, error) {
+	m.records[in.Record.OrganizationId] = in.Record
+	return &UpsertApiQuotaRecordOutput{Record: in.Record}, nil
+}
+
+func (m *mockRepository) SelectApiKeyRecords(r *arc.Request, in *SelectApiKeyRecordsInput) (*SelectApiKeyRecordsOutput, error) {
+	// No keys, so no cached sessions to delete.
+	return &SelectApiKeyRecordsOutput{}, nil
+}
+
+func newTestRequest(t *testing.T, roleName string) *arc.Request {
+	enforcer, err := gconf.LoadCasbin(&gconf.Casbin{
+		Model:  "../../../../../../casbin/projects/api/policies/default/model.conf",
+		Policy: "../../../../../../casbin/projects/api/policies/default/policy.csv",
+	})
+	require.NoError(t, err)
+
+	casbinCache := val.NewCache[string, *casbin.Enforcer]()
+	casbinCache.Set(consts.ConfigKeyCasbinApiDefault, enforcer)
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		Casbin: casbinCache,
+	})
+
+	r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+
+	session, err := arcDomain.CreateServerSession(r, &arc.CreateServerSessionInput{
+		OrganizationId: consts.AbodeMineOrganizationId(),
+		UserId:         consts.AbodeMineBotUserId(),
+		RoleName:       roleName,
+		SessionType:    arc.SessionTypeSystem,
+		TTL:            1,
+		DoNotSave:      true,
+	})
+	require.NoError(t, err)
+
+	r.SetSession(session)
+
+	return r
+}
+
+func TestDomain_UpdateApiQuota(t *testing.T) {
+	orgId := uuid.New()
+	repo := &mockRepository{records: make(map[uuid.UUID]*arc.ApiQuota)}
+	dom := NewDomain(&NewDomainInput{Repository: repo})
+
+	r := newTestRequest(t, consts.RoleApiAdminUser)
+
+	// Non-admin keys are rejected.
+	_, err := dom.UpdateApiQuota(newTestRequest(t, "api_user"), &UpdateApiQuotaInput{
+		OrganizationId: orgId,
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.First(err).Code)
+
+	// Both quotas are required to provision an organization.
+	_, err = dom.UpdateApiQuota(r, &UpdateApiQuotaInput{
+		OrganizationId: orgId,
+		DailyQuota:     ptr.Int64(100),
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_INVALID_ARGUMENT, errors.First(err).Code)
+
+	_, err = dom.UpdateApiQuota(r, &UpdateApiQuotaInput{
+		OrganizationId: orgId,
+		DailyQuota:     ptr.Int64(100),
+		MonthlyQuota:   ptr.Int64(3000),
+		Layouts:        map[string]bool{entities.UsageLayoutBase: true},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "/layouts/base", errors.First(err).Path)
+
+	expiresAt := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	out, err := dom.UpdateApiQuota(r, &UpdateApiQuotaInput{
+		OrganizationId: orgId,
+		DailyQuota:     ptr.Int64(100),
+		MonthlyQuota:   ptr.Int64(3000),
+		Layouts: map[string]bool{
+			entities.UsageLayoutComps:    true,
+			entities.UsageLayoutTimeline: true,
+		},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), out.Entity.DailyQuota)
+	assert.True(t, out.Entity.Layouts[entities.UsageLayoutComps])
+	assert.False(t, out.Entity.Layouts[entities.UsageLayoutAddress])
+	require.NotNil(t, out.Entity.ExpiresAt)
+	assert.Equal(t, expiresAt, *out.Entity.ExpiresAt)
+
+	// Omitted fields are left as they are.
+	out, err = dom.UpdateApiQuota(r, &UpdateApiQuotaInput{
+		OrganizationId: orgId,
+		MonthlyQuota:   ptr.Int64(5000),
+		Layouts:        map[string]bool{entities.UsageLayoutComps: false},
+		ExpiresAt:      new(time.Time),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), out.Entity.DailyQuota)
+	assert.Equal(t, int64(5000), out.Entity.MonthlyQuota)
+	assert.False(t, out.Entity.Layouts[entities.UsageLayoutComps])
+	assert.True(t, out.Entity.Layouts[entities.UsageLayoutTimeline])
+	assert.Nil(t, out.Entity.ExpiresAt)
+
+	selectOut, err := dom.SelectApiQuota(r, &SelectApiQuotaInput{
+		OrganizationId: orgId,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, out.Entity, selectOut.Entity)
+}
@@ -0,0 +1,347 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+	"abodemine/projects/api/domains/auth"
+)
+
+type Repository interface {
+	SelectApiQuotaRecord(r *arc.Request, in *SelectApiQuotaRecordInput) (*SelectApiQuotaRecordOutput, error)
+	UpsertApiQuotaRecord(r *arc.Request, in *UpsertApiQuotaRecordInput) (*UpsertApiQuotaRecordOutput, error)
+
+	SelectApiKeyRecords(r *arc.Request, in *SelectApiKeyRecordsInput) (*SelectApiKeyRecordsOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// apiQuotaLayoutColumns maps the layouts that can be
+// toggled to their api_quotas columns.
+var apiQuotaLayoutColumns = []struct {
+	Layout string
+	Column string
+}{
+	{Layout: entities.UsageLayoutAddress, Column: "address_lo_enabled"},
+	{Layout: entities.UsageLayoutAssessor, Column: "assessor_lo_enabled"},
+	{Layout: entities.UsageLayoutComps, Column: "comps_lo_enabled"},
+	{Layout: entities.UsageLayoutListing, Column: "listing_lo_enabled"},
+	{Layout: entities.UsageLayoutRecorder, Column: "recorder_lo_enabled"},
+	{Layout: entities.UsageLayoutRentEstimate, Column: "rent_estimate_lo_enabled"},
+	{Layout: entities.UsageLayoutSaleEstimate, Column: "sale_estimate_lo_enabled"},
+	{Layout: entities.UsageLayoutMarketStats, Column: "market_stats_lo_enabled"},
+	{Layout: entities.UsageLayoutTimeline, Column: "timeline_lo_enabled"},
+}
+
+// layoutFields returns pointers to the layout fields of
+// the record, in the order of apiQuotaLayoutColumns.
+func layoutFields(record *arc.ApiQuota) []*bool {
+	return []*bool{
+		&record.AddressLayoutEnabled,
+		&record.AssessorLayoutEnabled,
+		&record.CompsLayoutEnabled,
+		&record.ListingLayoutEnabled,
+		&record.RecorderLayoutEnabled,
+		&record.RentEstimateLayoutEnabled,
+		&record.SaleEstimateLayoutEnabled,
+		&record.MarketStatsLayoutEnabled,
+		&record.TimelineLayoutEnabled,
+	}
+}
+
+type SelectApiQuotaRecordInput struct {
+	OrganizationId uuid.UUID
+}
+
+type SelectApiQuotaRecordOutput struct {
+	// Nil when the organization has no quota.
+	Record *arc.ApiQuota
+}
+
+func (repo *repository) SelectApiQuotaRecord(r *arc.Request, in *SelectApiQuotaRecordInput) (*SelectApiQuotaRecordOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"daily_quota",
+			"monthly_quota",
+			"expires_at",
+		).
+		From("api_quotas").
+		Where("organization_id = ?", in.OrganizationId)
+
+	for _, c := range apiQuotaLayoutColumns {
+		builder = builder.Column(c.Column)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "1bf77705-f061-4ff4-9b4f-c2f93241833d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "0ae23323-6e31-4ab6-b3ff-6e5804fe2868")
+	}
+
+	var expiresAt pgtype.Timestamptz
+
+	out := &SelectApiQuotaRecordOutput{}
+	record := new(arc.ApiQuota)
+
+	dest := []any{
+		&record.Id,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+		&record.OrganizationId,
+		&record.DailyQuota,
+		&record.MonthlyQuota,
+		&expiresAt,
+	}
+
+	for _, field := range layoutFields(record) {
+		dest = append(dest, field)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, nil
+		}
+
+		return nil, &errors.Object{
+			Id:     "b47c426d-4a6e-44ad-8be5-f74fb1b2df44",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to fetch row.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if expiresAt.Valid {
+		record.ExpiresAt = expiresAt.Time
+	}
+
+	out.Record = record
+
+	return out, nil
+}
+
+type UpsertApiQuotaRecordInput struct {
+	Record *arc.ApiQuota
+}
+
+type UpsertApiQuotaRecordOutput struct {
+	Record *arc.ApiQuota
+}
+
+// UpsertApiQuotaRecord inserts the quota of the organization, or
+// replaces its quotas, layouts and expiry. Rate limits, notification
+// percents and the id of existing records are left as they are.
+func (repo *repository) UpsertApiQuotaRecord(r *arc.Request, in *UpsertApiQuotaRecordInput) (*UpsertApiQuotaRecordOutput, error) {
+	record := in.Record
+	now := time.Now()
+
+	var expiresAt pgtype.Timestamptz
+
+	if !record.ExpiresAt.IsZero() {
+		expiresAt = pgtype.Timestamptz{Time: record.ExpiresAt, Valid: true}
+	}
+
+	columns := []string{
+		"id",
+		"created_at",
+		"updated_at",
+		"organization_id",
+		"daily_quota",
+		"monthly_quota",
+		"expires_at",
+	}
+
+	values := []any{
+		record.Id,
+		now,
+		now,
+		record.OrganizationId,
+		record.DailyQuota,
+		record.MonthlyQuota,
+		expiresAt,
+	}
+
+	suffix := `
+		on conflict (organization_id) do update set
+			updated_at = excluded.updated_at,
+			daily_quota = excluded.daily_quota,
+			monthly_quota = excluded.monthly_quota,
+			expires_at = excluded.expires_at`
+
+	for i, field := range layoutFields(record) {
+		column := apiQuotaLayoutColumns[i].Column
+
+		columns = append(columns, column)
+		values = append(values, *field)
+		suffix += ",\n\t\t\t" + column + " = excluded." + column
+	}
+
+	suffix += `
+		returning id, created_at, updated_at`
+
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("api_quotas").
+		Columns(columns...).
+		Values(values...).
+		Suffix(suffix).
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "f174413e-cded-4045-9f7d-9770ceb13b8d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "2705af76-93bb-41b9-946f-d03c7033d283")
+	}
+
+	if err := row.Scan(
+		&record.Id,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	); err != nil {
+		return nil, &errors.Object{
+			Id:     "4ed83449-5aa3-4af0-a116-343ae1c1fbd5",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to upsert api quota.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out := &UpsertApiQuotaRecordOutput{
+		Record: record,
+	}
+
+	return out, nil
+}
+
+type SelectApiKeyRecordsInput struct {
+	OrganizationId uuid.UUID
+}
+
+type SelectApiKeyRecordsOutput struct {
+	// Oldest first.
+	Records []*auth.ApiKey
+}
+
+// SelectApiKeyRecords selects the api keys of the organization
+// with their hashes, to find their cached sessions.
+func (repo *repository) SelectApiKeyRecords(r *arc.Request, in *SelectApiKeyRecordsInput) (*SelectApiKeyRecordsOutput, error) {
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"id",
+			"created_at",
+			"organization_id",
+			"key_type",
+			"key_status",
+			"key_hash",
+			"name",
+			"last_used_at",
+		).
+		From("api_keys").
+		Where("organization_id = ?", in.OrganizationId).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "27bf569b-90b4-4e29-8157-0979ae45ee31",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "31022fdc-420d-42ac-8575-db7f561fd0e9")
+	}
+
+	defer rows.Close()
+
+	out := &SelectApiKeyRecordsOutput{}
+
+	for rows.Next() {
+		var (
+			name       pgtype.Text
+			lastUsedAt pgtype.Timestamptz
+		)
+
+		record := new(auth.ApiKey)
+
+		if err := rows.Scan(
+			&record.Id,
+			&record.CreatedAt,
+			&record.OrganizationId,
+			&record.KeyType,
+			&record.KeyStatus,
+			&record.KeyHash,
+			&name,
+			&lastUsedAt,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "978bcf0d-aab8-4376-8a4e-b01c9a4d16ea",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		record.Name = name.String
+
+		if lastUsedAt.Valid {
+			record.LastUsedAt = lastUsedAt.Time
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "c36b77e6-91d4-4240-b1ad-818642889c0c",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
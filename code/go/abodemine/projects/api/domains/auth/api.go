@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
 
 	"abodemine/domains/arc"
 	"abodemine/lib/consts"
@@ -28,6 +30,11 @@ func (t ApiKeyType) Base64() string {
 	return base64.RawStdEncoding.EncodeToString(buf)
 }
 
+// ApiSessionKey returns the valkey key of the cached session of an api key.
+func ApiSessionKey(keyType ApiKeyType, keyHash string) string {
+	return fmt.Sprintf("apik:%s:%s", keyType.Base64(), keyHash)
+}
+
 var validApiKeyTypes = map[ApiKeyType]struct{}{
 	ApiKeyTypeLegacy: {},
 }
@@ -72,6 +79,7 @@ type SelectApiSessionOutput struct {
 	Invalid        bool
 	QuotaExhausted string
 	Session        arc.ServerSession
+	RateLimits     *RateLimits
 }
 
 func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (*SelectApiSessionOutput, error) {
@@ -120,11 +128,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 		return nil, errors.Forward(err, "ba8b0b15-464b-4f9c-ac36-a94a580fdc02")
 	}
 
-	key := fmt.Sprintf(
-		"apik:%s:%s",
-		in.KeyType.Base64(),
-		in.KeyHash,
-	)
+	key := ApiSessionKey(in.KeyType, in.KeyHash)
 
 	scriptOut := selectScript.Exec(
 		context.Background(),
@@ -153,6 +157,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to execute script.",
 				Cause:  scriptOut.Error().Error(),
+				Err:    scriptOut.Error(),
 			}
 		}
 	}
@@ -164,6 +169,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -182,6 +188,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output[0].",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -198,6 +205,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 			Code:   errors.Code_RESOURCE_EXHAUSTED,
 			Detail: "Failed to parse script output[1].",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -214,6 +222,7 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to parse script output[2].",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -230,6 +239,34 @@ func (dom *domain) SelectApiSession(r *arc.Request, in *SelectApiSessionInput) (
 		Session: session,
 	}
 
+	// Sessions cached before rate limits were added have no field.
+	if len(scriptOutArray) > 3 {
+		rateLimitsStr, err := scriptOutArray[3].ToString()
+		if err != nil && !valkey.IsValkeyNil(err) {
+			return nil, &errors.Object{
+				Id:     "942f7b6e-196f-4425-a1e0-51088b5f2ac1",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to parse script output[3].",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if rateLimitsStr != "" {
+			out.RateLimits = new(RateLimits)
+
+			if err := json.Unmarshal([]byte(rateLimitsStr), out.RateLimits); err != nil {
+				return nil, &errors.Object{
+					Id:     "d5b9a71a-ebd0-4c6c-93ee-e3935e88ceb8",
+					Code:   errors.Code_UNKNOWN,
+					Detail: "Failed to decode rate limits.",
+					Cause:  err.Error(),
+					Err:    err,
+				}
+			}
+		}
+	}
+
 	return out, nil
 }
 
@@ -239,6 +276,7 @@ type UpdateApiSessionInput struct {
 	KeyType        ApiKeyType
 	KeyHash        string
 	Session        arc.ServerSession
+	RateLimits     *RateLimits
 	TTL            int32
 }
 
@@ -290,11 +328,7 @@ func (dom *domain) UpdateApiSession(r *arc.Request, in *UpdateApiSessionInput) (
 		return nil, errors.Forward(err, "832d8693-8c6b-45dc-be7d-431635559fcd")
 	}
 
-	key := fmt.Sprintf(
-		"apik:%s:%s",
-		in.KeyType.Base64(),
-		in.KeyHash,
-	)
+	key := ApiSessionKey(in.KeyType, in.KeyHash)
 
 	var sessionBytes []byte
 
@@ -305,6 +339,21 @@ func (dom *domain) UpdateApiSession(r *arc.Request, in *UpdateApiSessionInput) (
 		}
 	}
 
+	var rateLimitsBytes []byte
+
+	if in.RateLimits != nil {
+		rateLimitsBytes, err = json.Marshal(in.RateLimits)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "afe73139-a37e-4740-a289-595be5f52658",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to encode rate limits.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+	}
+
 	scriptOut := updateScript.Exec(
 		context.Background(),
 		valkeyCli,
@@ -314,6 +363,7 @@ func (dom *domain) UpdateApiSession(r *arc.Request, in *UpdateApiSessionInput) (
 			in.QuotaExhausted,
 			string(sessionBytes),
 			strconv.FormatInt(int64(in.TTL), 10),
+			string(rateLimitsBytes),
 		},
 	)
 
@@ -323,6 +373,7 @@ func (dom *domain) UpdateApiSession(r *arc.Request, in *UpdateApiSessionInput) (
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute script.",
 			Cause:  scriptOut.Error().Error(),
+			Err:    scriptOut.Error(),
 		}
 	}
 
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ const (
 
 type Domain interface {
 	Authenticate(ctx context.Context, in *AuthenticateInput) (*AuthenticateOutput, error)
+	CreateApiRequest(ctx context.Context, in *CreateApiRequestInput) (*CreateApiRequestOutput, error)
 
 	InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaTransactionInput) (*InsertApiQuotaTransactionOutput, error)
 
@@ -30,6 +32,7 @@ type Domain interface {
 
 type domain struct {
 	repository Repository
+	now        func() time.Time
 
 	ArcDomain   arc.Domain
 	TokenDomain token.Domain
@@ -38,6 +41,10 @@ type domain struct {
 type NewDomainInput struct {
 	Repository Repository
 
+	// Now is the clock of the rate limiter and of the quota
+	// periods. Defaults to time.Now.
+	Now func() time.Time
+
 	ArcDomain   arc.Domain
 	TokenDomain token.Domain
 }
@@ -51,6 +58,7 @@ func NewDomain(in *NewDomainInput) *domain {
 
 	return &domain{
 		repository:  rep,
+		now:         val.Ternary(in.Now == nil, time.Now, in.Now),
 		ArcDomain:   in.ArcDomain,
 		TokenDomain: in.TokenDomain,
 	}
@@ -58,6 +66,9 @@ func NewDomain(in *NewDomainInput) *domain {
 
 type AuthenticateInput struct {
 	AuthorizationHeader []string
+
+	// ResponseHeader receives the rate limit headers, if set.
+	ResponseHeader http.Header
 }
 
 type AuthenticateOutput struct {
@@ -106,6 +117,10 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 
 		request.SetSession(session)
 
+		if record := arc.AccessRecordFromContext(ctx); record != nil {
+			record.SetSession(session)
+		}
+
 		return request, nil
 	}
 
@@ -136,12 +151,28 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 				Detail: "Not enough daily quota to complete the request.",
 			}
 		case arc.QuotaExhaustedMonthly:
+			//errcatalog:retryable=false
 			return nil, &errors.Object{
 				Id:     "b9422b42-1d54-4869-8483-f6029b7d63a4",
 				Code:   errors.Code_RESOURCE_EXHAUSTED,
 				Label:  "MONTHLY_QUOTA_EXHAUSTED",
 				Detail: "Not enough monthly quota to complete the request.",
 			}
+		case arc.QuotaExhaustedExpired:
+			return nil, &errors.Object{
+				Id:     "378e4fa1-9d5a-401e-a795-93125d1326e9",
+				Code:   errors.Code_PERMISSION_DENIED,
+				Label:  "QUOTA_EXPIRED",
+				Detail: "The api access of the organization has expired.",
+			}
+		}
+
+		if err := dom.enforceRateLimit(systemRequest, &enforceRateLimitInput{
+			Session:        selectApiSessionOut.Session,
+			RateLimits:     selectApiSessionOut.RateLimits,
+			ResponseHeader: in.ResponseHeader,
+		}); err != nil {
+			return nil, errors.Forward(err, "a93f1a03-7929-401e-ae21-43edf571e17b")
 		}
 
 		request, err := createRequest(selectApiSessionOut.Session)
@@ -200,11 +231,33 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 
 	selectApiQuotaAvailabilityOut, err := dom.repository.SelectApiQuotaAvailability(systemRequest, &SelectApiQuotaAvailabilityInput{
 		OrganizationId: record.OrganizationId,
+		Now:            dom.now(),
 	})
 	if err != nil {
 		return nil, errors.Forward(err, "bd07a2d0-b5a8-4335-9b61-c33dfd80ef32")
 	}
 
+	quotaExpiresAt := selectApiQuotaAvailabilityOut.ExpiresAt
+
+	if !quotaExpiresAt.IsZero() && !dom.now().Before(quotaExpiresAt) {
+		_, err = dom.UpdateApiSession(systemRequest, &UpdateApiSessionInput{
+			QuotaExhausted: arc.QuotaExhaustedExpired,
+			KeyType:        keyType,
+			KeyHash:        keyHash,
+			TTL:            DefaultApiSessionTtl,
+		})
+		if err != nil {
+			return nil, errors.Forward(err, "010b397b-03c4-4a25-9ced-05ab2829e58c")
+		}
+
+		return nil, &errors.Object{
+			Id:     "27c97eb5-0a3e-4576-962e-514c6d5310d9",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Label:  "QUOTA_EXPIRED",
+			Detail: "The api access of the organization has expired.",
+		}
+	}
+
 	hasDailyQuota := selectApiQuotaAvailabilityOut.HasDailyQuota
 	hasMonthlyQuota := selectApiQuotaAvailabilityOut.HasMonthlyQuota
 
@@ -234,6 +287,7 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 				Detail: "Not enough daily quota to complete the request.",
 			}
 		case !hasMonthlyQuota:
+			//errcatalog:retryable=false
 			return nil, &errors.Object{
 				Id:     "856d32fb-7afe-476d-aae4-de8e185a4e04",
 				Code:   errors.Code_RESOURCE_EXHAUSTED,
@@ -255,6 +309,17 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 		))
 	}
 
+	// Cached sessions must not outlive the quota.
+	if !quotaExpiresAt.IsZero() {
+		ttl = int32(max(
+			1,
+			min(
+				int64(ttl),
+				int64(quotaExpiresAt.Sub(dom.now()).Seconds()),
+			),
+		))
+	}
+
 	session, err := dom.ArcDomain.CreateServerSession(
 		systemRequest,
 		&arc.CreateServerSessionInput{
@@ -276,15 +341,24 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 	}
 
 	_, err = dom.UpdateApiSession(systemRequest, &UpdateApiSessionInput{
-		KeyType: keyType,
-		KeyHash: keyHash,
-		Session: session,
-		TTL:     ttl,
+		KeyType:    keyType,
+		KeyHash:    keyHash,
+		Session:    session,
+		RateLimits: selectApiQuotaAvailabilityOut.RateLimits,
+		TTL:        ttl,
 	})
 	if err != nil {
 		return nil, errors.Forward(err, "a10ddbca-fd8c-45a4-82bb-c50b77c008f8")
 	}
 
+	if err := dom.enforceRateLimit(systemRequest, &enforceRateLimitInput{
+		Session:        session,
+		RateLimits:     selectApiQuotaAvailabilityOut.RateLimits,
+		ResponseHeader: in.ResponseHeader,
+	}); err != nil {
+		return nil, errors.Forward(err, "a84ae34c-b3ae-4c34-a22e-0bdebae70a4c")
+	}
+
 	request, err := createRequest(session)
 	if err != nil {
 		return nil, errors.Forward(err, "50a891b0-57da-4983-a9e3-0c96f25ba20c")
@@ -297,6 +371,78 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 	return out, nil
 }
 
+type CreateApiRequestInput struct {
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+	ApiKeyId       uuid.UUID
+	RoleName       string
+}
+
+type CreateApiRequestOutput struct {
+	Request *arc.Request
+}
+
+// CreateApiRequest creates a request on behalf of an organization
+// outside of an http call, e.g. for background jobs started with
+// an api key. The enabled layouts are read at creation time and
+// the session is never cached.
+func (dom *domain) CreateApiRequest(ctx context.Context, in *CreateApiRequestInput) (*CreateApiRequestOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "062635b8-ca7f-42e1-8f80-52ebeed7a8e4",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	systemRequest, err := dom.createSystemRequest(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "28ca70b4-fcbb-4bd9-8072-dfdf0d8a85cb")
+	}
+
+	selectApiQuotaAvailabilityOut, err := dom.repository.SelectApiQuotaAvailability(systemRequest, &SelectApiQuotaAvailabilityInput{
+		OrganizationId: in.OrganizationId,
+		Now:            dom.now(),
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "ccaa8daf-94bd-4666-9271-bd1c76591fb4")
+	}
+
+	session, err := dom.ArcDomain.CreateServerSession(
+		systemRequest,
+		&arc.CreateServerSessionInput{
+			OrganizationId: in.OrganizationId,
+			UserId:         in.UserId,
+			KeyId:          in.ApiKeyId,
+			RoleName:       in.RoleName,
+			SessionType:    arc.SessionTypeApiServer,
+			Timezone:       "UTC",
+			TTL:            1,
+			Flags:          selectApiQuotaAvailabilityOut.EnabledLayouts,
+			DoNotSave:      true,
+		},
+	)
+	if err != nil {
+		return nil, errors.Forward(err, "50bdef91-6317-4082-b33d-5555294aab97")
+	}
+
+	request, err := dom.ArcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: ctx,
+		Flags:   session.Flags(),
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "a6c8bafd-2195-4c16-9c05-d526fc0ee8ad")
+	}
+
+	request.SetSession(session)
+
+	out := &CreateApiRequestOutput{
+		Request: request,
+	}
+
+	return out, nil
+}
+
 type InsertApiQuotaTransactionInput struct {
 	Entity *arc.ApiQuotaTransaction
 }
@@ -315,10 +461,25 @@ func (dom *domain) InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaT
 
 	in.Entity.OrganizationId = r.Session().OrganizationId()
 
+	// The quota periods of the transaction are those of its timestamp.
+	if in.Entity.TrxTimestamp.IsZero() {
+		in.Entity.TrxTimestamp = dom.now()
+	}
+
 	if in.Entity.ApiKeyId == nil {
 		in.Entity.ApiKeyId = ptr.UUID(r.Session().KeyId())
 	}
 
+	idempotentCall := arc.IdempotentCallFromContext(r.Context())
+
+	if idempotentCall != nil {
+		if in.Entity.Meta == nil {
+			in.Entity.Meta = make(map[string]any)
+		}
+
+		in.Entity.Meta["idempotency_key"] = idempotentCall.Key
+	}
+
 	insertOut, err := dom.repository.InsertApiQuotaTransactionRecord(r, &InsertApiQuotaTransactionRecordInput{
 		Record: in.Entity,
 	})
@@ -326,6 +487,21 @@ func (dom *domain) InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaT
 		return nil, errors.Forward(err, "0f22efd1-6ac1-4129-b3b1-a6a9deef07f4")
 	}
 
+	if idempotentCall != nil {
+		idempotentCall.AddTransaction(in.Entity.Id)
+	}
+
+	if record := arc.AccessRecordFromContext(r.Context()); record != nil {
+		record.AddQuotaCharge(insertOut.TrxLayoutSum)
+	}
+
+	dom.recordApiQuotaNotifications(r, &recordApiQuotaNotificationsInput{
+		OrganizationId: in.Entity.OrganizationId,
+		ApiKeyId:       in.Entity.ApiKeyId,
+		Now:            in.Entity.TrxTimestamp,
+		Transaction:    insertOut,
+	})
+
 	if !insertOut.HasDailyQuota {
 		if insertOut.DailyQuota-insertOut.DailyUsage+insertOut.TrxLayoutSum > 0 {
 			return nil, &errors.Object{
@@ -337,23 +513,25 @@ func (dom *domain) InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaT
 		}
 
 		// Only update the cached session if the daily quota is exhausted.
+		// Requests created by CreateApiRequest have no cached session.
 
-		systemRequest, err := dom.createSystemRequest(r.Context())
-		if err != nil {
-			return nil, errors.Forward(err, "48a0bc41-3d42-4065-bfc4-f57fd2414a86")
-		}
+		if session := r.Session(); session.KeyHash() != "" {
+			systemRequest, err := dom.createSystemRequest(r.Context())
+			if err != nil {
+				return nil, errors.Forward(err, "48a0bc41-3d42-4065-bfc4-f57fd2414a86")
+			}
 
-		session := r.Session()
-		updateApiKeyInput := &UpdateApiSessionInput{
-			QuotaExhausted: arc.QuotaExhaustedDaily,
-			KeyType:        ApiKeyType(session.KeyType()),
-			KeyHash:        session.KeyHash(),
-			TTL:            DefaultApiSessionTtl,
-		}
+			updateApiKeyInput := &UpdateApiSessionInput{
+				QuotaExhausted: arc.QuotaExhaustedDaily,
+				KeyType:        ApiKeyType(session.KeyType()),
+				KeyHash:        session.KeyHash(),
+				TTL:            DefaultApiSessionTtl,
+			}
 
-		_, err = dom.UpdateApiSession(systemRequest, updateApiKeyInput)
-		if err != nil {
-			return nil, errors.Forward(err, "6d0b265c-92e0-4f9e-a850-a1cdf21c439c")
+			_, err = dom.UpdateApiSession(systemRequest, updateApiKeyInput)
+			if err != nil {
+				return nil, errors.Forward(err, "6d0b265c-92e0-4f9e-a850-a1cdf21c439c")
+			}
 		}
 
 		return nil, &errors.Object{
@@ -366,6 +544,7 @@ func (dom *domain) InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaT
 
 	if !insertOut.HasMonthlyQuota {
 		if insertOut.MonthlyQuota-insertOut.MonthlyUsage+insertOut.TrxLayoutSum > 0 {
+			//errcatalog:retryable=false
 			return nil, &errors.Object{
 				Id:     "f79b4e65-4713-432c-b0f1-b3872ea2e19b",
 				Code:   errors.Code_RESOURCE_EXHAUSTED,
@@ -375,25 +554,28 @@ func (dom *domain) InsertApiQuotaTransaction(r *arc.Request, in *InsertApiQuotaT
 		}
 
 		// Only update the cached session if the monthly quota is exhausted.
+		// Requests created by CreateApiRequest have no cached session.
 
-		systemRequest, err := dom.createSystemRequest(r.Context())
-		if err != nil {
-			return nil, errors.Forward(err, "af5a2133-9130-45c9-8538-f7100026779e")
-		}
+		if session := r.Session(); session.KeyHash() != "" {
+			systemRequest, err := dom.createSystemRequest(r.Context())
+			if err != nil {
+				return nil, errors.Forward(err, "af5a2133-9130-45c9-8538-f7100026779e")
+			}
 
-		session := r.Session()
-		updateApiKeyInput := &UpdateApiSessionInput{
-			QuotaExhausted: arc.QuotaExhaustedMonthly,
-			KeyType:        ApiKeyType(session.KeyType()),
-			KeyHash:        session.KeyHash(),
-			TTL:            DefaultApiSessionTtl,
-		}
+			updateApiKeyInput := &UpdateApiSessionInput{
+				QuotaExhausted: arc.QuotaExhaustedMonthly,
+				KeyType:        ApiKeyType(session.KeyType()),
+				KeyHash:        session.KeyHash(),
+				TTL:            DefaultApiSessionTtl,
+			}
 
-		_, err = dom.UpdateApiSession(systemRequest, updateApiKeyInput)
-		if err != nil {
-			return nil, errors.Forward(err, "b10405c2-52fa-4cc3-9b16-cc62b7d39452")
+			_, err = dom.UpdateApiSession(systemRequest, updateApiKeyInput)
+			if err != nil {
+				return nil, errors.Forward(err, "b10405c2-52fa-4cc3-9b16-cc62b7d39452")
+			}
 		}
 
+		//errcatalog:retryable=false
 		return nil, &errors.Object{
 			Id:     "43e398ef-e725-43f0-ae26-6ca121a30954",
 			Code:   errors.Code_RESOURCE_EXHAUSTED,
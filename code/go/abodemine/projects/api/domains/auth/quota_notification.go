@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+)
+
+// crossedQuotaPercents returns the percentages of quota that the
+// usage went past, from before (exclusive) to after (inclusive).
+func crossedQuotaPercents(quota, before, after int64, percents []int16) []int16 {
+	if quota <= 0 || after <= before {
+		return nil
+	}
+
+	var crossed []int16
+
+	for _, percent := range percents {
+		if percent <= 0 {
+			continue
+		}
+
+		// Multiply first, so small quotas don't round down to 0.
+		threshold := quota * int64(percent)
+
+		if before*100 < threshold && after*100 >= threshold {
+			crossed = append(crossed, percent)
+		}
+	}
+
+	slices.Sort(crossed)
+
+	return slices.Compact(crossed)
+}
+
+type recordApiQuotaNotificationsInput struct {
+	OrganizationId uuid.UUID
+	ApiKeyId       *uuid.UUID
+	Now            time.Time
+	Transaction    *InsertApiQuotaTransactionRecordOutput
+}
+
+// recordApiQuotaNotifications records the quota percentages crossed by
+// a transaction. The usage includes the requested amounts even when the
+// transaction was rejected, so 100% is recorded once demand exceeds the
+// quota. Failures are logged, since usage was already accounted for.
+func (dom *domain) recordApiQuotaNotifications(r *arc.Request, in *recordApiQuotaNotificationsInput) {
+	trx := in.Transaction
+
+	// Requests without layouts count as one base request.
+	amount := max(trx.TrxLayoutSum, 1)
+
+	var records []*arc.ApiQuotaNotification
+
+	periods := []struct {
+		period      arc.QuotaPeriod
+		periodStart time.Time
+		quota       int64
+		usage       int64
+	}{
+		{
+			period:      arc.QuotaPeriodDaily,
+			periodStart: arc.QuotaPeriodStart(arc.QuotaPeriodDaily, in.Now),
+			quota:       trx.DailyQuota,
+			usage:       trx.DailyUsage,
+		},
+		{
+			period:      arc.QuotaPeriodMonthly,
+			periodStart: arc.QuotaPeriodStart(arc.QuotaPeriodMonthly, in.Now),
+			quota:       trx.MonthlyQuota,
+			usage:       trx.MonthlyUsage,
+		},
+	}
+
+	for _, p := range periods {
+		for _, percent := range crossedQuotaPercents(p.quota, p.usage-amount, p.usage, trx.NotifyPercents) {
+			records = append(records, &arc.ApiQuotaNotification{
+				OrganizationId: in.OrganizationId,
+				ApiKeyId:       in.ApiKeyId,
+				QuotaPeriod:    p.period,
+				PeriodStart:    p.periodStart,
+				Percent:        percent,
+				Quota:          p.quota,
+				Usage:          p.usage,
+			})
+		}
+	}
+
+	if len(records) == 0 {
+		return
+	}
+
+	insertOut, err := dom.repository.InsertApiQuotaNotificationRecords(r, &InsertApiQuotaNotificationRecordsInput{
+		Records: records,
+	})
+	if err != nil {
+		r.Logger().Error().
+			Err(err).
+			Str("id", "c6712817-b64d-4139-b142-620ad655dfdf").
+			Str("organization_id", in.OrganizationId.String()).
+			Msg("Failed to record api quota notifications.")
+
+		return
+	}
+
+	for _, record := range insertOut.Records {
+		r.Logger().Info().
+			Str("organization_id", record.OrganizationId.String()).
+			Int16("quota_period", int16(record.QuotaPeriod)).
+			Int16("percent", record.Percent).
+			Int64("quota", record.Quota).
+			Int64("usage", record.Usage).
+			Msg("Api quota threshold crossed.")
+	}
+}
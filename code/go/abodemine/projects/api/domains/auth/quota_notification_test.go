@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossedQuotaPercents(t *testing.T) {
+	percents := []int16{100, 50, 80, 50}
+
+	tests := []struct {
+		name   string
+		quota  int64
+		before int64
+		after  int64
+		want   []int16
+	}{
+		{name: "Below", quota: 1000, before: 10, after: 20},
+		{name: "Exactly", quota: 1000, before: 499, after: 500, want: []int16{50}},
+		{name: "Already Crossed", quota: 1000, before: 500, after: 501},
+		{name: "Several", quota: 1000, before: 400, after: 1200, want: []int16{50, 80, 100}},
+		{name: "Small Quota", quota: 3, before: 1, after: 2, want: []int16{50}},
+		{name: "No Quota", quota: 0, before: 0, after: 10},
+		{name: "No Usage", quota: 1000, before: 600, after: 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, crossedQuotaPercents(tt.quota, tt.before, tt.after, percents))
+		})
+	}
+}
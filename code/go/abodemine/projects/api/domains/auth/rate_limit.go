@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+)
+
+// RateLimits are the request rates of an organization, as configured
+// in api_quotas. Rates are in requests per second and bursts in
+// requests. A zero rate disables the limit.
+type RateLimits struct {
+	OrgRate  int32 `json:"or,omitempty"`
+	OrgBurst int32 `json:"ob,omitempty"`
+	KeyRate  int32 `json:"kr,omitempty"`
+	KeyBurst int32 `json:"kb,omitempty"`
+}
+
+// MaxRateLimit is the highest rate, in requests per second. The
+// limits count emission intervals in microseconds, which higher
+// rates would round down to zero.
+const MaxRateLimit = int32(time.Second / time.Microsecond)
+
+// capRateLimits lowers the rates above MaxRateLimit to it.
+func capRateLimits(limits *RateLimits) *RateLimits {
+	v := *limits
+
+	v.OrgRate = min(v.OrgRate, MaxRateLimit)
+	v.KeyRate = min(v.KeyRate, MaxRateLimit)
+
+	return &v
+}
+
+type checkRateLimitInput struct {
+	OrganizationId uuid.UUID
+	ApiKeyId       uuid.UUID
+	RateLimits     *RateLimits
+}
+
+type checkRateLimitOutput struct {
+	// False when no limit applies to the request.
+	Limited bool
+
+	Allowed    bool
+	Limit      int64
+	Remaining  int64
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// rateLimitKey shares the hash slot of the organization,
+// so the org and key limits can be checked atomically.
+func rateLimitKey(orgId uuid.UUID, suffix string) string {
+	return fmt.Sprintf(
+		"{rl:%s}:%s",
+		base64.RawStdEncoding.EncodeToString(orgId[:]),
+		suffix,
+	)
+}
+
+// checkRateLimit counts the request against the org and key limits,
+// and returns the status of the most restrictive one.
+func (dom *domain) checkRateLimit(r *arc.Request, in *checkRateLimitInput) (*checkRateLimitOutput, error) {
+	out := &checkRateLimitOutput{}
+
+	limits := in.RateLimits
+	if limits == nil {
+		return out, nil
+	}
+
+	var (
+		keys []string
+		args []string
+	)
+
+	addLimit := func(key string, rate, burst int32) {
+		if rate <= 0 {
+			return
+		}
+
+		keys = append(keys, key)
+		args = append(
+			args,
+			strconv.FormatInt(max(time.Second.Microseconds()/int64(rate), 1), 10),
+			strconv.FormatInt(int64(max(burst, 1)), 10),
+		)
+	}
+
+	addLimit(rateLimitKey(in.OrganizationId, "o"), limits.OrgRate, limits.OrgBurst)
+
+	if in.ApiKeyId != uuid.Nil {
+		addLimit(
+			rateLimitKey(in.OrganizationId, "k:"+base64.RawStdEncoding.EncodeToString(in.ApiKeyId[:])),
+			limits.KeyRate,
+			limits.KeyBurst,
+		)
+	}
+
+	if len(keys) == 0 {
+		return out, nil
+	}
+
+	valkeyCli, err := dom.ArcDomain.SelectValkey(consts.ConfigKeyValkeyApi)
+	if err != nil {
+		return nil, errors.Forward(err, "f5136cba-20e2-469f-a834-a4fd611ef17e")
+	}
+
+	checkScript, err := dom.ArcDomain.SelectValkeyScript("check-api-rate-limit")
+	if err != nil {
+		return nil, errors.Forward(err, "13ed003c-821c-4c7b-95b1-3eb3e2407b38")
+	}
+
+	args = append([]string{strconv.FormatInt(dom.now().UnixMicro(), 10)}, args...)
+
+	values, err := checkScript.Exec(r.Context(), valkeyCli, keys, args).AsIntSlice()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "4e02fd0e-78cc-41e7-aad1-482deb849980",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if len(values) < 5 {
+		return nil, &errors.Object{
+			Id:     "451d5e44-3ae4-4f65-9513-7311c39bb1d6",
+			Code:   errors.Code_INTERNAL,
+			Detail: "Script output length mismatch.",
+		}
+	}
+
+	out.Limited = true
+	out.Allowed = values[0] == 1
+	out.Limit = values[1]
+	out.Remaining = values[2]
+	out.RetryAfter = time.Duration(values[3]) * time.Microsecond
+	out.Reset = time.Duration(values[4]) * time.Microsecond
+
+	return out, nil
+}
+
+// writeRateLimitHeaders sets the X-RateLimit-* headers, and
+// Retry-After when the request was not allowed. Durations
+// are rounded up to the second.
+func writeRateLimitHeaders(header http.Header, status *checkRateLimitOutput) {
+	if header == nil || !status.Limited {
+		return
+	}
+
+	seconds := func(d time.Duration) string {
+		return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+	}
+
+	header.Set("X-RateLimit-Limit", strconv.FormatInt(status.Limit, 10))
+	header.Set("X-RateLimit-Remaining", strconv.FormatInt(status.Remaining, 10))
+	header.Set("X-RateLimit-Reset", seconds(status.Reset))
+
+	if !status.Allowed {
+		header.Set("Retry-After", seconds(status.RetryAfter))
+	}
+}
+
+type enforceRateLimitInput struct {
+	Session        arc.ServerSession
+	RateLimits     *RateLimits
+	ResponseHeader http.Header
+}
+
+func (dom *domain) enforceRateLimit(r *arc.Request, in *enforceRateLimitInput) error {
+	checkOut, err := dom.checkRateLimit(r, &checkRateLimitInput{
+		OrganizationId: in.Session.OrganizationId(),
+		ApiKeyId:       in.Session.KeyId(),
+		RateLimits:     in.RateLimits,
+	})
+	if err != nil {
+		return errors.Forward(err, "bbe964b8-020c-4e21-b32f-136122dc82b4")
+	}
+
+	writeRateLimitHeaders(in.ResponseHeader, checkOut)
+
+	if checkOut.Limited && !checkOut.Allowed {
+		return &errors.Object{
+			Id:     "ef97a143-e121-4847-8c73-f3fe0bdf09a0",
+			Code:   errors.Code_RESOURCE_EXHAUSTED,
+			Label:  "RATE_LIMIT_EXCEEDED",
+			Detail: "Too many requests.",
+			Meta: map[string]any{
+				"retryAfter": checkOut.RetryAfter.Seconds(),
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/projects/api/conf"
+)
+
+func TestWriteRateLimitHeaders(t *testing.T) {
+	header := http.Header{}
+
+	writeRateLimitHeaders(header, &checkRateLimitOutput{})
+	assert.Empty(t, header)
+
+	writeRateLimitHeaders(header, &checkRateLimitOutput{
+		Limited:   true,
+		Allowed:   true,
+		Limit:     10,
+		Remaining: 9,
+		Reset:     100 * time.Millisecond,
+	})
+	assert.Equal(t, "10", header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "9", header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", header.Get("X-RateLimit-Reset"))
+	assert.Empty(t, header.Get("Retry-After"))
+
+	writeRateLimitHeaders(header, &checkRateLimitOutput{
+		Limited:    true,
+		Limit:      10,
+		RetryAfter: 1500 * time.Millisecond,
+		Reset:      10 * time.Second,
+	})
+	assert.Equal(t, "0", header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "10", header.Get("X-RateLimit-Reset"))
+	assert.Equal(t, "2", header.Get("Retry-After"))
+}
+
+func TestCapRateLimits(t *testing.T) {
+	limits := capRateLimits(&RateLimits{
+		OrgRate:  2_000_000,
+		OrgBurst: 10,
+		KeyRate:  100,
+		KeyBurst: 5,
+	})
+
+	assert.Equal(t, &RateLimits{
+		OrgRate:  MaxRateLimit,
+		OrgBurst: 10,
+		KeyRate:  100,
+		KeyBurst: 5,
+	}, limits)
+
+	// Capped rates keep an emission interval of a microsecond.
+	assert.Equal(t, int64(1), time.Second.Microseconds()/int64(limits.OrgRate))
+}
+
+/*
+GO_TEST_COUNT=1 \
+GO_TEST_PARAMS="-v" \
+make -C ${ABODEMINE_WORKSPACE}/code/go/abodemine \
+test/abodemine/projects/api/domains/auth \
+RUN="TestDomain_CheckRateLimit"
+*/
+func TestDomain_CheckRateLimit(t *testing.T) {
+	if os.Getenv("RUN") != t.Name() {
+		t.Skip("This test MUST be selected manually.")
+	}
+
+	config := conf.MustResolveAndLoadOnce()
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		Valkey:       config.Valkey,
+		ValkeyScript: config.ValkeyScript,
+	})
+
+	now := time.Now()
+
+	dom := NewDomain(&NewDomainInput{
+		ArcDomain: arcDomain,
+		Now: func() time.Time {
+			return now
+		},
+	})
+
+	r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+
+	// New ids on every run, so no state is left from previous runs.
+	orgId := uuid.New()
+	keyA := uuid.New()
+	keyB := uuid.New()
+
+	limits := &RateLimits{
+		OrgRate:  2,
+		OrgBurst: 3,
+		KeyRate:  1,
+		KeyBurst: 2,
+	}
+
+	check := func(keyId uuid.UUID) *checkRateLimitOutput {
+		out, err := dom.checkRateLimit(r, &checkRateLimitInput{
+			OrganizationId: orgId,
+			ApiKeyId:       keyId,
+			RateLimits:     limits,
+		})
+		require.NoError(t, err)
+		require.True(t, out.Limited)
+		return out
+	}
+
+	// The key burst is exhausted first.
+	out := check(keyA)
+	assert.True(t, out.Allowed)
+	assert.Equal(t, int64(2), out.Limit)
+	assert.Equal(t, int64(1), out.Remaining)
+
+	out = check(keyA)
+	assert.True(t, out.Allowed)
+	assert.Equal(t, int64(0), out.Remaining)
+
+	out = check(keyA)
+	assert.False(t, out.Allowed)
+	assert.Equal(t, time.Second, out.RetryAfter)
+
+	// Denied requests are not counted against the org.
+	out = check(keyB)
+	assert.True(t, out.Allowed)
+	assert.Equal(t, int64(3), out.Limit)
+	assert.Equal(t, int64(0), out.Remaining)
+
+	out = check(keyB)
+	assert.False(t, out.Allowed)
+	assert.Equal(t, int64(3), out.Limit)
+	assert.Equal(t, 500*time.Millisecond, out.RetryAfter)
+
+	now = now.Add(500 * time.Millisecond)
+
+	out = check(keyB)
+	assert.True(t, out.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+
+	out = check(keyA)
+	assert.True(t, out.Allowed)
+
+	// The org is exhausted again, while keyB still has room.
+	out = check(keyB)
+	assert.False(t, out.Allowed)
+	assert.Equal(t, int64(3), out.Limit)
+
+	// Limits without a rate are not checked.
+	out, err = dom.checkRateLimit(r, &checkRateLimitInput{
+		OrganizationId: orgId,
+		ApiKeyId:       keyA,
+		RateLimits:     &RateLimits{},
+	})
+	require.NoError(t, err)
+	assert.False(t, out.Limited)
+}
@@ -19,6 +19,7 @@ type Repository interface {
 	SelectApiKeyRecord(r *arc.Request, in *SelectApiKeyRecordInput) (*SelectApiKeyRecordOutput, error)
 
 	InsertApiQuotaTransactionRecord(r *arc.Request, in *InsertApiQuotaTransactionRecordInput) (*InsertApiQuotaTransactionRecordOutput, error)
+	InsertApiQuotaNotificationRecords(r *arc.Request, in *InsertApiQuotaNotificationRecordsInput) (*InsertApiQuotaNotificationRecordsOutput, error)
 	SelectApiQuotaAvailability(r *arc.Request, in *SelectApiQuotaAvailabilityInput) (*SelectApiQuotaAvailabilityOutput, error)
 
 	SelectClientRecord(r *arc.Request, in *SelectClientRecordInput) (*SelectClientRecordOutput, error)
@@ -82,6 +83,7 @@ func (rep *repository) SelectApiKeyRecord(r *arc.Request, in *SelectApiKeyRecord
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -117,6 +119,7 @@ func (rep *repository) SelectApiKeyRecord(r *arc.Request, in *SelectApiKeyRecord
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -152,21 +155,25 @@ type InsertApiQuotaTransactionRecordOutput struct {
 	HasMonthlyQuota bool
 
 	TrxLayoutSum int64
+
+	// Percentages of the quotas to notify when crossed.
+	NotifyPercents []int16
 }
 
 func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *InsertApiQuotaTransactionRecordInput) (*InsertApiQuotaTransactionRecordOutput, error) {
 	record := in.Record
 
+	now := val.Ternary(record.TrxTimestamp.IsZero(), time.Now(), record.TrxTimestamp)
+
 	apiQuotaAvailabilityPrefixOut, err := repo.buildApiQuotaAvailabilityPrefix(r, &buildApiQuotaAvailabilityPrefixInput{
 		OrganizationId:            record.OrganizationId,
 		ApiQuotaTransactionRecord: record,
+		Now:                       now,
 	})
 	if err != nil {
 		return nil, errors.Forward(err, "a5da13f3-6127-4986-ac96-79b37cec5592")
 	}
 
-	now := time.Now()
-
 	builder := squirrel.StatementBuilder.
 		PlaceholderFormat(squirrel.Dollar).
 		Insert("api_quota_transactions").
@@ -187,6 +194,8 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 			"recorder_lo_amount",
 			"rent_estimate_lo_amount",
 			"sale_estimate_lo_amount",
+			"market_stats_lo_amount",
+			"timeline_lo_amount",
 		).
 		PrefixExpr(apiQuotaAvailabilityPrefixOut.SquirrelExpr).
 		Values(
@@ -206,6 +215,8 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 			squirrel.Expr("case when (select ok from has_quota) then ? else 0 end", record.RecorderLayoutAmount),
 			squirrel.Expr("case when (select ok from has_quota) then ? else 0 end", record.RentEstimateLayoutAmount),
 			squirrel.Expr("case when (select ok from has_quota) then ? else 0 end", record.SaleEstimateLayoutAmount),
+			squirrel.Expr("case when (select ok from has_quota) then ? else 0 end", record.MarketStatsLayoutAmount),
+			squirrel.Expr("case when (select ok from has_quota) then ? else 0 end", record.TimelineLayoutAmount),
 		).
 		Suffix(
 			`
@@ -215,10 +226,12 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 				(select ok from has_daily_quota),
 				coalesce((select monthly_quota from api_quotas where organization_id = ?), 0),
 				(select ending_monthly_usage.total_sum from ending_monthly_usage),
-				(select ok from has_monthly_quota)
+				(select ok from has_monthly_quota),
+				(select notify_percents from api_quotas where organization_id = ?)
 			`,
 			record.OrganizationId,
 			record.OrganizationId,
+			record.OrganizationId,
 		)
 
 	sql, args, err := builder.ToSql()
@@ -228,6 +241,7 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -247,6 +261,7 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 		&out.MonthlyQuota,
 		&out.MonthlyUsage,
 		&out.HasMonthlyQuota,
+		&out.NotifyPercents,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return out, nil
@@ -257,6 +272,122 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+type InsertApiQuotaNotificationRecordsInput struct {
+	Records []*arc.ApiQuotaNotification
+}
+
+type InsertApiQuotaNotificationRecordsOutput struct {
+	// Records inserted, excluding the ones already recorded for the period.
+	Records []*arc.ApiQuotaNotification
+}
+
+func (repo *repository) InsertApiQuotaNotificationRecords(r *arc.Request, in *InsertApiQuotaNotificationRecordsInput) (*InsertApiQuotaNotificationRecordsOutput, error) {
+	out := &InsertApiQuotaNotificationRecordsOutput{}
+
+	if len(in.Records) == 0 {
+		return out, nil
+	}
+
+	now := time.Now()
+
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("api_quota_notifications").
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"meta",
+			"organization_id",
+			"api_key_id",
+			"quota_period",
+			"period_start",
+			"percent",
+			"quota",
+			"usage",
+		).
+		Suffix(`
+			on conflict (organization_id, quota_period, period_start, percent) do nothing
+			returning id
+		`)
+
+	byId := make(map[uuid.UUID]*arc.ApiQuotaNotification, len(in.Records))
+
+	for _, record := range in.Records {
+		if record.Id == uuid.Nil {
+			id, err := val.NewUUID7()
+			if err != nil {
+				return nil, errors.Forward(err, "196bf347-1555-49c6-bf07-69b45f8456b6")
+			}
+
+			record.Id = id
+		}
+
+		byId[record.Id] = record
+
+		builder = builder.Values(
+			record.Id,
+			now,
+			now,
+			record.Meta,
+			record.OrganizationId,
+			record.ApiKeyId,
+			record.QuotaPeriod,
+			record.PeriodStart,
+			record.Percent,
+			record.Quota,
+			record.Usage,
+		)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "9f996efd-f71e-4470-9688-faa45da3dec6",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "3592e281-d347-4354-861b-2f1adc806e06")
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, &errors.Object{
+				Id:     "0494141b-b146-4e3c-9dd2-85f4452eb2ba",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Records = append(out.Records, byId[id])
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "38899655-e414-4c18-aeff-ccdfdb04ee62",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -265,12 +396,19 @@ func (repo *repository) InsertApiQuotaTransactionRecord(r *arc.Request, in *Inse
 
 type SelectApiQuotaAvailabilityInput struct {
 	OrganizationId uuid.UUID
+
+	// Now selects the quota periods. Defaults to time.Now.
+	Now time.Time
 }
 
 type SelectApiQuotaAvailabilityOutput struct {
 	EnabledLayouts  []string
 	HasDailyQuota   bool
 	HasMonthlyQuota bool
+	RateLimits      *RateLimits
+
+	// Zero when the quota never expires.
+	ExpiresAt time.Time
 }
 
 func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApiQuotaAvailabilityInput) (*SelectApiQuotaAvailabilityOutput, error) {
@@ -278,6 +416,7 @@ func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApi
 	apiQuotaAvailabilityPrefixOut, err := repo.buildApiQuotaAvailabilityPrefix(r, &buildApiQuotaAvailabilityPrefixInput{
 		OrganizationId:            in.OrganizationId,
 		ApiQuotaTransactionRecord: &arc.ApiQuotaTransaction{},
+		Now:                       val.Ternary(in.Now.IsZero(), time.Now(), in.Now),
 	})
 	if err != nil {
 		return nil, errors.Forward(err, "50717fcd-e1a7-4674-83e0-a3ffbc120573")
@@ -297,12 +436,31 @@ func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApi
 				case when api_quotas.listing_lo_enabled then ARRAY['API_LISTING_LAYOUT_ENABLED'] end ||
 				case when api_quotas.recorder_lo_enabled then ARRAY['API_RECORDER_LAYOUT_ENABLED'] end ||
 				case when api_quotas.rent_estimate_lo_enabled then ARRAY['API_RENT_ESTIMATE_LAYOUT_ENABLED'] end ||
-				case when api_quotas.sale_estimate_lo_enabled then ARRAY['API_SALE_ESTIMATE_LAYOUT_ENABLED'] end
+				case when api_quotas.sale_estimate_lo_enabled then ARRAY['API_SALE_ESTIMATE_LAYOUT_ENABLED'] end ||
+				case when api_quotas.market_stats_lo_enabled then ARRAY['API_MARKET_STATS_LAYOUT_ENABLED'] end ||
+				case when api_quotas.timeline_lo_enabled then ARRAY['API_TIMELINE_LAYOUT_ENABLED'] end
 			from api_quotas
 			where organization_id = ?
 			)`,
 			in.OrganizationId,
 		)).
+		Column(squirrel.Expr(
+			`(
+			select array[
+				coalesce(org_rate_limit, 0),
+				coalesce(org_rate_burst, 0),
+				coalesce(key_rate_limit, 0),
+				coalesce(key_rate_burst, 0)
+			]
+			from api_quotas
+			where organization_id = ?
+			)`,
+			in.OrganizationId,
+		)).
+		Column(squirrel.Expr(
+			"(select expires_at from api_quotas where organization_id = ?)",
+			in.OrganizationId,
+		)).
 		PrefixExpr(apiQuotaAvailabilityPrefixOut.SquirrelExpr)
 
 	sql, args, err := builder.ToSql()
@@ -312,6 +470,7 @@ func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApi
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -322,10 +481,18 @@ func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApi
 
 	out := &SelectApiQuotaAvailabilityOutput{}
 
+	var (
+		// Org rate, org burst, key rate and key burst.
+		rateLimits []int32
+		expiresAt  pgtype.Timestamptz
+	)
+
 	if err := row.Scan(
 		&out.HasDailyQuota,
 		&out.HasMonthlyQuota,
 		&out.EnabledLayouts,
+		&rateLimits,
+		&expiresAt,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return out, nil
@@ -336,15 +503,33 @@ func (repo *repository) SelectApiQuotaAvailability(r *arc.Request, in *SelectApi
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
+	if expiresAt.Valid {
+		out.ExpiresAt = expiresAt.Time
+	}
+
+	if len(rateLimits) == 4 {
+		out.RateLimits = capRateLimits(&RateLimits{
+			OrgRate:  rateLimits[0],
+			OrgBurst: rateLimits[1],
+			KeyRate:  rateLimits[2],
+			KeyBurst: rateLimits[3],
+		})
+	}
+
 	return out, nil
 }
 
 type buildApiQuotaAvailabilityPrefixInput struct {
 	OrganizationId            uuid.UUID
 	ApiQuotaTransactionRecord *arc.ApiQuotaTransaction
+
+	// Now selects the quota periods, rather than the clock
+	// and the time zone of the database.
+	Now time.Time
 }
 
 type buildApiQuotaAvailabilityPrefixOutput struct {
@@ -361,7 +546,9 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 		int64(trxRecord.ListingLayoutAmount) +
 		int64(trxRecord.RecorderLayoutAmount) +
 		int64(trxRecord.RentEstimateLayoutAmount) +
-		int64(trxRecord.SaleEstimateLayoutAmount)
+		int64(trxRecord.SaleEstimateLayoutAmount) +
+		int64(trxRecord.MarketStatsLayoutAmount) +
+		int64(trxRecord.TimelineLayoutAmount)
 
 	originalTrxLayoutSum := trxLayoutSum
 
@@ -374,7 +561,7 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 		`
 		with current_daily_usage as (
 			select
-				extract(day from trx_timestamp) as day,
+				trx_timestamp >= ? as today,
 				sum(base_req_amount) as base_req_sum,
 				sum(address_lo_amount) as address_lo_sum,
 				sum(assessor_lo_amount) as assessor_lo_sum,
@@ -382,12 +569,14 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 				sum(listing_lo_amount) as listing_lo_sum,
 				sum(recorder_lo_amount) as recorder_lo_sum,
 				sum(rent_estimate_lo_amount) as rent_estimate_lo_sum,
-				sum(sale_estimate_lo_amount) as sale_estimate_lo_sum
+				sum(sale_estimate_lo_amount) as sale_estimate_lo_sum,
+				sum(market_stats_lo_amount) as market_stats_lo_sum,
+				sum(timeline_lo_amount) as timeline_lo_sum
 			from api_quota_transactions
 			where
 				organization_id = ?
-				and trx_timestamp >= date_trunc('month', current_date)
-			group by day
+				and trx_timestamp >= ?
+			group by today
 		), ending_daily_usage as (
 			select sum(
 				? +
@@ -401,10 +590,12 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 							coalesce(listing_lo_sum, 0) +
 							coalesce(recorder_lo_sum, 0) +
 							coalesce(rent_estimate_lo_sum, 0) +
-							coalesce(sale_estimate_lo_sum, 0)
+							coalesce(sale_estimate_lo_sum, 0) +
+							coalesce(market_stats_lo_sum, 0) +
+							coalesce(timeline_lo_sum, 0)
 						)
 						from current_daily_usage
-						where day = extract(day from current_date)
+						where today
 					),
 					0
 				)
@@ -428,7 +619,9 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 				sum(listing_lo_sum) as listing_lo_sum,
 				sum(recorder_lo_sum) as recorder_lo_sum,
 				sum(rent_estimate_lo_sum) as rent_estimate_lo_sum,
-				sum(sale_estimate_lo_sum) as sale_estimate_lo_sum
+				sum(sale_estimate_lo_sum) as sale_estimate_lo_sum,
+				sum(market_stats_lo_sum) as market_stats_lo_sum,
+				sum(timeline_lo_sum) as timeline_lo_sum
 			from current_daily_usage
 		), ending_monthly_usage as (
 			select
@@ -441,6 +634,8 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 					coalesce(recorder_lo_sum, 0) +
 					coalesce(rent_estimate_lo_sum, 0) +
 					coalesce(sale_estimate_lo_sum, 0) +
+					coalesce(market_stats_lo_sum, 0) +
+					coalesce(timeline_lo_sum, 0) +
 					?
 				) as total_sum
 			from current_monthly_usage
@@ -461,7 +656,9 @@ func (repo *repository) buildApiQuotaAvailabilityPrefix(_ *arc.Request, in *buil
 			) as ok
 		)
 		`,
+		arc.QuotaPeriodStart(arc.QuotaPeriodDaily, in.Now),
 		orgId,
+		arc.QuotaPeriodStart(arc.QuotaPeriodMonthly, in.Now),
 		trxLayoutSum,
 		orgId,
 		trxLayoutSum,
@@ -504,6 +701,7 @@ func (rep *repository) SelectClientRecord(r *arc.Request, in *SelectClientRecord
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -531,6 +729,7 @@ func (rep *repository) SelectClientRecord(r *arc.Request, in *SelectClientRecord
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
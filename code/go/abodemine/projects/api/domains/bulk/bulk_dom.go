@@ -0,0 +1,1010 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"abodemine/domains/address"
+	"abodemine/domains/arc"
+	"abodemine/domains/property"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/ptr"
+	"abodemine/lib/storage"
+	"abodemine/lib/val"
+	"abodemine/projects/api/conf"
+	"abodemine/projects/api/domains/auth"
+	"abodemine/projects/api/domains/search"
+)
+
+type BulkJobStatus int16
+
+const (
+	BulkJobStatusPending   BulkJobStatus = 100
+	BulkJobStatusRunning   BulkJobStatus = 200
+	BulkJobStatusSucceeded BulkJobStatus = 300
+	BulkJobStatusFailed    BulkJobStatus = 400
+)
+
+var bulkJobStatusNames = map[BulkJobStatus]string{
+	BulkJobStatusPending:   entities.BulkJobStatusPending,
+	BulkJobStatusRunning:   entities.BulkJobStatusRunning,
+	BulkJobStatusSucceeded: entities.BulkJobStatusSucceeded,
+	BulkJobStatusFailed:    entities.BulkJobStatusFailed,
+}
+
+type BulkJobFormat int16
+
+const (
+	BulkJobFormatCsv    BulkJobFormat = 100
+	BulkJobFormatNdjson BulkJobFormat = 200
+)
+
+var bulkJobFormatNames = map[BulkJobFormat]string{
+	BulkJobFormatCsv:    entities.BulkJobFormatCsv,
+	BulkJobFormatNdjson: entities.BulkJobFormatNdjson,
+}
+
+const (
+	DefaultMaxRows          = 100_000
+	DefaultConcurrency      = 8
+	DefaultDownloadTokenTtl = 7 * 24 * time.Hour
+	DefaultLeaseTtl         = 5 * time.Minute
+	DefaultMaxAttempts      = 3
+
+	// finalUpdateTimeout bounds the update recording the outcome
+	// of a job, which still runs when the worker is stopping.
+	finalUpdateTimeout = 30 * time.Second
+
+	// Rows enriched between quota transactions and progress updates.
+	chunkSize = 100
+
+	outputObjectName = "output.ndjson"
+)
+
+type Domain interface {
+	CreateBulkJob(r *arc.Request, in *CreateBulkJobInput) (*CreateBulkJobOutput, error)
+	SelectBulkJob(r *arc.Request, in *SelectBulkJobInput) (*SelectBulkJobOutput, error)
+	ProcessNextBulkJob(r *arc.Request, in *ProcessNextBulkJobInput) (*ProcessNextBulkJobOutput, error)
+}
+
+type domain struct {
+	repository Repository
+
+	addressDomain  address.Domain
+	authDomain     auth.Domain
+	propertyDomain property.Domain
+
+	storage          storage.Backend
+	dynamodbTable    string
+	downloadTokenTtl time.Duration
+	maxRows          int
+	concurrency      int
+	leaseTtl         time.Duration
+	maxAttempts      int
+}
+
+type NewDomainInput struct {
+	Repository Repository
+
+	// Bulk jobs are rejected when Config has no BulkJobs.
+	Config *conf.Config
+
+	AddressDomain  address.Domain
+	AuthDomain     auth.Domain
+	PropertyDomain property.Domain
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	dom := &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+		addressDomain:    in.AddressDomain,
+		authDomain:       in.AuthDomain,
+		propertyDomain:   in.PropertyDomain,
+		downloadTokenTtl: DefaultDownloadTokenTtl,
+		maxRows:          DefaultMaxRows,
+		concurrency:      DefaultConcurrency,
+		leaseTtl:         DefaultLeaseTtl,
+		maxAttempts:      DefaultMaxAttempts,
+	}
+
+	if in.Config == nil || in.Config.File == nil || in.Config.File.BulkJobs == nil {
+		return dom
+	}
+
+	bulkJobs := in.Config.File.BulkJobs
+
+	dom.storage = in.Config.BulkJobsStorage
+	dom.dynamodbTable = bulkJobs.DynamodbTable
+
+	if bulkJobs.DownloadTokenTtl > 0 {
+		dom.downloadTokenTtl = bulkJobs.DownloadTokenTtl
+	}
+
+	if bulkJobs.MaxRows > 0 {
+		dom.maxRows = bulkJobs.MaxRows
+	}
+
+	if bulkJobs.Concurrency > 0 {
+		dom.concurrency = bulkJobs.Concurrency
+	}
+
+	if bulkJobs.LeaseTtl > 0 {
+		dom.leaseTtl = bulkJobs.LeaseTtl
+	}
+
+	if bulkJobs.MaxAttempts > 0 {
+		dom.maxAttempts = bulkJobs.MaxAttempts
+	}
+
+	return dom
+}
+
+type CreateBulkJobInput struct {
+	// CSV or NDJSON. Inferred from FileName when empty.
+	Format   string
+	FileName string
+	Data     []byte
+
+	Layouts []string
+}
+
+type CreateBulkJobOutput struct {
+	Entity *entities.BulkJob
+}
+
+func (dom *domain) CreateBulkJob(r *arc.Request, in *CreateBulkJobInput) (*CreateBulkJobOutput, error) {
+	if dom.storage == nil {
+		return nil, &errors.Object{
+			Id:     "4e2f398d-be79-4b3e-9419-abfbd575a7a2",
+			Code:   errors.Code_UNIMPLEMENTED,
+			Detail: "Bulk jobs are not available.",
+		}
+	}
+
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "4528c3ee-f7c9-4a54-9f0d-f97569c0df93",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	// Fail early if a layout is not enabled, instead of
+	// failing the job once it is picked up by a worker.
+	if _, err := search.NewSelectPropertyInput(r, in.Layouts); err != nil {
+		return nil, errors.Forward(err, "d8df9412-cd8b-480d-9165-2a4e47235325")
+	}
+
+	format, err := parseBulkJobFormat(in.Format, in.FileName)
+	if err != nil {
+		return nil, errors.Forward(err, "ac7eeee2-ba34-4047-afa1-ad0e8efe0f33")
+	}
+
+	var totalRows int
+
+	if err := readBulkJobRows(format, bytes.NewReader(in.Data), func(_ *bulkJobRow) error {
+		totalRows++
+
+		if totalRows > dom.maxRows {
+			return &errors.Object{
+				Id:     "8fc52ab6-2c79-405c-a811-9bd0b4cd1432",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: fmt.Sprintf("The file has more than %d rows.", dom.maxRows),
+				Path:   "/file",
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, errors.Forward(err, "c339462b-8544-49c6-8550-e5a7c86dc8d6")
+	}
+
+	if totalRows == 0 {
+		return nil, &errors.Object{
+			Id:     "a992e428-4915-40da-baec-774b362e754f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The file has no rows.",
+			Path:   "/file",
+		}
+	}
+
+	id, err := val.NewUUID7()
+	if err != nil {
+		return nil, errors.Forward(err, "ec0661f3-caf9-4353-80b6-4a828d715b60")
+	}
+
+	session := r.Session()
+	now := time.Now()
+
+	record := &BulkJob{
+		Id:        id,
+		CreatedAt: now,
+		UpdatedAt: now,
+
+		OrganizationId: session.OrganizationId(),
+		UserId:         session.UserId(),
+		ApiKeyId:       session.KeyId(),
+		RoleName:       session.RoleName(),
+
+		JobStatus:   BulkJobStatusPending,
+		InputFormat: format,
+		Layouts:     normalizeLayouts(in.Layouts),
+		TotalRows:   totalRows,
+	}
+
+	inputObject := dom.jobObject(record, inputObjectName(format))
+	inputObject.Body = bytes.NewReader(in.Data)
+	inputObject.Size = int64(len(in.Data))
+
+	if err := dom.storage.Put(r.Context(), inputObject); err != nil {
+		return nil, errors.Forward(err, "9a54109b-e38a-4c14-9b38-5ae7a51a7839")
+	}
+
+	record.InputObject = dom.storage.PathJoin(inputObject.Dir, inputObject.Name)
+
+	if _, err := dom.repository.InsertBulkJobRecord(r, &InsertBulkJobRecordInput{
+		Record: record,
+	}); err != nil {
+		return nil, errors.Forward(err, "6f21e888-8238-485c-9f11-1e73fb242802")
+	}
+
+	out := &CreateBulkJobOutput{
+		Entity: newBulkJobEntity(record),
+	}
+
+	return out, nil
+}
+
+type SelectBulkJobInput struct {
+	Id uuid.UUID
+}
+
+type SelectBulkJobOutput struct {
+	Entity *entities.BulkJob
+}
+
+func (dom *domain) SelectBulkJob(r *arc.Request, in *SelectBulkJobInput) (*SelectBulkJobOutput, error) {
+	if in == nil || in.Id == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "9c0c782b-79be-4576-8b78-e8894cec2432",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing job id.",
+			Path:   "/id",
+		}
+	}
+
+	selectOut, err := dom.repository.SelectBulkJobRecord(r, &SelectBulkJobRecordInput{
+		Id:             in.Id,
+		OrganizationId: r.Session().OrganizationId(),
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "f2dac568-aa8d-459d-9710-4e573a3c12bf")
+	}
+
+	if selectOut.Record == nil {
+		return nil, &errors.Object{
+			Id:     "671a1ca5-3cf7-46ea-a4f1-09320c1f6c78",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Bulk job not found.",
+			Path:   "/id",
+		}
+	}
+
+	out := &SelectBulkJobOutput{
+		Entity: newBulkJobEntity(selectOut.Record),
+	}
+
+	return out, nil
+}
+
+type ProcessNextBulkJobInput struct{}
+
+type ProcessNextBulkJobOutput struct {
+	// Nil when there were no pending jobs.
+	Entity *entities.BulkJob
+}
+
+// ProcessNextBulkJob claims the oldest pending job and enriches
+// it. Failures are recorded on the job, so the returned error
+// is only set when the job could not be claimed or updated.
+//
+// The lease of the job is extended while it is processed. Jobs
+// of workers that stopped extending it are claimed again from
+// the start, so the rows enriched before are charged again.
+// A job that is claimed more than maxAttempts times fails.
+// When the worker is stopping, the job is released to pending.
+func (dom *domain) ProcessNextBulkJob(r *arc.Request, in *ProcessNextBulkJobInput) (*ProcessNextBulkJobOutput, error) {
+	if dom.storage == nil {
+		return nil, &errors.Object{
+			Id:     "95e795c5-2693-4d61-b4c4-db8a7a16cd16",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Missing storage backend.",
+		}
+	}
+
+	claimOut, err := dom.repository.ClaimBulkJobRecord(r, &ClaimBulkJobRecordInput{
+		LeaseTtl: dom.leaseTtl,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "ec174bb1-9f8c-48ba-8577-50f29524bc64")
+	}
+
+	out := &ProcessNextBulkJobOutput{}
+
+	record := claimOut.Record
+	if record == nil {
+		return out, nil
+	}
+
+	var update *UpdateBulkJobRecordInput
+
+	if record.Attempts > dom.maxAttempts {
+		update = &UpdateBulkJobRecordInput{
+			Id:          record.Id,
+			JobStatus:   BulkJobStatusFailed,
+			ErrorDetail: "The job was attempted too many times.",
+			Finished:    true,
+		}
+	} else {
+		update, err = dom.processLeasedBulkJob(r, record)
+		if err != nil {
+			r.Logger().Error().
+				Err(err).
+				Str("bulk_job_id", record.Id.String()).
+				Msg("Failed to process bulk job.")
+
+			update = &UpdateBulkJobRecordInput{
+				Id:           record.Id,
+				JobStatus:    BulkJobStatusFailed,
+				EnrichedRows: record.EnrichedRows,
+				FailedRows:   record.FailedRows,
+				ErrorDetail:  errorDetail(err),
+				Finished:     true,
+			}
+		}
+
+		if r.Context().Err() != nil {
+			update = &UpdateBulkJobRecordInput{
+				Id:        record.Id,
+				JobStatus: BulkJobStatusPending,
+			}
+		}
+	}
+
+	update.Attempts = record.Attempts
+
+	// The outcome is recorded even if the worker is stopping.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), finalUpdateTimeout)
+	defer cancel()
+
+	updateOut, err := dom.repository.UpdateBulkJobRecord(r.Clone(arc.CloneRequestWithContext(ctx)), update)
+	if err != nil {
+		return nil, errors.Forward(err, "d58cd265-81a2-4cb1-98dc-65ac39e335bd")
+	}
+
+	if !updateOut.Updated {
+		r.Logger().Warn().
+			Str("bulk_job_id", record.Id.String()).
+			Msg("Bulk job was claimed by another worker.")
+
+		return out, nil
+	}
+
+	if update.JobStatus == BulkJobStatusPending {
+		return out, nil
+	}
+
+	record.JobStatus = update.JobStatus
+	record.OutputObject = update.OutputObject
+	record.ErrorDetail = update.ErrorDetail
+	record.DownloadToken = update.DownloadToken
+	record.DownloadTokenExpiresAt = update.DownloadTokenExpiresAt
+	record.FinishedAt = time.Now()
+
+	out.Entity = newBulkJobEntity(record)
+
+	return out, nil
+}
+
+// processLeasedBulkJob processes the job while extending its
+// lease. Processing stops if the lease is lost.
+func (dom *domain) processLeasedBulkJob(r *arc.Request, record *BulkJob) (*UpdateBulkJobRecordInput, error) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	pr := r.Clone(arc.CloneRequestWithContext(ctx))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		dom.extendBulkJobLease(pr, record, cancel)
+	}()
+
+	update, err := dom.processBulkJob(pr, record)
+
+	cancel()
+	<-done
+
+	return update, err
+}
+
+// extendBulkJobLease extends the lease of the job until the
+// context of r is done. It cancels the processing when the job
+// was claimed by another worker. Failed extensions are retried,
+// since the lease outlives a few of them.
+func (dom *domain) extendBulkJobLease(r *arc.Request, record *BulkJob, cancel context.CancelFunc) {
+	ticker := time.NewTicker(dom.leaseTtl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		extendOut, err := dom.repository.ExtendBulkJobLeaseRecord(r, &ExtendBulkJobLeaseRecordInput{
+			Id:       record.Id,
+			LeaseTtl: dom.leaseTtl,
+			Attempts: record.Attempts,
+		})
+		if err != nil {
+			if r.Context().Err() != nil {
+				return
+			}
+
+			r.Logger().Error().
+				Err(err).
+				Str("bulk_job_id", record.Id.String()).
+				Msg("Failed to extend bulk job lease.")
+
+			continue
+		}
+
+		if !extendOut.Extended {
+			r.Logger().Warn().
+				Str("bulk_job_id", record.Id.String()).
+				Msg("Lost bulk job lease.")
+
+			cancel()
+
+			return
+		}
+	}
+}
+
+// processBulkJob enriches every row of the job and delivers the
+// results. The row counters of record are updated as it goes.
+// If the job stops early, e.g. when the quota is exhausted,
+// the rows enriched so far are still delivered.
+func (dom *domain) processBulkJob(r *arc.Request, record *BulkJob) (*UpdateBulkJobRecordInput, error) {
+	// Quota is charged to the organization that created the job.
+	createApiRequestOut, err := dom.authDomain.CreateApiRequest(r.Context(), &auth.CreateApiRequestInput{
+		OrganizationId: record.OrganizationId,
+		UserId:         record.UserId,
+		ApiKeyId:       record.ApiKeyId,
+		RoleName:       record.RoleName,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "d37f4de9-fcfb-45ae-a52e-35acced8dcae")
+	}
+
+	apiRequest := createApiRequestOut.Request
+
+	selectPropertyInput, err := search.NewSelectPropertyInput(apiRequest, record.Layouts)
+	if err != nil {
+		return nil, errors.Forward(err, "b529060e-0b1f-4beb-9d3b-1f5cbc615cd1")
+	}
+
+	input, err := dom.storage.Get(r.Context(), dom.jobObject(record, inputObjectName(record.InputFormat)))
+	if err != nil {
+		return nil, errors.Forward(err, "33f4f229-8d92-4735-8764-202be51f0236")
+	}
+
+	defer func() {
+		if err := input.Close(); err != nil {
+			r.Logger().Error().
+				Err(err).
+				Str("id", "892b186d-03c7-462e-88af-72e8be16bafa").
+				Msg("Failed to close bulk job input.")
+		}
+	}()
+
+	outputFile, err := os.CreateTemp("", "bulk-job-*.ndjson")
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "3f6b1f2d-70b1-4eb0-a01e-3a0edc83e80a",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create temporary file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	defer func() {
+		if err := outputFile.Close(); err != nil {
+			r.Logger().Error().
+				Err(err).
+				Str("id", "ab75130f-52e0-4bae-8813-d749600be9b4").
+				Msg("Failed to close temporary file.")
+		}
+
+		if err := os.Remove(outputFile.Name()); err != nil {
+			r.Logger().Error().
+				Err(err).
+				Str("id", "94e30a62-3fa8-46d1-a7bc-35faef666f58").
+				Msg("Failed to remove temporary file.")
+		}
+	}()
+
+	writer := bufio.NewWriter(outputFile)
+	written := 0
+
+	rows := make([]*bulkJobRow, 0, chunkSize)
+
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+
+		enrichOut, err := dom.enrichRows(apiRequest, selectPropertyInput, rows)
+		if err != nil {
+			return errors.Forward(err, "48644a77-cb91-4073-8822-37761040a8f3")
+		}
+
+		if enrichOut.EnrichedRows > 0 {
+			enrichOut.Transaction.Description = ptr.String("/api/v3/bulk-jobs")
+
+			if _, err := dom.authDomain.InsertApiQuotaTransaction(apiRequest, &auth.InsertApiQuotaTransactionInput{
+				Entity: enrichOut.Transaction,
+			}); err != nil {
+				return errors.Forward(err, "bbf3ec85-4693-4066-a465-2d2cc0ef7a30")
+			}
+		}
+
+		if err := writeResults(writer, enrichOut.Results); err != nil {
+			return errors.Forward(err, "535aaefc-9c18-41c7-964d-0298f119249e")
+		}
+
+		written += len(enrichOut.Results)
+		record.EnrichedRows += enrichOut.EnrichedRows
+		record.FailedRows += enrichOut.FailedRows
+
+		rows = rows[:0]
+
+		updateOut, err := dom.repository.UpdateBulkJobRecord(r, &UpdateBulkJobRecordInput{
+			Id:           record.Id,
+			Attempts:     record.Attempts,
+			JobStatus:    BulkJobStatusRunning,
+			EnrichedRows: record.EnrichedRows,
+			FailedRows:   record.FailedRows,
+		})
+		if err != nil {
+			return errors.Forward(err, "f245d3eb-63cc-4ec9-8743-de7c8bd35dcc")
+		}
+
+		if !updateOut.Updated {
+			return &errors.Object{
+				Id:     "58979bf8-711a-4d8f-a1f7-097de1a029de",
+				Code:   errors.Code_ABORTED,
+				Detail: "The job was claimed by another worker.",
+			}
+		}
+
+		return nil
+	}
+
+	jobErr := readBulkJobRows(record.InputFormat, input, func(row *bulkJobRow) error {
+		rows = append(rows, row)
+
+		if len(rows) < chunkSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if jobErr == nil {
+		jobErr = flush()
+	}
+
+	if jobErr != nil && written == 0 {
+		return nil, errors.Forward(jobErr, "e77b6c7b-ccc9-4334-a309-e93aef12671a")
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, &errors.Object{
+			Id:     "e3405626-5d7a-435c-97af-545b7f099e8c",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write temporary file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if _, err := outputFile.Seek(0, io.SeekStart); err != nil {
+		return nil, &errors.Object{
+			Id:     "b22927bc-ca0d-49ce-93fe-34c337ae339e",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to rewind temporary file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	outputObject := dom.jobObject(record, outputObjectName)
+	outputObject.Body = outputFile
+
+	if err := dom.storage.Put(r.Context(), outputObject); err != nil {
+		return nil, errors.Forward(err, "f8c349ef-6984-42a2-8091-ddaac758125a")
+	}
+
+	update := &UpdateBulkJobRecordInput{
+		Id:           record.Id,
+		JobStatus:    BulkJobStatusSucceeded,
+		EnrichedRows: record.EnrichedRows,
+		FailedRows:   record.FailedRows,
+		OutputObject: dom.storage.PathJoin(outputObject.Dir, outputObject.Name),
+		Finished:     true,
+	}
+
+	if dom.dynamodbTable != "" {
+		createDownloadTokenOut, err := dom.createDownloadToken(r, record, update.OutputObject)
+		if err != nil {
+			return nil, errors.Forward(err, "b8b2ea4b-570d-443e-9d0a-3b60e06969be")
+		}
+
+		update.DownloadToken = createDownloadTokenOut.Token
+		update.DownloadTokenExpiresAt = createDownloadTokenOut.ExpiresAt
+	}
+
+	if jobErr != nil {
+		r.Logger().Error().
+			Err(jobErr).
+			Str("bulk_job_id", record.Id.String()).
+			Int("written_rows", written).
+			Msg("Bulk job stopped early.")
+
+		update.JobStatus = BulkJobStatusFailed
+		update.ErrorDetail = errorDetail(jobErr)
+	}
+
+	return update, nil
+}
+
+type enrichRowsOutput struct {
+	Results []*entities.BulkJobResult
+
+	// Transaction sums the layouts of the enriched rows.
+	Transaction *arc.ApiQuotaTransaction
+
+	EnrichedRows int
+	FailedRows   int
+}
+
+// enrichRows enriches the rows concurrently, keeping their order.
+func (dom *domain) enrichRows(r *arc.Request, selectPropertyInput *property.SelectPropertyInput, rows []*bulkJobRow) (*enrichRowsOutput, error) {
+	results := make([]*entities.BulkJobResult, len(rows))
+	selectPropertyOuts := make([]*property.SelectPropertyOutput, len(rows))
+
+	var g errgroup.Group
+	g.SetLimit(dom.concurrency)
+
+	for i, row := range rows {
+		g.Go(func() error {
+			result, selectPropertyOut, err := dom.enrichRow(r, selectPropertyInput, row)
+			if err != nil {
+				return errors.Forward(err, "1ffee670-9905-46cb-b7c7-1c206a53154d")
+			}
+
+			results[i] = result
+			selectPropertyOuts[i] = selectPropertyOut
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, errors.Forward(err, "4b1b2240-748c-4112-a0a7-06c1aca60bc8")
+	}
+
+	out := &enrichRowsOutput{
+		Results:     results,
+		Transaction: &arc.ApiQuotaTransaction{},
+	}
+
+	for i, result := range results {
+		switch {
+		case result.Error != nil:
+			out.FailedRows++
+		case len(result.Properties) > 0:
+			out.EnrichedRows++
+			addLayoutSums(out.Transaction, selectPropertyOuts[i])
+		}
+	}
+
+	return out, nil
+}
+
+// enrichRow resolves a row the same way /api/v3/search does.
+// Invalid search criteria fail the row. Any other error fails
+// the job. Rows matching no property have no properties and
+// no error, and are not charged.
+func (dom *domain) enrichRow(r *arc.Request, selectPropertyInput *property.SelectPropertyInput, row *bulkJobRow) (*entities.BulkJobResult, *property.SelectPropertyOutput, error) {
+	result := &entities.BulkJobResult{
+		Row:        row.Row,
+		Id:         row.Id,
+		Properties: []*entities.Property{},
+	}
+
+	var aupids []*uuid.UUID
+
+	if row.Aupid != nil {
+		aupids = []*uuid.UUID{row.Aupid}
+	} else {
+		if err := search.ValidateApiSearchAddress(row.Address); err != nil {
+			result.Error = rowError(err)
+			return result, nil, nil
+		}
+
+		selectPropertyAddressOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
+			IncludePropertyRefs: true,
+			ApiSearchAddress:    row.Address,
+		})
+		if err != nil {
+			if isRowError(err) {
+				result.Error = rowError(err)
+				return result, nil, nil
+			}
+
+			return nil, nil, errors.Forward(err, "036efa91-3f5d-4fc8-8928-1213b9982331")
+		}
+
+		for _, propertyRef := range selectPropertyAddressOut.PropertyRefEntities {
+			aupids = append(aupids, propertyRef.Aupid)
+		}
+	}
+
+	if len(aupids) == 0 {
+		return result, nil, nil
+	}
+
+	in := *selectPropertyInput
+	in.Aupids = aupids
+
+	selectPropertyOut, err := dom.propertyDomain.SelectProperty(r, &in)
+	if err != nil {
+		if isRowError(err) {
+			result.Error = rowError(err)
+			return result, nil, nil
+		}
+
+		return nil, nil, errors.Forward(err, "7eaf3e4a-bd60-4ff5-acc8-8ba19f62e5fe")
+	}
+
+	if selectPropertyOut.PropertyEntities != nil {
+		result.Properties = selectPropertyOut.PropertyEntities
+	}
+
+	return result, selectPropertyOut, nil
+}
+
+type createDownloadTokenOutput struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// createDownloadToken registers a token in the packer
+// secure-download table, restricted to the given object.
+func (dom *domain) createDownloadToken(r *arc.Request, record *BulkJob, object string) (*createDownloadTokenOutput, error) {
+	awsConfig, err := r.Dom().SelectAWS("default")
+	if err != nil {
+		return nil, errors.Forward(err, "5b9f57e1-0dc6-4d84-8149-86aacd0befff")
+	}
+
+	b := make([]byte, 32)
+
+	if _, err := rand.Read(b); err != nil {
+		return nil, &errors.Object{
+			Id:     "4dfd2fff-f61e-4f7c-8300-19c7fe6607b8",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to generate token.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out := &createDownloadTokenOutput{
+		Token:     base64.RawURLEncoding.EncodeToString(b),
+		ExpiresAt: time.Now().Add(dom.downloadTokenTtl).UTC(),
+	}
+
+	dynamodbClient := dynamodb.NewFromConfig(awsConfig)
+
+	if _, err := dynamodbClient.PutItem(r.Context(), &dynamodb.PutItemInput{
+		TableName: &dom.dynamodbTable,
+		Item: map[string]types.AttributeValue{
+			"token":           &types.AttributeValueMemberS{Value: out.Token},
+			"object":          &types.AttributeValueMemberS{Value: downloadObjectKey(object)},
+			"organization_id": &types.AttributeValueMemberS{Value: record.OrganizationId.String()},
+			"bulk_job_id":     &types.AttributeValueMemberS{Value: record.Id.String()},
+			"expires_at":      &types.AttributeValueMemberN{Value: strconv.FormatInt(out.ExpiresAt.Unix(), 10)},
+		},
+	}); err != nil {
+		return nil, &errors.Object{
+			Id:     "d99e3d64-a98c-4f05-b1b9-47d84e903ac4",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to put DynamoDB item.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+func (dom *domain) jobObject(record *BulkJob, name string) *storage.Object {
+	return &storage.Object{
+		Dir: dom.storage.PathJoin(
+			dom.storage.Path(),
+			"bulk-jobs",
+			record.OrganizationId.String(),
+			record.Id.String(),
+		),
+		Name: name,
+	}
+}
+
+func inputObjectName(format BulkJobFormat) string {
+	return val.Ternary(format == BulkJobFormatNdjson, "input.ndjson", "input.csv")
+}
+
+// downloadObjectKey returns the object name expected by the
+// secure-download endpoint, which is relative to the bucket.
+func downloadObjectKey(object string) string {
+	return strings.TrimPrefix(object, "/")
+}
+
+func parseBulkJobFormat(format, fileName string) (BulkJobFormat, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".csv":
+			return BulkJobFormatCsv, nil
+		case ".ndjson", ".jsonl":
+			return BulkJobFormatNdjson, nil
+		}
+
+		return 0, &errors.Object{
+			Id:     "cb38f47c-fb51-4d04-a1eb-bb96475c4a47",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The format is required when the file name has no .csv or .ndjson extension.",
+			Path:   "/format",
+		}
+	}
+
+	for k, v := range bulkJobFormatNames {
+		if strings.EqualFold(format, v) {
+			return k, nil
+		}
+	}
+
+	return 0, &errors.Object{
+		Id:     "05b82600-2b03-4066-8930-edd68b7502a4",
+		Code:   errors.Code_INVALID_ARGUMENT,
+		Detail: fmt.Sprintf("Invalid format: %s.", format),
+		Path:   "/format",
+	}
+}
+
+func normalizeLayouts(layouts []string) []string {
+	out := make([]string, len(layouts))
+
+	for i, layout := range layouts {
+		out[i] = strings.ToUpper(strings.TrimSpace(layout))
+	}
+
+	return out
+}
+
+func writeResults(w io.Writer, results []*entities.BulkJobResult) error {
+	enc := json.NewEncoder(w)
+
+	for _, result := range results {
+		if err := enc.Encode(result); err != nil {
+			return &errors.Object{
+				Id:     "514b4322-7f39-4447-8b8f-36cf5a0e369b",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to encode result.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func addLayoutSums(trx *arc.ApiQuotaTransaction, out *property.SelectPropertyOutput) {
+	trx.AddressLayoutAmount += out.AddressLayoutSum
+	trx.AssessorLayoutAmount += out.AssessorLayoutSum
+	trx.CompsLayoutAmount += out.CompsLayoutSum
+	trx.ListingLayoutAmount += out.ListingLayoutSum
+	trx.RecorderLayoutAmount += out.RecorderLayoutSum
+	trx.RentEstimateLayoutAmount += out.RentalAvmLayoutSum
+	trx.SaleEstimateLayoutAmount += out.SaleAvmLayoutSum
+	trx.TimelineLayoutAmount += out.TimelineLayoutSum
+}
+
+func isRowError(err error) bool {
+	code := errors.CodeOf(err)
+	return code == errors.Code_INVALID_ARGUMENT || code == errors.Code_NOT_FOUND
+}
+
+// rowError returns the error shown to the customer for a row.
+func rowError(err error) *errors.Object {
+	return errors.Sanitize(err, false).First()
+}
+
+func errorDetail(err error) string {
+	return errors.Sanitize(err, false).First().Detail
+}
+
+func newBulkJobEntity(record *BulkJob) *entities.BulkJob {
+	entity := &entities.BulkJob{
+		Id:           record.Id,
+		Status:       bulkJobStatusNames[record.JobStatus],
+		Format:       bulkJobFormatNames[record.InputFormat],
+		Layouts:      record.Layouts,
+		TotalRows:    record.TotalRows,
+		EnrichedRows: record.EnrichedRows,
+		FailedRows:   record.FailedRows,
+		CreatedAt:    record.CreatedAt,
+	}
+
+	if entity.Layouts == nil {
+		entity.Layouts = []string{}
+	}
+
+	if record.ErrorDetail != "" {
+		entity.Error = ptr.String(record.ErrorDetail)
+	}
+
+	if record.DownloadToken != "" {
+		entity.Download = &entities.BulkJobDownload{
+			Token:     record.DownloadToken,
+			Object:    downloadObjectKey(record.OutputObject),
+			ExpiresAt: record.DownloadTokenExpiresAt,
+		}
+	}
+
+	if !record.StartedAt.IsZero() {
+		entity.StartedAt = ptr.Time(record.StartedAt)
+	}
+
+	if !record.FinishedAt.IsZero() {
+		entity.FinishedAt = ptr.Time(record.FinishedAt)
+	}
+
+	return entity
+}
@@ -0,0 +1,484 @@
+package bulk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+	"abodemine/lib/val"
+)
+
+type Repository interface {
+	InsertBulkJobRecord(r *arc.Request, in *InsertBulkJobRecordInput) (*InsertBulkJobRecordOutput, error)
+	SelectBulkJobRecord(r *arc.Request, in *SelectBulkJobRecordInput) (*SelectBulkJobRecordOutput, error)
+	ClaimBulkJobRecord(r *arc.Request, in *ClaimBulkJobRecordInput) (*ClaimBulkJobRecordOutput, error)
+	UpdateBulkJobRecord(r *arc.Request, in *UpdateBulkJobRecordInput) (*UpdateBulkJobRecordOutput, error)
+	ExtendBulkJobLeaseRecord(r *arc.Request, in *ExtendBulkJobLeaseRecordInput) (*ExtendBulkJobLeaseRecordOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+type BulkJob struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	OrganizationId uuid.UUID
+	UserId         uuid.UUID
+	ApiKeyId       uuid.UUID
+	RoleName       string
+
+	JobStatus   BulkJobStatus
+	InputFormat BulkJobFormat
+	Layouts     []string
+
+	InputObject  string
+	OutputObject string
+
+	TotalRows    int
+	EnrichedRows int
+	FailedRows   int
+
+	ErrorDetail string
+
+	DownloadToken          string
+	DownloadTokenExpiresAt time.Time
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// Attempts counts the claims of the job.
+	Attempts int
+}
+
+var bulkJobColumns = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"organization_id",
+	"user_id",
+	"api_key_id",
+	"role_name",
+	"job_status",
+	"input_format",
+	"layouts",
+	"input_object",
+	"output_object",
+	"total_rows",
+	"enriched_rows",
+	"failed_rows",
+	"error_detail",
+	"download_token",
+	"download_token_expires_at",
+	"started_at",
+	"finished_at",
+	"attempts",
+}
+
+func scanBulkJob(row pgx.Row) (*BulkJob, error) {
+	var (
+		userId                 pgtype.UUID
+		apiKeyId               pgtype.UUID
+		outputObject           pgtype.Text
+		errorDetail            pgtype.Text
+		downloadToken          pgtype.Text
+		downloadTokenExpiresAt pgtype.Timestamptz
+		startedAt              pgtype.Timestamptz
+		finishedAt             pgtype.Timestamptz
+	)
+
+	record := new(BulkJob)
+
+	if err := row.Scan(
+		&record.Id,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+		&record.OrganizationId,
+		&userId,
+		&apiKeyId,
+		&record.RoleName,
+		&record.JobStatus,
+		&record.InputFormat,
+		&record.Layouts,
+		&record.InputObject,
+		&outputObject,
+		&record.TotalRows,
+		&record.EnrichedRows,
+		&record.FailedRows,
+		&errorDetail,
+		&downloadToken,
+		&downloadTokenExpiresAt,
+		&startedAt,
+		&finishedAt,
+		&record.Attempts,
+	); err != nil {
+		return nil, err
+	}
+
+	if userId.Valid {
+		u, err := val.UUIDFromBytes(userId.Bytes[:])
+		if err != nil {
+			return nil, errors.Forward(err, "e4c17ec8-8b45-4be7-bad2-b1b0dfdd1382")
+		}
+
+		record.UserId = u
+	}
+
+	if apiKeyId.Valid {
+		u, err := val.UUIDFromBytes(apiKeyId.Bytes[:])
+		if err != nil {
+			return nil, errors.Forward(err, "67e884ae-6d5f-4812-af93-35e8f5799388")
+		}
+
+		record.ApiKeyId = u
+	}
+
+	record.OutputObject = outputObject.String
+	record.ErrorDetail = errorDetail.String
+	record.DownloadToken = downloadToken.String
+
+	if downloadTokenExpiresAt.Valid {
+		record.DownloadTokenExpiresAt = downloadTokenExpiresAt.Time
+	}
+
+	if startedAt.Valid {
+		record.StartedAt = startedAt.Time
+	}
+
+	if finishedAt.Valid {
+		record.FinishedAt = finishedAt.Time
+	}
+
+	return record, nil
+}
+
+type InsertBulkJobRecordInput struct {
+	Record *BulkJob
+}
+
+type InsertBulkJobRecordOutput struct{}
+
+func (repo *repository) InsertBulkJobRecord(r *arc.Request, in *InsertBulkJobRecordInput) (*InsertBulkJobRecordOutput, error) {
+	record := in.Record
+
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("bulk_jobs").
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"user_id",
+			"api_key_id",
+			"role_name",
+			"job_status",
+			"input_format",
+			"layouts",
+			"input_object",
+			"total_rows",
+		).
+		Values(
+			record.Id,
+			record.CreatedAt,
+			record.UpdatedAt,
+			record.OrganizationId,
+			val.Ternary(record.UserId == uuid.Nil, nil, &record.UserId),
+			val.Ternary(record.ApiKeyId == uuid.Nil, nil, &record.ApiKeyId),
+			record.RoleName,
+			record.JobStatus,
+			record.InputFormat,
+			record.Layouts,
+			record.InputObject,
+			record.TotalRows,
+		).
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "23cf8b2f-620f-4089-92a9-6bd9474ef3ce",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if _, err := extutils.PgxExec(r, consts.ConfigKeyPostgresApi, sql, args); err != nil {
+		return nil, errors.Forward(err, "3ff73fb8-78e3-4d6f-932e-001b65230186")
+	}
+
+	return &InsertBulkJobRecordOutput{}, nil
+}
+
+type SelectBulkJobRecordInput struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+}
+
+type SelectBulkJobRecordOutput struct {
+	// Nil when the job does not exist for the organization.
+	Record *BulkJob
+}
+
+func (repo *repository) SelectBulkJobRecord(r *arc.Request, in *SelectBulkJobRecordInput) (*SelectBulkJobRecordOutput, error) {
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(bulkJobColumns...).
+		From("bulk_jobs").
+		Where("id = ?", in.Id).
+		Where("organization_id = ?", in.OrganizationId).
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "39d9e831-3fad-4bd9-a895-3a88b643be1a",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "dfbd0e87-fb84-471a-ae78-7d1ed2f8c5be")
+	}
+
+	out := &SelectBulkJobRecordOutput{}
+
+	record, err := scanBulkJob(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, nil
+		}
+
+		return nil, &errors.Object{
+			Id:     "9f129873-0536-44cb-b922-f94127d26339",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to fetch row.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out.Record = record
+
+	return out, nil
+}
+
+type ClaimBulkJobRecordInput struct {
+	// LeaseTtl is how long the job is claimed, unless the
+	// lease is extended.
+	LeaseTtl time.Duration
+}
+
+type ClaimBulkJobRecordOutput struct {
+	// Nil when there are no pending jobs.
+	Record *BulkJob
+}
+
+// ClaimBulkJobRecord marks the oldest pending job as running and
+// returns it. Running jobs whose lease expired are claimed again,
+// from the start. Concurrent workers never claim the same job.
+func (repo *repository) ClaimBulkJobRecord(r *arc.Request, in *ClaimBulkJobRecordInput) (*ClaimBulkJobRecordOutput, error) {
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("bulk_jobs").
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("started_at", squirrel.Expr("now()")).
+		Set("heartbeat_at", squirrel.Expr("now()")).
+		Set("locked_until", squirrel.Expr("now() + ? * interval '1 millisecond'", in.LeaseTtl.Milliseconds())).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("job_status", BulkJobStatusRunning).
+		Set("enriched_rows", 0).
+		Set("failed_rows", 0).
+		Where(
+			`id = (
+				select id
+				from bulk_jobs
+				where job_status = ?
+				or (job_status = ? and locked_until < now())
+				order by created_at
+				limit 1
+				for update skip locked
+			)`,
+			BulkJobStatusPending,
+			BulkJobStatusRunning,
+		).
+		Suffix("returning " + strings.Join(bulkJobColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "10d6402c-8e48-4fa9-ac33-5beadeb484d8",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "01350a60-9a4f-4a88-b57c-72918c623add")
+	}
+
+	out := &ClaimBulkJobRecordOutput{}
+
+	record, err := scanBulkJob(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return out, nil
+		}
+
+		return nil, &errors.Object{
+			Id:     "1579e17a-cc80-4a90-8137-a7aa46defd21",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to fetch row.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	out.Record = record
+
+	return out, nil
+}
+
+type UpdateBulkJobRecordInput struct {
+	Id uuid.UUID
+
+	// Attempts of the claim holding the lease. The job is not
+	// updated once claimed again by another worker.
+	Attempts int
+
+	JobStatus    BulkJobStatus
+	EnrichedRows int
+	FailedRows   int
+
+	// The fields below are only set when not empty.
+
+	OutputObject           string
+	ErrorDetail            string
+	DownloadToken          string
+	DownloadTokenExpiresAt time.Time
+
+	// Finished sets finished_at. The lease of finished jobs
+	// is released.
+	Finished bool
+}
+
+type UpdateBulkJobRecordOutput struct {
+	// Updated is false when the job was claimed again.
+	Updated bool
+}
+
+func (repo *repository) UpdateBulkJobRecord(r *arc.Request, in *UpdateBulkJobRecordInput) (*UpdateBulkJobRecordOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("bulk_jobs").
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("job_status", in.JobStatus).
+		Set("enriched_rows", in.EnrichedRows).
+		Set("failed_rows", in.FailedRows).
+		Where("id = ?", in.Id).
+		Where("attempts = ?", in.Attempts)
+
+	if in.OutputObject != "" {
+		builder = builder.Set("output_object", in.OutputObject)
+	}
+
+	if in.ErrorDetail != "" {
+		builder = builder.Set("error_detail", in.ErrorDetail)
+	}
+
+	if in.DownloadToken != "" {
+		builder = builder.
+			Set("download_token", in.DownloadToken).
+			Set("download_token_expires_at", in.DownloadTokenExpiresAt)
+	}
+
+	if in.Finished {
+		builder = builder.
+			Set("finished_at", squirrel.Expr("now()")).
+			Set("locked_until", nil)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "e8b2db78-b1c0-4b9f-becd-aab2f19f3614",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	ct, err := extutils.PgxExec(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "f5c6efb0-b327-46f4-8b28-9f83e4dce3a0")
+	}
+
+	out := &UpdateBulkJobRecordOutput{
+		Updated: ct.RowsAffected() > 0,
+	}
+
+	return out, nil
+}
+
+type ExtendBulkJobLeaseRecordInput struct {
+	Id       uuid.UUID
+	LeaseTtl time.Duration
+
+	// Attempts of the claim holding the lease.
+	Attempts int
+}
+
+type ExtendBulkJobLeaseRecordOutput struct {
+	// Extended is false when the job is no longer running,
+	// e.g. when another worker claimed it after the lease expired.
+	Extended bool
+}
+
+func (repo *repository) ExtendBulkJobLeaseRecord(r *arc.Request, in *ExtendBulkJobLeaseRecordInput) (*ExtendBulkJobLeaseRecordOutput, error) {
+	sql, args, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("bulk_jobs").
+		Set("heartbeat_at", squirrel.Expr("now()")).
+		Set("locked_until", squirrel.Expr("now() + ? * interval '1 millisecond'", in.LeaseTtl.Milliseconds())).
+		Where("id = ?", in.Id).
+		Where("job_status = ?", BulkJobStatusRunning).
+		Where("attempts = ?", in.Attempts).
+		ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "21ed994f-95ad-450c-ab59-43599d113872",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	ct, err := extutils.PgxExec(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "095fc993-e0ea-4f3c-a88e-40c953d50f3a")
+	}
+
+	out := &ExtendBulkJobLeaseRecordOutput{
+		Extended: ct.RowsAffected() > 0,
+	}
+
+	return out, nil
+}
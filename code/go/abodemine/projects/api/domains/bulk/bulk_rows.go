@@ -0,0 +1,252 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"abodemine/lib/errors"
+	"abodemine/models"
+)
+
+// Longest NDJSON line accepted, in bytes.
+const maxNdjsonLineSize = 64 * 1024
+
+// bulkJobRow is one row of an input file, with the same
+// search criteria accepted by /api/v3/search.
+type bulkJobRow struct {
+	Row int
+
+	Id      string
+	Aupid   *uuid.UUID
+	Address *models.ApiSearchAddress
+}
+
+// ndjsonRow is the shape of an NDJSON input line.
+type ndjsonRow struct {
+	Id    string `json:"id"`
+	Aupid string `json:"aupid"`
+
+	models.ApiSearchAddress
+}
+
+// csvColumns maps the normalized CSV header names to the row
+// fields. Headers are matched case-insensitively, ignoring
+// spaces, dashes and underscores, so both zip5 and ZIP_5 work.
+var csvColumns = map[string]func(row *ndjsonRow, v string){
+	"id":                  func(row *ndjsonRow, v string) { row.Id = v },
+	"aupid":               func(row *ndjsonRow, v string) { row.Aupid = v },
+	"fullstreetaddress":   func(row *ndjsonRow, v string) { row.FullStreetAddress = v },
+	"address":             func(row *ndjsonRow, v string) { row.FullStreetAddress = v },
+	"housenumber":         func(row *ndjsonRow, v string) { row.HouseNumber = v },
+	"streetpredirection":  func(row *ndjsonRow, v string) { row.StreetPreDirection = v },
+	"streetname":          func(row *ndjsonRow, v string) { row.StreetName = v },
+	"streetsuffix":        func(row *ndjsonRow, v string) { row.StreetSuffix = v },
+	"streetpostdirection": func(row *ndjsonRow, v string) { row.StreetPostDirection = v },
+	"unittype":            func(row *ndjsonRow, v string) { row.UnitType = v },
+	"unitnumber":          func(row *ndjsonRow, v string) { row.UnitNumber = v },
+	"city":                func(row *ndjsonRow, v string) { row.City = v },
+	"state":               func(row *ndjsonRow, v string) { row.State = v },
+	"zip5":                func(row *ndjsonRow, v string) { row.Zip5 = v },
+	"zip":                 func(row *ndjsonRow, v string) { row.Zip5 = v },
+}
+
+func normalizeCsvHeader(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// readBulkJobRows calls fn for every row of the input, in order.
+// Rows are only checked for syntax here. Search criteria are
+// validated while enriching, so an incomplete address fails its
+// row instead of the whole job.
+func readBulkJobRows(format BulkJobFormat, r io.Reader, fn func(row *bulkJobRow) error) error {
+	switch format {
+	case BulkJobFormatCsv:
+		return readCsvRows(r, fn)
+	case BulkJobFormatNdjson:
+		return readNdjsonRows(r, fn)
+	}
+
+	return &errors.Object{
+		Id:     "db1bd049-47be-4db1-8499-92572c8bc038",
+		Code:   errors.Code_INVALID_ARGUMENT,
+		Detail: "Unsupported format.",
+		Path:   "/format",
+	}
+}
+
+func readCsvRows(r io.Reader, fn func(row *bulkJobRow) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+
+		return &errors.Object{
+			Id:     "f47c9041-021a-4e89-b0da-6e51879f6c24",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to read the CSV header.",
+			Path:   "/file",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	setters := make([]func(row *ndjsonRow, v string), len(header))
+	var hasColumns bool
+
+	for i, name := range header {
+		// Excel adds a byte order mark to UTF-8 files.
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+
+		if setter, ok := csvColumns[normalizeCsvHeader(name)]; ok {
+			setters[i] = setter
+			hasColumns = true
+		}
+	}
+
+	if !hasColumns {
+		return &errors.Object{
+			Id:     "08b96192-4464-4416-8b89-228baa3ba88f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The CSV header must include an aupid or address column.",
+			Path:   "/file",
+		}
+	}
+
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return &errors.Object{
+				Id:     "8825638c-f76d-46b6-99c9-20bdd1628a66",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: fmt.Sprintf("Failed to read CSV row %d.", n),
+				Path:   "/file",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		in := new(ndjsonRow)
+
+		for i, v := range record {
+			if i < len(setters) && setters[i] != nil {
+				setters[i](in, strings.TrimSpace(v))
+			}
+		}
+
+		row, err := newBulkJobRow(n, in)
+		if err != nil {
+			return errors.Forward(err, "c5e55878-d024-4d88-8724-ab4781d6db16")
+		}
+
+		if err := fn(row); err != nil {
+			return errors.Forward(err, "f8e169bf-e87f-4329-9c9a-f70ad159d905")
+		}
+	}
+}
+
+func readNdjsonRows(r io.Reader, fn func(row *bulkJobRow) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxNdjsonLineSize)
+
+	for n := 0; scanner.Scan(); {
+		line := bytes.TrimSpace(scanner.Bytes())
+
+		// Blank lines are skipped and do not count as rows.
+		if len(line) == 0 {
+			continue
+		}
+
+		n++
+
+		in := new(ndjsonRow)
+
+		if err := json.Unmarshal(line, in); err != nil {
+			return &errors.Object{
+				Id:     "bf13fc5c-52b9-487b-88a7-ed5a452ab445",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: fmt.Sprintf("Failed to parse NDJSON row %d.", n),
+				Path:   "/file",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		row, err := newBulkJobRow(n, in)
+		if err != nil {
+			return errors.Forward(err, "244c0dcd-ca11-4ee9-a732-243520303ed1")
+		}
+
+		if err := fn(row); err != nil {
+			return errors.Forward(err, "08004443-9cab-450a-85a8-6d399f772e7f")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return &errors.Object{
+			Id:     "5490d1c2-f250-41f8-a9a1-580af654a129",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to read the NDJSON file.",
+			Path:   "/file",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+func newBulkJobRow(n int, in *ndjsonRow) (*bulkJobRow, error) {
+	row := &bulkJobRow{
+		Row: n,
+		Id:  in.Id,
+	}
+
+	if in.Aupid != "" {
+		aupid, err := uuid.Parse(in.Aupid)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "59fe7212-c078-4718-85bd-94a3a9b4f633",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: fmt.Sprintf("Invalid aupid in row %d.", n),
+				Path:   "/file",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		row.Aupid = &aupid
+
+		return row, nil
+	}
+
+	address := in.ApiSearchAddress
+	row.Address = &address
+
+	return row, nil
+}
@@ -0,0 +1,157 @@
+package bulk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+func TestReadBulkJobRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  BulkJobFormat
+		data    string
+		rows    []*bulkJobRow
+		errorId string
+		path    string
+	}{
+		{
+			name:   "CSV",
+			format: BulkJobFormatCsv,
+			data: "\ufeffID,Full Street Address,City,State,ZIP_5,Unknown\n" +
+				"a,5500 NE 12th Ave,Portland,OR,97211,x\n" +
+				"b, 100 Main St ,Portland,OR\n",
+			rows: []*bulkJobRow{
+				{Row: 1, Id: "a"},
+				{Row: 2, Id: "b"},
+			},
+		},
+		{
+			name:   "CSV Aupid",
+			format: BulkJobFormatCsv,
+			data:   "aupid\n0195f3c4-1b2a-7c3d-8e4f-5a6b7c8d9e0f\n",
+			rows: []*bulkJobRow{
+				{Row: 1},
+			},
+		},
+		{
+			name:   "CSV Without Known Columns",
+			format: BulkJobFormatCsv,
+			data:   "foo,bar\n1,2\n",
+			// Rows are not read when the header is invalid.
+			errorId: "08b96192-4464-4416-8b89-228baa3ba88f",
+			path:    "/file",
+		},
+		{
+			name:    "CSV Invalid Aupid",
+			format:  BulkJobFormatCsv,
+			data:    "aupid\nnot-a-uuid\n",
+			errorId: "59fe7212-c078-4718-85bd-94a3a9b4f633",
+			path:    "/file",
+		},
+		{
+			name:   "NDJSON",
+			format: BulkJobFormatNdjson,
+			data: `{"id": "a", "fullStreetAddress": "5500 NE 12th Ave", "zip5": "97211"}` + "\n\n" +
+				`{"id": "b", "aupid": "0195f3c4-1b2a-7c3d-8e4f-5a6b7c8d9e0f"}` + "\n",
+			rows: []*bulkJobRow{
+				{Row: 1, Id: "a"},
+				{Row: 2, Id: "b"},
+			},
+		},
+		{
+			name:    "NDJSON Malformed",
+			format:  BulkJobFormatNdjson,
+			data:    "{\"id\": \"a\"}\n{\"id\":\n",
+			errorId: "bf13fc5c-52b9-487b-88a7-ed5a452ab445",
+			path:    "/file",
+		},
+		{
+			name:    "Unsupported Format",
+			data:    "aupid\n",
+			errorId: "db1bd049-47be-4db1-8499-92572c8bc038",
+			path:    "/format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []*bulkJobRow
+
+			err := readBulkJobRows(tt.format, strings.NewReader(tt.data), func(row *bulkJobRow) error {
+				rows = append(rows, row)
+				return nil
+			})
+			if tt.errorId != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.errorId, errors.First(err).Id)
+					assert.Equal(t, tt.path, errors.First(err).Path)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, rows, len(tt.rows))
+
+			for i, row := range rows {
+				assert.Equal(t, tt.rows[i].Row, row.Row)
+				assert.Equal(t, tt.rows[i].Id, row.Id)
+				assert.True(t, (row.Aupid == nil) != (row.Address == nil))
+			}
+		})
+	}
+}
+
+func TestReadBulkJobRows_CsvColumns(t *testing.T) {
+	data := "Full Street Address,City,State,ZIP_5\n 5500 NE 12th Ave ,Portland,OR,97211\n"
+
+	var rows []*bulkJobRow
+
+	require.NoError(t, readBulkJobRows(BulkJobFormatCsv, strings.NewReader(data), func(row *bulkJobRow) error {
+		rows = append(rows, row)
+		return nil
+	}))
+
+	require.Len(t, rows, 1)
+	require.NotNil(t, rows[0].Address)
+	assert.Equal(t, "5500 NE 12th Ave", rows[0].Address.FullStreetAddress)
+	assert.Equal(t, "Portland", rows[0].Address.City)
+	assert.Equal(t, "OR", rows[0].Address.State)
+	assert.Equal(t, "97211", rows[0].Address.Zip5)
+}
+
+func TestParseBulkJobFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		fileName string
+		want     BulkJobFormat
+		errorId  string
+	}{
+		{name: "Explicit", format: "ndjson", fileName: "input.csv", want: BulkJobFormatNdjson},
+		{name: "CSV Extension", fileName: "input.CSV", want: BulkJobFormatCsv},
+		{name: "JSONL Extension", fileName: "input.jsonl", want: BulkJobFormatNdjson},
+		{name: "Unknown Extension", fileName: "input.txt", errorId: "cb38f47c-fb51-4d04-a1eb-bb96475c4a47"},
+		{name: "Invalid Format", format: "xml", errorId: "05b82600-2b03-4066-8930-edd68b7502a4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBulkJobFormat(tt.format, tt.fileName)
+			if tt.errorId != "" {
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.errorId, err.(*errors.Object).Id)
+					assert.Equal(t, "/format", err.(*errors.Object).Path)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -130,6 +130,7 @@ func (s *domain) processProperties(r *arc.Request, interimProps []models.Propert
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to query elasticsearch for property data.",
 					Cause:  err.Error(),
+					Err:    err,
 					Meta:   map[string]any{"aupid": obj.OldAupid},
 				})
 				continue
@@ -155,6 +156,7 @@ func (s *domain) processProperties(r *arc.Request, interimProps []models.Propert
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query elasticsearch for property data.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -186,6 +188,7 @@ func (s *domain) enrichPropertiesData(ctx context.Context, props []models.Proper
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query elasticsearch for property data",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta:   map[string]any{"aupid": obj.Aupid},
 			}
 		}
@@ -316,6 +319,7 @@ func (s *domain) processEnabledLayouts(r *arc.Request, includedLayouts []string,
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to process assessor layout.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -339,6 +343,7 @@ func (s *domain) processEnabledLayouts(r *arc.Request, includedLayouts []string,
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to process recorder layout.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -360,6 +365,7 @@ func (s *domain) processEnabledLayouts(r *arc.Request, includedLayouts []string,
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to process rentEstimate layout.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -390,6 +396,7 @@ func (s *domain) processEnabledLayouts(r *arc.Request, includedLayouts []string,
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to process saleEstimateWithComps layout.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	} else {
@@ -853,6 +860,7 @@ func (s *domain) ProcessSaleEstimateLayout(ctx context.Context, es_requests []ma
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to process saleEstimate comps.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -914,6 +922,7 @@ func (s *domain) processSaleEstimateComps(ctx context.Context, saleEstimateHit m
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query elasticsearch for comp data.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta:   map[string]any{"comp_id": strCompId},
 			})
 			continue
@@ -1386,6 +1395,7 @@ func (s *domain) ParseCount(client *street.Client, batch *street.Batch, interimP
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to send batch to Street.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -69,24 +69,77 @@ func (dom *domain) SearchProperty(r *arc.Request, in *SearchPropertyInput) (*Sea
 			}
 		}
 
-		hasFullAddress := searchAddress.FullStreetAddress != ""
-		hasHouseAndStreet := searchAddress.HouseNumber != "" && searchAddress.StreetName != ""
-		hasCityAndState := searchAddress.City != "" && searchAddress.State != ""
-		hasZip5 := searchAddress.Zip5 != ""
+		if err := ValidateApiSearchAddress(searchAddress); err != nil {
+			return nil, errors.Forward(err, "d108f10b-05d5-418f-9e38-42caa1bd6d7d")
+		}
+	}
 
-		hasValidSearchAddress := (hasFullAddress || hasHouseAndStreet) && (hasCityAndState || hasZip5)
-		if !hasValidSearchAddress {
-			return nil, &errors.Object{
-				Id:     "9f19a3a3-10ff-4039-a819-f044ea46c89b",
-				Code:   errors.Code_INVALID_ARGUMENT,
-				Detail: "A valid search address requires a street component (Full Address or House Number with Street Name) and a location component (City and State, or Zip5).",
-			}
+	selectPropertyInput, err := NewSelectPropertyInput(r, in.Layouts)
+	if err != nil {
+		return nil, errors.Forward(err, "083009e7-ad12-4b75-94c8-920b4cecc71f")
+	}
+
+	var aupids []*uuid.UUID
+
+	out := &SearchPropertyOutput{}
+
+	if in.Aupid != nil {
+		aupids = []*uuid.UUID{in.Aupid}
+	} else {
+		selectPropertyAddressOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
+			IncludePropertyRefs: true,
+			ApiSearchAddress:    in.ApiSearchAddress,
+		})
+		if err != nil {
+			return nil, errors.Forward(err, "7f8829a4-d047-4566-9ecf-9b07205e03fb")
+		}
+
+		if len(selectPropertyAddressOut.PropertyRefEntities) == 0 {
+			return out, nil
+		}
+
+		aupids = make([]*uuid.UUID, len(selectPropertyAddressOut.PropertyRefEntities))
+
+		for i, propertyRef := range selectPropertyAddressOut.PropertyRefEntities {
+			aupids[i] = propertyRef.Aupid
 		}
 	}
 
+	selectPropertyInput.Aupids = aupids
+
+	selectPropertyOut, err := dom.propertyDomain.SelectProperty(r, selectPropertyInput)
+	if err != nil {
+		return nil, errors.Forward(err, "67664b7c-7f53-4028-bf70-3120af907ce5")
+	}
+
+	_, err = dom.authDomain.InsertApiQuotaTransaction(r, &auth.InsertApiQuotaTransactionInput{
+		Entity: &arc.ApiQuotaTransaction{
+			Description:              ptr.String("/api/v3/search"),
+			AddressLayoutAmount:      selectPropertyOut.AddressLayoutSum,
+			AssessorLayoutAmount:     selectPropertyOut.AssessorLayoutSum,
+			CompsLayoutAmount:        selectPropertyOut.CompsLayoutSum,
+			ListingLayoutAmount:      selectPropertyOut.ListingLayoutSum,
+			RecorderLayoutAmount:     selectPropertyOut.RecorderLayoutSum,
+			RentEstimateLayoutAmount: selectPropertyOut.RentalAvmLayoutSum,
+			SaleEstimateLayoutAmount: selectPropertyOut.SaleAvmLayoutSum,
+			TimelineLayoutAmount:     selectPropertyOut.TimelineLayoutSum,
+		},
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "7c5e8f3c-b982-4429-ae19-a42b5a182935")
+	}
+
+	out.PropertyEntities = selectPropertyOut.PropertyEntities
+
+	return out, nil
+}
+
+// NewSelectPropertyInput maps the requested layouts to the property
+// sections to include, rejecting layouts not enabled for the organization.
+func NewSelectPropertyInput(r *arc.Request, layouts []string) (*property.SelectPropertyInput, error) {
 	selectPropertyInput := &property.SelectPropertyInput{}
 
-	for _, layout := range in.Layouts {
+	for _, layout := range layouts {
 		switch strings.ToUpper(strings.TrimSpace(layout)) {
 		case "ADDRESS":
 			if !r.HasFlag(flags.ApiAddressLayoutEnabled) {
@@ -151,6 +204,15 @@ func (dom *domain) SearchProperty(r *arc.Request, in *SearchPropertyInput) (*Sea
 				}
 			}
 			selectPropertyInput.IncludeSaleEstimate = true
+		case "TIMELINE":
+			if !r.HasFlag(flags.ApiTimelineLayoutEnabled) {
+				return nil, &errors.Object{
+					Id:     "e559079e-e7e6-46da-bd1e-f945e8cb2f48",
+					Code:   errors.Code_PERMISSION_DENIED,
+					Detail: "The timeline layout is not enabled for this organization.",
+				}
+			}
+			selectPropertyInput.IncludeTimeline = true
 		default:
 			return nil, &errors.Object{
 				Id:     "004b8aeb-27e1-4a48-a8cb-4a1e96c1f8f5",
@@ -160,56 +222,25 @@ func (dom *domain) SearchProperty(r *arc.Request, in *SearchPropertyInput) (*Sea
 		}
 	}
 
-	var aupids []*uuid.UUID
-
-	out := &SearchPropertyOutput{}
-
-	if in.Aupid != nil {
-		aupids = []*uuid.UUID{in.Aupid}
-	} else {
-		selectPropertyAddressOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
-			IncludePropertyRefs: true,
-			ApiSearchAddress:    in.ApiSearchAddress,
-		})
-		if err != nil {
-			return nil, errors.Forward(err, "7f8829a4-d047-4566-9ecf-9b07205e03fb")
-		}
-
-		if len(selectPropertyAddressOut.PropertyRefEntities) == 0 {
-			return out, nil
-		}
-
-		aupids = make([]*uuid.UUID, len(selectPropertyAddressOut.PropertyRefEntities))
+	return selectPropertyInput, nil
+}
 
-		for i, propertyRef := range selectPropertyAddressOut.PropertyRefEntities {
-			aupids[i] = propertyRef.Aupid
+// ValidateApiSearchAddress checks the address has both a street
+// and a location component.
+func ValidateApiSearchAddress(searchAddress *models.ApiSearchAddress) error {
+	hasFullAddress := searchAddress.FullStreetAddress != ""
+	hasHouseAndStreet := searchAddress.HouseNumber != "" && searchAddress.StreetName != ""
+	hasCityAndState := searchAddress.City != "" && searchAddress.State != ""
+	hasZip5 := searchAddress.Zip5 != ""
+
+	hasValidSearchAddress := (hasFullAddress || hasHouseAndStreet) && (hasCityAndState || hasZip5)
+	if !hasValidSearchAddress {
+		return &errors.Object{
+			Id:     "9f19a3a3-10ff-4039-a819-f044ea46c89b",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "A valid search address requires a street component (Full Address or House Number with Street Name) and a location component (City and State, or Zip5).",
 		}
 	}
 
-	selectPropertyInput.Aupids = aupids
-
-	selectPropertyOut, err := dom.propertyDomain.SelectProperty(r, selectPropertyInput)
-	if err != nil {
-		return nil, errors.Forward(err, "67664b7c-7f53-4028-bf70-3120af907ce5")
-	}
-
-	_, err = dom.authDomain.InsertApiQuotaTransaction(r, &auth.InsertApiQuotaTransactionInput{
-		Entity: &arc.ApiQuotaTransaction{
-			Description:              ptr.String("/api/v3/search"),
-			AddressLayoutAmount:      selectPropertyOut.AddressLayoutSum,
-			AssessorLayoutAmount:     selectPropertyOut.AssessorLayoutSum,
-			CompsLayoutAmount:        selectPropertyOut.CompsLayoutSum,
-			ListingLayoutAmount:      selectPropertyOut.ListingLayoutSum,
-			RecorderLayoutAmount:     selectPropertyOut.RecorderLayoutSum,
-			RentEstimateLayoutAmount: selectPropertyOut.RentalAvmLayoutSum,
-			SaleEstimateLayoutAmount: selectPropertyOut.SaleAvmLayoutSum,
-		},
-	})
-	if err != nil {
-		return nil, errors.Forward(err, "7c5e8f3c-b982-4429-ae19-a42b5a182935")
-	}
-
-	out.PropertyEntities = selectPropertyOut.PropertyEntities
-
-	return out, nil
+	return nil
 }
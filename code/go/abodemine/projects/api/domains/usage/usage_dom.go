@@ -0,0 +1,264 @@
+package usage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+)
+
+// Domain reports the quota usage recorded in api_quota_transactions.
+// Periods are UTC days and months.
+type Domain interface {
+	SelectUsage(r *arc.Request, in *SelectUsageInput) (*SelectUsageOutput, error)
+	SelectBillingStatements(r *arc.Request, in *SelectBillingStatementsInput) (*SelectBillingStatementsOutput, error)
+}
+
+type domain struct {
+	repository Repository
+}
+
+type NewDomainInput struct {
+	Repository Repository
+}
+
+func NewDomain(in *NewDomainInput) Domain {
+	return &domain{
+		repository: val.Ternary(
+			in.Repository == nil,
+			NewRepository(),
+			in.Repository,
+		),
+	}
+}
+
+type SelectUsageInput struct {
+	// Day to report, and its month. Defaults to today.
+	Date time.Time
+}
+
+type SelectUsageOutput struct {
+	Entity *entities.Usage
+}
+
+// SelectUsage reports the usage of the organization of the session.
+func (dom *domain) SelectUsage(r *arc.Request, in *SelectUsageInput) (*SelectUsageOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "a6ef22d0-e124-440a-85eb-ccd4c7bf1ac9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	orgId := r.Session().OrganizationId()
+
+	date := val.Ternary(in.Date.IsZero(), time.Now(), in.Date).UTC()
+	dayStart := arc.QuotaPeriodStart(arc.QuotaPeriodDaily, date)
+	monthStart := arc.QuotaPeriodStart(arc.QuotaPeriodMonthly, date)
+
+	quotasOut, err := dom.repository.SelectApiQuotaRecords(r, &SelectApiQuotaRecordsInput{
+		OrganizationId: orgId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "6d3a6be5-4b81-448e-9886-23a83c3cfdd8")
+	}
+
+	quota := quotasOut.Records[orgId]
+	if quota == nil {
+		quota = &arc.ApiQuota{}
+	}
+
+	daily, err := dom.selectUsagePeriod(r, orgId, dayStart, dayStart.AddDate(0, 0, 1), quota.DailyQuota)
+	if err != nil {
+		return nil, errors.Forward(err, "b528d74c-f97b-42e8-8f12-5f049cf01d48")
+	}
+
+	monthly, err := dom.selectUsagePeriod(r, orgId, monthStart, monthStart.AddDate(0, 1, 0), quota.MonthlyQuota)
+	if err != nil {
+		return nil, errors.Forward(err, "64c37481-5e45-4617-9ef0-49b81a904f77")
+	}
+
+	notificationsOut, err := dom.repository.SelectApiQuotaNotificationRecords(r, &SelectApiQuotaNotificationRecordsInput{
+		OrganizationId: orgId,
+		From:           monthStart,
+		To:             monthly.PeriodEnd,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "ca610ff3-780e-47bc-90b2-5128e775c64f")
+	}
+
+	entity := &entities.Usage{
+		Daily:         daily,
+		Monthly:       monthly,
+		Notifications: make([]*entities.UsageNotification, 0, len(notificationsOut.Records)),
+	}
+
+	for _, record := range notificationsOut.Records {
+		entity.Notifications = append(entity.Notifications, &entities.UsageNotification{
+			Period: val.Ternary(
+				record.QuotaPeriod == arc.QuotaPeriodDaily,
+				entities.UsagePeriodDaily,
+				entities.UsagePeriodMonthly,
+			),
+			PeriodStart: record.PeriodStart,
+			Percent:     record.Percent,
+			Quota:       record.Quota,
+			Usage:       record.Usage,
+			CreatedAt:   record.CreatedAt,
+		})
+	}
+
+	out := &SelectUsageOutput{
+		Entity: entity,
+	}
+
+	return out, nil
+}
+
+func (dom *domain) selectUsagePeriod(r *arc.Request, orgId uuid.UUID, from, to time.Time, quota int64) (*entities.UsagePeriod, error) {
+	selectOut, err := dom.repository.SelectUsageRecords(r, &SelectUsageRecordsInput{
+		OrganizationId: orgId,
+		From:           from,
+		To:             to,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "d948d6c0-c934-4e98-9531-a991518b8b90")
+	}
+
+	period := newUsagePeriod(from, to, quota)
+
+	for _, record := range selectOut.Records {
+		addUsageRecord(period, record)
+	}
+
+	return period, nil
+}
+
+func newUsagePeriod(from, to time.Time, quota int64) *entities.UsagePeriod {
+	return &entities.UsagePeriod{
+		PeriodStart: from,
+		PeriodEnd:   to,
+		Quota:       quota,
+		ByLayout:    make(map[string]int64, len(usageLayoutColumns)),
+		ByApiKey:    []*entities.UsageByApiKey{},
+	}
+}
+
+// addUsageRecord adds the sums of an api key to the period.
+func addUsageRecord(period *entities.UsagePeriod, record *UsageRecord) {
+	byApiKey := &entities.UsageByApiKey{
+		ApiKeyId: record.ApiKeyId,
+		Requests: record.Requests,
+		ByLayout: make(map[string]int64, len(usageLayoutColumns)),
+	}
+
+	for i, c := range usageLayoutColumns {
+		amount := record.Amounts[i]
+
+		byApiKey.Used += amount
+		byApiKey.ByLayout[c.Layout] = amount
+		period.ByLayout[c.Layout] += amount
+	}
+
+	period.Used += byApiKey.Used
+	period.Requests += byApiKey.Requests
+	period.ByApiKey = append(period.ByApiKey, byApiKey)
+}
+
+type SelectBillingStatementsInput struct {
+	// Any time in the month to bill.
+	Month time.Time
+
+	// All organizations when Nil.
+	OrganizationId uuid.UUID
+}
+
+type SelectBillingStatementsOutput struct {
+	// Ordered by organization. Organizations with a quota
+	// have a statement even without usage.
+	Entities []*entities.BillingStatement
+}
+
+// SelectBillingStatements builds the monthly statements of the
+// organizations. It doesn't read the session, so it must only be
+// called with system requests.
+func (dom *domain) SelectBillingStatements(r *arc.Request, in *SelectBillingStatementsInput) (*SelectBillingStatementsOutput, error) {
+	if in == nil || in.Month.IsZero() {
+		return nil, &errors.Object{
+			Id:     "ab0c39d7-dbd0-4f63-8c65-48433e9fad7f",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing month.",
+			Path:   "/month",
+		}
+	}
+
+	from := arc.QuotaPeriodStart(arc.QuotaPeriodMonthly, in.Month)
+	to := from.AddDate(0, 1, 0)
+
+	quotasOut, err := dom.repository.SelectApiQuotaRecords(r, &SelectApiQuotaRecordsInput{
+		OrganizationId: in.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "60501f83-19ca-4048-9002-279318f3f830")
+	}
+
+	usageOut, err := dom.repository.SelectUsageRecords(r, &SelectUsageRecordsInput{
+		OrganizationId: in.OrganizationId,
+		From:           from,
+		To:             to,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "03796369-6414-4537-8e55-1a23a8625282")
+	}
+
+	statements := make(map[uuid.UUID]*entities.BillingStatement)
+
+	statement := func(orgId uuid.UUID) *entities.BillingStatement {
+		if s, ok := statements[orgId]; ok {
+			return s
+		}
+
+		var monthlyQuota int64
+
+		if quota := quotasOut.Records[orgId]; quota != nil {
+			monthlyQuota = quota.MonthlyQuota
+		}
+
+		s := &entities.BillingStatement{
+			OrganizationId: orgId,
+			UsagePeriod:    newUsagePeriod(from, to, monthlyQuota),
+		}
+
+		statements[orgId] = s
+
+		return s
+	}
+
+	for orgId := range quotasOut.Records {
+		statement(orgId)
+	}
+
+	for _, record := range usageOut.Records {
+		addUsageRecord(statement(record.OrganizationId).UsagePeriod, record)
+	}
+
+	out := &SelectBillingStatementsOutput{
+		Entities: make([]*entities.BillingStatement, 0, len(statements)),
+	}
+
+	for _, s := range statements {
+		out.Entities = append(out.Entities, s)
+	}
+
+	slices.SortFunc(out.Entities, func(a, b *entities.BillingStatement) int {
+		return slices.Compare(a.OrganizationId[:], b.OrganizationId[:])
+	})
+
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+)
+
+type mockRepository struct {
+	quotas map[uuid.UUID]*arc.ApiQuota
+	usage  []*UsageRecord
+
+	usageIn *SelectUsageRecordsInput
+}
+
+func (m *mockRepository) SelectUsageRecords(r *arc.Request, in *SelectUsageRecordsInput) (*SelectUsageRecordsOutput, error) {
+	m.usageIn = in
+	return &SelectUsageRecordsOutput{Records: m.usage}, nil
+}
+
+func (m *mockRepository) SelectApiQuotaRecords(r *arc.Request, in *SelectApiQuotaRecordsInput) (*SelectApiQuotaRecordsOutput, error) {
+	return &SelectApiQuotaRecordsOutput{Records: m.quotas}, nil
+}
+
+func (m *mockRepository) SelectApiQuotaNotificationRecords(r *arc.Request, in *SelectApiQuotaNotificationRecordsInput) (*SelectApiQuotaNotificationRecordsOutput, error) {
+	return &SelectApiQuotaNotificationRecordsOutput{}, nil
+}
+
+func amounts(base, assessor int64) []int64 {
+	a := make([]int64, len(usageLayoutColumns))
+	a[0] = base
+	a[2] = assessor
+	return a
+}
+
+func TestDomain_SelectBillingStatements(t *testing.T) {
+	orgA := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	orgB := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	keyA := uuid.New()
+
+	repo := &mockRepository{
+		quotas: map[uuid.UUID]*arc.ApiQuota{
+			orgA: {OrganizationId: orgA, MonthlyQuota: 1000},
+			orgB: {OrganizationId: orgB, MonthlyQuota: 500},
+		},
+		usage: []*UsageRecord{
+			{OrganizationId: orgA, Requests: 2, Amounts: amounts(2, 0)},
+			{OrganizationId: orgA, ApiKeyId: &keyA, Requests: 3, Amounts: amounts(1, 4)},
+		},
+	}
+
+	dom := NewDomain(&NewDomainInput{Repository: repo})
+
+	_, err := dom.SelectBillingStatements(&arc.Request{}, &SelectBillingStatementsInput{})
+	assert.Error(t, err)
+
+	out, err := dom.SelectBillingStatements(&arc.Request{}, &SelectBillingStatementsInput{
+		Month: time.Date(2026, time.February, 14, 23, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), repo.usageIn.From)
+	assert.Equal(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), repo.usageIn.To)
+
+	require.Len(t, out.Entities, 2)
+
+	a := out.Entities[0]
+	assert.Equal(t, orgA, a.OrganizationId)
+	assert.Equal(t, int64(1000), a.Quota)
+	assert.Equal(t, int64(7), a.Used)
+	assert.Equal(t, int64(5), a.Requests)
+	assert.Equal(t, int64(3), a.ByLayout[entities.UsageLayoutBase])
+	assert.Equal(t, int64(4), a.ByLayout[entities.UsageLayoutAssessor])
+	require.Len(t, a.ByApiKey, 2)
+	assert.Nil(t, a.ByApiKey[0].ApiKeyId)
+	assert.Equal(t, int64(5), a.ByApiKey[1].Used)
+
+	// Organizations with a quota but no usage are billed too.
+	b := out.Entities[1]
+	assert.Equal(t, orgB, b.OrganizationId)
+	assert.Equal(t, int64(0), b.Used)
+	assert.Empty(t, b.ByApiKey)
+}
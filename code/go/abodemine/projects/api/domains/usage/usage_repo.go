@@ -0,0 +1,339 @@
+package usage
+
+import (
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+)
+
+type Repository interface {
+	SelectUsageRecords(r *arc.Request, in *SelectUsageRecordsInput) (*SelectUsageRecordsOutput, error)
+	SelectApiQuotaRecords(r *arc.Request, in *SelectApiQuotaRecordsInput) (*SelectApiQuotaRecordsOutput, error)
+	SelectApiQuotaNotificationRecords(r *arc.Request, in *SelectApiQuotaNotificationRecordsInput) (*SelectApiQuotaNotificationRecordsOutput, error)
+}
+
+type repository struct{}
+
+func NewRepository() Repository {
+	return &repository{}
+}
+
+// usageLayoutColumns maps the usage layouts to their
+// api_quota_transactions columns, in output order.
+var usageLayoutColumns = []struct {
+	Layout string
+	Column string
+}{
+	{Layout: entities.UsageLayoutBase, Column: "base_req_amount"},
+	{Layout: entities.UsageLayoutAddress, Column: "address_lo_amount"},
+	{Layout: entities.UsageLayoutAssessor, Column: "assessor_lo_amount"},
+	{Layout: entities.UsageLayoutComps, Column: "comps_lo_amount"},
+	{Layout: entities.UsageLayoutListing, Column: "listing_lo_amount"},
+	{Layout: entities.UsageLayoutRecorder, Column: "recorder_lo_amount"},
+	{Layout: entities.UsageLayoutRentEstimate, Column: "rent_estimate_lo_amount"},
+	{Layout: entities.UsageLayoutSaleEstimate, Column: "sale_estimate_lo_amount"},
+	{Layout: entities.UsageLayoutMarketStats, Column: "market_stats_lo_amount"},
+	{Layout: entities.UsageLayoutTimeline, Column: "timeline_lo_amount"},
+}
+
+// UsageRecord sums the transactions of an api key.
+type UsageRecord struct {
+	OrganizationId uuid.UUID
+	ApiKeyId       *uuid.UUID
+	Requests       int64
+
+	// Sums in the order of usageLayoutColumns.
+	Amounts []int64
+}
+
+type SelectUsageRecordsInput struct {
+	// All organizations when Nil.
+	OrganizationId uuid.UUID
+
+	// Transactions in [From, To).
+	From time.Time
+	To   time.Time
+}
+
+type SelectUsageRecordsOutput struct {
+	// Ordered by organization and api key.
+	Records []*UsageRecord
+}
+
+func (repo *repository) SelectUsageRecords(r *arc.Request, in *SelectUsageRecordsInput) (*SelectUsageRecordsOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"organization_id",
+			"api_key_id",
+			"count(*)",
+		).
+		From("api_quota_transactions").
+		Where("trx_timestamp >= ?", in.From).
+		Where("trx_timestamp < ?", in.To).
+		GroupBy("organization_id", "api_key_id").
+		OrderBy("organization_id", "api_key_id nulls first")
+
+	for _, c := range usageLayoutColumns {
+		builder = builder.Column("coalesce(sum(" + c.Column + "), 0)")
+	}
+
+	if in.OrganizationId != uuid.Nil {
+		builder = builder.Where("organization_id = ?", in.OrganizationId)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "84280b27-91c5-49b3-a2d3-afadacfcec85",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "55b37e39-47e7-46e7-81ce-72b2bf6663d7")
+	}
+
+	defer rows.Close()
+
+	out := &SelectUsageRecordsOutput{}
+
+	for rows.Next() {
+		var apiKeyId pgtype.UUID
+
+		record := &UsageRecord{
+			Amounts: make([]int64, len(usageLayoutColumns)),
+		}
+
+		dest := []any{
+			&record.OrganizationId,
+			&apiKeyId,
+			&record.Requests,
+		}
+
+		for i := range record.Amounts {
+			dest = append(dest, &record.Amounts[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, &errors.Object{
+				Id:     "d8ad64e6-13dd-4049-a89e-8230095e41a1",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if apiKeyId.Valid {
+			id := uuid.UUID(apiKeyId.Bytes)
+			record.ApiKeyId = &id
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "c2c303a9-7f4b-45c5-b76b-15a6d3a861e0",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+type SelectApiQuotaRecordsInput struct {
+	// All organizations when Nil.
+	OrganizationId uuid.UUID
+}
+
+type SelectApiQuotaRecordsOutput struct {
+	// By organization.
+	Records map[uuid.UUID]*arc.ApiQuota
+}
+
+func (repo *repository) SelectApiQuotaRecords(r *arc.Request, in *SelectApiQuotaRecordsInput) (*SelectApiQuotaRecordsOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"id",
+			"organization_id",
+			"daily_quota",
+			"monthly_quota",
+		).
+		From("api_quotas")
+
+	if in.OrganizationId != uuid.Nil {
+		builder = builder.Where("organization_id = ?", in.OrganizationId)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "e42ed97e-4973-4b91-b8ed-763f4ff5dd0c",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "e1a73e02-225f-4842-aa08-aae5c1c83c9f")
+	}
+
+	defer rows.Close()
+
+	out := &SelectApiQuotaRecordsOutput{
+		Records: make(map[uuid.UUID]*arc.ApiQuota),
+	}
+
+	for rows.Next() {
+		record := new(arc.ApiQuota)
+
+		if err := rows.Scan(
+			&record.Id,
+			&record.OrganizationId,
+			&record.DailyQuota,
+			&record.MonthlyQuota,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "fa7dbd29-72ea-4ea3-b937-5756127da02b",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Records[record.OrganizationId] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "71c5884f-0ae7-4df6-99db-5f6a64022b63",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
+
+type SelectApiQuotaNotificationRecordsInput struct {
+	OrganizationId uuid.UUID
+
+	// Notifications of periods starting in [From, To).
+	From time.Time
+	To   time.Time
+}
+
+type SelectApiQuotaNotificationRecordsOutput struct {
+	// Oldest first.
+	Records []*arc.ApiQuotaNotification
+}
+
+func (repo *repository) SelectApiQuotaNotificationRecords(r *arc.Request, in *SelectApiQuotaNotificationRecordsInput) (*SelectApiQuotaNotificationRecordsOutput, error) {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(
+			"id",
+			"created_at",
+			"organization_id",
+			"api_key_id",
+			"quota_period",
+			"period_start",
+			"percent",
+			"quota",
+			"usage",
+		).
+		From("api_quota_notifications").
+		Where("organization_id = ?", in.OrganizationId).
+		Where("period_start >= ?", in.From).
+		Where("period_start < ?", in.To).
+		OrderBy("created_at", "percent")
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "547aa980-9361-4385-bed6-f85ceecbaf85",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresApi, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "6ed99a7a-7cb4-489e-911c-a9d1451e4cb8")
+	}
+
+	defer rows.Close()
+
+	out := &SelectApiQuotaNotificationRecordsOutput{}
+
+	for rows.Next() {
+		var apiKeyId pgtype.UUID
+
+		record := new(arc.ApiQuotaNotification)
+
+		if err := rows.Scan(
+			&record.Id,
+			&record.CreatedAt,
+			&record.OrganizationId,
+			&apiKeyId,
+			&record.QuotaPeriod,
+			&record.PeriodStart,
+			&record.Percent,
+			&record.Quota,
+			&record.Usage,
+		); err != nil {
+			return nil, &errors.Object{
+				Id:     "bd33a4a8-bcd6-4a56-ac61-1bc86d7fb97d",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if apiKeyId.Valid {
+			id := uuid.UUID(apiKeyId.Bytes)
+			record.ApiKeyId = &id
+		}
+
+		out.Records = append(out.Records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "2e112c9b-5adb-462f-b991-3ce95952d1bf",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/admin"
+	"abodemine/projects/api/domains/auth"
+)
+
+type Handler interface {
+	SelectApiQuota(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdateApiQuota(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	SelectApiSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeleteApiSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	AdminDomain admin.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	AdminDomain admin.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:   in.ArcDomain,
+		AuthDomain:  in.AuthDomain,
+		AdminDomain: in.AdminDomain,
+	}
+}
+
+// authenticate authenticates the request and parses the
+// organization id of the path. Errors are written to w.
+func (h *handler) authenticate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) (*arc.Request, uuid.UUID, bool) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "0012b1ce-aea8-41ed-bb45-9dd510a09487"))
+		return nil, uuid.Nil, false
+	}
+
+	arcRequest := authOut.Request
+
+	orgId, err := uuid.Parse(ps.ByName("organizationId"))
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "e49bf628-b053-41d7-a7a9-2ae8e09ab56d",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid organization id.",
+			Path:   "/organizationId",
+		})
+		return nil, uuid.Nil, false
+	}
+
+	return arcRequest, orgId, true
+}
+
+func (h *handler) SelectApiQuota(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	arcRequest, orgId, ok := h.authenticate(w, r, ps)
+	if !ok {
+		return
+	}
+
+	out, err := h.AdminDomain.SelectApiQuota(arcRequest, &admin.SelectApiQuotaInput{
+		OrganizationId: orgId,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "7f5dd265-5c60-4e5b-9ab5-0b9e8b3e4ada"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, out.Entity)
+}
+
+// UpdateApiQuota provisions or updates the quota of the organization.
+// Omitted fields are left as they are.
+func (h *handler) UpdateApiQuota(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	arcRequest, orgId, ok := h.authenticate(w, r, ps)
+	if !ok {
+		return
+	}
+
+	input := &UpdateApiQuotaInput{}
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "af97bfc9-34a5-4fe3-96cb-a217b3274562",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	domainInput, err := input.ToDomainModel()
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "098d5d1f-8bc3-4271-b28b-e369e67dd4af"))
+		return
+	}
+
+	domainInput.OrganizationId = orgId
+
+	out, err := h.AdminDomain.UpdateApiQuota(arcRequest, domainInput)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "eed064e0-aeca-456c-a30f-856b74a3f52f"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, out.Entity)
+}
+
+func (h *handler) SelectApiSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	arcRequest, orgId, ok := h.authenticate(w, r, ps)
+	if !ok {
+		return
+	}
+
+	out, err := h.AdminDomain.SelectApiSessions(arcRequest, &admin.SelectApiSessionsInput{
+		OrganizationId: orgId,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "354d20ea-e324-4ee7-9f7d-3cfd9755ab26"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, out.Entities)
+}
+
+func (h *handler) DeleteApiSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	arcRequest, orgId, ok := h.authenticate(w, r, ps)
+	if !ok {
+		return
+	}
+
+	out, err := h.AdminDomain.DeleteApiSessions(arcRequest, &admin.DeleteApiSessionsInput{
+		OrganizationId: orgId,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "532f7d5a-a02c-4778-b455-f3fd245f5193"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, &DeleteApiSessionsOutput{
+		Deleted: out.Deleted,
+	})
+}
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"encoding/json"
+	"time"
+
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/admin"
+)
+
+type UpdateApiQuotaInput struct {
+	DailyQuota   *int64          `json:"dailyQuota"`
+	MonthlyQuota *int64          `json:"monthlyQuota"`
+	Layouts      map[string]bool `json:"layouts"`
+
+	// Missing leaves the expiry as it is, and null removes it.
+	ExpiresAt json.RawMessage `json:"expiresAt"`
+}
+
+func (input *UpdateApiQuotaInput) ToDomainModel() (*admin.UpdateApiQuotaInput, error) {
+	domainInput := &admin.UpdateApiQuotaInput{
+		DailyQuota:   input.DailyQuota,
+		MonthlyQuota: input.MonthlyQuota,
+		Layouts:      input.Layouts,
+	}
+
+	if len(input.ExpiresAt) > 0 {
+		var expiresAt *time.Time
+
+		if err := json.Unmarshal(input.ExpiresAt, &expiresAt); err != nil {
+			return nil, &errors.Object{
+				Id:     "3e039731-ee8b-462e-8557-0327f0c5dabe",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid expiresAt, expected an RFC 3339 date-time or null.",
+				Path:   "/expiresAt",
+			}
+		}
+
+		domainInput.ExpiresAt = expiresAt
+
+		if expiresAt == nil {
+			domainInput.ExpiresAt = new(time.Time)
+		}
+	}
+
+	return domainInput, nil
+}
+
+type DeleteApiSessionsOutput struct {
+	Deleted int64 `json:"deleted"`
+}
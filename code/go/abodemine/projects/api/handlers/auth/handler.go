@@ -48,9 +48,10 @@ type TokenExchangeOutput struct {
 func (h *handler) TokenExchange(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
 		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "3a218eb4-375c-432a-aac0-50f9d94d8e38"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "3a218eb4-375c-432a-aac0-50f9d94d8e38"))
 		return
 	}
 
@@ -58,11 +59,12 @@ func (h *handler) TokenExchange(w http.ResponseWriter, r *http.Request, ps httpr
 
 	inB, err := io.ReadAll(r.Body)
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), &errors.Object{
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
 			Id:     "3ac3e3b9-c7a6-4bbe-b2f5-591413a7faab",
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to read request body.",
 			Cause:  err.Error(),
+			Err:    err,
 		})
 		return
 	}
@@ -70,11 +72,12 @@ func (h *handler) TokenExchange(w http.ResponseWriter, r *http.Request, ps httpr
 	tokenExchangeInput := new(TokenExchangeInput)
 
 	if err := json.Unmarshal(inB, tokenExchangeInput); err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), &errors.Object{
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
 			Id:     "0197d777-5880-4582-ac8e-7553a9abe81a",
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Invalid request body.",
 			Cause:  err.Error(),
+			Err:    err,
 		})
 		return
 	}
@@ -86,7 +89,7 @@ func (h *handler) TokenExchange(w http.ResponseWriter, r *http.Request, ps httpr
 		RedirectUri: tokenExchangeInput.RedirectUri,
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), errors.Forward(err, "1e85ecb3-9642-46d8-9df5-dcbd4a92b325"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "1e85ecb3-9642-46d8-9df5-dcbd4a92b325"))
 		return
 	}
 
@@ -0,0 +1,164 @@
+package bulk
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/auth"
+	"abodemine/projects/api/domains/bulk"
+)
+
+const (
+	// Large enough for 100k rows of full addresses.
+	maxUploadSize = 64 << 20
+
+	// Larger multipart parts are buffered on disk.
+	maxUploadMemory = 8 << 20
+)
+
+type Handler interface {
+	CreateBulkJob(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	SelectBulkJob(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain  arc.Domain
+	AuthDomain auth.Domain
+	BulkDomain bulk.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain  arc.Domain
+	AuthDomain auth.Domain
+	BulkDomain bulk.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:  in.ArcDomain,
+		AuthDomain: in.AuthDomain,
+		BulkDomain: in.BulkDomain,
+	}
+}
+
+// CreateBulkJob accepts a multipart/form-data upload with the
+// file part, an optional format and the layouts, either repeated
+// or comma separated.
+func (h *handler) CreateBulkJob(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "9adea5f1-eb50-480f-a0ec-da03b6560658"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "8e9c2ca8-086e-414d-958f-2347f8b32225",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid multipart body. Files are limited to 64 MiB.",
+			Cause:  err.Error(),
+			Err:    err,
+		})
+		return
+	}
+
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "8bc4207b-850d-485a-80c3-7dd0fa758362",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing file.",
+			Path:   "/file",
+		})
+		return
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "eb554767-0681-4b60-b4ab-fcc01ca0399a",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to read file.",
+			Path:   "/file",
+			Cause:  err.Error(),
+			Err:    err,
+		})
+		return
+	}
+
+	var layouts []string
+
+	for _, v := range r.MultipartForm.Value["layouts"] {
+		for _, layout := range strings.Split(v, ",") {
+			if layout = strings.TrimSpace(layout); layout != "" {
+				layouts = append(layouts, layout)
+			}
+		}
+	}
+
+	out, err := h.BulkDomain.CreateBulkJob(arcRequest, &bulk.CreateBulkJobInput{
+		Format:   r.FormValue("format"),
+		FileName: fileHeader.Filename,
+		Data:     data,
+		Layouts:  layouts,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "5f8979cb-6189-43e4-a95a-9a9cb7a409aa"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusAccepted, out.Entity)
+}
+
+func (h *handler) SelectBulkJob(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "ca695fe7-f1bc-4e30-91c5-5286e8659235"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "5e2fbceb-e62b-4df4-8131-d3dce2947a9e",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid job id.",
+			Path:   "/id",
+		})
+		return
+	}
+
+	out, err := h.BulkDomain.SelectBulkJob(arcRequest, &bulk.SelectBulkJobInput{
+		Id: id,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "cb54c7a0-6cae-451f-a3c0-dc835f1ce45c"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, out.Entity)
+}
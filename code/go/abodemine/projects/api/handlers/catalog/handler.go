@@ -0,0 +1,37 @@
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+)
+
+type Handler interface {
+	ListErrors(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain arc.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain arc.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain: in.ArcDomain,
+	}
+}
+
+// ListErrors publishes the error catalog, so that the clients can
+// look up the Id of an error. It requires no authentication.
+func (h *handler) ListErrors(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// The catalog only changes with a deploy.
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, errors.Catalog())
+}
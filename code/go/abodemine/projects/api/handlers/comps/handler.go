@@ -0,0 +1,68 @@
+package comps
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/comps"
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/auth"
+)
+
+type Handler interface {
+	SelectComps(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	CompsDomain comps.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	CompsDomain comps.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:   in.ArcDomain,
+		AuthDomain:  in.AuthDomain,
+		CompsDomain: in.CompsDomain,
+	}
+}
+
+func (h *handler) SelectComps(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "91becb07-c2e7-4390-b87f-0171b8fc0a76"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	input := &SelectCompsInput{}
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "21aaa962-3eec-48e9-afb4-76f22d823a00",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	out, err := h.CompsDomain.SelectComps(arcRequest, input.ToDomainModel())
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "59e7bbb5-76d3-44ab-933f-cc72ec6e377f"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, NewSelectCompsOutput(out))
+}
@@ -0,0 +1,47 @@
+package comps
+
+import (
+	"github.com/google/uuid"
+
+	"abodemine/domains/comps"
+	"abodemine/entities"
+)
+
+type SelectCompsInput struct {
+	Aupid  *uuid.UUID `json:"aupid"`
+	Radius float64    `json:"radius"`
+	Months int        `json:"months"`
+	Count  int        `json:"count"`
+}
+
+func (input *SelectCompsInput) ToDomainModel() *comps.SelectCompsInput {
+	return &comps.SelectCompsInput{
+		Aupid:       input.Aupid,
+		RadiusMiles: input.Radius,
+		Months:      input.Months,
+		Count:       input.Count,
+	}
+}
+
+type SelectCompsOutput struct {
+	Radius float64              `json:"radius"`
+	Months int                  `json:"months"`
+	Count  int                  `json:"count"`
+	Comps  []*entities.CompSale `json:"comps"`
+}
+
+func NewSelectCompsOutput(out *comps.SelectCompsOutput) *SelectCompsOutput {
+	compSales := out.CompSaleEntities
+
+	// Ensure we return an empty slice instead of null.
+	if compSales == nil {
+		compSales = []*entities.CompSale{}
+	}
+
+	return &SelectCompsOutput{
+		Radius: out.RadiusMiles,
+		Months: out.Months,
+		Count:  out.Count,
+		Comps:  compSales,
+	}
+}
@@ -1,32 +1,45 @@
 package handlers
 
 import (
-	"net/http"
-	"sync"
-
 	"github.com/julienschmidt/httprouter"
-	"github.com/rs/zerolog/log"
 
 	"abodemine/domains/address"
 	"abodemine/domains/arc"
 	"abodemine/domains/assessor"
 	"abodemine/domains/avm"
+	"abodemine/domains/comps"
 	listings "abodemine/domains/listings"
+	"abodemine/domains/market"
 	"abodemine/domains/property"
 	"abodemine/domains/recorder"
+	"abodemine/domains/timeline"
 	"abodemine/domains/token"
+	"abodemine/lib/errors"
+	"abodemine/lib/health"
 	"abodemine/middleware"
 	"abodemine/projects/api/conf"
+	"abodemine/projects/api/domains/admin"
 	auth "abodemine/projects/api/domains/auth"
+	"abodemine/projects/api/domains/bulk"
 	search "abodemine/projects/api/domains/search"
+	"abodemine/projects/api/domains/usage"
+	admin_handler "abodemine/projects/api/handlers/admin"
 	auth_handler "abodemine/projects/api/handlers/auth"
+	bulk_handler "abodemine/projects/api/handlers/bulk"
+	catalog_handler "abodemine/projects/api/handlers/catalog"
+	comps_handler "abodemine/projects/api/handlers/comps"
 	listings_handler "abodemine/projects/api/handlers/listings"
+	market_handler "abodemine/projects/api/handlers/market"
 	search_handler "abodemine/projects/api/handlers/search"
+	usage_handler "abodemine/projects/api/handlers/usage"
 )
 
-func Router(c *conf.Config) *httprouter.Router {
+// Router registers the routes of the server. The checker serves the
+// health checks, and is shut down by the server on SIGTERM.
+func Router(c *conf.Config, checker *health.Checker) (*httprouter.Router, error) {
 	arcDomain := arc.NewDomain(&arc.NewDomainInput{
 		DeploymentEnvironment: c.File.DeploymentEnvironment,
+		AWS:                   c.AWS,
 		Casbin:                c.Casbin,
 		Duration:              c.Duration,
 		Flags:                 c.File.Flags,
@@ -61,12 +74,26 @@ func Router(c *conf.Config) *httprouter.Router {
 		AddressDomain: addressDomain,
 	})
 
+	compsDomain := comps.NewDomain(&comps.NewDomainInput{
+		AuthDomain: authDomain,
+	})
+
+	marketDomain := market.NewDomain(&market.NewDomainInput{
+		AuthDomain: authDomain,
+	})
+
+	timelineDomain := timeline.NewDomain(&timeline.NewDomainInput{
+		ListingDomain:  listingsDomain,
+		RecorderDomain: recorderDomain,
+	})
+
 	propertyDomain := property.NewDomain(&property.NewDomainInput{
 		AddressDomain:  addressDomain,
 		AssessorDomain: assessorDomain,
 		AvmDomain:      avmDomain,
 		ListingDomain:  listingsDomain,
 		RecorderDomain: recorderDomain,
+		TimelineDomain: timelineDomain,
 	})
 
 	searchDomain := search.NewDomain(&search.NewDomainInput{
@@ -75,6 +102,23 @@ func Router(c *conf.Config) *httprouter.Router {
 		PropertyDomain: propertyDomain,
 	})
 
+	bulkDomain := bulk.NewDomain(&bulk.NewDomainInput{
+		Config:         c,
+		AddressDomain:  addressDomain,
+		AuthDomain:     authDomain,
+		PropertyDomain: propertyDomain,
+	})
+
+	usageDomain := usage.NewDomain(&usage.NewDomainInput{})
+
+	adminDomain := admin.NewDomain(&admin.NewDomainInput{})
+
+	adminHandler := admin_handler.NewHandler(&admin_handler.NewHandlerInput{
+		ArcDomain:   arcDomain,
+		AuthDomain:  authDomain,
+		AdminDomain: adminDomain,
+	})
+
 	authHandler := auth_handler.NewHandler(&auth_handler.NewHandlerInput{
 		ArcDomain:  arcDomain,
 		AuthDomain: authDomain,
@@ -92,27 +136,63 @@ func Router(c *conf.Config) *httprouter.Router {
 		ListingsDomain: listingsDomain,
 	})
 
+	bulkHandler := bulk_handler.NewHandler(&bulk_handler.NewHandlerInput{
+		ArcDomain:  arcDomain,
+		AuthDomain: authDomain,
+		BulkDomain: bulkDomain,
+	})
+
+	compsHandler := comps_handler.NewHandler(&comps_handler.NewHandlerInput{
+		ArcDomain:   arcDomain,
+		AuthDomain:  authDomain,
+		CompsDomain: compsDomain,
+	})
+
+	marketHandler := market_handler.NewHandler(&market_handler.NewHandlerInput{
+		ArcDomain:    arcDomain,
+		AuthDomain:   authDomain,
+		MarketDomain: marketDomain,
+	})
+
 	searchHandler := search_handler.NewHandler(authDomain, searchDomain, arcDomain)
 
+	catalogHandler := catalog_handler.NewHandler(&catalog_handler.NewHandlerInput{
+		ArcDomain: arcDomain,
+	})
+
+	usageHandler := usage_handler.NewHandler(&usage_handler.NewHandlerInput{
+		ArcDomain:   arcDomain,
+		AuthDomain:  authDomain,
+		UsageDomain: usageDomain,
+	})
+
+	openApiValidator, err := middleware.NewOpenApiValidator(&middleware.NewOpenApiValidatorInput{
+		ArcDomain:         arcDomain,
+		Spec:              c.OpenApi,
+		ValidateResponses: c.File.OpenApi != nil && c.File.OpenApi.ValidateResponses,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "1bf78350-8744-4635-9c67-97c05c6d90db")
+	}
+
+	// Metered routes accept an Idempotency-Key header, so that
+	// retried requests are not charged again.
+	idempotency := middleware.NewIdempotency(&middleware.NewIdempotencyInput{
+		ArcDomain: arcDomain,
+	})
+
+	httpMetrics := middleware.NewMetrics(&middleware.NewMetricsInput{
+		Server:     "api",
+		Registerer: c.Metrics,
+	})
+
 	// httprouter doesn't support subrouting, so we have to prefix all routes.
 	router := httprouter.New()
 
-	// Health check.
-	var healthCheckLog sync.Once
-
-	router.GET(
-		"/d5aecc76-03e9-4920-8ee5-30f3c390bc52",
-		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-			healthCheckLog.Do(func() {
-				log.Info().
-					Str("host", r.Host).
-					Str("remote_addr", r.RemoteAddr).
-					Msg("Health check. This event is logged only once.")
-			})
-
-			w.WriteHeader(http.StatusOK)
-		},
-	)
+	// Health checks. The status of each dependency is only served
+	// on the admin listener.
+	router.GET("/livez", checker.Livez)
+	router.GET("/readyz", checker.Readyz)
 
 	////////////////////////////////////////////////////////////////////////////
 	// v2 routes.
@@ -123,7 +203,7 @@ func Router(c *conf.Config) *httprouter.Router {
 
 	// v2Prefix := "/api/v2"
 
-	// router.POST(v2Prefix+"/search", middleware.GzipHandler(middleware.SentryMiddlewareHandler(legacyHandler.Search)))
+	// router.POST(v2Prefix+"/search", middleware.GzipHandler(middleware.ErrorReportHandler(legacyHandler.Search)))
 
 	////////////////////////////////////////////////////////////////////////////
 	// v3 routes.
@@ -131,20 +211,70 @@ func Router(c *conf.Config) *httprouter.Router {
 
 	v3Prefix := "/api/v3"
 
+	router.GET(
+		v3Prefix+"/admin/organizations/:organizationId/quota",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(adminHandler.SelectApiQuota)))))),
+	)
+
+	router.PATCH(
+		v3Prefix+"/admin/organizations/:organizationId/quota",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(adminHandler.UpdateApiQuota)))))),
+	)
+
+	router.GET(
+		v3Prefix+"/admin/organizations/:organizationId/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(adminHandler.SelectApiSessions)))))),
+	)
+
+	router.DELETE(
+		v3Prefix+"/admin/organizations/:organizationId/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(adminHandler.DeleteApiSessions)))))),
+	)
+
 	router.POST(
 		v3Prefix+"/auth/token/exchange",
-		middleware.GzipHandler(middleware.SentryMiddlewareHandler(authHandler.TokenExchange)),
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(authHandler.TokenExchange)))))),
+	)
+
+	router.POST(
+		v3Prefix+"/bulk-jobs",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(bulkHandler.CreateBulkJob)))))),
+	)
+
+	router.GET(
+		v3Prefix+"/bulk-jobs/:id",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(bulkHandler.SelectBulkJob)))))),
+	)
+
+	router.POST(
+		v3Prefix+"/comps",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(idempotency.Handler(compsHandler.SelectComps))))))),
 	)
 
 	router.POST(
 		v3Prefix+"/listings",
-		middleware.GzipHandler(middleware.SentryMiddlewareHandler(listingsHandler.GetListings)),
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(idempotency.Handler(listingsHandler.GetListings))))))),
+	)
+
+	router.POST(
+		v3Prefix+"/market-stats",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(idempotency.Handler(marketHandler.SelectMarketStats))))))),
 	)
 
 	router.POST(
 		v3Prefix+"/search",
-		middleware.GzipHandler(middleware.SentryMiddlewareHandler(searchHandler.SearchProperty)),
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(idempotency.Handler(searchHandler.SearchProperty))))))),
+	)
+
+	router.GET(
+		v3Prefix+"/usage",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(usageHandler.SelectUsage)))))),
+	)
+
+	router.GET(
+		v3Prefix+"/errors",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(middleware.GzipHandler(middleware.ErrorReportHandler(openApiValidator.Handler(catalogHandler.ListErrors)))))),
 	)
 
-	return router
+	return router, nil
 }
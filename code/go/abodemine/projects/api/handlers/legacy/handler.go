@@ -40,9 +40,10 @@ func NewHandler(in *NewHandlerInput) *handler {
 func (h *handler) Search(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
 		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "4cd05b07-fcdb-4734-878e-0dc80f2666a8"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "4cd05b07-fcdb-4734-878e-0dc80f2666a8"))
 		return
 	}
 
@@ -51,18 +52,19 @@ func (h *handler) Search(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	var request []models.PropertySearchRequests
 	err = json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", &errors.Object{
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", &errors.Object{
 			Id:     "857a50a4-b2b7-453f-b3c2-ae233e7cefdd",
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to read request body.",
 			Cause:  err.Error(),
+			Err:    err,
 		})
 		return
 	}
 
 	response, err := h.LegacyDomain.Search(arcRequest, request)
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "aac5e2af-66df-4cae-8c97-f291c6bd7267"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "aac5e2af-66df-4cae-8c97-f291c6bd7267"))
 		return
 	}
 
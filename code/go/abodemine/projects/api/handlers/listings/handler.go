@@ -39,9 +39,10 @@ func NewHandler(in *NewHandlerInput) *handler {
 func (h *handler) GetListings(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
 		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "597e64eb-43aa-4d21-9740-9ebfa8f5cfa0"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "597e64eb-43aa-4d21-9740-9ebfa8f5cfa0"))
 		return
 	}
 
@@ -49,7 +50,7 @@ func (h *handler) GetListings(w http.ResponseWriter, r *http.Request, _ httprout
 
 	searchListingsInput := &SearchListingsInput{}
 	if err := json.NewDecoder(r.Body).Decode(&searchListingsInput); err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), &errors.Object{
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
 			Id:     "7cb7020b-d014-42d1-9552-9af9ba25f515",
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Invalid request body.",
@@ -60,7 +61,7 @@ func (h *handler) GetListings(w http.ResponseWriter, r *http.Request, _ httprout
 	searchListingsInputDomain := searchListingsInput.ToDomainModel()
 	out, err := h.ListingsDomain.SearchListings(arcRequest, &searchListingsInputDomain)
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), errors.Forward(err, "54591cb9-62ba-435d-a690-cf067cfe53cc"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "54591cb9-62ba-435d-a690-cf067cfe53cc"))
 		return
 	}
 
@@ -0,0 +1,68 @@
+package market
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/market"
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/auth"
+)
+
+type Handler interface {
+	SelectMarketStats(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain    arc.Domain
+	AuthDomain   auth.Domain
+	MarketDomain market.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain    arc.Domain
+	AuthDomain   auth.Domain
+	MarketDomain market.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:    in.ArcDomain,
+		AuthDomain:   in.AuthDomain,
+		MarketDomain: in.MarketDomain,
+	}
+}
+
+func (h *handler) SelectMarketStats(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "4806b554-6fc4-45a5-ad79-30907828b613"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	input := &SelectMarketStatsInput{}
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "1771ed14-d571-4256-9907-ffba3f3cc682",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	out, err := h.MarketDomain.SelectMarketStats(arcRequest, input.ToDomainModel())
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "f3d65e01-64e7-4802-b281-1032f54a5bce"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, NewSelectMarketStatsOutput(out))
+}
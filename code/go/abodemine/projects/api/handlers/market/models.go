@@ -0,0 +1,75 @@
+package market
+
+import (
+	"time"
+
+	"abodemine/domains/market"
+	"abodemine/entities"
+	"abodemine/lib/geom"
+)
+
+type SelectMarketStatsInput struct {
+	Interval  string   `json:"interval"`
+	StartDate string   `json:"startDate"`
+	EndDate   string   `json:"endDate"`
+	Zip5Codes []string `json:"zip5Codes"`
+	Fips      string   `json:"fips"`
+
+	GeoPolygon *GeoPolygon `json:"geoPolygon"`
+}
+
+type GeoPolygon struct {
+	Points []GeoPoint `json:"points"`
+}
+
+// GeoPoint represents a geographic coordinate
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func (input *SelectMarketStatsInput) ToDomainModel() *market.SelectMarketStatsInput {
+	domainInput := &market.SelectMarketStatsInput{
+		Interval:  input.Interval,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		Zip5Codes: input.Zip5Codes,
+		Fips:      input.Fips,
+	}
+
+	if input.GeoPolygon != nil {
+		domainInput.Polygon = make([]geom.Point, len(input.GeoPolygon.Points))
+
+		for i, point := range input.GeoPolygon.Points {
+			domainInput.Polygon[i] = geom.Point{
+				Lat: point.Lat,
+				Lon: point.Lon,
+			}
+		}
+	}
+
+	return domainInput
+}
+
+type SelectMarketStatsOutput struct {
+	Interval  string                  `json:"interval"`
+	StartDate string                  `json:"startDate"`
+	EndDate   string                  `json:"endDate"`
+	Buckets   []*entities.MarketStats `json:"buckets"`
+}
+
+func NewSelectMarketStatsOutput(out *market.SelectMarketStatsOutput) *SelectMarketStatsOutput {
+	buckets := out.MarketStatsEntities
+
+	// Ensure we return an empty slice instead of null.
+	if buckets == nil {
+		buckets = []*entities.MarketStats{}
+	}
+
+	return &SelectMarketStatsOutput{
+		Interval:  out.Interval,
+		StartDate: out.StartDate.Format(time.DateOnly),
+		EndDate:   out.EndDate.Format(time.DateOnly),
+		Buckets:   buckets,
+	}
+}
@@ -45,9 +45,10 @@ func (h *handler) SearchProperty(w http.ResponseWriter, r *http.Request, _ httpr
 	// Authenticate request.
 	authOut, err := h.authDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
 		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.arcDomain, w, "", err)
+		arc.HttpApiErrorResponse(h.arcDomain, w, r, "", err)
 		return
 	}
 	arcRequest := authOut.Request
@@ -55,7 +56,7 @@ func (h *handler) SearchProperty(w http.ResponseWriter, r *http.Request, _ httpr
 	var input SearchPropertyInput
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		arc.HttpApiErrorResponse(h.arcDomain, w, arcRequest.Id().String(), &errors.Object{
+		arc.HttpApiErrorResponse(h.arcDomain, w, r, arcRequest.Id().String(), &errors.Object{
 			Id:     "6244d443-5de2-4ee0-aa93-e10da4d935bc",
 			Code:   errors.Code_INVALID_ARGUMENT,
 			Detail: "Invalid request body.",
@@ -73,7 +74,7 @@ func (h *handler) SearchProperty(w http.ResponseWriter, r *http.Request, _ httpr
 		ApiSearchAddress: &input.ApiSearchAddress,
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.arcDomain, w, arcRequest.Id().String(), err)
+		arc.HttpApiErrorResponse(h.arcDomain, w, r, arcRequest.Id().String(), err)
 		return
 	}
 
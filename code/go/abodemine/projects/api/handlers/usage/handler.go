@@ -0,0 +1,77 @@
+package usage
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/auth"
+	"abodemine/projects/api/domains/usage"
+)
+
+type Handler interface {
+	SelectUsage(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	UsageDomain usage.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain   arc.Domain
+	AuthDomain  auth.Domain
+	UsageDomain usage.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:   in.ArcDomain,
+		AuthDomain:  in.AuthDomain,
+		UsageDomain: in.UsageDomain,
+	}
+}
+
+// SelectUsage accepts an optional date query parameter (YYYY-MM-DD)
+// to report a day other than today, along with its month.
+func (h *handler) SelectUsage(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthorizationHeader: r.Header["Authorization"],
+		ResponseHeader:      w.Header(),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "df8d891d-8dee-42bb-b4af-5b9295624180"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	input := &usage.SelectUsageInput{}
+
+	if v := r.URL.Query().Get("date"); v != "" {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+				Id:     "614a17ce-78d5-4f02-841e-3387d17a2317",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid date, expected YYYY-MM-DD.",
+				Path:   "/date",
+			})
+			return
+		}
+
+		input.Date = date
+	}
+
+	out, err := h.UsageDomain.SelectUsage(arcRequest, input)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "0314ddcb-243f-4409-bc4b-82def0e91397"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, out.Entity)
+}
@@ -114,7 +114,7 @@ func Load(config *Config) error {
 				}
 			}
 
-			item, err := gconf.LoadValkeyScript(w)
+			item, err := gconf.LoadValkeyScript(l, w)
 			if err != nil {
 				return errors.Forward(err, "16b3f36e-94be-49a8-809c-c4a42de0c534")
 			}
@@ -155,6 +155,7 @@ func ResolveAndLoad(ctx context.Context, path string) (*Config, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to load default AWS config.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -42,7 +42,13 @@ type File struct {
 	Postgres   map[string]*gconf.Postgres   `json:"postgres,omitempty" yaml:"postgres,omitempty"`
 	Valkey     map[string]*gconf.Valkey     `json:"valkey,omitempty" yaml:"valkey,omitempty"`
 
-	Sentry *gconf.Sentry `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	Sentry        *gconf.Sentry        `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	ErrorReporter *gconf.ErrorReporter `json:"error_reporter,omitempty" yaml:"error_reporter,omitempty"`
+	Tracing       *gconf.Tracing       `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+
+	// MetricsTextfileDir is the directory of the textfile collector
+	// the tasks write their metrics to when they exit.
+	MetricsTextfileDir string `json:"metrics_textfile_dir,omitempty" yaml:"metrics_textfile_dir,omitempty"`
 
 	LogLevel   string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
 	NoLogColor bool   `json:"no_log_color,omitempty" yaml:"no_log_color,omitempty"`
@@ -84,13 +90,26 @@ func Load(config *Config) error {
 	file.DeploymentEnvironment = gconf.DeploymentEnvironmentFromString(file.DeploymentEnvironmentStr)
 	log.Info().Str("deployment_environment", gconf.DeploymentEnvironmentToString(file.DeploymentEnvironment)).Send()
 
-	// Load first to ensure config errors go to Sentry.
-	if file.Sentry != nil {
-		if _, err := logging.InitSentry(file.Sentry, file.DeploymentEnvironment, file.NoLogColor); err != nil {
+	// Load first to ensure config errors are reported.
+	if file.Sentry != nil || file.ErrorReporter != nil {
+		if err := logging.InitReporter(&logging.InitReporterInput{
+			Config:                file.ErrorReporter,
+			Sentry:                file.Sentry,
+			DeploymentEnvironment: file.DeploymentEnvironment,
+			NoLogColor:            file.NoLogColor,
+		}); err != nil {
 			return errors.Forward(err, "9de38903-1a81-40d1-a09a-c438a6980cd5")
 		}
 	}
 
+	if file.Tracing != nil {
+		if err := gconf.LoadTracing(file.Tracing, "datapipe"); err != nil {
+			return errors.Forward(err, "3c419547-27e0-4ab4-ae78-fea66a78b639")
+		}
+
+		log.Info().Msg("Loaded Tracing configuration.")
+	}
+
 	if err := flags.ValidateMany(file.Flags); err != nil {
 		return errors.Forward(err, "4a2acc2f-019c-4b96-a886-7916f4058d84")
 	}
@@ -144,7 +163,7 @@ func Load(config *Config) error {
 				}
 			}
 
-			item, err := gconf.LoadValkeyScript(w)
+			item, err := gconf.LoadValkeyScript(l, w)
 			if err != nil {
 				return errors.Forward(err, "6d4c1443-ae61-4643-a552-113abb0f33c3")
 			}
@@ -185,6 +204,7 @@ func ResolveAndLoad(ctx context.Context, path string) (*Config, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to load default AWS config.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -7,7 +7,7 @@ type Lambdas struct {
 }
 
 type TaskLauncher struct {
-	SqsQueueUrl string             `json:"sqs_queue_url,omitempty" yaml:"sqs_queue_url,omitempty"`
+	SqsQueueUrl string             `json:"sqs_queue_url,omitempty" yaml:"sqs_queue_url,omitempty" conf:"url"`
 	Tasks       *TaskLauncherTasks `json:"tasks,omitempty" yaml:"tasks,omitempty"`
 }
 
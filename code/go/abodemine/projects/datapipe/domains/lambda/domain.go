@@ -9,11 +9,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
 
 	"abodemine/domains/arc"
 	"abodemine/lib/distsync"
 	"abodemine/lib/errors"
+	"abodemine/lib/tracing"
 	"abodemine/lib/val"
 	"abodemine/projects/datapipe/conf"
 )
@@ -98,14 +99,14 @@ func (dom *domain) HandleTaskLauncherLambdaEvent(r *arc.Request, in *HandleTaskL
 
 	msgCount := len(in.SqsMessages)
 
-	log.Info().
+	r.Logger().Info().
 		Int("message_count", msgCount).
 		Send()
 
 	out := &HandleTaskLauncherLambdaEventOutput{}
 
 	if msgCount == 0 {
-		log.Info().Msg("Nothing to do.")
+		r.Logger().Info().Msg("Nothing to do.")
 		return out, nil
 	}
 
@@ -148,7 +149,7 @@ func (dom *domain) ProcessTaskLauncherMessage(r *arc.Request, in *ProcessTaskLau
 			QueueUrl:      &in.SqsQueueUrl,
 			ReceiptHandle: &msg.ReceiptHandle,
 		}); err != nil {
-			log.Error().
+			r.Logger().Error().
 				Err(err).
 				Msg("Failed to delete message.")
 		}
@@ -173,6 +174,7 @@ func (dom *domain) ProcessTaskLauncherMessage(r *arc.Request, in *ProcessTaskLau
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to unmarshal task body.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"message_body": string(msg.Body),
 			},
@@ -198,7 +200,13 @@ type ProcessTaskLauncherTaskInput struct {
 type ProcessTaskLauncherTaskOutput struct{}
 
 func (dom *domain) ProcessTaskLauncherTask(r *arc.Request, in *ProcessTaskLauncherTaskInput) (*ProcessTaskLauncherTaskOutput, error) {
-	log.Info().
+	r, span := r.StartSpan("datapipe.launch "+in.Body.Task,
+		attribute.String("datapipe.partner", in.Body.Partner),
+		attribute.String("datapipe.task", in.Body.Task),
+	)
+	defer span.End()
+
+	r.Logger().Info().
 		Str("partner", in.Body.Partner).
 		Str("task", in.Body.Task).
 		Msg("Processing task.")
@@ -228,6 +236,7 @@ func (dom *domain) ProcessTaskLauncherTask(r *arc.Request, in *ProcessTaskLaunch
 	}
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, errors.Forward(err, "bf47d4c0-98a6-41f6-975c-156d6e704d6b")
 	}
 
@@ -338,7 +347,7 @@ func (dom *domain) processTaskLauncherFetcherTask(r *arc.Request, in *ProcessTas
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_key", partnerKey).
 		Msg("Checking lock.")
 
@@ -357,7 +366,7 @@ func (dom *domain) processTaskLauncherFetcherTask(r *arc.Request, in *ProcessTas
 
 	if lockerStatus.Code == distsync.LockStatusAcquiredRead ||
 		lockerStatus.Code == distsync.LockStatusAcquiredWrite {
-		log.Info().
+		r.Logger().Info().
 			Str("lock_status", lockerStatus.Code.String()).
 			Str("reader_expires_at", lockerStatus.ReaderExpiresAt.Format(time.RFC3339)).
 			Str("writer_expires_at", lockerStatus.WriterExpiresAt.Format(time.RFC3339)).
@@ -368,7 +377,7 @@ func (dom *domain) processTaskLauncherFetcherTask(r *arc.Request, in *ProcessTas
 		return out, nil
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_status", lockerStatus.Code.String()).
 		Msg("Lock is available.")
 
@@ -399,6 +408,9 @@ func (dom *domain) processTaskLauncherFetcherTask(r *arc.Request, in *ProcessTas
 			})
 		}
 
+		// Continue the run and the trace of the launcher in the task.
+		environment = append(environment, taskEnvironment(r)...)
+
 		containerOverrides = append(containerOverrides, types.ContainerOverride{
 			Command:     containerOverride.Command,
 			Environment: environment,
@@ -436,6 +448,7 @@ func (dom *domain) processTaskLauncherFetcherTask(r *arc.Request, in *ProcessTas
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to run task.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -546,7 +559,7 @@ func (dom *domain) processTaskLauncherLoaderTask(r *arc.Request, in *ProcessTask
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_key", partnerKey).
 		Msg("Checking lock.")
 
@@ -565,7 +578,7 @@ func (dom *domain) processTaskLauncherLoaderTask(r *arc.Request, in *ProcessTask
 
 	if lockerStatus.Code == distsync.LockStatusAcquiredRead ||
 		lockerStatus.Code == distsync.LockStatusAcquiredWrite {
-		log.Info().
+		r.Logger().Info().
 			Str("lock_status", lockerStatus.Code.String()).
 			Str("reader_expires_at", lockerStatus.ReaderExpiresAt.Format(time.RFC3339)).
 			Str("writer_expires_at", lockerStatus.WriterExpiresAt.Format(time.RFC3339)).
@@ -576,7 +589,7 @@ func (dom *domain) processTaskLauncherLoaderTask(r *arc.Request, in *ProcessTask
 		return out, nil
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_status", lockerStatus.Code.String()).
 		Msg("Lock is available.")
 
@@ -607,6 +620,9 @@ func (dom *domain) processTaskLauncherLoaderTask(r *arc.Request, in *ProcessTask
 			})
 		}
 
+		// Continue the run and the trace of the launcher in the task.
+		environment = append(environment, taskEnvironment(r)...)
+
 		containerOverrides = append(containerOverrides, types.ContainerOverride{
 			Command:     containerOverride.Command,
 			Environment: environment,
@@ -644,6 +660,7 @@ func (dom *domain) processTaskLauncherLoaderTask(r *arc.Request, in *ProcessTask
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to run task.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -754,7 +771,7 @@ func (dom *domain) processTaskLauncherOsloaderTask(r *arc.Request, in *ProcessTa
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_key", partnerKey).
 		Msg("Checking lock.")
 
@@ -773,7 +790,7 @@ func (dom *domain) processTaskLauncherOsloaderTask(r *arc.Request, in *ProcessTa
 
 	if lockerStatus.Code == distsync.LockStatusAcquiredRead ||
 		lockerStatus.Code == distsync.LockStatusAcquiredWrite {
-		log.Info().
+		r.Logger().Info().
 			Str("lock_status", lockerStatus.Code.String()).
 			Str("reader_expires_at", lockerStatus.ReaderExpiresAt.Format(time.RFC3339)).
 			Str("writer_expires_at", lockerStatus.WriterExpiresAt.Format(time.RFC3339)).
@@ -784,7 +801,7 @@ func (dom *domain) processTaskLauncherOsloaderTask(r *arc.Request, in *ProcessTa
 		return out, nil
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_status", lockerStatus.Code.String()).
 		Msg("Lock is available.")
 
@@ -815,6 +832,9 @@ func (dom *domain) processTaskLauncherOsloaderTask(r *arc.Request, in *ProcessTa
 			})
 		}
 
+		// Continue the run and the trace of the launcher in the task.
+		environment = append(environment, taskEnvironment(r)...)
+
 		containerOverrides = append(containerOverrides, types.ContainerOverride{
 			Command:     containerOverride.Command,
 			Environment: environment,
@@ -852,6 +872,7 @@ func (dom *domain) processTaskLauncherOsloaderTask(r *arc.Request, in *ProcessTa
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to run task.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -962,7 +983,7 @@ func (dom *domain) processTaskLauncherSyntherTask(r *arc.Request, in *ProcessTas
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_key", partnerKey).
 		Msg("Checking lock.")
 
@@ -981,7 +1002,7 @@ func (dom *domain) processTaskLauncherSyntherTask(r *arc.Request, in *ProcessTas
 
 	if lockerStatus.Code == distsync.LockStatusAcquiredRead ||
 		lockerStatus.Code == distsync.LockStatusAcquiredWrite {
-		log.Info().
+		r.Logger().Info().
 			Str("lock_status", lockerStatus.Code.String()).
 			Str("reader_expires_at", lockerStatus.ReaderExpiresAt.Format(time.RFC3339)).
 			Str("writer_expires_at", lockerStatus.WriterExpiresAt.Format(time.RFC3339)).
@@ -992,7 +1013,7 @@ func (dom *domain) processTaskLauncherSyntherTask(r *arc.Request, in *ProcessTas
 		return out, nil
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_status", lockerStatus.Code.String()).
 		Msg("Lock is available.")
 
@@ -1023,6 +1044,9 @@ func (dom *domain) processTaskLauncherSyntherTask(r *arc.Request, in *ProcessTas
 			})
 		}
 
+		// Continue the run and the trace of the launcher in the task.
+		environment = append(environment, taskEnvironment(r)...)
+
 		containerOverrides = append(containerOverrides, types.ContainerOverride{
 			Command:     containerOverride.Command,
 			Environment: environment,
@@ -1060,8 +1084,29 @@ func (dom *domain) processTaskLauncherSyntherTask(r *arc.Request, in *ProcessTas
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to run task.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
 	return out, nil
 }
+
+// taskEnvironment returns the environment variables propagating
+// the id and the trace of the request to the launched task.
+func taskEnvironment(r *arc.Request) []types.KeyValuePair {
+	environment := []types.KeyValuePair{
+		{
+			Name:  val.PtrRef(arc.RunIdEnv),
+			Value: val.PtrRef(r.Id().String()),
+		},
+	}
+
+	for k, v := range tracing.InjectEnv(r.Context()) {
+		environment = append(environment, types.KeyValuePair{
+			Name:  &k,
+			Value: &v,
+		})
+	}
+
+	return environment
+}
@@ -359,6 +359,7 @@ func (dr *Assessor) New(headers map[int]string, fields []string) (entities.DataR
 					Code:   errors.Code_UNKNOWN,
 					Detail: "ATTOMID is required.",
 					Cause:  err.Error(),
+					Err:    err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -2808,6 +2809,7 @@ func (dr *Assessor) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		dr.AMId = u
@@ -3207,6 +3209,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to build SQL.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -3217,6 +3220,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to begin transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -3238,6 +3242,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to scan record.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -3259,9 +3264,14 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to commit transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
+		in.InvalidatePropertiesFunc(r, &entities.PropertyRefs{
+			ADAttomIds: ids[:recordCount],
+		})
+
 		builder = newBuilder()
 		dfObject = updateObjectOut.Entity
 		recordCount = 0
@@ -345,6 +345,7 @@ func (dr *Listing) New(headers map[int]string, fields []string) (entities.DataRe
 					Id:    "b5440ef0-0458-4f75-9069-3b3c53505042",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": v,
 					},
@@ -358,6 +359,7 @@ func (dr *Listing) New(headers map[int]string, fields []string) (entities.DataRe
 					Id:    "2edee4c8-0423-4f8c-a93e-74e9805db9f3",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": v,
 					},
@@ -371,6 +373,7 @@ func (dr *Listing) New(headers map[int]string, fields []string) (entities.DataRe
 					Id:    "fb61c5d4-e618-4860-baae-4bcacab2296e",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": v,
 					},
@@ -384,6 +387,7 @@ func (dr *Listing) New(headers map[int]string, fields []string) (entities.DataRe
 					Id:    "1e44df38-b834-4d7a-885c-e0bfdf19d460",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": v,
 					},
@@ -1679,6 +1683,7 @@ func (dr *Listing) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		dr.AMId = u
@@ -38,6 +38,7 @@ func (dr *PropertyDelete) New(headers map[int]string, fields []string) (entities
 					Id:    "66c4eef2-fa56-43e5-8eda-1471bb8bd1cd",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -67,6 +68,10 @@ func (dr *PropertyDelete) SQLColumns() []string {
 	}
 }
 
+func (dr *PropertyDelete) AppendPropertyRefs(refs *entities.PropertyRefs) {
+	refs.ADAttomIds = append(refs.ADAttomIds, dr.ATTOMID)
+}
+
 func (dr *PropertyDelete) SQLTable() string {
 	return "ad_df_assessor"
 }
@@ -101,6 +106,7 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 	processedRecords := int64(0)
 	scanner := in.Scanner
 	values := make([]any, in.BatchSize)
+	refs := &entities.PropertyRefs{}
 
 	pgxPool, err := r.Dom().SelectPgxPool(consts.ConfigKeyPostgresDatapipe)
 	if err != nil {
@@ -122,6 +128,7 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to build SQL.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -132,6 +139,7 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to begin transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -153,6 +161,7 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to query rows.",
 				Cause:  rows.Err().Error(),
+				Err:    rows.Err(),
 			}
 		}
 
@@ -172,12 +181,16 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to commit transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
+		in.InvalidatePropertiesFunc(r, refs)
+
 		builder = newBuilder()
 		dfObject = updateObjectOut.Entity
 		recordCount = 0
+		refs = &entities.PropertyRefs{}
 
 		return nil
 	}
@@ -214,6 +227,7 @@ func (dr *PropertyDelete) LoadFunc(r *arc.Request, in *entities.LoadDataRecordIn
 		}
 
 		values[recordCount] = recordValues[0]
+		record.(*PropertyDelete).AppendPropertyRefs(refs)
 
 		recordCount++
 		processedRecords++
@@ -274,6 +274,7 @@ func (dr *Recorder) New(headers map[int]string, fields []string) (entities.DataR
 					Id:    "97e255d7-90df-4e9d-a27a-35627f5609f0",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -287,6 +288,7 @@ func (dr *Recorder) New(headers map[int]string, fields []string) (entities.DataR
 					Id:    "efc554f7-9a49-48e4-99a3-8781d0663a4f",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -1355,6 +1357,10 @@ func (dr *Recorder) SQLColumns() []string {
 	}
 }
 
+func (dr *Recorder) AppendPropertyRefs(refs *entities.PropertyRefs) {
+	refs.ADAttomIds = append(refs.ADAttomIds, dr.ATTOMID)
+}
+
 func (dr *Recorder) SQLTable() string {
 	return "ad_df_recorder"
 }
@@ -1368,6 +1374,7 @@ func (dr *Recorder) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		dr.AMId = u
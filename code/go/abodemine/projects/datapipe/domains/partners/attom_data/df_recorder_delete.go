@@ -25,6 +25,7 @@ func (dr *RecorderDelete) New(headers map[int]string, fields []string) (entities
 					Id:    "f93c97fa-709a-4f04-ba0a-678144f4d5c7",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -54,6 +55,10 @@ func (dr *RecorderDelete) SQLColumns() []string {
 	}
 }
 
+func (dr *RecorderDelete) AppendPropertyRefs(refs *entities.PropertyRefs) {
+	refs.ADTransactionIds = append(refs.ADTransactionIds, dr.TransactionID)
+}
+
 func (dr *RecorderDelete) SQLTable() string {
 	return "ad_df_recorder"
 }
@@ -55,6 +55,7 @@ func (dr *RentalAvm) New(headers map[int]string, fields []string) (entities.Data
 					Id:    "6004e19f-6c48-4ff5-a486-04f91e633b9c",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -172,6 +173,10 @@ func (dr *RentalAvm) SQLColumns() []string {
 	}
 }
 
+func (dr *RentalAvm) AppendPropertyRefs(refs *entities.PropertyRefs) {
+	refs.ADAttomIds = append(refs.ADAttomIds, dr.ATTOMID)
+}
+
 func (dr *RentalAvm) SQLTable() string {
 	return "ad_df_rental_avm"
 }
@@ -185,6 +190,7 @@ func (dr *RentalAvm) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		dr.AMId = u
@@ -148,6 +148,7 @@ func (dr *Address) New(headers map[int]string, fields []string) (entities.DataRe
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to parse AddressMasterID.",
 					Cause:  err.Error(),
+					Err:    err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -262,6 +263,7 @@ func (dr *Address) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -325,6 +325,7 @@ func (dr *Assessor) New(headers map[int]string, fields []string) (entities.DataR
 					Id:    "f9549361-7a1e-4078-9577-8716757ae23b",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -1968,6 +1969,7 @@ func (dr *Assessor) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 		dr.AMId = u
@@ -2360,6 +2362,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to build SQL.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"deleteCount": len(deleteIds),
 					"insertCount": len(insertIds),
@@ -2374,6 +2377,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to begin transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -2395,6 +2399,7 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to scan record.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -2416,9 +2421,14 @@ func (dr *Assessor) LoadFunc(r *arc.Request, in *entities.LoadDataRecordInput) (
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to commit transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
+		in.InvalidatePropertiesFunc(r, &entities.PropertyRefs{
+			FAPropertyIds: append(deleteIds, insertIds...),
+		})
+
 		builder = newBuilder()
 		deleteIds = []int64{}
 		dfObject = updateObjectOut.Entity
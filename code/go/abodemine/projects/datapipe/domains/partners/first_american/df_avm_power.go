@@ -74,6 +74,7 @@ func (dr *AVMPower) New(headers map[int]string, fields []string) (entities.DataR
 					Id:    "0f366e8e-e5cf-4e87-ac51-d2cfd645c998",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": v,
 					},
@@ -251,6 +252,10 @@ func (dr *AVMPower) SQLColumns() []string {
 	}
 }
 
+func (dr *AVMPower) AppendPropertyRefs(refs *entities.PropertyRefs) {
+	refs.FAPropertyIds = append(refs.FAPropertyIds, dr.PropertyID)
+}
+
 func (dr *AVMPower) SQLTable() string {
 	return "fa_df_avm_power"
 }
@@ -264,6 +269,7 @@ func (dr *AVMPower) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -91,6 +91,7 @@ func (rep *repository) InsertDataFileDirectoryRecord(r *arc.Request, in *InsertD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -118,6 +119,7 @@ func (rep *repository) InsertDataFileDirectoryRecord(r *arc.Request, in *InsertD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to insert row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -192,6 +194,7 @@ func (rep *repository) SelectDataFileDirectoryRecord(r *arc.Request, in *SelectD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -218,10 +221,11 @@ func (rep *repository) SelectDataFileDirectoryRecord(r *arc.Request, in *SelectD
 			return out, nil
 		}
 		return nil, &errors.Object{
-			Id:     "5a6b7c8d-9e0f-1g2h-3i4j-5k6l7m8n9o0p",
+			Id:     "4ff48721-11bc-45c2-a544-2859562e23c8",
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -308,6 +312,7 @@ func (rep *repository) UpdateDataFileDirectoryRecord(r *arc.Request, in *UpdateD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -336,6 +341,7 @@ func (rep *repository) UpdateDataFileDirectoryRecord(r *arc.Request, in *UpdateD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
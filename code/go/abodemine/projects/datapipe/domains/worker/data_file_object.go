@@ -275,6 +275,7 @@ func (rep *repository) InsertDataFileObjectRecord(r *arc.Request, in *InsertData
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -310,6 +311,7 @@ func (rep *repository) InsertDataFileObjectRecord(r *arc.Request, in *InsertData
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to insert row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -403,6 +405,7 @@ func (rep *repository) SelectDataFileObjectRecord(r *arc.Request, in *SelectData
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -438,6 +441,7 @@ func (rep *repository) SelectDataFileObjectRecord(r *arc.Request, in *SelectData
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -529,6 +533,7 @@ func (rep *repository) UpdateDataFileObjectRecord(r *arc.Request, in *UpdateData
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -561,6 +566,7 @@ func (rep *repository) UpdateDataFileObjectRecord(r *arc.Request, in *UpdateData
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -695,6 +701,7 @@ func (repo *repository) SelectUnparsedDataFileObjectRecords(r *arc.Request, in *
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to select row.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -14,10 +14,10 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
 
 	"abodemine/domains/address"
 	"abodemine/domains/arc"
+	"abodemine/domains/propertycache"
 	"abodemine/lib/distsync"
 	"abodemine/lib/errors"
 	"abodemine/lib/storage"
@@ -50,7 +50,8 @@ type Domain interface {
 type domain struct {
 	config *conf.Config
 
-	addressDomain address.Domain
+	addressDomain       address.Domain
+	propertyCacheDomain propertycache.Domain
 
 	repository         Repository
 	osSearchRepository opensearch.Repository
@@ -59,7 +60,8 @@ type domain struct {
 type NewDomainInput struct {
 	Config *conf.Config
 
-	AddressDomain address.Domain
+	AddressDomain       address.Domain
+	PropertyCacheDomain propertycache.Domain
 
 	Repository         Repository
 	OsSearchRepository opensearch.Repository
@@ -69,6 +71,11 @@ func NewDomain(in *NewDomainInput) *domain {
 	return &domain{
 		config:        in.Config,
 		addressDomain: in.AddressDomain,
+		propertyCacheDomain: val.Ternary(
+			in.PropertyCacheDomain == nil,
+			propertycache.NewDomain(&propertycache.NewDomainInput{}),
+			in.PropertyCacheDomain,
+		),
 		repository: val.Ternary(
 			in.Repository == nil,
 			NewRepository(),
@@ -152,7 +159,7 @@ type ExecFetchDataSourceInput struct {
 type ExecFetchDataSourceOutput struct{}
 
 func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceInput) (*ExecFetchDataSourceOutput, error) {
-	log.Info().
+	r.Logger().Info().
 		Str("rclone_checkers", in.RcloneCheckers).
 		Str("rclone_transfers", in.RcloneTransfers).
 		Str("rclone_source", in.RcloneSource).
@@ -166,6 +173,7 @@ func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceIn
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to generate log file name.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -198,6 +206,7 @@ func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceIn
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to run rclone.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"stderr": stderr.String(),
 			},
@@ -211,6 +220,7 @@ func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceIn
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to open log file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer file.Close()
@@ -236,6 +246,7 @@ func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceIn
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to read log file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -245,10 +256,10 @@ func (dom *domain) ExecFetchDataSource(r *arc.Request, in *ExecFetchDataSourceIn
 
 	if hasTransferredSomething {
 		// TODO: trigger loader posting to SQS.
-		log.Info().
+		r.Logger().Info().
 			Msg("Data source fetch completed.")
 	} else {
-		log.Info().
+		r.Logger().Info().
 			Msg("There was nothing to transfer.")
 	}
 
@@ -447,7 +458,7 @@ func (dom *domain) Lock(r *arc.Request, in *LockInput) (*LockOutput, error) {
 		return nil, errors.Forward(err, "0328467c-d538-4b4d-b324-76e064335e90")
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("lock_key", partnerKey).
 		Msg("Lock acquired.")
 
@@ -469,10 +480,10 @@ func (dom *domain) Lock(r *arc.Request, in *LockInput) (*LockOutput, error) {
 		for {
 			select {
 			case <-extendCtx.Done():
-				log.Info().Msg("Extend lock done. Unlocking.")
+				r.Logger().Info().Msg("Extend lock done. Unlocking.")
 
 				if err := locker.Unlock(context.Background()); err != nil {
-					log.Error().
+					r.Logger().Error().
 						Err(errors.Forward(err, "2fad4b77-d060-4be2-9cd1-83b213bdf690")).
 						Send()
 				}
@@ -482,7 +493,7 @@ func (dom *domain) Lock(r *arc.Request, in *LockInput) (*LockOutput, error) {
 				if err := locker.Extend(extendCtx); err != nil {
 					// Info log because canceling extend is expected.
 
-					log.Info().
+					r.Logger().Info().
 						Err(errors.Forward(err, "8c60c639-b460-47e3-b1de-e969a8de6120")).
 						Send()
 
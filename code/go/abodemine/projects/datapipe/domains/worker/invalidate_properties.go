@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/propertycache"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/extutils"
+	"abodemine/projects/datapipe/entities"
+)
+
+// InvalidateProperties deletes the cached layouts of the
+// properties matching refs. It is called once records are
+// committed, so failures are logged instead of returned.
+func (dom *domain) InvalidateProperties(r *arc.Request, refs *entities.PropertyRefs) {
+	if refs == nil || refs.Len() == 0 {
+		return
+	}
+
+	// Callers may pass the request of the committed
+	// batch, whose transaction can't be used anymore.
+	r = r.Clone()
+
+	selectOut, err := dom.repository.SelectPropertyAupidRecords(r, &SelectPropertyAupidRecordsInput{
+		Refs: refs,
+	})
+	if err != nil {
+		r.Logger().Error().
+			Err(err).
+			Str("id", "2ed03039-2eb0-44e1-ab23-11d22026b5ed").
+			Int("ref_count", refs.Len()).
+			Msg("Failed to select properties to invalidate.")
+
+		return
+	}
+
+	dom.deleteCachedProperties(r, selectOut.Aupids)
+}
+
+func (dom *domain) deleteCachedProperties(r *arc.Request, aupids []uuid.UUID) {
+	if len(aupids) == 0 {
+		return
+	}
+
+	if _, err := dom.propertyCacheDomain.DeleteProperties(r, &propertycache.DeletePropertiesInput{
+		Aupids: aupids,
+	}); err != nil {
+		r.Logger().Error().
+			Err(err).
+			Str("id", "7a8aa54c-49a5-42a8-ad27-d0ef46035521").
+			Int("aupid_count", len(aupids)).
+			Msg("Failed to invalidate cached properties.")
+	}
+}
+
+type SelectPropertyAupidRecordsInput struct {
+	Refs *entities.PropertyRefs
+}
+
+type SelectPropertyAupidRecordsOutput struct {
+	Aupids []uuid.UUID
+}
+
+// SelectPropertyAupidRecords resolves partner keys to AUPIDs.
+// Recorder transactions are resolved through ad_df_recorder,
+// so they must be selected before the records are deleted.
+func (repo *repository) SelectPropertyAupidRecords(r *arc.Request, in *SelectPropertyAupidRecordsInput) (*SelectPropertyAupidRecordsOutput, error) {
+	sql := `
+		select id
+		from properties
+		where
+			ad_attom_id = any ($1)
+			or fa_property_id = any ($2)
+		union
+		select properties.id
+		from ad_df_recorder
+		join properties on properties.ad_attom_id = ad_df_recorder.attomid
+		where ad_df_recorder.transaction_id = any ($3)
+	`
+
+	args := []any{
+		in.Refs.ADAttomIds,
+		in.Refs.FAPropertyIds,
+		in.Refs.ADTransactionIds,
+	}
+
+	rows, err := extutils.PgxQuery(r, consts.ConfigKeyPostgresDatapipe, sql, args)
+	if err != nil {
+		return nil, errors.Forward(err, "d43a2f14-323c-4b4c-b631-d08e5ecd0eea")
+	}
+
+	defer rows.Close()
+
+	out := &SelectPropertyAupidRecordsOutput{}
+
+	for rows.Next() {
+		var aupid uuid.UUID
+
+		if err := rows.Scan(&aupid); err != nil {
+			return nil, &errors.Object{
+				Id:     "984f4a78-1c79-4055-85a6-eaf2e6954756",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to scan row.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		out.Aupids = append(out.Aupids, aupid)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, &errors.Object{
+			Id:     "5c2079c8-b46c-4beb-80f7-0b3168cb448a",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to query rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"abodemine/lib/metrics"
+)
+
+// MetricsRegistry holds the metrics of the tasks, which are short
+// lived and so are written to the textfile collector when they exit
+// rather than scraped.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	rowsLoaded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "datapipe",
+		Name:      "rows_loaded_total",
+		Help:      "Number of rows loaded by the batches.",
+	}, []string{"target", "operation"})
+
+	batchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "datapipe",
+		Name:      "batch_duration_seconds",
+		Help:      "Latency of the batches, from build to commit.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"target", "operation"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(rowsLoaded, batchDuration)
+}
+
+// observeBatch records a batch of rows committed to the target.
+func observeBatch(target, operation string, rows int, start time.Time) {
+	rowsLoaded.WithLabelValues(target, operation).Add(float64(rows))
+	batchDuration.WithLabelValues(target, operation).Observe(time.Since(start).Seconds())
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
 	"github.com/zeebo/xxh3"
 	"golang.org/x/sync/errgroup"
 
@@ -66,7 +65,7 @@ func (dom *domain) LoadOpenSearch(r *arc.Request, in *LoadOpenSearchInput) (*Loa
 	}
 
 	if !indexExistsOut.Exists {
-		log.Info().
+		r.Logger().Info().
 			Str("index_name", indexName).
 			Msg("Creating index.")
 
@@ -188,7 +187,7 @@ func (dom *domain) LoadOpenSearch(r *arc.Request, in *LoadOpenSearchInput) (*Loa
 
 	if directory.Status == entities.DataFileDirectoryStatusDone ||
 		directory.Status == entities.DataFileDirectoryStatusIgnored {
-		log.Debug().
+		r.Logger().Debug().
 			Str("path", directory.Path).
 			Msg("Directory is already processed.")
 		return out, nil
@@ -212,7 +211,7 @@ func (dom *domain) LoadOpenSearch(r *arc.Request, in *LoadOpenSearchInput) (*Loa
 		return nil, errors.Forward(err, "b99bd608-194a-42e5-ac23-8be9acbf5d76")
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("directory_path", directory.Path).
 		Int("fips_count", len(selectFipsOut.Models)).
 		Send()
@@ -266,15 +265,13 @@ RETRY:
 	}
 
 	if err := g.Wait(); err != nil {
-		firstError := errors.First(err)
-
-		if firstError.Code == errors.Code_RESOURCE_EXHAUSTED {
+		if errors.CodeOf(err) == errors.Code_RESOURCE_EXHAUSTED {
 			duration, err := backoff.Next()
 			if err != nil {
 				return nil, errors.Forward(err, "115b74e8-916f-4e9c-823d-2c3d6b2cdf53")
 			}
 
-			log.Warn().
+			r.Logger().Warn().
 				Dur("duration", duration).
 				Int("retries", backoff.Retries()).
 				Msg("Sleeping before retry due to resource exhaustion.")
@@ -321,7 +318,7 @@ func (dom *domain) LoadOpenSearchObject(r *arc.Request, in *LoadOpenSearchObject
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("fips", in.Fips).
 		Msg("Loading OpenSearch object.")
 
@@ -369,7 +366,7 @@ func (dom *domain) LoadOpenSearchObject(r *arc.Request, in *LoadOpenSearchObject
 
 	if dfObject.Status == entities.DataFileObjectStatusDone ||
 		dfObject.Status == entities.DataFileObjectStatusIgnored {
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Fips).
 			Msg("Object already processed.")
 		return out, nil
@@ -393,6 +390,8 @@ func (dom *domain) LoadOpenSearchObject(r *arc.Request, in *LoadOpenSearchObject
 	dfObject.RecordCount = 0
 
 	for {
+		start := time.Now()
+
 		selectAddressesOut, err := dom.addressDomain.SelectPropertyAddress(r, &address.SelectPropertyAddressInput{
 			Fips: in.Fips,
 			IdGt: &lastId,
@@ -433,9 +432,7 @@ func (dom *domain) LoadOpenSearchObject(r *arc.Request, in *LoadOpenSearchObject
 		}); err != nil {
 			var out *LoadOpenSearchObjectOutput
 
-			firstErr := errors.First(err)
-
-			if firstErr.Code == errors.Code_RESOURCE_EXHAUSTED {
+			if errors.CodeOf(err) == errors.Code_RESOURCE_EXHAUSTED {
 				out = &LoadOpenSearchObjectOutput{
 					LastId: lastId,
 					Retry:  true,
@@ -454,6 +451,8 @@ func (dom *domain) LoadOpenSearchObject(r *arc.Request, in *LoadOpenSearchObject
 			return nil, errors.Forward(err, "c44aba29-362e-4194-a524-91357e431d94")
 		}
 
+		observeBatch("opensearch", "index", len(selectAddressesOut.AddressDocuments), start)
+
 		dfObject = updateObjectOut.Record
 
 		lastId, err = val.UUIDFromString(selectAddressesOut.AddressDocuments[len(selectAddressesOut.AddressDocuments)-1].OpenSearchId())
@@ -13,7 +13,6 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/encoding/charmap"
 	"golang.org/x/text/transform"
@@ -55,7 +54,7 @@ func (dom *domain) ProcessDataSourceDirV2(r *arc.Request, in *ProcessDataSourceD
 		path.Clean(strings.ReplaceAll(in.Path, in.Backend.PathSeparator(), "/")),
 	)
 
-	log.Info().
+	r.Logger().Info().
 		Str("path", directoryPath).
 		Msg("Processing data source directory.")
 
@@ -102,12 +101,12 @@ func (dom *domain) ProcessDataSourceDirV2(r *arc.Request, in *ProcessDataSourceD
 
 	switch directory.Status {
 	case entities.DataFileDirectoryStatusDone:
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Path).
 			Msg("Directory already processed.")
 		return out, nil
 	case entities.DataFileDirectoryStatusIgnored:
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Path).
 			Msg("Directory ignored.")
 		return out, nil
@@ -152,7 +151,7 @@ func (dom *domain) ProcessDataSourceDirV2(r *arc.Request, in *ProcessDataSourceD
 		return out, nil
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Int32("totalObjectsToLoad", totalObjectsToLoad).
 		Msg("Processing data sources.")
 
@@ -183,7 +182,7 @@ func (dom *domain) ProcessDataSourceDirV2(r *arc.Request, in *ProcessDataSourceD
 		})
 		if err != nil {
 			// Prevent this error from shadowing the errgroup error.
-			log.Error().
+			r.Logger().Error().
 				Err(errors.Forward(err, "1f275025-b748-4637-bfd4-be7778c70f93")).
 				Send()
 			break
@@ -224,7 +223,7 @@ func (dom *domain) ProcessDataSourceDirV2(r *arc.Request, in *ProcessDataSourceD
 
 					if r := recover(); r != nil {
 						stopProcessing.Store(true)
-						log.Error().
+						gr.Logger().Error().
 							Err(&errors.Object{
 								Id:     "18017cd6-3be2-4032-9b82-8b9c7e51ac36",
 								Code:   errors.Code_INTERNAL,
@@ -304,7 +303,7 @@ func (dom *domain) ProcessDataSourceObjectV2(r *arc.Request, in *ProcessDataSour
 	switch {
 	case fileType == 0:
 		if in.DataFileType == 0 {
-			log.Info().
+			r.Logger().Info().
 				Str("path", in.Path).
 				Msg("Skipping unknown data file type.")
 			return out, nil
@@ -346,7 +345,7 @@ func (dom *domain) ProcessDataSourceObjectV2(r *arc.Request, in *ProcessDataSour
 	case ".txt", ".zip":
 		// This file format is supported.
 	default:
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Path).
 			Msg("Ignoring unsupported file format.")
 		return out, nil
@@ -387,12 +386,12 @@ func (dom *domain) ProcessDataSourceObjectV2(r *arc.Request, in *ProcessDataSour
 
 	switch dfObject.Status {
 	case entities.DataFileObjectStatusToDo:
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Path).
 			Msg("Found new data source object.")
 		out.TotalObjectsToLoad++
 	case entities.DataFileObjectStatusInProgress:
-		log.Info().
+		r.Logger().Info().
 			Str("path", in.Path).
 			Msg("Found pending data source object.")
 		out.TotalObjectsToLoad++
@@ -442,7 +441,7 @@ func (dom *domain) LoadDataSourceObject(r *arc.Request, in *LoadDataSourceObject
 
 	dfObject := in.DataFileObject
 
-	log.Info().
+	r.Logger().Info().
 		Int("batchNumber", in.BatchNumber).
 		Str("fileDir", dfObject.FileDir).
 		Str("fileName", dfObject.FileName).
@@ -451,7 +450,7 @@ func (dom *domain) LoadDataSourceObject(r *arc.Request, in *LoadDataSourceObject
 	fileExt := strings.ToLower(path.Ext(dfObject.FileName))
 
 	if fileExt != ".zip" {
-		log.Warn().
+		r.Logger().Warn().
 			Int("batchNumber", in.BatchNumber).
 			Str("fileExt", fileExt).
 			Str("fileDir", dfObject.FileDir).
@@ -508,6 +507,7 @@ func (dom *domain) LoadZipDataSourceObject(r *arc.Request, in *LoadZipDataSource
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to open zip file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer zipArchive.Close()
@@ -519,6 +519,7 @@ func (dom *domain) LoadZipDataSourceObject(r *arc.Request, in *LoadZipDataSource
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create zip reader.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -559,7 +560,7 @@ type LoadTxtDataSourceObjectInput struct {
 type LoadTxtDataSourceObjectOutput struct{}
 
 func (dom *domain) LoadTxtDataSourceObject(r *arc.Request, in *LoadTxtDataSourceObjectInput) (*LoadTxtDataSourceObjectOutput, error) {
-	log.Info().
+	r.Logger().Info().
 		Str("path", in.Path).
 		Msg("Processing txt data file.")
 
@@ -579,17 +580,19 @@ func (dom *domain) LoadTxtDataSourceObject(r *arc.Request, in *LoadTxtDataSource
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to open data file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
 	defer func() {
 		if err := readCloser.Close(); err != nil {
-			log.Error().
+			r.Logger().Error().
 				Err(&errors.Object{
 					Id:     "0711fa9f-0864-4adc-9ac0-bef5f9356f85",
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to close data file.",
 					Cause:  err.Error(),
+					Err:    err,
 				}).
 				Send()
 		}
@@ -658,6 +661,7 @@ func (dom *domain) LoadTxtDataSourceObject(r *arc.Request, in *LoadTxtDataSource
 		Headers:                  headers,
 		Scanner:                  scanner,
 		UpdateDataFileObjectFunc: dom.UpdateDataFileObject,
+		InvalidatePropertiesFunc: dom.InvalidateProperties,
 	}
 
 	var loadRecordOut *entities.LoadDataRecordOutput
@@ -697,7 +701,7 @@ func (dom *domain) LoadTxtDataSourceObject(r *arc.Request, in *LoadTxtDataSource
 		}
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Str("path", in.Path).
 		Int64("deletedRecords", loadRecordOut.DeletedRecords).
 		Int64("processedRecords", loadRecordOut.ProcessedRecords).
@@ -709,6 +713,7 @@ func (dom *domain) LoadTxtDataSourceObject(r *arc.Request, in *LoadTxtDataSource
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to scan file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -752,6 +757,7 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 	scanner := in.Scanner
 	processedRecords := int64(0)
 	values := make([]any, in.BatchSize)
+	refs := &entities.PropertyRefs{}
 
 	pgxPool, err := r.Dom().SelectPgxPool(consts.ConfigKeyPostgresDatapipe)
 	if err != nil {
@@ -764,6 +770,8 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 			return nil
 		}
 
+		start := time.Now()
+
 		builder = builder.Where(squirrel.Eq{column: values[:recordCount]})
 
 		sql, args, err := builder.ToSql()
@@ -773,6 +781,7 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to build SQL.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -783,12 +792,28 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to begin transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
 		// This clone won't replace the original arc.
 		r = r.Clone(arc.CloneRequestWithPgxTx(consts.ConfigKeyPostgresDatapipe, tx))
 
+		// Resolve the properties before their records are gone.
+		var aupids []uuid.UUID
+
+		if refs.Len() > 0 {
+			selectAupidsOut, err := dom.repository.SelectPropertyAupidRecords(r, &SelectPropertyAupidRecordsInput{
+				Refs: refs,
+			})
+			if err != nil {
+				extutils.RollbackPgxTx(r.Context(), tx, "bf6d122e-a8f2-49f0-afcf-5a81ad565e1b")
+				return errors.Forward(err, "1579476a-47d7-4cc8-9e12-032fe8890901")
+			}
+
+			aupids = selectAupidsOut.Aupids
+		}
+
 		_, err = dom.repository.RemoveDataRecords(r, &RemoveDataRecordsInput{
 			SQL:  sql,
 			Args: args,
@@ -814,12 +839,18 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to commit transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
+		observeBatch(in.DataRecord.SQLTable(), "delete", int(recordCount), start)
+
+		dom.deleteCachedProperties(r, aupids)
+
 		builder = newBuilder()
 		dfObject = updateObjectOut.Entity
 		recordCount = 0
+		refs = &entities.PropertyRefs{}
 
 		return nil
 	}
@@ -857,6 +888,10 @@ func (dom *domain) BatchDeleteDataRecord(r *arc.Request, in *entities.LoadDataRe
 
 		values[recordCount] = recordValues[0]
 
+		if propertyRecord, ok := record.(entities.PropertyDataRecord); ok {
+			propertyRecord.AppendPropertyRefs(refs)
+		}
+
 		recordCount++
 		processedRecords++
 	}
@@ -886,6 +921,7 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 	dfObject := in.DataFileObject
 	scanner := in.Scanner
 	processedRecords := int64(0)
+	refs := &entities.PropertyRefs{}
 
 	pgxPool, err := r.Dom().SelectPgxPool(consts.ConfigKeyPostgresDatapipe)
 	if err != nil {
@@ -898,6 +934,8 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 			return nil
 		}
 
+		start := time.Now()
+
 		sql, args, err := builder.ToSql()
 		if err != nil {
 			return &errors.Object{
@@ -905,6 +943,7 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to build SQL.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -915,6 +954,7 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to begin transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -946,12 +986,18 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to commit transaction.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
+		observeBatch(in.DataRecord.SQLTable(), "insert", int(recordCount), start)
+
+		dom.InvalidateProperties(r, refs)
+
 		builder = newBuilder()
 		dfObject = updateObjectOut.Entity
 		recordCount = 0
+		refs = &entities.PropertyRefs{}
 
 		return nil
 	}
@@ -977,6 +1023,10 @@ func (dom *domain) BatchInsertDataRecord(r *arc.Request, in *entities.LoadDataRe
 
 		builder = builder.Values(recordValues...)
 
+		if propertyRecord, ok := record.(entities.PropertyDataRecord); ok {
+			propertyRecord.AppendPropertyRefs(refs)
+		}
+
 		recordCount++
 		processedRecords++
 	}
@@ -20,6 +20,8 @@ type Repository interface {
 
 	CreateDataRecords(r *arc.Request, in *CreateDataRecordsInput) (*CreateDataRecordsOutput, error)
 	RemoveDataRecords(r *arc.Request, in *RemoveDataRecordsInput) (*RemoveDataRecordsOutput, error)
+
+	SelectPropertyAupidRecords(r *arc.Request, in *SelectPropertyAupidRecordsInput) (*SelectPropertyAupidRecordsOutput, error)
 }
 
 type repository struct{}
@@ -49,6 +51,7 @@ func (repo *repository) CreateDataRecords(r *arc.Request, in *CreateDataRecordsI
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to query rows.",
 			Cause:  rows.Err().Error(),
+			Err:    rows.Err(),
 		}
 	}
 
@@ -78,6 +81,7 @@ func (repo *repository) RemoveDataRecords(r *arc.Request, in *RemoveDataRecordsI
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to query rows.",
 			Cause:  rows.Err().Error(),
+			Err:    rows.Err(),
 		}
 	}
 
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 
 	"abodemine/domains/address"
@@ -45,7 +44,7 @@ func (dom *domain) SyncProperties(r *arc.Request, in *SyncPropertiesInput) (*Syn
 		}()
 	}
 
-	log.Info().Msg("Syncing properties.")
+	r.Logger().Info().Msg("Syncing properties.")
 
 	// Ensure we have up-to-date zip5s.
 	if err := dom.addressDomain.UpdateZip5Table(r); err != nil {
@@ -59,7 +58,7 @@ func (dom *domain) SyncProperties(r *arc.Request, in *SyncPropertiesInput) (*Syn
 		return nil, errors.Forward(err, "ec6e908c-a93a-4acc-b40f-15bd17f40c70")
 	}
 
-	log.Info().
+	r.Logger().Info().
 		Int("zip5_count", len(selectZip5Out.Models)).
 		Send()
 
@@ -71,7 +70,7 @@ func (dom *domain) SyncProperties(r *arc.Request, in *SyncPropertiesInput) (*Syn
 
 	for i, zip5 := range selectZip5Out.Models {
 		g.Go(func() error {
-			log.Info().
+			r.Logger().Info().
 				Int("remaining", len(selectZip5Out.Models)-i).
 				Str("zip5", zip5.Zip5).
 				Msg("Processing Zip5.")
@@ -347,6 +346,7 @@ func (dom *domain) SyncPropertiesByZip5(r *arc.Request, in *SyncPropertiesByZip5
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to begin transaction.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -363,6 +363,7 @@ func (dom *domain) SyncPropertiesByZip5(r *arc.Request, in *SyncPropertiesByZip5
 
 	newAddresses := []opensearch.Document{}
 	newAddressesIds := []uuid.UUID{}
+	newAddressesAupids := []uuid.UUID{}
 
 	for rows.Next() {
 		record := &entities.PropertyAddress{}
@@ -390,6 +391,10 @@ func (dom *domain) SyncPropertiesByZip5(r *arc.Request, in *SyncPropertiesByZip5
 
 		newAddresses = append(newAddresses, record)
 		newAddressesIds = append(newAddressesIds, *record.Id)
+
+		if record.Aupid != nil {
+			newAddressesAupids = append(newAddressesAupids, *record.Aupid)
+		}
 	}
 
 	rows.Close()
@@ -400,12 +405,13 @@ func (dom *domain) SyncPropertiesByZip5(r *arc.Request, in *SyncPropertiesByZip5
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to query rows.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
 	out := &SyncPropertiesByZip5Output{}
 
-	log.Info().
+	r.Logger().Info().
 		Int("batch_count", in.BatchCount).
 		Str("batch_id", batchId.String()).
 		Int("match_count", len(newAddressesIds)).
@@ -453,15 +459,13 @@ RETRY:
 		IndexName: in.OpenSearchIndexName,
 		Items:     newAddresses,
 	}); err != nil {
-		firstErr := errors.First(err)
-
-		if firstErr.Code == errors.Code_RESOURCE_EXHAUSTED {
+		if errors.CodeOf(err) == errors.Code_RESOURCE_EXHAUSTED {
 			duration, err := backoff.Next()
 			if err != nil {
 				return nil, errors.Forward(err, "d63ac939-f215-4d2d-855b-071559662e80")
 			}
 
-			log.Warn().
+			r.Logger().Warn().
 				Int("batch_count", in.BatchCount).
 				Str("batch_id", batchId.String()).
 				Dur("duration", duration).
@@ -483,9 +487,13 @@ RETRY:
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to commit transaction.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
+	// The properties may have been cached without an address.
+	dom.deleteCachedProperties(r, newAddressesAupids)
+
 	out.MatchCount = len(newAddressesIds)
 
 	return out, nil
@@ -74,6 +74,25 @@ const (
 	DataRecordModeLoadFunc
 )
 
+// PropertyDataRecord is implemented by DataRecords keyed by a
+// partner property, so loading them invalidates the cached
+// layouts of the matching AUPIDs.
+type PropertyDataRecord interface {
+	AppendPropertyRefs(refs *PropertyRefs)
+}
+
+// PropertyRefs identifies the properties touched by a batch
+// of records, using the partner keys of the records.
+type PropertyRefs struct {
+	ADAttomIds       []int64
+	ADTransactionIds []int64
+	FAPropertyIds    []int64
+}
+
+func (refs *PropertyRefs) Len() int {
+	return len(refs.ADAttomIds) + len(refs.ADTransactionIds) + len(refs.FAPropertyIds)
+}
+
 type DataRecordLoadParams struct {
 	LoadFunc func(r *arc.Request, in *LoadDataRecordInput) (*LoadDataRecordOutput, error)
 	Mode     int
@@ -88,6 +107,11 @@ type LoadDataRecordInput struct {
 	Headers                  map[int]string
 	Scanner                  *bufio.Scanner
 	UpdateDataFileObjectFunc func(r *arc.Request, in *UpdateDataFileObjectInput) (*UpdateDataFileObjectOutput, error)
+
+	// InvalidatePropertiesFunc must be called once a batch is
+	// committed. Failures are only logged, since the records are
+	// already loaded and cached layouts expire on their own.
+	InvalidatePropertiesFunc func(r *arc.Request, refs *PropertyRefs)
 }
 
 type LoadDataRecordOutput struct {
@@ -27,7 +27,8 @@ type File struct {
 
 	Flags []string `json:"flags,omitempty" yaml:"flags,omitempty"`
 
-	Sentry *gconf.Sentry `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	Sentry        *gconf.Sentry        `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	ErrorReporter *gconf.ErrorReporter `json:"error_reporter,omitempty" yaml:"error_reporter,omitempty"`
 
 	LogLevel   string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
 	NoLogColor bool   `json:"no_log_color,omitempty" yaml:"no_log_color,omitempty"`
@@ -66,9 +67,14 @@ func Load(config *Config) error {
 	file.DeploymentEnvironment = gconf.DeploymentEnvironmentFromString(file.DeploymentEnvironmentStr)
 	log.Info().Str("deployment_environment", gconf.DeploymentEnvironmentToString(file.DeploymentEnvironment)).Send()
 
-	// Load first to ensure config errors go to Sentry.
-	if file.Sentry != nil {
-		if _, err := logging.InitSentry(file.Sentry, file.DeploymentEnvironment, file.NoLogColor); err != nil {
+	// Load first to ensure config errors are reported.
+	if file.Sentry != nil || file.ErrorReporter != nil {
+		if err := logging.InitReporter(&logging.InitReporterInput{
+			Config:                file.ErrorReporter,
+			Sentry:                file.Sentry,
+			DeploymentEnvironment: file.DeploymentEnvironment,
+			NoLogColor:            file.NoLogColor,
+		}); err != nil {
 			return errors.Forward(err, "b04245e5-46e9-43f7-bca6-3336a56a8f16")
 		}
 	}
@@ -112,6 +118,7 @@ func ResolveAndLoad(ctx context.Context, path string) (*Config, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to load default AWS config.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
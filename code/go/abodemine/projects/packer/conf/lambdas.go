@@ -10,9 +10,9 @@ type SecureDownload struct {
 }
 
 type DynamodbTable struct {
-	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty" conf:"required"`
 }
 
 type S3Bucket struct {
-	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty" conf:"required"`
 }
@@ -1,6 +1,7 @@
 package lambda
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -168,6 +169,7 @@ func (dom *domain) HandleSecureDownloadLambdaEvent(r *arc.Request, in *HandleSec
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get DynamoDB item.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -178,6 +180,26 @@ func (dom *domain) HandleSecureDownloadLambdaEvent(r *arc.Request, in *HandleSec
 		}
 	}
 
+	// Tokens issued for a single object, e.g. bulk job results,
+	// can only redeem that object until they expire.
+
+	if v, ok := getItemOut.Item["object"].(*types.AttributeValueMemberS); ok && v.Value != s3ObjectName {
+		return nil, &errors.Object{
+			Id:   "1216129d-8e24-468b-b14a-a56e003a4833",
+			Code: errors.Code_UNAUTHENTICATED,
+		}
+	}
+
+	if v, ok := getItemOut.Item["expires_at"].(*types.AttributeValueMemberN); ok {
+		expiresAt, err := strconv.ParseInt(v.Value, 10, 64)
+		if err != nil || time.Now().Unix() >= expiresAt {
+			return nil, &errors.Object{
+				Id:   "48c408fc-ece3-41f8-a686-ea85ea9b40cb",
+				Code: errors.Code_UNAUTHENTICATED,
+			}
+		}
+	}
+
 	s3Client := s3.NewFromConfig(dom.config.AWS.Get("default"))
 	presignClient := s3.NewPresignClient(s3Client)
 
@@ -196,6 +218,7 @@ func (dom *domain) HandleSecureDownloadLambdaEvent(r *arc.Request, in *HandleSec
 			Code:   errors.Code_INTERNAL,
 			Detail: "Failed to presign S3 get object.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1,19 +1,26 @@
 package conf
 
 import (
+	"context"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 	"github.com/valkey-io/valkey-go"
 
+	"abodemine/lib/consts"
 	"abodemine/lib/errors"
 	"abodemine/lib/flags"
 	"abodemine/lib/gconf"
 	"abodemine/lib/logging"
+	"abodemine/lib/metrics"
 	"abodemine/lib/val"
 )
 
@@ -27,6 +34,15 @@ type Config struct {
 	PGxPool      *val.Cache[string, *pgxpool.Pool]
 	Valkey       *val.Cache[string, valkey.Client]
 	ValkeyScript *val.Cache[string, *valkey.Lua]
+
+	// Metrics is served on the admin listener of the http server.
+	Metrics *prometheus.Registry
+
+	// Source reads the config file again on reload.
+	Source *gconf.ConfigSource
+
+	// Clients is nil unless the reload of the config file is enabled.
+	Clients *gconf.ClientReloader
 }
 
 type File struct {
@@ -36,14 +52,22 @@ type File struct {
 	HttpServer *gconf.HttpServer `json:"http_server,omitempty" yaml:"http_server,omitempty"`
 
 	Casbin     map[string]*gconf.Casbin     `json:"casbin,omitempty" yaml:"casbin,omitempty"`
-	Duration   map[string]string            `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Duration   map[string]string            `json:"duration,omitempty" yaml:"duration,omitempty" conf:"duration"`
 	Flags      []string                     `json:"flags,omitempty" yaml:"flags,omitempty"`
 	OpenSearch map[string]*gconf.OpenSearch `json:"opensearch,omitempty" yaml:"opensearch,omitempty"`
 	Paseto     map[string]*gconf.Paseto     `json:"paseto,omitempty" yaml:"paseto,omitempty"`
 	Postgres   map[string]*gconf.Postgres   `json:"postgres,omitempty" yaml:"postgres,omitempty"`
 	Valkey     map[string]*gconf.Valkey     `json:"valkey,omitempty" yaml:"valkey,omitempty"`
 
-	Sentry *gconf.Sentry `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	Oidc    map[string]*OidcProvider `json:"oidc,omitempty" yaml:"oidc,omitempty"`
+	Otp     *Otp                     `json:"otp,omitempty" yaml:"otp,omitempty"`
+	Tenants map[string]*Tenant       `json:"tenants,omitempty" yaml:"tenants,omitempty"`
+
+	Sentry        *gconf.Sentry        `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	ErrorReporter *gconf.ErrorReporter `json:"error_reporter,omitempty" yaml:"error_reporter,omitempty"`
+	Tracing       *gconf.Tracing       `json:"tracing,omitempty" yaml:"tracing,omitempty"`
+
+	Reload *gconf.ConfigReload `json:"reload,omitempty" yaml:"reload,omitempty"`
 
 	LogLevel   string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
 	NoLogColor bool   `json:"no_log_color,omitempty" yaml:"no_log_color,omitempty"`
@@ -54,7 +78,12 @@ func Resolve(configPath string) (*Config, error) {
 
 	f := new(File)
 
-	if err := gconf.ResolveConfig(f, configPath, envKey); err != nil {
+	source, err := gconf.NewConfigSource(configPath, envKey)
+	if err != nil {
+		return nil, errors.Forward(err, "21ed6740-2880-4192-bfd5-9214aedc4d7b")
+	}
+
+	if err := source.Load(context.Background(), f); err != nil {
 		return nil, errors.Forward(err, "48ffd9f3-3329-4dac-adad-c057aa70b78a")
 	}
 
@@ -63,7 +92,8 @@ func Resolve(configPath string) (*Config, error) {
 	}
 
 	return &Config{
-		File: f,
+		File:   f,
+		Source: source,
 	}, nil
 }
 
@@ -82,13 +112,83 @@ func Load(config *Config) error {
 	file.DeploymentEnvironment = gconf.DeploymentEnvironmentFromString(file.DeploymentEnvironmentStr)
 	log.Info().Str("deployment_environment", gconf.DeploymentEnvironmentToString(file.DeploymentEnvironment)).Send()
 
-	// Load first to ensure config errors go to Sentry.
-	if file.Sentry != nil {
-		if _, err := logging.InitSentry(file.Sentry, file.DeploymentEnvironment, file.NoLogColor); err != nil {
+	// Load first to ensure config errors are reported.
+	if file.Sentry != nil || file.ErrorReporter != nil {
+		if err := logging.InitReporter(&logging.InitReporterInput{
+			Config:                file.ErrorReporter,
+			Sentry:                file.Sentry,
+			DeploymentEnvironment: file.DeploymentEnvironment,
+			NoLogColor:            file.NoLogColor,
+		}); err != nil {
 			return errors.Forward(err, "dcfdbb46-7f61-4bb4-a892-be653d7d0ba7")
 		}
 	}
 
+	if file.Tracing != nil {
+		if err := gconf.LoadTracing(file.Tracing, "saas"); err != nil {
+			return errors.Forward(err, "fefadfee-814c-428f-b71c-1b64f18cb9c8")
+		}
+
+		log.Info().Msg("Loaded Tracing configuration.")
+	}
+
+	config.Metrics = metrics.NewRegistry()
+
+	if otp := file.Otp; otp != nil {
+		if otp.TtlStr != "" {
+			ttl, err := gconf.LoadDuration(otp.TtlStr)
+			if err != nil {
+				return errors.Forward(err, "0be1eac8-f99e-4776-9084-bee467d0afb1")
+			}
+
+			otp.Ttl = ttl
+		}
+
+		if otp.LockoutStr != "" {
+			lockout, err := gconf.LoadDuration(otp.LockoutStr)
+			if err != nil {
+				return errors.Forward(err, "7a544398-863f-4536-98f4-5ce6c3567895")
+			}
+
+			otp.Lockout = lockout
+		}
+
+		if otp.RequestWindowStr != "" {
+			window, err := gconf.LoadDuration(otp.RequestWindowStr)
+			if err != nil {
+				return errors.Forward(err, "7bc6def2-5069-4a81-9373-16e32e3ac543")
+			}
+
+			otp.RequestWindow = window
+		}
+
+		switch otp.Sender {
+		case OtpSenderLog:
+		case OtpSenderFile:
+			if otp.File == "" {
+				return &errors.Object{
+					Id:     "d1338fb4-281d-466b-9228-5b51f277ea6e",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Missing file.",
+					Path:   "/otp/file",
+				}
+			}
+		default:
+			return &errors.Object{
+				Id:     "3ce91d63-51e4-4647-98fe-5c30e75a73c7",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid sender.",
+				Path:   "/otp/sender",
+			}
+		}
+
+		log.Info().Str("sender", otp.Sender).Msg("Loaded Otp configuration.")
+	}
+
+	if err := loadOidc(file); err != nil {
+		return errors.Forward(err, "8fce6654-df8e-4ebc-8c5d-1e3d0c3b06ac")
+	}
+
 	config.Casbin = val.NewCache[string, *casbin.Enforcer]()
 
 	for k, v := range file.Casbin {
@@ -178,7 +278,7 @@ func Load(config *Config) error {
 				}
 			}
 
-			item, err := gconf.LoadValkeyScript(w)
+			item, err := gconf.LoadValkeyScript(l, w)
 			if err != nil {
 				return errors.Forward(err, "25d332bd-e5c8-4a2a-9a27-28babbc98236")
 			}
@@ -188,9 +288,254 @@ func Load(config *Config) error {
 		}
 	}
 
+	if err := loadTenants(config); err != nil {
+		return errors.Forward(err, "1eb69bf9-844f-429d-be16-fecfd223db33")
+	}
+
+	if file.Reload != nil {
+		if err := gconf.LoadConfigReload(file.Reload); err != nil {
+			return errors.Forward(err, "fe49d61b-22b1-4970-b470-3e4212051f5e")
+		}
+
+		config.Clients = gconf.NewClientReloader(&gconf.NewClientReloaderInput{
+			Caches: &gconf.ClientCaches{
+				OpenSearch: config.OpenSearch,
+				Paseto:     config.Paseto,
+				PGxPool:    config.PGxPool,
+				Valkey:     config.Valkey,
+			},
+			Configs:    clientConfigs(file),
+			CloseDelay: file.Reload.CloseDelay,
+		})
+
+		log.Info().
+			Dur("interval", file.Reload.Interval).
+			Msg("Loaded Reload configuration.")
+	}
+
+	return nil
+}
+
+// loadTenants validates the tenants, and MUST be called
+// after the Casbin and Paseto configs are loaded.
+func loadTenants(config *Config) error {
+	hosts := make(map[string]string)
+
+	for k, v := range config.File.Tenants {
+		if v == nil {
+			continue
+		}
+
+		path := "/tenants/" + k
+
+		organizationId, err := uuid.Parse(v.OrganizationIdStr)
+		if err != nil || organizationId == uuid.Nil {
+			return &errors.Object{
+				Id:     "2ee2bc78-9a9d-4143-a3ba-570040ad2089",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid organization id.",
+				Path:   path + "/organization_id",
+			}
+		}
+
+		v.OrganizationId = organizationId
+
+		if len(v.Hosts) == 0 {
+			return &errors.Object{
+				Id:     "74830d26-d19c-4ef9-9a1e-7dc2c8b15f3b",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing hosts.",
+				Path:   path + "/hosts",
+			}
+		}
+
+		for i, host := range v.Hosts {
+			host = strings.ToLower(strings.TrimSpace(host))
+
+			if other, ok := hosts[host]; ok || host == "" {
+				return &errors.Object{
+					Id:     "1ec65fb1-dcce-4084-aba2-800a92aeebf8",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid or duplicate host.",
+					Path:   path + "/hosts",
+					Meta: map[string]any{
+						"host":  host,
+						"other": other,
+					},
+				}
+			}
+
+			hosts[host] = k
+			v.Hosts[i] = host
+		}
+
+		if v.Paseto == "" {
+			v.Paseto = consts.ConfigKeyPasetoSession
+		}
+
+		if !config.Paseto.Has(v.Paseto) {
+			return &errors.Object{
+				Id:     "411a86f7-13ab-414e-aa53-1bfb6e954d3c",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Unknown Paseto config.",
+				Path:   path + "/paseto",
+			}
+		}
+
+		if v.Casbin == "" {
+			v.Casbin = consts.ConfigKeyCasbinSaasWhitelabel
+		}
+
+		if !config.Casbin.Has(v.Casbin) {
+			return &errors.Object{
+				Id:     "cb2bf439-9917-49df-94f4-937400e73f5e",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Unknown Casbin config.",
+				Path:   path + "/casbin",
+			}
+		}
+
+		if v.Cookie == nil {
+			v.Cookie = &TenantCookie{}
+		}
+
+		if v.Cookie.Name == "" {
+			v.Cookie.Name = consts.CookieAbodeMineESOWebSession
+		}
+
+		log.Info().Str("key", k).Strs("hosts", v.Hosts).Msg("Loaded Tenant configuration.")
+	}
+
+	return nil
+}
+
+func loadOidc(file *File) error {
+	for k, v := range file.Oidc {
+		if v == nil {
+			continue
+		}
+
+		path := "/oidc/" + k
+
+		switch {
+		case v.Issuer == "":
+			return &errors.Object{
+				Id:     "b2f5c4ad-a1fc-4286-a2a9-c3274a5cb24a",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing issuer.",
+				Path:   path + "/issuer",
+			}
+		case v.ClientId == "":
+			return &errors.Object{
+				Id:     "bd5237b0-2b41-4c81-8706-dabe0e49efa3",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing client id.",
+				Path:   path + "/client_id",
+			}
+		case v.RedirectUri == "":
+			return &errors.Object{
+				Id:     "f78eef5e-9b31-4190-971f-3b08fc7d02ff",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing redirect uri.",
+				Path:   path + "/redirect_uri",
+			}
+		case len(v.DomainsStr) == 0:
+			return &errors.Object{
+				Id:     "55efa13e-4787-41c3-976a-a437f3fa6e65",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing domains.",
+				Path:   path + "/domains",
+			}
+		}
+
+		if v.GroupsClaim == "" {
+			v.GroupsClaim = "groups"
+		}
+
+		v.Domains = make(map[string]uuid.UUID, len(v.DomainsStr))
+
+		for domain, organizationIdStr := range v.DomainsStr {
+			organizationId, err := uuid.Parse(organizationIdStr)
+			if err != nil || organizationId == uuid.Nil {
+				return &errors.Object{
+					Id:     "57efa963-56b3-4b32-85c3-2c436bb1f783",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid organization id.",
+					Path:   path + "/domains/" + domain,
+				}
+			}
+
+			v.Domains[strings.ToLower(strings.TrimSpace(domain))] = organizationId
+		}
+
+		for i, mapping := range v.RoleMappings {
+			if mapping == nil || mapping.Group == "" || mapping.Role == "" {
+				return &errors.Object{
+					Id:     "8c8566ba-17ca-42f0-8d4c-6122a151fb52",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid role mapping.",
+					Path:   path + "/role_mappings/" + strconv.Itoa(i),
+				}
+			}
+		}
+
+		log.Info().Str("key", k).Str("issuer", v.Issuer).Msg("Loaded Oidc configuration.")
+	}
+
 	return nil
 }
 
+func clientConfigs(file *File) *gconf.ClientConfigs {
+	return &gconf.ClientConfigs{
+		OpenSearch: file.OpenSearch,
+		Paseto:     file.Paseto,
+		Postgres:   file.Postgres,
+		Valkey:     file.Valkey,
+	}
+}
+
+// Reload rebuilds the OpenSearch, Paseto, Postgres and Valkey clients
+// whose config changed in the body of the config file. The other
+// changes of the file are ignored until a restart.
+func (c *Config) Reload(ctx context.Context, body []byte) error {
+	if c.Clients == nil {
+		return &errors.Object{
+			Id:     "8b6a8ec9-1f30-4583-aa08-d24c04406b58",
+			Code:   errors.Code_FAILED_PRECONDITION,
+			Detail: "Reload is not enabled.",
+			Path:   "/reload",
+		}
+	}
+
+	f := new(File)
+
+	if err := c.Source.Decode(body, f); err != nil {
+		return errors.Forward(err, "2a78121b-c6c3-447a-8d04-fb31bbd41b2d")
+	}
+
+	if err := c.Clients.Reload(clientConfigs(f)); err != nil {
+		return errors.Forward(err, "140275ce-d8e3-4f38-a60e-fb31b116537a")
+	}
+
+	return nil
+}
+
+// Close closes the Postgres pools and the Valkey clients. It must be
+// called only once the in-flight requests are drained.
+func (c *Config) Close() {
+	if c.PGxPool != nil {
+		for _, k := range c.PGxPool.Keys() {
+			c.PGxPool.Get(k).Close()
+		}
+	}
+
+	if c.Valkey != nil {
+		for _, k := range c.Valkey.Keys() {
+			c.Valkey.Get(k).Close()
+		}
+	}
+}
+
 func ResolveAndLoad(path string) (*Config, error) {
 	config, err := Resolve(path)
 	if err != nil {
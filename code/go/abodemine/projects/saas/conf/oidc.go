@@ -0,0 +1,42 @@
+package conf
+
+import "github.com/google/uuid"
+
+// OidcProvider enables single sign-on with an OpenID Connect
+// identity provider, using the authorization code flow with PKCE.
+type OidcProvider struct {
+	// Issuer is the url the provider metadata is discovered from.
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty" conf:"required,url"`
+
+	ClientId     string `json:"client_id,omitempty" yaml:"client_id,omitempty" conf:"required"`
+	ClientSecret string `json:"client_secret,omitempty" yaml:"client_secret,omitempty"`
+
+	// RedirectUri MUST be the callback route of the provider,
+	// /api/auth/oidc/<name>/callback, as registered with the provider.
+	RedirectUri string `json:"redirect_uri,omitempty" yaml:"redirect_uri,omitempty" conf:"required,url"`
+
+	// Scopes defaults to openid, email and profile.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// GroupsClaim is the id token claim holding the groups
+	// of the user. Defaults to groups.
+	GroupsClaim string `json:"groups_claim,omitempty" yaml:"groups_claim,omitempty"`
+
+	// Domains maps the email domains of the users to the
+	// organizations they are provisioned into.
+	Domains    map[string]uuid.UUID `json:"-" yaml:"-"`
+	DomainsStr map[string]string    `json:"domains,omitempty" yaml:"domains,omitempty"`
+
+	// RoleMappings are matched in order against the groups of
+	// the user, and the first match wins.
+	RoleMappings []*OidcRoleMapping `json:"role_mappings,omitempty" yaml:"role_mappings,omitempty"`
+
+	// DefaultRole is given to users no mapping matches. Users
+	// are rejected when it is empty.
+	DefaultRole string `json:"default_role,omitempty" yaml:"default_role,omitempty"`
+}
+
+type OidcRoleMapping struct {
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	Role  string `json:"role,omitempty" yaml:"role,omitempty"`
+}
@@ -0,0 +1,45 @@
+package conf
+
+import "time"
+
+const (
+	OtpSenderLog  = "log"
+	OtpSenderFile = "file"
+)
+
+// Otp enables one-time-password login.
+type Otp struct {
+	// Sender is one of log or file. Both MUST only be used
+	// for local development and tests.
+	Sender string `json:"sender,omitempty" yaml:"sender,omitempty"`
+
+	// File is the path of the file codes are appended to,
+	// with the file sender.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Ttl defaults to 10 minutes.
+	Ttl    time.Duration `json:"-" yaml:"-"`
+	TtlStr string        `json:"ttl,omitempty" yaml:"ttl,omitempty" conf:"duration"`
+
+	// MaxAttempts per code. Defaults to 5.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+
+	// MaxFailures per email, before the email is locked out.
+	// Defaults to 10.
+	MaxFailures int `json:"max_failures,omitempty" yaml:"max_failures,omitempty"`
+
+	// Lockout defaults to 30 minutes.
+	Lockout    time.Duration `json:"-" yaml:"-"`
+	LockoutStr string        `json:"lockout,omitempty" yaml:"lockout,omitempty" conf:"duration"`
+
+	// MaxRequests per email within RequestWindow. Defaults to 5.
+	MaxRequests int `json:"max_requests,omitempty" yaml:"max_requests,omitempty"`
+
+	// MaxRequestsPerIp per client address within RequestWindow.
+	// Defaults to 30.
+	MaxRequestsPerIp int `json:"max_requests_per_ip,omitempty" yaml:"max_requests_per_ip,omitempty"`
+
+	// RequestWindow defaults to 15 minutes.
+	RequestWindow    time.Duration `json:"-" yaml:"-"`
+	RequestWindowStr string        `json:"request_window,omitempty" yaml:"request_window,omitempty" conf:"duration"`
+}
@@ -0,0 +1,31 @@
+package conf
+
+import "github.com/google/uuid"
+
+// Tenant is a white-label (ESO) tenant, served on its own hosts.
+type Tenant struct {
+	// Hosts the tenant is resolved by, without port.
+	Hosts []string `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+
+	OrganizationId    uuid.UUID `json:"-" yaml:"-"`
+	OrganizationIdStr string    `json:"organization_id,omitempty" yaml:"organization_id,omitempty"`
+
+	// Paseto is the name of the Paseto config of the sessions
+	// of the tenant. Defaults to session.
+	Paseto string `json:"paseto,omitempty" yaml:"paseto,omitempty"`
+
+	// Casbin is the name of the Casbin config enforced on the
+	// sessions of the tenant. Defaults to saas/whitelabel.
+	Casbin string `json:"casbin,omitempty" yaml:"casbin,omitempty"`
+
+	Cookie *TenantCookie `json:"cookie,omitempty" yaml:"cookie,omitempty"`
+}
+
+type TenantCookie struct {
+	// Name defaults to am-ews.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Domain is left unset by default, so the cookie is only
+	// sent to the host that set it.
+	Domain string `json:"domain,omitempty" yaml:"domain,omitempty"`
+}
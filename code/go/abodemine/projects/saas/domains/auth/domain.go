@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/fxamacker/cbor/v2"
 
@@ -11,30 +12,67 @@ import (
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
 	"abodemine/projects/api/entities"
+	"abodemine/projects/saas/domains/tenant"
 	"abodemine/projects/saas/domains/user"
 )
 
 type Domain interface {
 	Authenticate(ctx context.Context, in *AuthenticateInput) (*AuthenticateOutput, error)
 
+	DeleteSession(r *arc.Request, in *DeleteSessionInput) error
+	DeleteSessions(r *arc.Request, in *DeleteSessionsInput) (*DeleteSessionsOutput, error)
+	ListSessions(r *arc.Request, in *ListSessionsInput) (*ListSessionsOutput, error)
 	Load(r *arc.Request) (*LoadOutput, error)
+	OidcLogin(r *arc.Request, in *OidcLoginInput) (*OidcLoginOutput, error)
+	OidcStart(ctx context.Context, in *OidcStartInput) (*OidcStartOutput, error)
+	OtpLogin(r *arc.Request, in *OtpLoginInput) (*OtpLoginOutput, error)
+	OtpRequest(ctx context.Context, in *OtpRequestInput) (*OtpRequestOutput, error)
 	TokenValidate(r *arc.Request, in *TokenValidateInput) (*TokenValidateOutput, error)
+	UpdateUserRole(r *arc.Request, in *UpdateUserRoleInput) error
 }
 
 type domain struct {
 	repository Repository
 
-	ArcDomain   arc.Domain
-	TokenDomain token.Domain
-	UserDomain  user.Domain
+	ArcDomain    arc.Domain
+	TenantDomain tenant.Domain
+	TokenDomain  token.Domain
+	UserDomain   user.Domain
+
+	oidcProviders map[string]*OidcProvider
+
+	otpSender      OtpSender
+	otpTtl         time.Duration
+	otpMaxAttempts int
+	otpMaxFailures int
+	otpLockout     time.Duration
+
+	otpMaxRequests      int
+	otpMaxRequestsPerIp int
+	otpRequestWindow    time.Duration
 }
 
 type NewDomainInput struct {
 	Repository Repository
 
-	ArcDomain   arc.Domain
-	TokenDomain token.Domain
-	UserDomain  user.Domain
+	ArcDomain    arc.Domain
+	TenantDomain tenant.Domain
+	TokenDomain  token.Domain
+	UserDomain   user.Domain
+
+	// OidcProviders enable AuthMethodOidc.
+	OidcProviders []*OidcProvider
+
+	// OtpSender enables AuthMethodOtp when set.
+	OtpSender      OtpSender
+	OtpTtl         time.Duration
+	OtpMaxAttempts int
+	OtpMaxFailures int
+	OtpLockout     time.Duration
+
+	OtpMaxRequests      int
+	OtpMaxRequestsPerIp int
+	OtpRequestWindow    time.Duration
 }
 
 func NewDomain(in *NewDomainInput) *domain {
@@ -44,28 +82,94 @@ func NewDomain(in *NewDomainInput) *domain {
 		rep = &repository{}
 	}
 
+	otpTtl := in.OtpTtl
+
+	if otpTtl <= 0 {
+		otpTtl = DefaultOtpTtl
+	}
+
+	otpMaxAttempts := in.OtpMaxAttempts
+
+	if otpMaxAttempts <= 0 {
+		otpMaxAttempts = DefaultOtpMaxAttempts
+	}
+
+	otpMaxFailures := in.OtpMaxFailures
+
+	if otpMaxFailures <= 0 {
+		otpMaxFailures = DefaultOtpMaxFailures
+	}
+
+	otpLockout := in.OtpLockout
+
+	if otpLockout <= 0 {
+		otpLockout = DefaultOtpLockout
+	}
+
+	otpMaxRequests := in.OtpMaxRequests
+
+	if otpMaxRequests <= 0 {
+		otpMaxRequests = DefaultOtpMaxRequests
+	}
+
+	otpMaxRequestsPerIp := in.OtpMaxRequestsPerIp
+
+	if otpMaxRequestsPerIp <= 0 {
+		otpMaxRequestsPerIp = DefaultOtpMaxRequestsPerIp
+	}
+
+	otpRequestWindow := in.OtpRequestWindow
+
+	if otpRequestWindow <= 0 {
+		otpRequestWindow = DefaultOtpRequestWindow
+	}
+
+	oidcProviders := make(map[string]*OidcProvider, len(in.OidcProviders))
+
+	for _, v := range in.OidcProviders {
+		oidcProviders[v.Name] = v
+	}
+
 	return &domain{
-		repository:  rep,
-		ArcDomain:   in.ArcDomain,
-		TokenDomain: in.TokenDomain,
-		UserDomain:  in.UserDomain,
+		repository:     rep,
+		ArcDomain:      in.ArcDomain,
+		TenantDomain:   in.TenantDomain,
+		TokenDomain:    in.TokenDomain,
+		UserDomain:     in.UserDomain,
+		oidcProviders:  oidcProviders,
+		otpSender:      in.OtpSender,
+		otpTtl:         otpTtl,
+		otpMaxAttempts: otpMaxAttempts,
+		otpMaxFailures: otpMaxFailures,
+		otpLockout:     otpLockout,
+
+		otpMaxRequests:      otpMaxRequests,
+		otpMaxRequestsPerIp: otpMaxRequestsPerIp,
+		otpRequestWindow:    otpRequestWindow,
 	}
 }
 
 const (
 	AuthMethodCookie = iota
 	AuthMethodToken
+	AuthMethodOtp
+	AuthMethodOidc
 )
 
 type AuthenticateInput struct {
 	AuthMethod  int
 	HttpRequest *http.Request
 	Token       string
+
+	// OtpCode is the code sent to the user, for AuthMethodOtp.
+	OtpCode string
 }
 
 type AuthenticateOutput struct {
 	Request           *arc.Request
 	TokenExchangeBody *entities.TokenExchangeBody
+	OtpTokenBody      *OtpTokenBody
+	OidcStateBody     *OidcStateBody
 }
 
 func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*AuthenticateOutput, error) {
@@ -80,6 +184,7 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 	var pasetoConfigName string
 	var tokenStr string
 	var tokenType uint16
+	var tnt *tenant.Tenant
 
 	switch in.AuthMethod {
 	case AuthMethodCookie:
@@ -91,7 +196,23 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 			}
 		}
 
-		cookie, err := in.HttpRequest.Cookie(consts.CookieAbodeMineSaasWebSession)
+		selectedTenant, err := dom.selectTenantByHost(in.HttpRequest.Host)
+		if err != nil {
+			return nil, errors.Forward(err, "321eb117-8b79-493d-9afd-9e7712109740")
+		}
+
+		cookieName := consts.CookieAbodeMineSaasWebSession
+		pasetoConfigName = consts.ConfigKeyPasetoSession
+		tokenType = token.TypeSaasServerSession
+
+		if selectedTenant != nil {
+			tnt = selectedTenant
+			cookieName = tnt.CookieName
+			pasetoConfigName = tnt.PasetoConfigName
+			tokenType = token.TypeESOWhiteLabelServerSession
+		}
+
+		cookie, err := in.HttpRequest.Cookie(cookieName)
 		if err != nil {
 			return nil, &errors.Object{
 				Id:     "5915278c-3ad1-476d-b26e-206037283409",
@@ -100,13 +221,36 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 			}
 		}
 
-		pasetoConfigName = consts.ConfigKeyPasetoSession
 		tokenStr = cookie.Value
-		tokenType = token.TypeSaasServerSession
 	case AuthMethodToken:
 		pasetoConfigName = consts.ConfigKeyPasetoTokenExchange
 		tokenStr = in.Token
 		tokenType = token.TypeTokenExchange
+	case AuthMethodOtp:
+		pasetoConfigName = consts.ConfigKeyPasetoSession
+		tokenStr = in.Token
+		tokenType = token.TypeOneTimePassword
+	case AuthMethodOidc:
+		if in.HttpRequest == nil {
+			return nil, &errors.Object{
+				Id:     "7513d16e-ccec-4d9e-bc42-c1e86ba5ba3f",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing http request.",
+			}
+		}
+
+		cookie, err := in.HttpRequest.Cookie(consts.CookieAbodeMineOidcState)
+		if err != nil {
+			return nil, &errors.Object{
+				Id:     "73ed814e-409b-4222-b818-b9b585228640",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing cookie",
+			}
+		}
+
+		pasetoConfigName = consts.ConfigKeyPasetoSession
+		tokenStr = cookie.Value
+		tokenType = token.TypeOidcState
 	default:
 		return nil, &errors.Object{
 			Id:     "06e2db52-631b-4a6d-8425-91f05f30de1e",
@@ -115,29 +259,11 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 		}
 	}
 
-	systemRequest, err := dom.ArcDomain.CreateRequest(&arc.CreateRequestInput{
-		Context: ctx,
-	})
+	systemRequest, err := dom.createSystemRequest(ctx)
 	if err != nil {
 		return nil, errors.Forward(err, "5327cce6-fd70-43eb-93ac-03eef1e2eeca")
 	}
 
-	systemSession, err := dom.ArcDomain.CreateServerSession(
-		systemRequest,
-		&arc.CreateServerSessionInput{
-			OrganizationId: consts.AbodeMineOrganizationId(),
-			UserId:         consts.AbodeMineBotUserId(),
-			RoleName:       consts.RoleSystemAuthCheckUser,
-			SessionType:    arc.SessionTypeSystem,
-			TTL:            1,
-			DoNotSave:      true,
-		})
-	if err != nil {
-		return nil, errors.Forward(err, "ef9574c7-89be-4fa4-ac28-bef2aafa7a4d")
-	}
-
-	systemRequest.SetSession(systemSession)
-
 	decodeTokenOut, err := dom.TokenDomain.DecodeToken(systemRequest, &token.DecodeTokenInput{
 		Value:            tokenStr,
 		TokenType:        tokenType,
@@ -148,16 +274,38 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 	}
 
 	switch tokenType {
-	case token.TypeSaasServerSession:
+	case token.TypeSaasServerSession, token.TypeESOWhiteLabelServerSession:
+		sessionType := arc.SessionTypeSaasServer
+
+		if tnt != nil {
+			sessionType = arc.SessionTypeESOWhiteLabelServer
+
+			if decodeTokenOut.OrganizationId != tnt.OrganizationId {
+				return nil, &errors.Object{
+					Id:     "94158f28-c5d1-482c-8b5d-6cac67f406f9",
+					Code:   errors.Code_UNAUTHENTICATED,
+					Detail: "The session does not belong to the tenant.",
+				}
+			}
+		}
+
 		session, err := dom.ArcDomain.SelectServerSession(systemRequest, &arc.SelectServerSessionInput{
 			OrganizationId: decodeTokenOut.OrganizationId,
 			Id:             decodeTokenOut.Id,
-			SessionType:    arc.SessionTypeSaasServer,
+			SessionType:    sessionType,
 		})
 		if err != nil {
 			return nil, errors.Forward(err, "7cfd8da9-8438-404c-8283-04485d7fc853")
 		}
 
+		if tnt != nil && session.Tenant() != tnt.Name {
+			return nil, &errors.Object{
+				Id:     "3f80be4b-fb35-48a5-9623-6bcd938f25fd",
+				Code:   errors.Code_UNAUTHENTICATED,
+				Detail: "The session does not belong to the tenant.",
+			}
+		}
+
 		request, err := dom.ArcDomain.CreateRequest(&arc.CreateRequestInput{
 			Context: ctx,
 		})
@@ -167,6 +315,10 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 
 		request.SetSession(session)
 
+		if record := arc.AccessRecordFromContext(ctx); record != nil {
+			record.SetSession(session)
+		}
+
 		return &AuthenticateOutput{
 			Request: request,
 		}, nil
@@ -189,6 +341,7 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to unmarshal token body.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -196,6 +349,26 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 			Request:           systemRequest,
 			TokenExchangeBody: tokenExchangeBody,
 		}, nil
+	case token.TypeOneTimePassword:
+		otpTokenBody, err := dom.verifyOtp(systemRequest, decodeTokenOut, in.OtpCode)
+		if err != nil {
+			return nil, errors.Forward(err, "1c046742-9baf-483b-8b75-a59d6615f1ae")
+		}
+
+		return &AuthenticateOutput{
+			Request:      systemRequest,
+			OtpTokenBody: otpTokenBody,
+		}, nil
+	case token.TypeOidcState:
+		oidcStateBody, err := dom.verifyOidcState(systemRequest, decodeTokenOut, in.HttpRequest.URL.Query().Get("state"))
+		if err != nil {
+			return nil, errors.Forward(err, "a418d155-d44e-43a8-87da-12067e96b238")
+		}
+
+		return &AuthenticateOutput{
+			Request:       systemRequest,
+			OidcStateBody: oidcStateBody,
+		}, nil
 	}
 
 	return nil, &errors.Object{
@@ -205,6 +378,55 @@ func (dom *domain) Authenticate(ctx context.Context, in *AuthenticateInput) (*Au
 	}
 }
 
+// createSystemRequest creates a request with a system session,
+// used before the user is authenticated.
+func (dom *domain) createSystemRequest(ctx context.Context) (*arc.Request, error) {
+	systemRequest, err := dom.ArcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "0282c232-2f9a-49d4-8401-3261934bc359")
+	}
+
+	systemSession, err := dom.ArcDomain.CreateServerSession(
+		systemRequest,
+		&arc.CreateServerSessionInput{
+			OrganizationId: consts.AbodeMineOrganizationId(),
+			UserId:         consts.AbodeMineBotUserId(),
+			RoleName:       consts.RoleSystemAuthCheckUser,
+			SessionType:    arc.SessionTypeSystem,
+			TTL:            1,
+			DoNotSave:      true,
+		})
+	if err != nil {
+		return nil, errors.Forward(err, "ef9574c7-89be-4fa4-ac28-bef2aafa7a4d")
+	}
+
+	systemRequest.SetSession(systemSession)
+
+	return systemRequest, nil
+}
+
+// selectTenantByHost returns nil for the hosts of the main app.
+func (dom *domain) selectTenantByHost(host string) (*tenant.Tenant, error) {
+	if dom.TenantDomain == nil {
+		return nil, nil
+	}
+
+	selectTenantOut, err := dom.TenantDomain.SelectTenant(&tenant.SelectTenantInput{
+		Host: host,
+	})
+	if err != nil {
+		if errors.CodeOf(err) == errors.Code_NOT_FOUND {
+			return nil, nil
+		}
+
+		return nil, errors.Forward(err, "6e361be4-7995-45c9-8276-5d32b481d96f")
+	}
+
+	return selectTenantOut.Tenant, nil
+}
+
 type LoadOutput struct {
 	WebSession *arc.WebClientSession `json:"web_session,omitempty"`
 }
@@ -212,11 +434,21 @@ type LoadOutput struct {
 func (dom *domain) Load(r *arc.Request) (*LoadOutput, error) {
 	session := r.Session()
 
+	sessionType := arc.SessionTypeSaasWebClient
+
+	if session.SessionType() == arc.SessionTypeESOWhiteLabelServer {
+		if err := dom.TenantDomain.CasbinEnforce(r, "/auth/load", "read"); err != nil {
+			return nil, errors.Forward(err, "30db3047-e6a9-4ece-9056-617c2de9ddfa")
+		}
+
+		sessionType = arc.SessionTypeESOWhiteLabelWebClient
+	}
+
 	out := &LoadOutput{
 		WebSession: &arc.WebClientSession{
 			IsLoading:       true,
 			IsAuthenticated: true,
-			SessionType:     arc.SessionTypeSaasWebClient,
+			SessionType:     sessionType,
 			Timezone:        session.Timezone(),
 		},
 	}
@@ -0,0 +1,450 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/token"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/oidc"
+	"abodemine/projects/saas/domains/user"
+)
+
+// OidcStateTtl bounds the time the user has to sign in
+// with the identity provider.
+const OidcStateTtl = 10 * time.Minute
+
+// OidcProvider is an OpenID Connect identity provider the
+// users of the organizations of its email domains sign in with.
+type OidcProvider struct {
+	Name         string
+	Provider     *oidc.Provider
+	ClientId     string
+	ClientSecret string
+	RedirectUri  string
+	Scopes       []string
+	GroupsClaim  string
+
+	// Domains maps lowercase email domains to organization ids.
+	Domains map[string]uuid.UUID
+
+	RoleMappings []*OidcRoleMapping
+	DefaultRole  string
+}
+
+type OidcRoleMapping struct {
+	Group    string
+	RoleName string
+}
+
+// roleName returns the role of the first mapping matching one
+// of the groups, or the default role.
+func (p *OidcProvider) roleName(groups []string) string {
+	for _, mapping := range p.RoleMappings {
+		for _, group := range groups {
+			if group == mapping.Group {
+				return mapping.RoleName
+			}
+		}
+	}
+
+	return p.DefaultRole
+}
+
+// organizationId returns the organization of the domain of the
+// email, or uuid.Nil.
+func (p *OidcProvider) organizationId(email string) uuid.UUID {
+	i := strings.LastIndexByte(email, '@')
+	if i < 0 {
+		return uuid.Nil
+	}
+
+	return p.Domains[strings.ToLower(email[i+1:])]
+}
+
+// OidcStateBody is stored with the state token of a login in
+// progress, which is kept in a cookie until the callback.
+type OidcStateBody struct {
+	Provider     string `json:"provider,omitempty"`
+	State        string `json:"state,omitempty"`
+	Nonce        string `json:"nonce,omitempty"`
+	CodeVerifier string `json:"code_verifier,omitempty"`
+	ReturnTo     string `json:"return_to,omitempty"`
+}
+
+type OidcStartInput struct {
+	Provider string
+
+	// ReturnTo is the path the user is redirected to once
+	// signed in. Defaults to /.
+	ReturnTo string
+}
+
+type OidcStartOutput struct {
+	AuthUrl    string
+	StateToken string
+	Expire     time.Duration
+}
+
+// OidcStart begins the authorization code flow with PKCE.
+// The state token MUST be set as a cookie before the user is
+// redirected to AuthUrl.
+func (dom *domain) OidcStart(ctx context.Context, in *OidcStartInput) (*OidcStartOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "552a5ad9-3b3c-40cd-a4bc-f34185251299",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	provider, err := dom.selectOidcProvider(in.Provider)
+	if err != nil {
+		return nil, errors.Forward(err, "d2df3406-2c4d-446c-afef-253eeb5f1f6e")
+	}
+
+	r, err := dom.createSystemRequest(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "419bf330-05dc-4dc0-88a6-ac12c68895a9")
+	}
+
+	body := &OidcStateBody{
+		Provider:     provider.Name,
+		State:        oidc.RandomString(32),
+		Nonce:        oidc.RandomString(32),
+		CodeVerifier: oidc.NewCodeVerifier(),
+		ReturnTo:     sanitizeReturnTo(in.ReturnTo),
+	}
+
+	authUrl, err := provider.Provider.AuthCodeUrl(ctx, &oidc.AuthCodeUrlInput{
+		ClientId:     provider.ClientId,
+		RedirectUri:  provider.RedirectUri,
+		Scopes:       provider.Scopes,
+		State:        body.State,
+		Nonce:        body.Nonce,
+		CodeVerifier: body.CodeVerifier,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "115e493f-8232-443a-8ee2-b64a40f81c93")
+	}
+
+	// The organization of the user is only known once signed in,
+	// so the token belongs to the organization of the app.
+	createTokenOut, err := dom.TokenDomain.CreateToken(r, &token.CreateTokenInput{
+		OrganizationId: consts.AbodeMineOrganizationId(),
+		TokenType:      token.TypeOidcState,
+		Value:          body,
+		Quota:          1,
+		TTL:            OidcStateTtl,
+
+		EncodeToken:            true,
+		EncodeTokenVersion:     token.VersionPasetoV4Local,
+		EncodePasetoConfigName: consts.ConfigKeyPasetoSession,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "4849b6fe-66c7-4d70-9596-c1b13a286953")
+	}
+
+	out := &OidcStartOutput{
+		AuthUrl:    authUrl,
+		StateToken: createTokenOut.EncodedToken,
+		Expire:     OidcStateTtl,
+	}
+
+	return out, nil
+}
+
+// verifyOidcState redeems the state token of the cookie, which
+// can only be used once, and checks the state returned by the
+// identity provider.
+func (dom *domain) verifyOidcState(r *arc.Request, decoded *token.DecodeTokenOutput, state string) (*OidcStateBody, error) {
+	selectTokenOut, err := dom.TokenDomain.SelectToken(r, &token.SelectTokenInput{
+		OrganizationId:  decoded.OrganizationId,
+		Id:              decoded.Id,
+		TokenType:       token.TypeOidcState,
+		QuotaDecreaseBy: 1,
+	})
+	if err != nil {
+		switch errors.CodeOf(err) {
+		case errors.Code_NOT_FOUND, errors.Code_RESOURCE_EXHAUSTED:
+			return nil, &errors.Object{
+				Id:     "50865764-a081-4391-8b13-d7120b633d67",
+				Code:   errors.Code_UNAUTHENTICATED,
+				Label:  "OIDC_EXPIRED",
+				Detail: "The sign in expired. Sign in again.",
+			}
+		default:
+			return nil, errors.Forward(err, "db4b66ac-db69-4c70-96ca-2333f470930e")
+		}
+	}
+
+	if err := dom.TokenDomain.DeleteToken(r, &token.DeleteTokenInput{
+		OrganizationId: decoded.OrganizationId,
+		Id:             decoded.Id,
+		TokenType:      token.TypeOidcState,
+	}); err != nil {
+		return nil, errors.Forward(err, "f614d770-e2f6-44ed-85dc-10e3860b54ed")
+	}
+
+	body := new(OidcStateBody)
+
+	if err := cbor.Unmarshal(selectTokenOut.Body(), body); err != nil {
+		return nil, &errors.Object{
+			Id:     "f25c9156-5f93-4588-95f0-29b832e17f35",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to unmarshal token body.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(body.State), []byte(state)) != 1 {
+		return nil, &errors.Object{
+			Id:     "30fadc87-7706-4425-93be-2781c292b469",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Label:  "OIDC_INVALID_STATE",
+			Detail: "Invalid state.",
+		}
+	}
+
+	return body, nil
+}
+
+type OidcLoginInput struct {
+	OidcStateBody *OidcStateBody
+
+	// Provider is the provider of the callback, which MUST be
+	// the one the login started with.
+	Provider string
+
+	// Code is the authorization code returned by the provider.
+	Code string
+}
+
+type OidcLoginOutput struct {
+	SessionToken string
+	Expire       time.Duration
+	ReturnTo     string
+}
+
+// OidcLogin creates the session of a user authenticated with
+// AuthMethodOidc. Users are provisioned on their first sign in,
+// into the organization of the domain of their email, and their
+// role follows the groups of the identity provider.
+func (dom *domain) OidcLogin(r *arc.Request, in *OidcLoginInput) (*OidcLoginOutput, error) {
+	if in == nil || in.OidcStateBody == nil {
+		return nil, &errors.Object{
+			Id:     "31569522-749d-4915-9738-5827a9cbe8c1",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	if in.Code == "" {
+		return nil, &errors.Object{
+			Id:     "f2125f68-8d0e-47f5-bb43-38b63a3a6381",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing code.",
+			Path:   "/code",
+		}
+	}
+
+	if in.Provider != in.OidcStateBody.Provider {
+		return nil, &errors.Object{
+			Id:     "12fb1bf9-de13-4280-b3c9-dbf36cdd80ce",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Label:  "OIDC_INVALID_STATE",
+			Detail: "The sign in started with another provider.",
+		}
+	}
+
+	provider, err := dom.selectOidcProvider(in.Provider)
+	if err != nil {
+		return nil, errors.Forward(err, "6e0a7b03-931f-4f99-93a4-784f64d933ef")
+	}
+
+	exchangeOut, err := provider.Provider.Exchange(r.Context(), &oidc.ExchangeInput{
+		ClientId:     provider.ClientId,
+		ClientSecret: provider.ClientSecret,
+		RedirectUri:  provider.RedirectUri,
+		Code:         in.Code,
+		CodeVerifier: in.OidcStateBody.CodeVerifier,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "ea9a19e1-a2e8-4b15-aa0c-c57ccc0da277")
+	}
+
+	claims, err := provider.Provider.VerifyIdToken(r.Context(), &oidc.VerifyIdTokenInput{
+		RawIdToken: exchangeOut.IdToken,
+		ClientId:   provider.ClientId,
+		Nonce:      in.OidcStateBody.Nonce,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "cef0b248-ec35-4ad4-89e1-c29e8f03ecad")
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, &errors.Object{
+			Id:     "cfb75479-358d-4464-8850-66dc2c10cb20",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Label:  "OIDC_EMAIL_UNVERIFIED",
+			Detail: "The identity provider did not return a verified email.",
+		}
+	}
+
+	organizationId := provider.organizationId(claims.Email)
+
+	if organizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "de8e74ca-9f78-4ef5-97ff-f5b452afb813",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Label:  "OIDC_DOMAIN_NOT_ALLOWED",
+			Detail: "The email domain is not allowed to sign in with this provider.",
+		}
+	}
+
+	roleName := provider.roleName(claims.Strings(provider.GroupsClaim))
+
+	if roleName == "" {
+		return nil, &errors.Object{
+			Id:     "14c24d1d-0b58-489e-8d20-c5c5af18dbed",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Label:  "OIDC_NO_ROLE",
+			Detail: "None of the groups of the user has access.",
+		}
+	}
+
+	u, err := dom.provisionOidcUser(r, &provisionOidcUserInput{
+		OrganizationId: organizationId,
+		ExternalId:     "oidc:" + provider.Name + ":" + claims.Subject,
+		Email:          claims.Email,
+		RoleName:       roleName,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "e754ab67-6eed-4b31-90e4-b224a8d9d62d")
+	}
+
+	ttl, err := r.Dom().SelectDuration(consts.ConfigKeyDurationSaasSession)
+	if err != nil {
+		return nil, errors.Forward(err, "19328d31-3975-42bf-9b0c-ff303f952193")
+	}
+
+	sessionToken, err := dom.createSaasServerSession(r, u, ttl, nil)
+	if err != nil {
+		return nil, errors.Forward(err, "7d9d462b-8cdc-4d17-89ad-a1192b996c0e")
+	}
+
+	out := &OidcLoginOutput{
+		SessionToken: sessionToken,
+		Expire:       ttl,
+		ReturnTo:     sanitizeReturnTo(in.OidcStateBody.ReturnTo),
+	}
+
+	return out, nil
+}
+
+type provisionOidcUserInput struct {
+	OrganizationId uuid.UUID
+	ExternalId     string
+	Email          string
+	RoleName       string
+}
+
+// provisionOidcUser selects the user by external id, and inserts
+// it when missing. Users created by other means are not linked,
+// so an identity provider cannot take over an existing account.
+func (dom *domain) provisionOidcUser(r *arc.Request, in *provisionOidcUserInput) (*user.User, error) {
+	selectUserOut, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
+		OrganizationId: in.OrganizationId,
+		ExternalId:     in.ExternalId,
+	})
+	if err == nil {
+		u := selectUserOut.User
+
+		if u.RoleName != in.RoleName {
+			if err := dom.UserDomain.UpdateUserRole(r, &user.UpdateUserRoleInput{
+				OrganizationId: in.OrganizationId,
+				Id:             u.Id,
+				RoleName:       in.RoleName,
+			}); err != nil {
+				return nil, errors.Forward(err, "5b7bd85d-bd01-4762-80c5-76db53b9a00b")
+			}
+
+			u.RoleName = in.RoleName
+		}
+
+		return u, nil
+	}
+
+	if errors.CodeOf(err) != errors.Code_NOT_FOUND {
+		return nil, errors.Forward(err, "216573f6-bff9-4f92-b8e4-6fb2a9925d3b")
+	}
+
+	if _, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
+		OrganizationId: in.OrganizationId,
+		Email:          in.Email,
+	}); err == nil {
+		return nil, &errors.Object{
+			Id:     "82152906-460a-46d4-abf8-8ad0a2af7807",
+			Code:   errors.Code_ALREADY_EXISTS,
+			Label:  "OIDC_ACCOUNT_EXISTS",
+			Detail: "An account with the email already exists. Sign in with it instead.",
+		}
+	} else if errors.CodeOf(err) != errors.Code_NOT_FOUND {
+		return nil, errors.Forward(err, "bcc99811-052d-4e15-98d3-3c5446f75cb6")
+	}
+
+	now := time.Now()
+
+	insertUserOut, err := dom.UserDomain.InsertUser(r, &user.InsertUserInput{
+		OrganizationId: in.OrganizationId,
+		User: &user.User{
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Username:   strings.ToLower(in.Email),
+			Email:      in.Email,
+			RoleName:   in.RoleName,
+			ExternalId: in.ExternalId,
+		},
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "03363033-3ff1-4534-bee8-92f846a0a77e")
+	}
+
+	return insertUserOut.User, nil
+}
+
+func (dom *domain) selectOidcProvider(name string) (*OidcProvider, error) {
+	provider, ok := dom.oidcProviders[name]
+	if !ok {
+		return nil, &errors.Object{
+			Id:     "de5cda7b-bd83-44de-8ced-9722cf821858",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Unknown identity provider.",
+			Meta: map[string]any{
+				"provider": name,
+			},
+		}
+	}
+
+	return provider, nil
+}
+
+// sanitizeReturnTo only allows local paths, to avoid redirecting
+// users to other sites.
+func sanitizeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") ||
+		strings.HasPrefix(returnTo, "//") ||
+		strings.HasPrefix(returnTo, "/\\") {
+		return "/"
+	}
+
+	return returnTo
+}
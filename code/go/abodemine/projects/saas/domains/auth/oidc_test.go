@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOidcProvider_RoleName(t *testing.T) {
+	provider := &OidcProvider{
+		RoleMappings: []*OidcRoleMapping{
+			{Group: "admins", RoleName: "root"},
+			{Group: "staff", RoleName: "saas_user"},
+		},
+	}
+
+	// The first mapping wins, whatever the order of the groups.
+	assert.Equal(t, "root", provider.roleName([]string{"staff", "admins"}))
+	assert.Equal(t, "saas_user", provider.roleName([]string{"staff"}))
+	assert.Equal(t, "", provider.roleName([]string{"guests"}))
+	assert.Equal(t, "", provider.roleName(nil))
+
+	provider.DefaultRole = "saas_user"
+
+	assert.Equal(t, "saas_user", provider.roleName([]string{"guests"}))
+}
+
+func TestOidcProvider_OrganizationId(t *testing.T) {
+	organizationId := uuid.New()
+
+	provider := &OidcProvider{
+		Domains: map[string]uuid.UUID{
+			"example.com": organizationId,
+		},
+	}
+
+	assert.Equal(t, organizationId, provider.organizationId("jane@example.com"))
+	assert.Equal(t, organizationId, provider.organizationId("Jane@Example.COM"))
+	assert.Equal(t, uuid.Nil, provider.organizationId("jane@sub.example.com"))
+	assert.Equal(t, uuid.Nil, provider.organizationId("jane@example.com.evil.test"))
+	assert.Equal(t, uuid.Nil, provider.organizationId("example.com"))
+}
+
+func TestSanitizeReturnTo(t *testing.T) {
+	testCases := []*struct {
+		in   string
+		want string
+	}{
+		{in: "", want: "/"},
+		{in: "/listings?page=2", want: "/listings?page=2"},
+		{in: "//evil.test", want: "/"},
+		{in: "/\\evil.test", want: "/"},
+		{in: "https://evil.test", want: "/"},
+		{in: "listings", want: "/"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, sanitizeReturnTo(tc.in), tc.in)
+	}
+}
@@ -0,0 +1,490 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/domains/token"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/projects/saas/domains/user"
+)
+
+const (
+	OtpCodeLength = 6
+
+	DefaultOtpTtl         = 10 * time.Minute
+	DefaultOtpMaxAttempts = 5
+	DefaultOtpMaxFailures = 10
+	DefaultOtpLockout     = 30 * time.Minute
+
+	DefaultOtpMaxRequests      = 5
+	DefaultOtpMaxRequestsPerIp = 30
+	DefaultOtpRequestWindow    = 15 * time.Minute
+)
+
+// OtpTokenBody is stored with the one-time-password token.
+// The code itself is not stored, only its MAC keyed by the
+// token id.
+type OtpTokenBody struct {
+	OrganizationId uuid.UUID `json:"organization_id,omitempty"`
+	UserId         uuid.UUID `json:"user_id,omitempty"`
+	CodeHash       []byte    `json:"code_hash,omitempty"`
+
+	// EmailHash keys the failures of the email, whether
+	// or not a user has it.
+	EmailHash []byte `json:"email_hash,omitempty"`
+}
+
+type OtpRequestInput struct {
+	OrganizationId uuid.UUID
+	Email          string
+
+	// Ip is the address of the client, requests are
+	// rate limited per address when set.
+	Ip string
+}
+
+type OtpRequestOutput struct {
+	// Token to redeem with the code sent to the user.
+	Token     string
+	ExpiresAt time.Time
+}
+
+// OtpRequest sends a one-time-password code to the user with
+// the email. To avoid disclosing which emails have an account,
+// a token is returned even if no user matches the email, and
+// no code is sent. Requests are rate limited per email and per
+// client address.
+func (dom *domain) OtpRequest(ctx context.Context, in *OtpRequestInput) (*OtpRequestOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "5c4cccb7-347d-410e-bf9c-8b0004b8ea81",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	if dom.otpSender == nil {
+		return nil, &errors.Object{
+			Id:     "3b5c6702-e11e-4574-b097-6416ec840c6a",
+			Code:   errors.Code_UNIMPLEMENTED,
+			Detail: "One-time-password login is not configured.",
+		}
+	}
+
+	if in.OrganizationId == uuid.Nil {
+		return nil, &errors.Object{
+			Id:     "e04918b4-52af-4f11-beaa-8a4e8b7a924d",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing organization id.",
+			Path:   "/organizationId",
+		}
+	}
+
+	email := strings.TrimSpace(in.Email)
+
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, &errors.Object{
+			Id:     "f1910c46-cc6d-461e-b9fd-a203cad6ad88",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid email.",
+			Path:   "/email",
+		}
+	}
+
+	r, err := dom.createSystemRequest(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "410907e9-8897-4e64-a904-6ac9172e311e")
+	}
+
+	emailHash := hashOtpEmail(email)
+
+	if err := dom.checkOtpRequestRate(r, in.OrganizationId, "e:"+base64.RawURLEncoding.EncodeToString(emailHash), dom.otpMaxRequests); err != nil {
+		return nil, errors.Forward(err, "e50875c6-f460-46aa-8b5e-27c4bf7d1019")
+	}
+
+	if in.Ip != "" {
+		if err := dom.checkOtpRequestRate(r, in.OrganizationId, "i:"+in.Ip, dom.otpMaxRequestsPerIp); err != nil {
+			return nil, errors.Forward(err, "77466865-5a16-49a9-9725-9de64d5ccd62")
+		}
+	}
+
+	if err := dom.checkOtpLockout(r, in.OrganizationId, emailHash); err != nil {
+		return nil, errors.Forward(err, "0b0cdc59-b32e-422d-bd78-39577799773f")
+	}
+
+	userId := uuid.Nil
+
+	selectUserOut, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
+		OrganizationId: in.OrganizationId,
+		Email:          email,
+	})
+	if err != nil {
+		if errors.CodeOf(err) != errors.Code_NOT_FOUND {
+			return nil, errors.Forward(err, "ec9932e3-f1f1-4e5f-b454-b1954cc1639d")
+		}
+	} else {
+		userId = selectUserOut.User.Id
+	}
+
+	code, err := generateOtpCode()
+	if err != nil {
+		return nil, errors.Forward(err, "2146504c-a3e8-4da9-bfd5-10dd4222f83c")
+	}
+
+	tokenId, err := uuid.NewRandom()
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "7dadaf1d-3c41-453d-a69d-4ed6b8e067d4",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to generate token id.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	createTokenOut, err := dom.TokenDomain.CreateToken(r, &token.CreateTokenInput{
+		Id:             tokenId,
+		OrganizationId: in.OrganizationId,
+		TokenType:      token.TypeOneTimePassword,
+		Value: &OtpTokenBody{
+			OrganizationId: in.OrganizationId,
+			UserId:         userId,
+			CodeHash:       hashOtpCode(tokenId, code),
+			EmailHash:      emailHash,
+		},
+		Quota: uint32(dom.otpMaxAttempts),
+		TTL:   dom.otpTtl,
+
+		EncodeToken:            true,
+		EncodeTokenVersion:     token.VersionPasetoV4Local,
+		EncodePasetoConfigName: consts.ConfigKeyPasetoSession,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "6c6a730d-be59-4d2f-b542-6877c3c1505c")
+	}
+
+	expiresAt := time.Now().Add(dom.otpTtl)
+
+	if userId != uuid.Nil {
+		if err := dom.otpSender.SendOtp(r, &SendOtpInput{
+			Email:     email,
+			Code:      code,
+			ExpiresAt: expiresAt,
+		}); err != nil {
+			return nil, errors.Forward(err, "44a89086-13fa-4baa-82b6-6eedaae53d5c")
+		}
+	}
+
+	out := &OtpRequestOutput{
+		Token:     createTokenOut.EncodedToken,
+		ExpiresAt: expiresAt,
+	}
+
+	return out, nil
+}
+
+type OtpLoginInput struct {
+	OtpTokenBody *OtpTokenBody
+}
+
+type OtpLoginOutput struct {
+	SessionToken string
+	Expire       time.Duration
+}
+
+// OtpLogin creates the session of a user authenticated
+// with AuthMethodOtp.
+func (dom *domain) OtpLogin(r *arc.Request, in *OtpLoginInput) (*OtpLoginOutput, error) {
+	if in == nil || in.OtpTokenBody == nil {
+		return nil, &errors.Object{
+			Id:     "31c846e1-8783-4211-aaea-219b1da0e9fa",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	selectUserOut, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
+		OrganizationId: in.OtpTokenBody.OrganizationId,
+		Id:             in.OtpTokenBody.UserId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "26a130f8-093b-4f83-9a69-c185e3454c45")
+	}
+
+	ttl, err := r.Dom().SelectDuration(consts.ConfigKeyDurationSaasSession)
+	if err != nil {
+		return nil, errors.Forward(err, "2988c0c3-f6d3-41e8-88ec-d5fa133afa49")
+	}
+
+	sessionToken, err := dom.createSaasServerSession(r, selectUserOut.User, ttl, nil)
+	if err != nil {
+		return nil, errors.Forward(err, "e6a19b8f-c44a-44d4-b501-f7bdad287565")
+	}
+
+	out := &OtpLoginOutput{
+		SessionToken: sessionToken,
+		Expire:       ttl,
+	}
+
+	return out, nil
+}
+
+// verifyOtp checks the code of a one-time-password token. Every
+// check counts as an attempt of the token, and failures count
+// towards the lockout of the email. Tokens of unknown emails
+// fail as a wrong code does, so they cannot be told apart. The token is deleted once
+// redeemed, so it can only be used once.
+func (dom *domain) verifyOtp(r *arc.Request, decoded *token.DecodeTokenOutput, code string) (*OtpTokenBody, error) {
+	selectTokenOut, err := dom.TokenDomain.SelectToken(r, &token.SelectTokenInput{
+		OrganizationId:  decoded.OrganizationId,
+		Id:              decoded.Id,
+		TokenType:       token.TypeOneTimePassword,
+		QuotaDecreaseBy: 1,
+		ReturnQuota:     true,
+	})
+	if err != nil {
+		switch errors.CodeOf(err) {
+		case errors.Code_NOT_FOUND, errors.Code_RESOURCE_EXHAUSTED:
+			return nil, &errors.Object{
+				Id:     "e5807733-785e-428d-b943-b543931e41ec",
+				Code:   errors.Code_UNAUTHENTICATED,
+				Label:  "OTP_EXPIRED",
+				Detail: "The code expired or was attempted too many times. Request a new code.",
+			}
+		default:
+			return nil, errors.Forward(err, "f8a0af5f-7615-43ef-835e-da9bd44e044c")
+		}
+	}
+
+	body := new(OtpTokenBody)
+
+	if err := cbor.Unmarshal(selectTokenOut.Body(), body); err != nil {
+		return nil, &errors.Object{
+			Id:     "93d4e101-5390-492c-b307-f73b108b8332",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to unmarshal token body.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := dom.checkOtpLockout(r, decoded.OrganizationId, body.EmailHash); err != nil {
+		return nil, errors.Forward(err, "fbe64394-2a6f-4e0e-b71b-4f1233de42fb")
+	}
+
+	// Tokens of unknown emails can never be redeemed.
+	match := hmac.Equal(body.CodeHash, hashOtpCode(decoded.Id, code))
+
+	if !match || body.UserId == uuid.Nil {
+		if err := dom.recordOtpFailure(r, decoded.OrganizationId, body.EmailHash); err != nil {
+			return nil, errors.Forward(err, "1684cd59-5a7f-48ae-be04-18b5f134d6ce")
+		}
+
+		return nil, &errors.Object{
+			Id:     "97406b51-3981-492f-98ab-f4c6d197f27f",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Label:  "OTP_INVALID",
+			Detail: "Invalid code.",
+		}
+	}
+
+	if err := dom.TokenDomain.DeleteToken(r, &token.DeleteTokenInput{
+		OrganizationId: decoded.OrganizationId,
+		Id:             decoded.Id,
+		TokenType:      token.TypeOneTimePassword,
+	}); err != nil {
+		return nil, errors.Forward(err, "747dbe54-4575-4a0c-bfc1-cb921aa87b83")
+	}
+
+	if err := dom.execOtpFailuresScript(r, "delete-otp-failures", decoded.OrganizationId, body.EmailHash, nil); err != nil {
+		return nil, errors.Forward(err, "399693a1-28e5-40ed-854f-b4b39ddef21e")
+	}
+
+	return body, nil
+}
+
+// checkOtpLockout fails if the email had too many failed
+// attempts within the lockout window.
+func (dom *domain) checkOtpLockout(r *arc.Request, organizationId uuid.UUID, emailHash []byte) error {
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyToken)
+	if err != nil {
+		return errors.Forward(err, "c3ef27aa-7e59-4d3f-a44c-2616443bed93")
+	}
+
+	script, err := r.Dom().SelectValkeyScript("select-otp-failures")
+	if err != nil {
+		return errors.Forward(err, "7451b08c-9836-4f85-8602-05362d9bd3f8")
+	}
+
+	failures, err := script.Exec(
+		r.Context(),
+		valkeyCli,
+		[]string{otpFailuresKey(organizationId, emailHash)},
+		nil,
+	).AsInt64()
+	if err != nil {
+		return &errors.Object{
+			Id:     "991fc278-abe7-4f14-84a1-f0c62dea0572",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if failures >= int64(dom.otpMaxFailures) {
+		return &errors.Object{
+			Id:     "7945fc9e-c3ce-41eb-9500-5741b24b0e7c",
+			Code:   errors.Code_RESOURCE_EXHAUSTED,
+			Label:  "OTP_LOCKED",
+			Detail: "Too many failed attempts. Try again later.",
+		}
+	}
+
+	return nil
+}
+
+func (dom *domain) recordOtpFailure(r *arc.Request, organizationId uuid.UUID, emailHash []byte) error {
+	return dom.execOtpFailuresScript(r, "record-otp-failure", organizationId, emailHash, []string{
+		strconv.FormatInt(dom.otpLockout.Milliseconds(), 10),
+	})
+}
+
+func (dom *domain) execOtpFailuresScript(r *arc.Request, name string, organizationId uuid.UUID, emailHash []byte, args []string) error {
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyToken)
+	if err != nil {
+		return errors.Forward(err, "dc8574b0-a454-4cf6-940b-7f46c3186a2d")
+	}
+
+	script, err := r.Dom().SelectValkeyScript(name)
+	if err != nil {
+		return errors.Forward(err, "db4e3b38-99f7-419c-867a-5dc620e369cf")
+	}
+
+	if err := script.Exec(
+		r.Context(),
+		valkeyCli,
+		[]string{otpFailuresKey(organizationId, emailHash)},
+		args,
+	).Error(); err != nil {
+		return &errors.Object{
+			Id:     "d2db073a-9269-47e3-809c-62557c839c02",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"script": name,
+			},
+		}
+	}
+
+	return nil
+}
+
+// checkOtpRequestRate counts a request of the subject, and fails
+// if the subject made more than max requests within the window.
+func (dom *domain) checkOtpRequestRate(r *arc.Request, organizationId uuid.UUID, subject string, max int) error {
+	valkeyCli, err := r.Dom().SelectValkey(consts.ConfigKeyValkeyToken)
+	if err != nil {
+		return errors.Forward(err, "fd7d372f-ddab-447d-b6bb-44804bd2686c")
+	}
+
+	script, err := r.Dom().SelectValkeyScript("record-otp-request")
+	if err != nil {
+		return errors.Forward(err, "51e04473-10c9-4fa7-9a18-62d949a00365")
+	}
+
+	requests, err := script.Exec(
+		r.Context(),
+		valkeyCli,
+		[]string{otpRequestsKey(organizationId, subject)},
+		[]string{strconv.FormatInt(dom.otpRequestWindow.Milliseconds(), 10)},
+	).AsInt64()
+	if err != nil {
+		return &errors.Object{
+			Id:     "95f4750e-430a-460b-8687-1116216b1660",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to execute script.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if requests > int64(max) {
+		return &errors.Object{
+			Id:     "ae2ddbf4-b8b6-47a9-a38b-13356c71f35f",
+			Code:   errors.Code_RESOURCE_EXHAUSTED,
+			Label:  "RATE_LIMIT_EXCEEDED",
+			Detail: "Too many code requests. Try again later.",
+		}
+	}
+
+	return nil
+}
+
+// otpFailuresKey shares the hash slot of the tokens of the organization.
+func otpFailuresKey(organizationId uuid.UUID, emailHash []byte) string {
+	return fmt.Sprintf(
+		"{%s}:otpf:%s",
+		base64.StdEncoding.EncodeToString(organizationId[:])[:22],
+		base64.RawURLEncoding.EncodeToString(emailHash),
+	)
+}
+
+func otpRequestsKey(organizationId uuid.UUID, subject string) string {
+	return fmt.Sprintf(
+		"{%s}:otpr:%s",
+		base64.StdEncoding.EncodeToString(organizationId[:])[:22],
+		subject,
+	)
+}
+
+// hashOtpEmail keeps the emails out of the keys.
+func hashOtpEmail(email string) []byte {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	return sum[:]
+}
+
+func generateOtpCode() (string, error) {
+	max := big.NewInt(1)
+
+	for i := 0; i < OtpCodeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", &errors.Object{
+			Id:     "51d08672-96dd-464b-81c6-d9a7d0755665",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to generate code.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return fmt.Sprintf("%0*d", OtpCodeLength, n), nil
+}
+
+func hashOtpCode(tokenId uuid.UUID, code string) []byte {
+	mac := hmac.New(sha256.New, tokenId[:])
+	mac.Write([]byte(strings.TrimSpace(code)))
+
+	return mac.Sum(nil)
+}
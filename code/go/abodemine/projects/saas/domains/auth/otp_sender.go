@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+)
+
+// OtpSender delivers one-time-password codes to users.
+type OtpSender interface {
+	SendOtp(r *arc.Request, in *SendOtpInput) error
+}
+
+type SendOtpInput struct {
+	Email     string
+	Code      string
+	ExpiresAt time.Time
+}
+
+// LogOtpSender logs codes instead of delivering them.
+// It MUST only be used for local development.
+type LogOtpSender struct{}
+
+func NewLogOtpSender() *LogOtpSender {
+	return &LogOtpSender{}
+}
+
+func (s *LogOtpSender) SendOtp(r *arc.Request, in *SendOtpInput) error {
+	r.Logger().Warn().
+		Str("email", in.Email).
+		Str("code", in.Code).
+		Time("expires_at", in.ExpiresAt).
+		Msg("One-time password.")
+
+	return nil
+}
+
+// FileOtpSender appends codes to a file instead of delivering
+// them, e.g. for end-to-end tests. It MUST only be used for
+// local development and tests.
+type FileOtpSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+type NewFileOtpSenderInput struct {
+	Path string
+}
+
+func NewFileOtpSender(in *NewFileOtpSenderInput) *FileOtpSender {
+	return &FileOtpSender{
+		path: in.Path,
+	}
+}
+
+func (s *FileOtpSender) SendOtp(r *arc.Request, in *SendOtpInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return &errors.Object{
+			Id:     "fcf4d839-6574-4ebd-b234-70313f350a40",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to open otp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s\t%s\t%s\n", in.ExpiresAt.UTC().Format(time.RFC3339), in.Email, in.Code); err != nil {
+		return &errors.Object{
+			Id:     "6b978168-c82c-4fb8-aec2-1113129be96d",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write otp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOtpCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code, err := generateOtpCode()
+		require.NoError(t, err)
+
+		assert.Len(t, code, OtpCodeLength)
+
+		_, err = strconv.Atoi(code)
+		assert.NoError(t, err)
+	}
+}
+
+func TestHashOtpCode(t *testing.T) {
+	tokenId := uuid.New()
+
+	hash := hashOtpCode(tokenId, "012345")
+
+	assert.Equal(t, hash, hashOtpCode(tokenId, " 012345 "))
+	assert.NotEqual(t, hash, hashOtpCode(tokenId, "012346"))
+
+	// The same code of another token has another hash.
+	assert.NotEqual(t, hash, hashOtpCode(uuid.New(), "012345"))
+}
+
+func TestOtpFailuresKey(t *testing.T) {
+	organizationId := uuid.New()
+	emailHash := hashOtpEmail("user@example.com")
+
+	key := otpFailuresKey(organizationId, emailHash)
+
+	// Emails are not stored in the keys, and do not depend on case.
+	assert.NotContains(t, key, "example")
+	assert.Equal(t, key, otpFailuresKey(organizationId, hashOtpEmail(" User@Example.com ")))
+	assert.NotEqual(t, key, otpFailuresKey(organizationId, hashOtpEmail("other@example.com")))
+	assert.NotEqual(t, key, otpFailuresKey(uuid.New(), emailHash))
+}
+
+func TestOtpRequestsKey(t *testing.T) {
+	organizationId := uuid.New()
+
+	key := otpRequestsKey(organizationId, "i:192.0.2.1")
+
+	// Both share the hash slot of the organization.
+	assert.Equal(t, key[:25], otpFailuresKey(organizationId, nil)[:25])
+	assert.NotEqual(t, key, otpRequestsKey(organizationId, "i:192.0.2.2"))
+}
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/projects/saas/domains/user"
+)
+
+// Session is an active session of a user.
+type Session struct {
+	Id          uuid.UUID `json:"id,omitempty"`
+	SessionType uint16    `json:"session_type,omitempty"`
+	Tenant      string    `json:"tenant,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
+
+	// Current is set for the session of the request.
+	Current bool `json:"current,omitempty"`
+}
+
+type ListSessionsInput struct {
+	// UserId defaults to the user of the session. Listing the
+	// sessions of another user requires admin permissions.
+	UserId uuid.UUID
+}
+
+type ListSessionsOutput struct {
+	Sessions []*Session `json:"sessions,omitempty"`
+}
+
+func (dom *domain) ListSessions(r *arc.Request, in *ListSessionsInput) (*ListSessionsOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "c2c81d59-9cae-429e-83e6-76a528a169e0",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	userId, err := dom.sessionsUserId(r, in.UserId, "read")
+	if err != nil {
+		return nil, errors.Forward(err, "a9655c29-7518-492c-b1aa-c928eedb04ed")
+	}
+
+	listOut, err := dom.ArcDomain.ListServerSessions(r, &arc.ListServerSessionsInput{
+		OrganizationId: r.Session().OrganizationId(),
+		UserId:         userId,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "0760e737-ab9c-46bd-a68c-18956a8eeb05")
+	}
+
+	out := &ListSessionsOutput{
+		Sessions: make([]*Session, 0, len(listOut.Sessions)),
+	}
+
+	for _, v := range listOut.Sessions {
+		out.Sessions = append(out.Sessions, &Session{
+			Id:          v.Session.Id(),
+			SessionType: v.Session.SessionType(),
+			Tenant:      v.Session.Tenant(),
+			CreatedAt:   v.Session.CreatedAt(),
+			ExpiresAt:   v.ExpiresAt,
+			Current:     v.Session.Id() == r.Session().Id(),
+		})
+	}
+
+	return out, nil
+}
+
+type DeleteSessionInput struct {
+	Id uuid.UUID
+
+	// UserId defaults to the user of the session. Deleting the
+	// sessions of another user requires admin permissions.
+	UserId uuid.UUID
+}
+
+func (dom *domain) DeleteSession(r *arc.Request, in *DeleteSessionInput) error {
+	if in == nil {
+		return &errors.Object{
+			Id:     "3227b36a-0a98-47a5-bdd3-2b73e31ddb2b",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	if in.Id == uuid.Nil {
+		return &errors.Object{
+			Id:     "2089d39a-1b87-47d5-81b8-eb97b74f3a0c",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing id.",
+			Path:   "/id",
+		}
+	}
+
+	userId, err := dom.sessionsUserId(r, in.UserId, "write")
+	if err != nil {
+		return errors.Forward(err, "45956547-e61f-4c7e-b694-888856a78047")
+	}
+
+	// Only the sessions of the user can be deleted, and the
+	// listing tells the session type.
+	listOut, err := dom.ArcDomain.ListServerSessions(r, &arc.ListServerSessionsInput{
+		OrganizationId: r.Session().OrganizationId(),
+		UserId:         userId,
+	})
+	if err != nil {
+		return errors.Forward(err, "2c9474ce-1615-4d4d-b858-d5aa3bad158c")
+	}
+
+	for _, v := range listOut.Sessions {
+		if v.Session.Id() != in.Id {
+			continue
+		}
+
+		if err := dom.ArcDomain.DeleteServerSession(r, &arc.DeleteServerSessionInput{
+			OrganizationId: r.Session().OrganizationId(),
+			Id:             in.Id,
+			SessionType:    v.Session.SessionType(),
+			UserId:         userId,
+		}); err != nil {
+			return errors.Forward(err, "4cc0abb6-1205-4ffb-bdac-2cdf70029fbe")
+		}
+
+		return nil
+	}
+
+	return &errors.Object{
+		Id:     "644b4e90-0f15-4aa0-a898-a0a5fd5c4a56",
+		Code:   errors.Code_NOT_FOUND,
+		Detail: "Session not found.",
+	}
+}
+
+type DeleteSessionsInput struct {
+	// UserId defaults to the user of the session, whose current
+	// session is kept. Deleting the sessions of another user
+	// requires admin permissions, and deletes all of them.
+	UserId uuid.UUID
+}
+
+type DeleteSessionsOutput struct {
+	Deleted int `json:"deleted"`
+}
+
+func (dom *domain) DeleteSessions(r *arc.Request, in *DeleteSessionsInput) (*DeleteSessionsOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "b8ca956f-ea2d-4c6e-883c-913d50743f41",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	userId, err := dom.sessionsUserId(r, in.UserId, "write")
+	if err != nil {
+		return nil, errors.Forward(err, "2c926d21-02d1-4a5f-91f7-85d1015735a5")
+	}
+
+	var exceptIds []uuid.UUID
+
+	if userId == r.Session().UserId() {
+		exceptIds = append(exceptIds, r.Session().Id())
+	}
+
+	deleteOut, err := dom.ArcDomain.DeleteUserServerSessions(r, &arc.DeleteUserServerSessionsInput{
+		OrganizationId: r.Session().OrganizationId(),
+		UserId:         userId,
+		ExceptIds:      exceptIds,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "61f971ef-b67d-4977-a568-c082c15ada89")
+	}
+
+	out := &DeleteSessionsOutput{
+		Deleted: deleteOut.Deleted,
+	}
+
+	return out, nil
+}
+
+type UpdateUserRoleInput struct {
+	UserId   uuid.UUID
+	RoleName string
+}
+
+// assignableRoles ranks the roles admins can assign to the users of
+// their organization. Root and the system roles are never assignable.
+var assignableRoles = map[string]int{
+	consts.RoleSaasUser:      1,
+	consts.RoleSaasAdminUser: 2,
+}
+
+// canManageRole reports whether a caller with the role callerRole
+// may grant or revoke the role, which must not outrank its own.
+func canManageRole(callerRole, role string) bool {
+	if callerRole == consts.RoleRoot || role == "" {
+		return true
+	}
+
+	rank, ok := assignableRoles[role]
+
+	return ok && rank <= assignableRoles[callerRole]
+}
+
+// UpdateUserRole changes the role of another user of the
+// organization, which logs the user out everywhere. Only the
+// assignableRoles can be granted, and neither the current nor
+// the new role of the user may outrank the role of the caller.
+func (dom *domain) UpdateUserRole(r *arc.Request, in *UpdateUserRoleInput) error {
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinSaasDefault,
+		"/admin/users/role",
+		"write",
+	); err != nil {
+		return errors.Forward(err, "fe96055d-6565-4d83-b7ad-7c04c31ae862")
+	}
+
+	if in == nil {
+		return &errors.Object{
+			Id:     "641d4d53-993c-4923-8dfd-dfe17975cc6d",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	if in.UserId == r.Session().UserId() {
+		return &errors.Object{
+			Id:     "e8bd67a5-331c-4ae7-8434-cd1a1f319f5a",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Detail: "Users cannot change their own role.",
+			Path:   "/userId",
+		}
+	}
+
+	if _, ok := assignableRoles[in.RoleName]; !ok {
+		return &errors.Object{
+			Id:     "947a932a-3ed0-4c85-aa22-f3617167a095",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The role cannot be assigned.",
+			Path:   "/role_name",
+		}
+	}
+
+	callerRole := r.Session().RoleName()
+
+	if !canManageRole(callerRole, in.RoleName) {
+		return &errors.Object{
+			Id:     "a1c2032d-cc5e-4b75-8bc4-369e09c0a9ed",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Detail: "The role outranks the role of the caller.",
+			Path:   "/role_name",
+		}
+	}
+
+	selectUserOut, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
+		OrganizationId: r.Session().OrganizationId(),
+		Id:             in.UserId,
+	})
+	if err != nil {
+		return errors.Forward(err, "f4c17e86-7b3d-49a8-be09-bdad4b1e7f6a")
+	}
+
+	if !canManageRole(callerRole, selectUserOut.User.RoleName) {
+		return &errors.Object{
+			Id:     "39b45f44-ea70-44be-818b-a51f328e3f31",
+			Code:   errors.Code_PERMISSION_DENIED,
+			Detail: "The role of the user outranks the role of the caller.",
+			Path:   "/userId",
+		}
+	}
+
+	if err := dom.UserDomain.UpdateUserRole(r, &user.UpdateUserRoleInput{
+		OrganizationId: r.Session().OrganizationId(),
+		Id:             in.UserId,
+		RoleName:       in.RoleName,
+	}); err != nil {
+		return errors.Forward(err, "76172bb2-ce7b-4b9f-ba6b-ad704302967e")
+	}
+
+	return nil
+}
+
+// sessionsUserId returns the user whose sessions are managed,
+// and checks admin permissions for the sessions of other users.
+func (dom *domain) sessionsUserId(r *arc.Request, userId uuid.UUID, act string) (uuid.UUID, error) {
+	sessionUserId := r.Session().UserId()
+
+	if userId == uuid.Nil || userId == sessionUserId {
+		if sessionUserId == uuid.Nil {
+			return uuid.Nil, &errors.Object{
+				Id:     "d1928846-7e7d-4576-86e0-4dce02db4b1c",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "The session has no user.",
+			}
+		}
+
+		return sessionUserId, nil
+	}
+
+	if err := r.CasbinEnforce(
+		consts.ConfigKeyCasbinSaasDefault,
+		"/admin/users/sessions",
+		act,
+	); err != nil {
+		return uuid.Nil, errors.Forward(err, "591aabde-498e-4acb-9e06-d3465a59e58a")
+	}
+
+	return userId, nil
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+	"abodemine/lib/val"
+	"abodemine/projects/saas/domains/user"
+)
+
+// fakeUserDomain holds the users, keyed by id.
+type fakeUserDomain struct {
+	user.Domain
+
+	users map[uuid.UUID]*user.User
+}
+
+func (d *fakeUserDomain) SelectUser(r *arc.Request, in *user.SelectUserInput) (*user.SelectUserOutput, error) {
+	u, ok := d.users[in.Id]
+	if !ok {
+		return nil, &errors.Object{
+			Id:     "0c6b0b8e-55f4-4b73-9d1c-0d5d1b54b4a7",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "User not found.",
+		}
+	}
+
+	return &user.SelectUserOutput{User: u}, nil
+}
+
+func (d *fakeUserDomain) UpdateUserRole(r *arc.Request, in *user.UpdateUserRoleInput) error {
+	d.users[in.Id].RoleName = in.RoleName
+
+	return nil
+}
+
+func newTestRequest(t *testing.T, roleName string) *arc.Request {
+	enforcer, err := gconf.LoadCasbin(&gconf.Casbin{
+		Model:  "../../../../../../casbin/projects/saas/policies/default/model.conf",
+		Policy: "../../../../../../casbin/projects/saas/policies/default/policy.csv",
+	})
+	require.NoError(t, err)
+
+	casbinCache := val.NewCache[string, *casbin.Enforcer]()
+	casbinCache.Set(consts.ConfigKeyCasbinSaasDefault, enforcer)
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		Casbin: casbinCache,
+	})
+
+	r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+
+	session, err := arcDomain.CreateServerSession(r, &arc.CreateServerSessionInput{
+		OrganizationId: uuid.New(),
+		UserId:         uuid.New(),
+		RoleName:       roleName,
+		SessionType:    arc.SessionTypeSaasWebClient,
+		TTL:            1,
+		DoNotSave:      true,
+	})
+	require.NoError(t, err)
+
+	r.SetSession(session)
+
+	return r
+}
+
+func TestDomain_AdminPermissions(t *testing.T) {
+	dom := &domain{}
+	userId := uuid.New()
+
+	for _, act := range []string{"read", "write"} {
+		// Users manage their own sessions only.
+		r := newTestRequest(t, consts.RoleSaasUser)
+
+		ownUserId, err := dom.sessionsUserId(r, uuid.Nil, act)
+		require.NoError(t, err)
+		assert.Equal(t, r.Session().UserId(), ownUserId)
+
+		_, err = dom.sessionsUserId(r, userId, act)
+		require.Error(t, err)
+		assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.CodeOf(err))
+
+		// Admins manage the sessions of other users.
+		adminUserId, err := dom.sessionsUserId(newTestRequest(t, consts.RoleSaasAdminUser), userId, act)
+		require.NoError(t, err)
+		assert.Equal(t, userId, adminUserId)
+	}
+
+	err := dom.UpdateUserRole(newTestRequest(t, consts.RoleSaasUser), nil)
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_UNAUTHENTICATED, errors.CodeOf(err))
+
+	// Admins pass the permission check, and fail on the input.
+	err = dom.UpdateUserRole(newTestRequest(t, consts.RoleSaasAdminUser), nil)
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_INVALID_ARGUMENT, errors.CodeOf(err))
+}
+
+func TestDomain_UpdateUserRole(t *testing.T) {
+	rootUserId := uuid.New()
+	adminUserId := uuid.New()
+	userId := uuid.New()
+
+	userDomain := &fakeUserDomain{
+		users: map[uuid.UUID]*user.User{
+			rootUserId:  {Id: rootUserId, RoleName: consts.RoleRoot},
+			adminUserId: {Id: adminUserId, RoleName: consts.RoleSaasAdminUser},
+			userId:      {Id: userId, RoleName: consts.RoleSaasUser},
+		},
+	}
+
+	dom := &domain{UserDomain: userDomain}
+	r := newTestRequest(t, consts.RoleSaasAdminUser)
+
+	// Root and the system roles are never assigned.
+	for _, roleName := range []string{consts.RoleRoot, consts.RoleSystemAuthCheckUser, "unknown"} {
+		err := dom.UpdateUserRole(r, &UpdateUserRoleInput{
+			UserId:   userId,
+			RoleName: roleName,
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.Code_INVALID_ARGUMENT, errors.CodeOf(err), roleName)
+	}
+
+	// Admins cannot change their own role.
+	err := dom.UpdateUserRole(r, &UpdateUserRoleInput{
+		UserId:   r.Session().UserId(),
+		RoleName: consts.RoleSaasUser,
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_PERMISSION_DENIED, errors.CodeOf(err))
+
+	// Nor the role of the users outranking them.
+	err = dom.UpdateUserRole(r, &UpdateUserRoleInput{
+		UserId:   rootUserId,
+		RoleName: consts.RoleSaasUser,
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.Code_PERMISSION_DENIED, errors.CodeOf(err))
+	assert.Equal(t, consts.RoleRoot, userDomain.users[rootUserId].RoleName)
+
+	require.NoError(t, dom.UpdateUserRole(r, &UpdateUserRoleInput{
+		UserId:   userId,
+		RoleName: consts.RoleSaasAdminUser,
+	}))
+	assert.Equal(t, consts.RoleSaasAdminUser, userDomain.users[userId].RoleName)
+
+	require.NoError(t, dom.UpdateUserRole(r, &UpdateUserRoleInput{
+		UserId:   adminUserId,
+		RoleName: consts.RoleSaasUser,
+	}))
+	assert.Equal(t, consts.RoleSaasUser, userDomain.users[adminUserId].RoleName)
+}
+
+func TestCanManageRole(t *testing.T) {
+	assert.True(t, canManageRole(consts.RoleRoot, consts.RoleRoot))
+	assert.True(t, canManageRole(consts.RoleSaasAdminUser, consts.RoleSaasUser))
+	assert.True(t, canManageRole(consts.RoleSaasAdminUser, consts.RoleSaasAdminUser))
+	assert.True(t, canManageRole(consts.RoleSaasAdminUser, ""))
+	assert.False(t, canManageRole(consts.RoleSaasAdminUser, consts.RoleRoot))
+	assert.False(t, canManageRole(consts.RoleSaasAdminUser, consts.RoleSystemAuthCheckUser))
+	assert.False(t, canManageRole(consts.RoleSaasUser, consts.RoleSaasAdminUser))
+}
@@ -9,6 +9,7 @@ import (
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
 	"abodemine/projects/api/entities"
+	"abodemine/projects/saas/domains/tenant"
 	"abodemine/projects/saas/domains/user"
 )
 
@@ -21,6 +22,9 @@ type TokenValidateOutput struct {
 	SessionToken string
 	RedirectUri  string
 	Expire       time.Duration
+
+	CookieName   string
+	CookieDomain string
 }
 
 func (dom *domain) TokenValidate(r *arc.Request, in *TokenValidateInput) (*TokenValidateOutput, error) {
@@ -63,14 +67,25 @@ func (dom *domain) TokenValidate(r *arc.Request, in *TokenValidateInput) (*Token
 		}
 	}
 
+	tnt, err := dom.selectTenantByHost(in.DownstreamHost)
+	if err != nil {
+		return nil, errors.Forward(err, "f32e7b32-b37e-4ad1-8907-7193a202a3e1")
+	}
+
+	if tnt != nil && tnt.OrganizationId != tokn.OrganizationId {
+		return nil, &errors.Object{
+			Id:     "7f27014c-2eca-4fcc-bb72-01942efe3db4",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "The token does not belong to the tenant.",
+		}
+	}
+
 	selectUserOut, err := dom.UserDomain.SelectUser(r, &user.SelectUserInput{
 		OrganizationId: tokn.OrganizationId,
 		ExternalId:     tokn.ExternalId,
 	})
 	if err != nil {
-		lastErr := errors.Last(err)
-
-		if lastErr.Code != errors.Code_NOT_FOUND {
+		if errors.CodeOf(err) != errors.Code_NOT_FOUND {
 			return nil, errors.Forward(err, "94766c72-6ee0-423f-a4c0-57804c91a3e4")
 		}
 
@@ -93,12 +108,18 @@ func (dom *domain) TokenValidate(r *arc.Request, in *TokenValidateInput) (*Token
 
 	user := selectUserOut.User
 
+	defaultExpireKey := consts.ConfigKeyDurationSaasSession
+
+	if tnt != nil {
+		defaultExpireKey = consts.ConfigKeyDurationSaasWhitelabelSessionDefault
+	}
+
 	var ttl time.Duration
 
 	if tokn.Expire > 0 {
 		ttl = time.Duration(tokn.Expire) * time.Second
 	} else {
-		defaultExpire, err := r.Dom().SelectDuration(consts.ConfigKeyDurationSaasSession)
+		defaultExpire, err := r.Dom().SelectDuration(defaultExpireKey)
 		if err != nil {
 			return nil, errors.Forward(err, "71b9060a-a05a-499e-87a5-c9c45efd8058")
 		}
@@ -106,45 +127,77 @@ func (dom *domain) TokenValidate(r *arc.Request, in *TokenValidateInput) (*Token
 		ttl = defaultExpire
 	}
 
+	out := &TokenValidateOutput{
+		RedirectUri: tokn.RedirectUri,
+		CookieName:  consts.CookieAbodeMineSaasWebSession,
+	}
+
+	if tnt != nil {
+		// The token exchange checks the max of the api config,
+		// which may differ from ours.
+		maxExpire, err := r.Dom().SelectDuration(consts.ConfigKeyDurationSaasWhitelabelSessionMax)
+		if err != nil {
+			return nil, errors.Forward(err, "88cc72ce-32a8-485b-8b92-278ee5e8dd28")
+		}
+
+		ttl = min(ttl, maxExpire)
+
+		out.CookieName = tnt.CookieName
+		out.CookieDomain = tnt.CookieDomain
+	}
+
+	sessionToken, err := dom.createSaasServerSession(r, user, ttl, tnt)
+	if err != nil {
+		return nil, errors.Forward(err, "b5b63829-0026-490f-8549-f728b8b4613b")
+	}
+
+	out.SessionToken = sessionToken
+	out.Expire = ttl
+
+	return out, nil
+}
+
+// createSaasServerSession creates the server session of the user,
+// and returns its encoded token. Sessions of tenants are ESO
+// sessions, encoded with the Paseto config of the tenant.
+func (dom *domain) createSaasServerSession(r *arc.Request, user *user.User, ttl time.Duration, tnt *tenant.Tenant) (string, error) {
+	sessionType := arc.SessionTypeSaasServer
+	tokenType := token.TypeSaasServerSession
+	pasetoConfigName := consts.ConfigKeyPasetoSession
+	tenantName := ""
+
+	if tnt != nil {
+		sessionType = arc.SessionTypeESOWhiteLabelServer
+		tokenType = token.TypeESOWhiteLabelServerSession
+		pasetoConfigName = tnt.PasetoConfigName
+		tenantName = tnt.Name
+	}
+
 	session, err := dom.ArcDomain.CreateServerSession(r, &arc.CreateServerSessionInput{
 		OrganizationId: user.OrganizationId,
 		UserId:         user.Id,
 		RoleName:       user.RoleName,
-		SessionType:    arc.SessionTypeSaasServer,
+		SessionType:    sessionType,
+		Tenant:         tenantName,
 		Timezone:       "UTC",
 		Username:       user.Username,
 		TTL:            ttl,
 	})
 	if err != nil {
-		return nil, errors.Forward(err, "934cef8c-2c8d-43db-b3ac-cdf774412ff1")
+		return "", errors.Forward(err, "934cef8c-2c8d-43db-b3ac-cdf774412ff1")
 	}
 
 	encodeTokenOut, err := dom.TokenDomain.EncodeToken(r, &token.EncodeTokenInput{
 		Id:               session.Id(),
 		OrganizationId:   user.OrganizationId,
-		TokenType:        token.TypeSaasServerSession,
+		TokenType:        tokenType,
 		Expire:           ttl,
 		Version:          token.VersionPasetoV4Local,
-		PasetoConfigName: consts.ConfigKeyPasetoSession,
+		PasetoConfigName: pasetoConfigName,
 	})
 	if err != nil {
-		return nil, errors.Forward(err, "f08a949c-aa54-433b-971f-b2f6fdd87258")
+		return "", errors.Forward(err, "f08a949c-aa54-433b-971f-b2f6fdd87258")
 	}
 
-	request, err := dom.ArcDomain.CreateRequest(&arc.CreateRequestInput{
-		Context: r.Context(),
-	})
-	if err != nil {
-		return nil, errors.Forward(err, "ef6aeca1-e4a4-4af0-850f-317f17050937")
-	}
-
-	request.SetSession(session)
-
-	out := &TokenValidateOutput{
-		SessionToken: encodeTokenOut.Value,
-		RedirectUri:  tokn.RedirectUri,
-		Expire:       ttl,
-	}
-
-	return out, nil
+	return encodeTokenOut.Value, nil
 }
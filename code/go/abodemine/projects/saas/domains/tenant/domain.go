@@ -0,0 +1,127 @@
+package tenant
+
+import (
+	"net"
+	"strings"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+)
+
+type Domain interface {
+	CasbinEnforce(r *arc.Request, obj, act string) error
+	SelectTenant(in *SelectTenantInput) (*SelectTenantOutput, error)
+}
+
+type domain struct {
+	byHost map[string]*Tenant
+	byName map[string]*Tenant
+}
+
+type NewDomainInput struct {
+	Tenants []*Tenant
+}
+
+func NewDomain(in *NewDomainInput) *domain {
+	dom := &domain{
+		byHost: make(map[string]*Tenant),
+		byName: make(map[string]*Tenant),
+	}
+
+	for _, v := range in.Tenants {
+		dom.byName[v.Name] = v
+
+		for _, host := range v.Hosts {
+			dom.byHost[strings.ToLower(host)] = v
+		}
+	}
+
+	return dom
+}
+
+type SelectTenantInput struct {
+	// Host may include a port.
+	Host string
+	Name string
+}
+
+type SelectTenantOutput struct {
+	Tenant *Tenant
+}
+
+// SelectTenant resolves a tenant by host or by name.
+// It fails with NOT_FOUND for the hosts of the main app.
+func (dom *domain) SelectTenant(in *SelectTenantInput) (*SelectTenantOutput, error) {
+	if in == nil {
+		return nil, &errors.Object{
+			Id:     "d503de37-87c5-4dc7-9136-3c5a8b0af59a",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	var tenant *Tenant
+
+	switch {
+	case in.Name != "":
+		tenant = dom.byName[in.Name]
+	case in.Host != "":
+		tenant = dom.byHost[normalizeHost(in.Host)]
+	default:
+		return nil, &errors.Object{
+			Id:     "fbf80ed1-f787-4f67-8cc7-3853f7e8c7fd",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "One of Host or Name must be provided.",
+		}
+	}
+
+	if tenant == nil {
+		return nil, &errors.Object{
+			Id:     "d91cb395-4e1d-4604-a973-00633006d475",
+			Code:   errors.Code_NOT_FOUND,
+			Detail: "Tenant not found.",
+		}
+	}
+
+	out := &SelectTenantOutput{
+		Tenant: tenant,
+	}
+
+	return out, nil
+}
+
+// CasbinEnforce enforces the Casbin config of the tenant of the
+// session. Sessions without a tenant are not checked.
+func (dom *domain) CasbinEnforce(r *arc.Request, obj, act string) error {
+	name := r.Session().Tenant()
+
+	if name == "" {
+		return nil
+	}
+
+	tenant, ok := dom.byName[name]
+	if !ok {
+		return &errors.Object{
+			Id:     "7611188c-6e6c-465b-a003-c6329c7b6f5b",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Detail: "Unknown tenant.",
+			Meta: map[string]any{
+				"tenant": name,
+			},
+		}
+	}
+
+	if err := r.CasbinEnforce(tenant.CasbinConfigName, obj, act); err != nil {
+		return errors.Forward(err, "c4bea31b-8c55-433b-bb14-5fe4fdb83fa4")
+	}
+
+	return nil
+}
+
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.ToLower(strings.TrimSpace(host))
+}
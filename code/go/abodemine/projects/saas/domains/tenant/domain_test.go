@@ -0,0 +1,43 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"abodemine/lib/errors"
+)
+
+func TestDomain_SelectTenant(t *testing.T) {
+	omega := &Tenant{
+		Name:           "omega",
+		OrganizationId: uuid.New(),
+		Hosts:          []string{"app.omega.test", "omega.abodemine.test"},
+	}
+
+	dom := NewDomain(&NewDomainInput{
+		Tenants: []*Tenant{omega},
+	})
+
+	for _, host := range []string{"app.omega.test", "APP.omega.test", "omega.abodemine.test:443"} {
+		out, err := dom.SelectTenant(&SelectTenantInput{Host: host})
+		require.NoError(t, err, host)
+		assert.Same(t, omega, out.Tenant, host)
+	}
+
+	out, err := dom.SelectTenant(&SelectTenantInput{Name: "omega"})
+	require.NoError(t, err)
+	assert.Same(t, omega, out.Tenant)
+
+	_, err = dom.SelectTenant(&SelectTenantInput{Host: "app.abodemine.test"})
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.Code_NOT_FOUND, errors.First(err).Code)
+	}
+
+	_, err = dom.SelectTenant(&SelectTenantInput{})
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.Code_INVALID_ARGUMENT, errors.First(err).Code)
+	}
+}
@@ -0,0 +1,16 @@
+package tenant
+
+import "github.com/google/uuid"
+
+// Tenant is a white-label (ESO) tenant.
+type Tenant struct {
+	Name           string
+	OrganizationId uuid.UUID
+	Hosts          []string
+
+	PasetoConfigName string
+	CasbinConfigName string
+
+	CookieName   string
+	CookieDomain string
+}
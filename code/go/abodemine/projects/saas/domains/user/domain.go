@@ -11,14 +11,19 @@ import (
 type Domain interface {
 	InsertUser(r *arc.Request, in *InsertUserInput) (*InsertUserOutput, error)
 	SelectUser(r *arc.Request, in *SelectUserInput) (*SelectUserOutput, error)
+	UpdateUserRole(r *arc.Request, in *UpdateUserRoleInput) error
 }
 
 type domain struct {
 	repository Repository
+
+	ArcDomain arc.Domain
 }
 
 type NewDomainInput struct {
 	Repository Repository
+
+	ArcDomain arc.Domain
 }
 
 func NewDomain(in *NewDomainInput) *domain {
@@ -30,6 +35,7 @@ func NewDomain(in *NewDomainInput) *domain {
 
 	return &domain{
 		repository: rep,
+		ArcDomain:  in.ArcDomain,
 	}
 }
 
@@ -91,6 +97,9 @@ type SelectUserInput struct {
 	OrganizationId uuid.UUID
 	Id             uuid.UUID
 	ExternalId     string
+
+	// Matched case-insensitively.
+	Email string
 }
 
 type SelectUserOutput struct {
@@ -110,11 +119,10 @@ func (dom *domain) SelectUser(r *arc.Request, in *SelectUserInput) (*SelectUserO
 		OrganizationId: in.OrganizationId,
 		Id:             in.Id,
 		ExternalId:     in.ExternalId,
+		Email:          in.Email,
 	})
 	if err != nil {
-		lastErr := errors.Last(err)
-
-		if lastErr.Code == errors.Code_NOT_FOUND {
+		if errors.CodeOf(err) == errors.Code_NOT_FOUND {
 			return nil, errors.Wrap(err, &errors.Object{
 				Id:     "e4bf0f9d-ee15-4729-a853-51c3c6bd4744",
 				Code:   errors.Code_NOT_FOUND,
@@ -131,3 +139,55 @@ func (dom *domain) SelectUser(r *arc.Request, in *SelectUserInput) (*SelectUserO
 
 	return out, nil
 }
+
+type UpdateUserRoleInput struct {
+	OrganizationId uuid.UUID
+	Id             uuid.UUID
+	RoleName       string
+}
+
+// UpdateUserRole changes the role of the user, and deletes
+// the sessions of the user, which still carry the old role.
+func (dom *domain) UpdateUserRole(r *arc.Request, in *UpdateUserRoleInput) error {
+	if in == nil {
+		return &errors.Object{
+			Id:     "6f482fac-7ed8-438b-9017-a531ee2e6157",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing input.",
+		}
+	}
+
+	if in.Id == uuid.Nil {
+		return &errors.Object{
+			Id:     "49b4d4aa-e98f-4b49-994e-43ff6948462d",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing id.",
+		}
+	}
+
+	if in.RoleName == "" {
+		return &errors.Object{
+			Id:     "0d829e48-6eda-4f05-978d-ede9cf991ff2",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing role name.",
+			Path:   "/role_name",
+		}
+	}
+
+	if err := dom.repository.UpdateUserRecordRole(r, &UpdateUserRecordRoleInput{
+		OrganizationId: in.OrganizationId,
+		Id:             in.Id,
+		RoleName:       in.RoleName,
+	}); err != nil {
+		return errors.Forward(err, "a7ab104a-a7ac-4a3f-90d1-66ec4f00c90e")
+	}
+
+	if _, err := dom.ArcDomain.DeleteUserServerSessions(r, &arc.DeleteUserServerSessionsInput{
+		OrganizationId: in.OrganizationId,
+		UserId:         in.Id,
+	}); err != nil {
+		return errors.Forward(err, "6d7eeced-bd69-45e6-af4e-cab7f01211ba")
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 type Repository interface {
 	InsertUserRecord(r *arc.Request, in *InsertUserRecordInput) (*InsertUserRecordOutput, error)
 	SelectUserRecord(r *arc.Request, in *SelectUserRecordInput) (*SelectUserRecordOutput, error)
+	UpdateUserRecordRole(r *arc.Request, in *UpdateUserRecordRoleInput) error
 }
 
 type repository struct{}
@@ -91,6 +92,7 @@ func (rep *repository) InsertUserRecord(r *arc.Request, in *InsertUserRecordInpu
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -125,6 +127,7 @@ func (rep *repository) InsertUserRecord(r *arc.Request, in *InsertUserRecordInpu
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to insert row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -158,6 +161,7 @@ type SelectUserRecordInput struct {
 	OrganizationId uuid.UUID
 	Id             uuid.UUID
 	ExternalId     string
+	Email          string
 }
 
 type SelectUserRecordOutput struct {
@@ -171,6 +175,7 @@ func (rep *repository) SelectUserRecord(r *arc.Request, in *SelectUserRecordInpu
 			"users.id",
 			"users.organization_id",
 			"users.username",
+			"users.email",
 			"roles.name",
 		).
 		From("users").
@@ -185,6 +190,10 @@ func (rep *repository) SelectUserRecord(r *arc.Request, in *SelectUserRecordInpu
 		builder = builder.Where("users.external_id = ?", in.ExternalId)
 	}
 
+	if in.Email != "" {
+		builder = builder.Where("lower(users.email) = lower(?)", in.Email)
+	}
+
 	sql, args, err := builder.ToSql()
 	if err != nil {
 		return nil, &errors.Object{
@@ -192,6 +201,7 @@ func (rep *repository) SelectUserRecord(r *arc.Request, in *SelectUserRecordInpu
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to build SQL.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -200,12 +210,15 @@ func (rep *repository) SelectUserRecord(r *arc.Request, in *SelectUserRecordInpu
 		return nil, errors.Forward(err, "52548080-87d0-42e2-bd53-8b5a8675e2db")
 	}
 
+	var email pgtype.Text
+
 	record := new(User)
 
 	if err := row.Scan(
 		&record.Id,
 		&record.OrganizationId,
 		&record.Username,
+		&email,
 		&record.RoleName,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -221,12 +234,73 @@ func (rep *repository) SelectUserRecord(r *arc.Request, in *SelectUserRecordInpu
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to fetch row.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
+	if email.Valid {
+		record.Email = email.String
+	}
+
 	out := &SelectUserRecordOutput{
 		Record: record,
 	}
 
 	return out, nil
 }
+
+type UpdateUserRecordRoleInput struct {
+	OrganizationId uuid.UUID
+	Id             uuid.UUID
+	RoleName       string
+}
+
+func (rep *repository) UpdateUserRecordRole(r *arc.Request, in *UpdateUserRecordRoleInput) error {
+	builder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("users").
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("role_id", squirrel.Expr("(select id from roles where name = ?)", in.RoleName)).
+		Where("organization_id = ?", in.OrganizationId).
+		Where("id = ?", in.Id).
+		Where("exists (select 1 from roles where name = ?)", in.RoleName).
+		Suffix("RETURNING id")
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return &errors.Object{
+			Id:     "4b242d18-38d6-442d-aa92-fe9a175e3cb9",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to build SQL.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	row, err := extutils.PgxQueryRow(r, consts.ConfigKeyPostgresSaas, sql, args)
+	if err != nil {
+		return errors.Forward(err, "6c7ceb03-d450-4a38-b27e-1ec1aa5ae234")
+	}
+
+	var id uuid.UUID
+
+	if err := row.Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &errors.Object{
+				Id:     "2d1247f5-6919-4b06-9641-fc338345ce81",
+				Code:   errors.Code_NOT_FOUND,
+				Detail: "User or role not found.",
+			}
+		}
+
+		return &errors.Object{
+			Id:     "caec416c-de2d-4618-b295-baa385b801d5",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to update row.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
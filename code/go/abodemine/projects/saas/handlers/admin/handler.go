@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/errors"
+	"abodemine/projects/saas/domains/auth"
+)
+
+type Handler interface {
+	DeleteUserSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	ListUserSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	UpdateUserRole(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+}
+
+type handler struct {
+	ArcDomain  arc.Domain
+	AuthDomain auth.Domain
+}
+
+type NewHandlerInput struct {
+	ArcDomain  arc.Domain
+	AuthDomain auth.Domain
+}
+
+func NewHandler(in *NewHandlerInput) *handler {
+	return &handler{
+		ArcDomain:  in.ArcDomain,
+		AuthDomain: in.AuthDomain,
+	}
+}
+
+func (h *handler) ListUserSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "87912f47-dc5f-4833-9ad1-09a164d24016"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	userId, err := parseUserId(ps)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "9d96459a-366a-4add-a645-04d418dff88a"))
+		return
+	}
+
+	listSessionsOut, err := h.AuthDomain.ListSessions(arcRequest, &auth.ListSessionsInput{
+		UserId: userId,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "29ea004e-61f2-4fd1-9a47-ac115b8d349c"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, listSessionsOut)
+}
+
+// DeleteUserSessions logs the user out everywhere.
+func (h *handler) DeleteUserSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "7aa742cf-0862-413e-b369-bd50636a580c"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	userId, err := parseUserId(ps)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "86b0f528-a10d-4295-9449-22f852d9aee3"))
+		return
+	}
+
+	deleteSessionsOut, err := h.AuthDomain.DeleteSessions(arcRequest, &auth.DeleteSessionsInput{
+		UserId: userId,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "2e8cbce0-e0ea-4282-94f2-f171a81855f7"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, deleteSessionsOut)
+}
+
+type UpdateUserRoleInput struct {
+	RoleName string `json:"role_name,omitempty"`
+}
+
+func (h *handler) UpdateUserRole(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "8226ba5e-9804-442a-9a5d-d582ab3765ca"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	userId, err := parseUserId(ps)
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "7b4357f2-5aab-407d-8daa-7319442868d4"))
+		return
+	}
+
+	input := new(UpdateUserRoleInput)
+
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "011063ec-a546-4f91-8945-2b21d81c79f5",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	if err := h.AuthDomain.UpdateUserRole(arcRequest, &auth.UpdateUserRoleInput{
+		UserId:   userId,
+		RoleName: input.RoleName,
+	}); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "097d025e-766d-4e64-906a-32fa81877402"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseUserId(ps httprouter.Params) (uuid.UUID, error) {
+	userId, err := uuid.Parse(ps.ByName("userId"))
+	if err != nil || userId == uuid.Nil {
+		return uuid.Nil, &errors.Object{
+			Id:     "b5212926-88e0-45a3-ac61-eedec4bb7b76",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid user id.",
+			Path:   "/userId",
+		}
+	}
+
+	return userId, nil
+}
@@ -1,9 +1,13 @@
 package auth
 
 import (
+	"encoding/json"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/julienschmidt/httprouter"
 
 	"abodemine/domains/arc"
@@ -13,7 +17,14 @@ import (
 )
 
 type Handler interface {
+	DeleteSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	DeleteSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	ListSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	Load(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	OidcCallback(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	OidcLogin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	OtpLogin(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
+	OtpRequest(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 
 	TokenValidate(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 }
@@ -21,24 +32,31 @@ type Handler interface {
 type handler struct {
 	ArcDomain  arc.Domain
 	AuthDomain auth.Domain
+
+	trustedProxies int
 }
 
 type NewHandlerInput struct {
 	ArcDomain  arc.Domain
 	AuthDomain auth.Domain
+
+	// TrustedProxies is the number of proxies in front of the
+	// server, see gconf.HttpServer.
+	TrustedProxies int
 }
 
 func NewHandler(in *NewHandlerInput) *handler {
 	return &handler{
-		ArcDomain:  in.ArcDomain,
-		AuthDomain: in.AuthDomain,
+		ArcDomain:      in.ArcDomain,
+		AuthDomain:     in.AuthDomain,
+		trustedProxies: in.TrustedProxies,
 	}
 }
 
 func (h *handler) Load(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Unauthenticated(err, "ffb8bc98-6f92-4f31-a120-786d1596be2d"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "ffb8bc98-6f92-4f31-a120-786d1596be2d"))
 		return
 	}
 
@@ -46,13 +64,255 @@ func (h *handler) Load(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 
 	loadOut, err := h.AuthDomain.Load(authOut.Request)
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), errors.Forward(err, "9b97079b-589c-4356-abf2-36aa55435004"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "9b97079b-589c-4356-abf2-36aa55435004"))
 		return
 	}
 
 	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, loadOut)
 }
 
+func (h *handler) ListSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "a7eba120-6416-4631-9c24-eecb16e2a3a5"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	listSessionsOut, err := h.AuthDomain.ListSessions(arcRequest, &auth.ListSessionsInput{})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "72496896-c00b-4ca7-a55d-6dd8b2d3e756"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, listSessionsOut)
+}
+
+func (h *handler) DeleteSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "b621b600-33db-457c-a3aa-53360a5ad623"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	sessionId, err := uuid.Parse(ps.ByName("sessionId"))
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "7c2e768b-771b-4850-a529-3dcd601ce6a9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid session id.",
+			Path:   "/sessionId",
+		})
+		return
+	}
+
+	if err := h.AuthDomain.DeleteSession(arcRequest, &auth.DeleteSessionInput{
+		Id: sessionId,
+	}); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "b2f115e1-0642-4f0e-9c7f-a343354c7dd4"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteSessions logs the user out everywhere, except from
+// the session of the request.
+func (h *handler) DeleteSessions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "8df08523-995a-4be9-afaa-a00592c09475"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	deleteSessionsOut, err := h.AuthDomain.DeleteSessions(arcRequest, &auth.DeleteSessionsInput{})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "03e41f04-4b9e-4a4f-9717-4ad2b3c75237"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, deleteSessionsOut)
+}
+
+type OtpRequestInput struct {
+	OrganizationId uuid.UUID `json:"organizationId,omitempty"`
+	Email          string    `json:"email,omitempty"`
+}
+
+type OtpRequestOutput struct {
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+func (h *handler) OtpRequest(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	input := new(OtpRequestInput)
+
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", &errors.Object{
+			Id:     "015f9c91-b56f-4e28-a752-b0e4704bb154",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	otpRequestOut, err := h.AuthDomain.OtpRequest(r.Context(), &auth.OtpRequestInput{
+		OrganizationId: input.OrganizationId,
+		Email:          input.Email,
+		Ip:             clientIp(r, h.trustedProxies),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "4eea9c4a-cf81-4baa-b54a-2644fd7fb1f9"))
+		return
+	}
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, &OtpRequestOutput{
+		Token:     otpRequestOut.Token,
+		ExpiresAt: otpRequestOut.ExpiresAt,
+	})
+}
+
+type OtpLoginInput struct {
+	Token string `json:"token,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+func (h *handler) OtpLogin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	input := new(OtpLoginInput)
+
+	if err := json.NewDecoder(r.Body).Decode(input); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", &errors.Object{
+			Id:     "687a3d35-56e1-4be9-a652-a5933b60c778",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+		})
+		return
+	}
+
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthMethod: auth.AuthMethodOtp,
+		Token:      input.Token,
+		OtpCode:    input.Code,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "06a6da4e-105c-4d83-992e-700c1d156445"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	otpLoginOut, err := h.AuthDomain.OtpLogin(arcRequest, &auth.OtpLoginInput{
+		OtpTokenBody: authOut.OtpTokenBody,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "f7d30ac9-690b-4890-8e17-b13312564a1b"))
+		return
+	}
+
+	setSessionCookie(w, &sessionCookie{
+		Name:   consts.CookieAbodeMineSaasWebSession,
+		Value:  otpLoginOut.SessionToken,
+		Expire: otpLoginOut.Expire,
+	})
+
+	arc.HttpApiDataResponse(h.ArcDomain, w, http.StatusOK, nil)
+}
+
+// OidcLogin redirects the user to the identity provider.
+func (h *handler) OidcLogin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	oidcStartOut, err := h.AuthDomain.OidcStart(r.Context(), &auth.OidcStartInput{
+		Provider: ps.ByName("provider"),
+		ReturnTo: r.URL.Query().Get("return_to"),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "c9c63459-aa4d-4e42-92ef-419af75af8db"))
+		return
+	}
+
+	// Lax, since the callback is a top-level navigation
+	// from the identity provider.
+	http.SetCookie(w, &http.Cookie{
+		Name:     consts.CookieAbodeMineOidcState,
+		Value:    oidcStartOut.StateToken,
+		Path:     "/api/auth/oidc/",
+		Expires:  time.Now().Add(oidcStartOut.Expire),
+		MaxAge:   int(oidcStartOut.Expire.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, oidcStartOut.AuthUrl, http.StatusFound)
+}
+
+// OidcCallback is the redirect uri of the identity provider.
+func (h *handler) OidcCallback(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     consts.CookieAbodeMineOidcState,
+		Path:     "/api/auth/oidc/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	query := r.URL.Query()
+
+	// The identity provider sends the state back with its errors,
+	// so they are only considered once the state is verified.
+	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{
+		AuthMethod:  auth.AuthMethodOidc,
+		HttpRequest: r,
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Unauthenticated(err, "7f0adcd5-af31-4a6b-bed9-8fc4db6e43ce"))
+		return
+	}
+
+	arcRequest := authOut.Request
+
+	// The error of the identity provider is logged rather than
+	// returned, as anyone can send it in the query.
+	if e := query.Get("error"); e != "" {
+		arcRequest.Logger().Warn().
+			Str("id", "c2ef5c11-470e-4436-a4c6-37fdfd3f9cf3").
+			Str("error", e).
+			Str("error_description", query.Get("error_description")).
+			Msg("Identity provider did not sign the user in.")
+
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "2aa71b6b-1dc8-4a42-beb0-0c86f5bbc716",
+			Code:   errors.Code_UNAUTHENTICATED,
+			Label:  "OIDC_PROVIDER_ERROR",
+			Detail: "The identity provider did not sign the user in.",
+		})
+		return
+	}
+
+	oidcLoginOut, err := h.AuthDomain.OidcLogin(arcRequest, &auth.OidcLoginInput{
+		OidcStateBody: authOut.OidcStateBody,
+		Provider:      ps.ByName("provider"),
+		Code:          query.Get("code"),
+	})
+	if err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "fc272fbe-3f1f-4fbd-bd49-535ef1cfe842"))
+		return
+	}
+
+	// The callback is a cross-site navigation, so a strict
+	// cookie is only sent from the next request.
+	setSessionCookie(w, &sessionCookie{
+		Name:   consts.CookieAbodeMineSaasWebSession,
+		Value:  oidcLoginOut.SessionToken,
+		Expire: oidcLoginOut.Expire,
+	})
+	http.Redirect(w, r, oidcLoginOut.ReturnTo, http.StatusFound)
+}
+
 type TokenValidateInput struct {
 	Token string `json:"token,omitempty"`
 }
@@ -63,7 +323,7 @@ func (h *handler) TokenValidate(w http.ResponseWriter, r *http.Request, ps httpr
 		Token:      ps.ByName("token"),
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "a8fe15c2-cfb1-4214-a715-7ba69a0c8bf7"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "a8fe15c2-cfb1-4214-a715-7ba69a0c8bf7"))
 		return
 	}
 
@@ -75,21 +335,68 @@ func (h *handler) TokenValidate(w http.ResponseWriter, r *http.Request, ps httpr
 		TokenExchangeBody: tokenExchangeBody,
 	})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, arcRequest.Id().String(), errors.Forward(err, "a7a8f225-7f3c-4b42-9014-dfd00561826b"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "a7a8f225-7f3c-4b42-9014-dfd00561826b"))
 		return
 	}
 
+	setSessionCookie(w, &sessionCookie{
+		Name:   validateTokenOut.CookieName,
+		Domain: validateTokenOut.CookieDomain,
+		Value:  validateTokenOut.SessionToken,
+		Expire: validateTokenOut.Expire,
+	})
+	http.Redirect(w, r, validateTokenOut.RedirectUri, http.StatusFound)
+}
+
+type sessionCookie struct {
+	Name   string
+	Domain string
+	Value  string
+	Expire time.Duration
+}
+
+func setSessionCookie(w http.ResponseWriter, in *sessionCookie) {
 	cookie := &http.Cookie{
-		Name:     consts.CookieAbodeMineSaasWebSession,
-		Value:    validateTokenOut.SessionToken,
+		Name:     in.Name,
+		Value:    in.Value,
 		Path:     "/",
-		Expires:  time.Now().Add(validateTokenOut.Expire),
-		MaxAge:   int(validateTokenOut.Expire.Seconds()),
+		Domain:   in.Domain,
+		Expires:  time.Now().Add(in.Expire),
+		MaxAge:   int(in.Expire.Seconds()),
 		Secure:   true,
 		HttpOnly: true,
 		SameSite: http.SameSiteStrictMode,
 	}
 
 	http.SetCookie(w, cookie)
-	http.Redirect(w, r, validateTokenOut.RedirectUri, http.StatusFound)
+}
+
+// clientIp is the address of the client. Behind trustedProxies
+// proxies, it is the X-Forwarded-For entry appended by the
+// outermost of them. The entries before it are set by the client
+// and are not trusted. Without proxies, or with fewer entries than
+// proxies, it is the address of the peer.
+func clientIp(r *http.Request, trustedProxies int) string {
+	if trustedProxies > 0 {
+		var hops []string
+
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(v, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+
+		if i := len(hops) - trustedProxies; i >= 0 {
+			if ip := net.ParseIP(hops[i]); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
 }
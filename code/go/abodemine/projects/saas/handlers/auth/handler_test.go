@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIp(t *testing.T) {
+	newRequest := func(forwardedFor ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/auth/otp/request", nil)
+		r.RemoteAddr = "10.0.1.7:43120"
+
+		for _, v := range forwardedFor {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+
+		return r
+	}
+
+	// Without proxies, the header is not trusted.
+	assert.Equal(t, "10.0.1.7", clientIp(newRequest("198.51.100.4"), 0))
+
+	// Behind the load balancer, the client is its last entry.
+	assert.Equal(t, "198.51.100.4", clientIp(newRequest("198.51.100.4"), 1))
+
+	// Entries set by the client are skipped.
+	assert.Equal(t, "198.51.100.4", clientIp(newRequest("203.0.113.9, 198.51.100.4"), 1))
+	assert.Equal(t, "198.51.100.4", clientIp(newRequest("203.0.113.9", "198.51.100.4"), 1))
+
+	// Behind two proxies, the client is the entry before the last.
+	assert.Equal(t, "198.51.100.4", clientIp(newRequest("203.0.113.9, 198.51.100.4, 10.0.2.3"), 2))
+	assert.Equal(t, "2001:db8::1", clientIp(newRequest("2001:db8::1 , 10.0.2.3"), 2))
+
+	// Missing or invalid entries fall back to the peer.
+	assert.Equal(t, "10.0.1.7", clientIp(newRequest(), 1))
+	assert.Equal(t, "10.0.1.7", clientIp(newRequest("198.51.100.4"), 2))
+	assert.Equal(t, "10.0.1.7", clientIp(newRequest("unknown"), 1))
+}
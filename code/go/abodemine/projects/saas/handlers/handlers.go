@@ -1,25 +1,29 @@
 package handlers
 
 import (
-	"net/http"
-	"sync"
-
 	"github.com/julienschmidt/httprouter"
-	"github.com/rs/zerolog/log"
 
 	"abodemine/domains/arc"
 	listings_domain "abodemine/domains/listings"
 	"abodemine/domains/token"
 	"abodemine/lib/app"
+	"abodemine/lib/health"
+	"abodemine/lib/oidc"
+	"abodemine/middleware"
 	"abodemine/projects/saas/conf"
 	auth_domain "abodemine/projects/saas/domains/auth"
+	"abodemine/projects/saas/domains/tenant"
 	"abodemine/projects/saas/domains/user"
+	admin_handler "abodemine/projects/saas/handlers/admin"
 	auth_handler "abodemine/projects/saas/handlers/auth"
+	catalog_handler "abodemine/projects/saas/handlers/catalog"
 	listings_handler "abodemine/projects/saas/handlers/listings"
 	"abodemine/projects/saas/handlers/tests"
 )
 
-func Router(c *conf.Config) *httprouter.Router {
+// Router registers the routes of the server. The checker serves the
+// health checks, and is shut down by the server on SIGTERM.
+func Router(c *conf.Config, checker *health.Checker) *httprouter.Router {
 	arcDomain := arc.NewDomain(&arc.NewDomainInput{
 		DeploymentEnvironment: c.File.DeploymentEnvironment,
 		Casbin:                c.Casbin,
@@ -35,19 +39,106 @@ func Router(c *conf.Config) *httprouter.Router {
 	// addressDomain := legacy_address_domain.NewDomain(&legacy_address_domain.NewDomainInput{})
 
 	tokenDomain := token.NewDomain(&token.NewDomainInput{})
-	userDomain := user.NewDomain(&user.NewDomainInput{})
+	userDomain := user.NewDomain(&user.NewDomainInput{
+		ArcDomain: arcDomain,
+	})
+
+	var tenants []*tenant.Tenant
 
-	authDomain := auth_domain.NewDomain(&auth_domain.NewDomainInput{
-		ArcDomain:   arcDomain,
-		TokenDomain: tokenDomain,
-		UserDomain:  userDomain,
+	for k, v := range c.File.Tenants {
+		if v == nil {
+			continue
+		}
+
+		tenants = append(tenants, &tenant.Tenant{
+			Name:             k,
+			OrganizationId:   v.OrganizationId,
+			Hosts:            v.Hosts,
+			PasetoConfigName: v.Paseto,
+			CasbinConfigName: v.Casbin,
+			CookieName:       v.Cookie.Name,
+			CookieDomain:     v.Cookie.Domain,
+		})
+	}
+
+	tenantDomain := tenant.NewDomain(&tenant.NewDomainInput{
+		Tenants: tenants,
 	})
 
-	authHandler := auth_handler.NewHandler(&auth_handler.NewHandlerInput{
+	authDomainInput := &auth_domain.NewDomainInput{
+		ArcDomain:    arcDomain,
+		TenantDomain: tenantDomain,
+		TokenDomain:  tokenDomain,
+		UserDomain:   userDomain,
+	}
+
+	if otp := c.File.Otp; otp != nil {
+		switch otp.Sender {
+		case conf.OtpSenderLog:
+			authDomainInput.OtpSender = auth_domain.NewLogOtpSender()
+		case conf.OtpSenderFile:
+			authDomainInput.OtpSender = auth_domain.NewFileOtpSender(&auth_domain.NewFileOtpSenderInput{
+				Path: otp.File,
+			})
+		}
+
+		authDomainInput.OtpTtl = otp.Ttl
+		authDomainInput.OtpMaxAttempts = otp.MaxAttempts
+		authDomainInput.OtpMaxFailures = otp.MaxFailures
+		authDomainInput.OtpLockout = otp.Lockout
+		authDomainInput.OtpMaxRequests = otp.MaxRequests
+		authDomainInput.OtpMaxRequestsPerIp = otp.MaxRequestsPerIp
+		authDomainInput.OtpRequestWindow = otp.RequestWindow
+	}
+
+	for k, v := range c.File.Oidc {
+		if v == nil {
+			continue
+		}
+
+		provider := &auth_domain.OidcProvider{
+			Name: k,
+			Provider: oidc.NewProvider(&oidc.NewProviderInput{
+				Issuer: v.Issuer,
+			}),
+			ClientId:     v.ClientId,
+			ClientSecret: v.ClientSecret,
+			RedirectUri:  v.RedirectUri,
+			Scopes:       v.Scopes,
+			GroupsClaim:  v.GroupsClaim,
+			Domains:      v.Domains,
+			DefaultRole:  v.DefaultRole,
+		}
+
+		for _, mapping := range v.RoleMappings {
+			provider.RoleMappings = append(provider.RoleMappings, &auth_domain.OidcRoleMapping{
+				Group:    mapping.Group,
+				RoleName: mapping.Role,
+			})
+		}
+
+		authDomainInput.OidcProviders = append(authDomainInput.OidcProviders, provider)
+	}
+
+	authDomain := auth_domain.NewDomain(authDomainInput)
+
+	adminHandler := admin_handler.NewHandler(&admin_handler.NewHandlerInput{
 		ArcDomain:  arcDomain,
 		AuthDomain: authDomain,
 	})
 
+	var trustedProxies int
+
+	if c.File.HttpServer != nil {
+		trustedProxies = c.File.HttpServer.TrustedProxies
+	}
+
+	authHandler := auth_handler.NewHandler(&auth_handler.NewHandlerInput{
+		ArcDomain:      arcDomain,
+		AuthDomain:     authDomain,
+		TrustedProxies: trustedProxies,
+	})
+
 	testsHandler := tests.NewHandler(&tests.NewHandlerInput{
 		ArcDomain: arcDomain,
 	})
@@ -57,16 +148,28 @@ func Router(c *conf.Config) *httprouter.Router {
 		// AddressDomain: addressDomain,
 	})
 
+	catalogHandler := catalog_handler.NewHandler(&catalog_handler.NewHandlerInput{
+		ArcDomain: arcDomain,
+	})
+
 	listingsHandler := listings_handler.NewHandler(&listings_handler.NewHandlerInput{
 		ArcDomain:      arcDomain,
 		AuthDomain:     authDomain,
 		ListingsDomain: listingsDomain,
+		TenantDomain:   tenantDomain,
+	})
+
+	httpMetrics := middleware.NewMetrics(&middleware.NewMetricsInput{
+		Server:     "saas",
+		Registerer: c.Metrics,
 	})
 
 	router := httprouter.New()
 
-	// Health check.
-	var healthCheckLog sync.Once
+	// Health checks. The status of each dependency is only served
+	// on the admin listener.
+	router.GET("/livez", checker.Livez)
+	router.GET("/readyz", checker.Readyz)
 
 	////////////////////////////////////////////////////////////////////////////
 	// Api routes.
@@ -75,32 +178,73 @@ func Router(c *conf.Config) *httprouter.Router {
 	apiPrefix := "/api"
 
 	router.GET(
-		apiPrefix+"/685f26df-dc42-4009-8784-63bd162d0255",
-		func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-			healthCheckLog.Do(func() {
-				log.Info().
-					Str("host", r.Host).
-					Str("remote_addr", r.RemoteAddr).
-					Msg("Health check. This event is logged only once.")
-			})
+		apiPrefix+"/admin/users/:userId/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(adminHandler.ListUserSessions))),
+	)
+
+	router.DELETE(
+		apiPrefix+"/admin/users/:userId/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(adminHandler.DeleteUserSessions))),
+	)
 
-			w.WriteHeader(http.StatusOK)
-		},
+	router.PUT(
+		apiPrefix+"/admin/users/:userId/role",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(adminHandler.UpdateUserRole))),
+	)
+
+	router.GET(
+		apiPrefix+"/errors",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(catalogHandler.ListErrors))),
 	)
 
 	router.POST(
 		apiPrefix+"/auth/load",
-		authHandler.Load,
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.Load))),
+	)
+
+	router.GET(
+		apiPrefix+"/auth/oidc/:provider/callback",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.OidcCallback))),
+	)
+
+	router.GET(
+		apiPrefix+"/auth/oidc/:provider/login",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.OidcLogin))),
+	)
+
+	router.POST(
+		apiPrefix+"/auth/otp/login",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.OtpLogin))),
+	)
+
+	router.POST(
+		apiPrefix+"/auth/otp/request",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.OtpRequest))),
+	)
+
+	router.GET(
+		apiPrefix+"/auth/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.ListSessions))),
+	)
+
+	router.DELETE(
+		apiPrefix+"/auth/sessions",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.DeleteSessions))),
+	)
+
+	router.DELETE(
+		apiPrefix+"/auth/sessions/:sessionId",
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.DeleteSession))),
 	)
 
 	router.GET(
 		apiPrefix+"/auth/token/validate/:token",
-		authHandler.TokenValidate,
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(authHandler.TokenValidate))),
 	)
 
 	router.POST(
 		apiPrefix+"/listings",
-		listingsHandler.GetListings,
+		middleware.RequestIdHandler(middleware.TracingHandler(httpMetrics.Handler(listingsHandler.GetListings))),
 	)
 
 	if c.File.DeploymentEnvironment < app.DeploymentEnvironment_TESTING {
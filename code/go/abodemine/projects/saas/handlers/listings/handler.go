@@ -10,6 +10,7 @@ import (
 	"abodemine/domains/listings"
 	"abodemine/lib/errors"
 	"abodemine/projects/saas/domains/auth"
+	"abodemine/projects/saas/domains/tenant"
 )
 
 type Handler interface {
@@ -20,12 +21,14 @@ type handler struct {
 	ArcDomain      arc.Domain
 	AuthDomain     auth.Domain
 	ListingsDomain listings.Domain
+	TenantDomain   tenant.Domain
 }
 
 type NewHandlerInput struct {
 	ArcDomain      arc.Domain
 	AuthDomain     auth.Domain
 	ListingsDomain listings.Domain
+	TenantDomain   tenant.Domain
 }
 
 func NewHandler(in *NewHandlerInput) *handler {
@@ -33,28 +36,40 @@ func NewHandler(in *NewHandlerInput) *handler {
 		ArcDomain:      in.ArcDomain,
 		AuthDomain:     in.AuthDomain,
 		ListingsDomain: in.ListingsDomain,
+		TenantDomain:   in.TenantDomain,
 	}
 }
 
 func (h *handler) GetListings(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	authOut, err := h.AuthDomain.Authenticate(r.Context(), &auth.AuthenticateInput{HttpRequest: r})
 	if err != nil {
-		arc.HttpApiErrorResponse(h.ArcDomain, w, "", errors.Forward(err, "b7609198-3b15-4422-a9ab-9abde2e8e67e"))
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, "", errors.Forward(err, "b7609198-3b15-4422-a9ab-9abde2e8e67e"))
 		return
 	}
 
 	arcRequest := authOut.Request
 
+	if err := h.TenantDomain.CasbinEnforce(arcRequest, "/listings", "read"); err != nil {
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "795eb958-39fc-425f-805a-ee329a807b0e"))
+		return
+	}
+
 	searchListingsInput := &SearchListingsInput{}
 	if err := json.NewDecoder(r.Body).Decode(&searchListingsInput); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), &errors.Object{
+			Id:     "bfd1e7d1-d8ca-48d3-b917-1d17e5ff55f7",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid request body.",
+			Cause:  err.Error(),
+			Err:    err,
+		})
 		return
 	}
 
 	searchListingsInputDomain := searchListingsInput.ToDomainModel()
 	out, err := h.ListingsDomain.SearchListings(arcRequest, &searchListingsInputDomain)
 	if err != nil {
-		http.Error(w, "Error searching listings: "+err.Error(), http.StatusInternalServerError)
+		arc.HttpApiErrorResponse(h.ArcDomain, w, r, arcRequest.Id().String(), errors.Forward(err, "c1b125e6-b561-48bc-a6c8-fc54c498fad2"))
 		return
 	}
 
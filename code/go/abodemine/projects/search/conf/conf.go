@@ -36,7 +36,8 @@ type File struct {
 	Postgres   map[string]*gconf.Postgres   `json:"postgres,omitempty" yaml:"postgres,omitempty"`
 	Valkey     map[string]*gconf.Valkey     `json:"valkey,omitempty" yaml:"valkey,omitempty"`
 
-	Sentry *gconf.Sentry `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	Sentry        *gconf.Sentry        `json:"sentry,omitempty" yaml:"sentry,omitempty"`
+	ErrorReporter *gconf.ErrorReporter `json:"error_reporter,omitempty" yaml:"error_reporter,omitempty"`
 
 	LogLevel   string `json:"log_level,omitempty" yaml:"log_level,omitempty"`
 	NoLogColor bool   `json:"no_log_color,omitempty" yaml:"no_log_color,omitempty"`
@@ -73,9 +74,14 @@ func Load(config *Config) error {
 	file.DeploymentEnvironment = gconf.DeploymentEnvironmentFromString(file.DeploymentEnvironmentStr)
 	log.Info().Str("deployment_environment", gconf.DeploymentEnvironmentToString(file.DeploymentEnvironment)).Send()
 
-	// Load first to ensure config errors go to Sentry.
-	if file.Sentry != nil {
-		if _, err := logging.InitSentry(file.Sentry, file.DeploymentEnvironment, file.NoLogColor); err != nil {
+	// Load first to ensure config errors are reported.
+	if file.Sentry != nil || file.ErrorReporter != nil {
+		if err := logging.InitReporter(&logging.InitReporterInput{
+			Config:                file.ErrorReporter,
+			Sentry:                file.Sentry,
+			DeploymentEnvironment: file.DeploymentEnvironment,
+			NoLogColor:            file.NoLogColor,
+		}); err != nil {
 			return errors.Forward(err, "dfc4faed-4002-469a-a9c0-856604b2380c")
 		}
 	}
@@ -125,7 +131,7 @@ func Load(config *Config) error {
 				}
 			}
 
-			item, err := gconf.LoadValkeyScript(w)
+			item, err := gconf.LoadValkeyScript(l, w)
 			if err != nil {
 				return errors.Forward(err, "edc9a0b3-6bcb-4aab-9697-94a673de0dc6")
 			}
@@ -166,6 +172,7 @@ func ResolveAndLoad(ctx context.Context, path string) (*Config, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to load default AWS config.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
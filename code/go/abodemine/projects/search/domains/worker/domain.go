@@ -510,6 +510,7 @@ func getClients(r *arc.Request) (*opensearch.Client, *pgxpool.Pool, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get OpenSearch client",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -521,6 +522,7 @@ func getClients(r *arc.Request) (*opensearch.Client, *pgxpool.Pool, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get database client",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -552,6 +554,7 @@ func setupIndex(ctx context.Context, osClient *opensearch.Client, baseIndexName
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create index",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer createRes.Body.Close()
@@ -648,6 +651,7 @@ func updateAlias(ctx context.Context, osClient *opensearch.Client, currentIndex,
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to get indices",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer indicesRes.Body.Close()
@@ -659,6 +663,7 @@ func updateAlias(ctx context.Context, osClient *opensearch.Client, currentIndex,
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to decode indices response",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -714,6 +719,7 @@ func updateAlias(ctx context.Context, osClient *opensearch.Client, currentIndex,
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to marshal alias action",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -729,6 +735,7 @@ func updateAlias(ctx context.Context, osClient *opensearch.Client, currentIndex,
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to update alias",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 	defer updateAliasRes.Body.Close()
@@ -967,6 +974,7 @@ func indexAddresses(ctx context.Context, addresses []*address.AddressDocument, o
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Bulk request failed after retries",
 			Cause:  lastErr.Error(),
+			Err:    lastErr,
 		}
 	}
 
@@ -72,6 +72,7 @@ func (repo *repository) IndexExists(r *arc.Request, in *IndexExistsInput) (*Inde
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to query opensearch.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -127,6 +128,7 @@ func (repo *repository) CreateIndex(r *arc.Request, in *CreateIndexInput) (*Crea
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to encode index create body to JSON.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -140,6 +142,7 @@ func (repo *repository) CreateIndex(r *arc.Request, in *CreateIndexInput) (*Crea
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create index in opensearch.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -189,6 +192,7 @@ func (repo *repository) PutDocument(r *arc.Request, in *PutDocumentInput) (*PutD
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to encode action metadata to JSON.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"document": item,
 				},
@@ -201,6 +205,7 @@ func (repo *repository) PutDocument(r *arc.Request, in *PutDocumentInput) (*PutD
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to encode document to JSON.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"document": item,
 				},
@@ -221,6 +226,7 @@ func (repo *repository) PutDocument(r *arc.Request, in *PutDocumentInput) (*PutD
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to bulk insert documents in opensearch.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -1,23 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
-	"sync"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"abodemine/domains/arc"
 	"abodemine/lib/app"
 	"abodemine/lib/errors"
 	"abodemine/lib/gconf"
+	"abodemine/lib/health"
+	"abodemine/lib/metrics"
+	"abodemine/lib/tracing"
 	"abodemine/projects/api/conf"
 	"abodemine/projects/api/handlers"
 )
 
+// defaultShutdownTimeout bounds the drain of the in-flight requests
+// when the config does not.
+const defaultShutdownTimeout = 25 * time.Second
+
 var listenCmd = &cobra.Command{
 	Use:          "listen",
 	SilenceUsage: true,
@@ -32,27 +43,64 @@ var listenCmd = &cobra.Command{
 			return errors.Forward(err, "fc78e9fc-0065-4fa7-a5a4-853815c16778")
 		}
 
+		// Flush the spans when the server stops.
+		defer tracing.Shutdown(context.Background())
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		checker := health.NewChecker(&health.NewCheckerInput{
+			Source: arc.NewDomain(&arc.NewDomainInput{
+				OpenSearch: config.OpenSearch,
+				PgxPool:    config.PGxPool,
+				Valkey:     config.Valkey,
+			}),
+		})
+
+		// Rotate the credentials of the clients without a restart.
+		if config.Clients != nil {
+			watcher, err := gconf.NewWatcher(ctx, &gconf.NewWatcherInput{
+				Source:   config.Source,
+				Interval: config.File.Reload.Interval,
+				Reload:   config.Reload,
+			})
+			if err != nil {
+				return errors.Forward(err, "2bfc56a5-b4ca-4a84-8fda-f99d0a10712b")
+			}
+
+			go watcher.Run(ctx)
+		}
+
+		server, listener, err := newHTTP(config, checker)
+		if err != nil {
+			return fmt.Errorf("failed to start HTTP server: %w", err)
+		}
+
+		adminServer := newAdmin(config, checker)
+
 		log.Info().
 			Int("pid", os.Getpid()).
 			Msg("Listening")
 
-		wg := new(sync.WaitGroup)
+		serveErr := make(chan error, 2)
 
-		wg.Add(1)
 		go func() {
-			defer wg.Done()
-			if err := startHTTP(config); err != nil {
-				log.Error().
-					Err(err).
-					Msg("Failed to start HTTP server.")
-
-				panic(err)
-			}
+			serveErr <- server.ServeTLS(listener, "", "")
 		}()
 
-		wg.Wait()
+		if adminServer != nil {
+			go func() {
+				serveErr <- adminServer.ListenAndServe()
+			}()
+		}
 
-		return nil
+		select {
+		case <-ctx.Done():
+		case err := <-serveErr:
+			return fmt.Errorf("failed to serve: %w", err)
+		}
+
+		return shutdown(config, checker, server, adminServer)
 	},
 }
 
@@ -60,20 +108,20 @@ func init() {
 	mainCmd.AddCommand(listenCmd)
 }
 
-func startHTTP(config *conf.Config) error {
+func newHTTP(config *conf.Config, checker *health.Checker) (*http.Server, net.Listener, error) {
 	httpServer := config.File.HttpServer
 
 	if httpServer == nil {
-		return errors.New("missing config")
+		return nil, nil, errors.New("missing config")
 	}
 
 	if httpServer.Tls == nil {
-		return errors.New("missing TLS")
+		return nil, nil, errors.New("missing TLS")
 	}
 
 	tc, err := gconf.GetTLS(httpServer.Tls)
 	if err != nil {
-		return fmt.Errorf("failed to get tls: %w", err)
+		return nil, nil, fmt.Errorf("failed to get tls: %w", err)
 	}
 
 	bind := fmt.Sprintf(
@@ -84,10 +132,13 @@ func startHTTP(config *conf.Config) error {
 
 	listener, err := net.Listen("tcp", bind)
 	if err != nil {
-		return fmt.Errorf("failed to net listen: %w", err)
+		return nil, nil, fmt.Errorf("failed to net listen: %w", err)
 	}
 
-	router := handlers.Router(config)
+	router, err := handlers.Router(config, checker)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create router: %w", err)
+	}
 
 	server := &http.Server{
 		Handler:   router,
@@ -99,5 +150,96 @@ func startHTTP(config *conf.Config) error {
 		Strs("hostnames", httpServer.Hostnames).
 		Msg("Serving HTTPS.")
 
-	return server.ServeTLS(listener, "", "")
+	return server, listener, nil
+}
+
+// newAdmin serves the metrics and the health checks on a plain HTTP
+// listener, apart from the public one. It returns nil if the config
+// has no admin listener.
+func newAdmin(config *conf.Config, checker *health.Checker) *http.Server {
+	if config.File.HttpServer == nil || config.File.HttpServer.Admin == nil {
+		return nil
+	}
+
+	admin := config.File.HttpServer.Admin
+
+	bind := fmt.Sprintf(
+		"%s:%d",
+		admin.Bind,
+		admin.Port,
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(config.Metrics))
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) { checker.Livez(w, r, nil) })
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) { checker.ReadyzReport(w, r, nil) })
+
+	log.Info().
+		Str("bind", bind).
+		Msg("Serving admin HTTP.")
+
+	return &http.Server{
+		Addr:              bind,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// shutdown reports the server not ready, drains the in-flight
+// requests, then closes the pools.
+func shutdown(config *conf.Config, checker *health.Checker, server, adminServer *http.Server) error {
+	httpServer := config.File.HttpServer
+
+	delay := time.Duration(0)
+	timeout := defaultShutdownTimeout
+
+	if httpServer.ShutdownDelay != "" {
+		v, err := gconf.LoadDuration(httpServer.ShutdownDelay)
+		if err != nil {
+			return errors.Forward(err, "0e8fe335-9322-49a7-a582-f4772ead52ff")
+		}
+
+		delay = v
+	}
+
+	if httpServer.ShutdownTimeout != "" {
+		v, err := gconf.LoadDuration(httpServer.ShutdownTimeout)
+		if err != nil {
+			return errors.Forward(err, "41257f4e-415d-49f4-a5d7-2f53d03cf2a6")
+		}
+
+		timeout = v
+	}
+
+	checker.ShutDown()
+
+	log.Info().
+		Dur("delay", delay).
+		Dur("timeout", timeout).
+		Msg("Shutting down.")
+
+	time.Sleep(delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to drain HTTP server.")
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to drain admin HTTP server.")
+		}
+	}
+
+	config.Close()
+
+	log.Info().Msg("Shut down.")
+
+	return nil
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/domains/arc"
+	"abodemine/lib/consts"
+	"abodemine/lib/errors"
+	"abodemine/lib/logging"
+	"abodemine/lib/val"
+	"abodemine/projects/api/conf"
+)
+
+var mainCmd = &cobra.Command{
+	Use:          "api-admin",
+	Short:        "Manage the api quotas and cached sessions of organizations.",
+	SilenceUsage: true,
+}
+
+func init() {
+	mainCmd.PersistentFlags().String("config", "", "Path to the api config file.")
+	if err := viper.BindPFlag("config", mainCmd.PersistentFlags().Lookup("config")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.PersistentFlags().String("organization-id", "", "Organization to manage.")
+	if err := viper.BindPFlag("organization-id", mainCmd.PersistentFlags().Lookup("organization-id")); err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	logging.ExecuteCobraCommand(mainCmd)
+}
+
+type createAdminRequestOutput struct {
+	Request        *arc.Request
+	OrganizationId uuid.UUID
+}
+
+// createAdminRequest loads the api config and creates a request
+// with an admin session, so the casbin policy of the api applies.
+func createAdminRequest(ctx context.Context) (*createAdminRequestOutput, error) {
+	orgId, err := uuid.Parse(viper.GetString("organization-id"))
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "6a277573-50a0-434b-bc99-61f19b9695d8",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Invalid organization id.",
+			Path:   "/organization-id",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	config, err := conf.ResolveAndLoad(viper.GetString("config"))
+	if err != nil {
+		return nil, errors.Forward(err, "4b2e1d1e-ece0-4214-86b4-bd3d7fa7506c")
+	}
+
+	arcDomain := arc.NewDomain(&arc.NewDomainInput{
+		DeploymentEnvironment: config.File.DeploymentEnvironment,
+		AWS:                   config.AWS,
+		Casbin:                config.Casbin,
+		Flags:                 config.File.Flags,
+		OpenSearch:            config.OpenSearch,
+		Paseto:                config.Paseto,
+		PgxPool:               config.PGxPool,
+		Valkey:                config.Valkey,
+		ValkeyScript:          config.ValkeyScript,
+		Values:                config.Values,
+	})
+
+	requestId, err := val.NewUUID7()
+	if err != nil {
+		return nil, errors.Forward(err, "8a5b6b9f-32af-456e-ab09-94f13247639e")
+	}
+
+	r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+		Id:      requestId,
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "adf14b72-1ee3-4d33-a70b-51f5e4300555")
+	}
+
+	session, err := arcDomain.CreateServerSession(r, &arc.CreateServerSessionInput{
+		OrganizationId: consts.AbodeMineOrganizationId(),
+		UserId:         consts.AbodeMineBotUserId(),
+		RoleName:       consts.RoleApiAdminUser,
+		SessionType:    arc.SessionTypeSystem,
+		TTL:            1,
+		DoNotSave:      true,
+	})
+	if err != nil {
+		return nil, errors.Forward(err, "255602b3-271b-4435-afc4-351edcd060bb")
+	}
+
+	r.SetSession(session)
+
+	out := &createAdminRequestOutput{
+		Request:        r,
+		OrganizationId: orgId,
+	}
+
+	return out, nil
+}
+
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return &errors.Object{
+			Id:     "724b623f-50d4-4c0f-86e0-a9f163650536",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to encode output.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/admin"
+)
+
+var quotaCmd = &cobra.Command{
+	Use:          "quota",
+	Short:        "Show or update the api quota of an organization.",
+	SilenceUsage: true,
+}
+
+var quotaGetCmd = &cobra.Command{
+	Use:          "get",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestOut, err := createAdminRequest(context.Background())
+		if err != nil {
+			return errors.Forward(err, "775626ab-6306-4e60-8cbd-eadf4288fa2d")
+		}
+
+		out, err := admin.NewDomain(&admin.NewDomainInput{}).SelectApiQuota(requestOut.Request, &admin.SelectApiQuotaInput{
+			OrganizationId: requestOut.OrganizationId,
+		})
+		if err != nil {
+			return errors.Forward(err, "ef98b8a7-83b8-495e-83bb-61f1355340a5")
+		}
+
+		return writeJSON(out.Entity)
+	},
+}
+
+// quotaSetCmd only updates the flags that are set. Both quotas
+// are required to provision a new organization.
+var quotaSetCmd = &cobra.Command{
+	Use:          "set",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+
+		input := &admin.UpdateApiQuotaInput{
+			Layouts: make(map[string]bool),
+		}
+
+		if flags.Changed("daily-quota") {
+			v := viper.GetInt64("quota.set.daily-quota")
+			input.DailyQuota = &v
+		}
+
+		if flags.Changed("monthly-quota") {
+			v := viper.GetInt64("quota.set.monthly-quota")
+			input.MonthlyQuota = &v
+		}
+
+		for _, layout := range viper.GetStringSlice("quota.set.enable") {
+			input.Layouts[layout] = true
+		}
+
+		for _, layout := range viper.GetStringSlice("quota.set.disable") {
+			input.Layouts[layout] = false
+		}
+
+		if viper.GetBool("quota.set.no-expiry") {
+			input.ExpiresAt = new(time.Time)
+		} else if v := viper.GetString("quota.set.expires-at"); v != "" {
+			expiresAt, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return &errors.Object{
+					Id:     "17b96bde-005a-4ddf-9783-0bc0a232a015",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid expiry, expected RFC 3339.",
+					Path:   "/expires-at",
+					Cause:  err.Error(),
+					Err:    err,
+				}
+			}
+
+			input.ExpiresAt = &expiresAt
+		}
+
+		requestOut, err := createAdminRequest(context.Background())
+		if err != nil {
+			return errors.Forward(err, "bc3adf53-042c-4ddc-bb3d-07cb9c64dcab")
+		}
+
+		input.OrganizationId = requestOut.OrganizationId
+
+		out, err := admin.NewDomain(&admin.NewDomainInput{}).UpdateApiQuota(requestOut.Request, input)
+		if err != nil {
+			return errors.Forward(err, "4c04b1e9-f27e-4806-8642-9a80fe77663e")
+		}
+
+		log.Info().
+			Str("organization_id", requestOut.OrganizationId.String()).
+			Int64("deleted_sessions", out.DeletedSessions).
+			Msg("Updated api quota.")
+
+		return writeJSON(out.Entity)
+	},
+}
+
+func init() {
+	quotaSetCmd.Flags().Int64("daily-quota", 0, "Daily quota.")
+	if err := viper.BindPFlag("quota.set.daily-quota", quotaSetCmd.Flags().Lookup("daily-quota")); err != nil {
+		panic(err)
+	}
+
+	quotaSetCmd.Flags().Int64("monthly-quota", 0, "Monthly quota.")
+	if err := viper.BindPFlag("quota.set.monthly-quota", quotaSetCmd.Flags().Lookup("monthly-quota")); err != nil {
+		panic(err)
+	}
+
+	quotaSetCmd.Flags().StringSlice("enable", nil, "Layouts to enable, e.g. comps,timeline.")
+	if err := viper.BindPFlag("quota.set.enable", quotaSetCmd.Flags().Lookup("enable")); err != nil {
+		panic(err)
+	}
+
+	quotaSetCmd.Flags().StringSlice("disable", nil, "Layouts to disable.")
+	if err := viper.BindPFlag("quota.set.disable", quotaSetCmd.Flags().Lookup("disable")); err != nil {
+		panic(err)
+	}
+
+	quotaSetCmd.Flags().String("expires-at", "", "Expiry of the api access (RFC 3339).")
+	if err := viper.BindPFlag("quota.set.expires-at", quotaSetCmd.Flags().Lookup("expires-at")); err != nil {
+		panic(err)
+	}
+
+	quotaSetCmd.Flags().Bool("no-expiry", false, "Remove the expiry of the api access.")
+	if err := viper.BindPFlag("quota.set.no-expiry", quotaSetCmd.Flags().Lookup("no-expiry")); err != nil {
+		panic(err)
+	}
+
+	quotaCmd.AddCommand(quotaGetCmd, quotaSetCmd)
+	mainCmd.AddCommand(quotaCmd)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"abodemine/lib/errors"
+	"abodemine/projects/api/domains/admin"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:          "sessions",
+	Short:        "List or delete the cached api sessions of an organization.",
+	SilenceUsage: true,
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:          "list",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestOut, err := createAdminRequest(context.Background())
+		if err != nil {
+			return errors.Forward(err, "566514ac-b6b2-4769-bf61-74959efb5a0c")
+		}
+
+		out, err := admin.NewDomain(&admin.NewDomainInput{}).SelectApiSessions(requestOut.Request, &admin.SelectApiSessionsInput{
+			OrganizationId: requestOut.OrganizationId,
+		})
+		if err != nil {
+			return errors.Forward(err, "d13d7758-b71f-41bd-8a4c-1f8d42c404f7")
+		}
+
+		return writeJSON(out.Entities)
+	},
+}
+
+var sessionsDeleteCmd = &cobra.Command{
+	Use:          "delete",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestOut, err := createAdminRequest(context.Background())
+		if err != nil {
+			return errors.Forward(err, "4d93daed-eea7-478d-9504-e7f2284ee907")
+		}
+
+		out, err := admin.NewDomain(&admin.NewDomainInput{}).DeleteApiSessions(requestOut.Request, &admin.DeleteApiSessionsInput{
+			OrganizationId: requestOut.OrganizationId,
+		})
+		if err != nil {
+			return errors.Forward(err, "1d0f5ab8-f3b0-47bd-a276-0de54e09610f")
+		}
+
+		log.Info().
+			Str("organization_id", requestOut.OrganizationId.String()).
+			Int64("deleted", out.Deleted).
+			Msg("Deleted cached api sessions.")
+
+		return nil
+	},
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsListCmd, sessionsDeleteCmd)
+	mainCmd.AddCommand(sessionsCmd)
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/domains/arc"
+	"abodemine/entities"
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+	"abodemine/projects/api/conf"
+	"abodemine/projects/api/domains/usage"
+)
+
+const (
+	statementsFormatCSV  = "csv"
+	statementsFormatJSON = "json"
+)
+
+var statementsCmd = &cobra.Command{
+	Use:          "statements",
+	Short:        "Export the monthly billing statements of the organizations.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := viper.GetString("statements.format")
+
+		if format != statementsFormatCSV && format != statementsFormatJSON {
+			return &errors.Object{
+				Id:     "e08a27f3-f0c0-40b4-9398-7194c6003c09",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid format.",
+				Path:   "/format",
+				Meta: map[string]any{
+					"format": format,
+				},
+			}
+		}
+
+		// The previous month, from its first day, since AddDate
+		// normalizes e.g. March 31 minus a month to March 3.
+		month := arc.QuotaPeriodStart(arc.QuotaPeriodMonthly, time.Now()).AddDate(0, -1, 0)
+
+		if v := viper.GetString("statements.month"); v != "" {
+			t, err := time.Parse("2006-01", v)
+			if err != nil {
+				return &errors.Object{
+					Id:     "8d666621-2194-491b-9e31-d6ae8e248206",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid month, expected YYYY-MM.",
+					Path:   "/month",
+					Cause:  err.Error(),
+					Err:    err,
+				}
+			}
+
+			month = t
+		}
+
+		var orgId uuid.UUID
+
+		if v := viper.GetString("statements.organization-id"); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return &errors.Object{
+					Id:     "9eae6ac2-b182-4248-bac8-56378cb514dd",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid organization id.",
+					Path:   "/organization-id",
+					Cause:  err.Error(),
+					Err:    err,
+				}
+			}
+
+			orgId = id
+		}
+
+		config, err := conf.ResolveAndLoad(viper.GetString("config"))
+		if err != nil {
+			return errors.Forward(err, "8edb62ce-f515-40f8-b48e-0ce7a8137af2")
+		}
+
+		arcDomain := arc.NewDomain(&arc.NewDomainInput{
+			DeploymentEnvironment: config.File.DeploymentEnvironment,
+			AWS:                   config.AWS,
+			Flags:                 config.File.Flags,
+			OpenSearch:            config.OpenSearch,
+			Paseto:                config.Paseto,
+			PgxPool:               config.PGxPool,
+			Valkey:                config.Valkey,
+			ValkeyScript:          config.ValkeyScript,
+			Values:                config.Values,
+		})
+
+		requestId, err := val.NewUUID7()
+		if err != nil {
+			return errors.Forward(err, "e05dcd26-08d6-4629-8877-95104ca41a30")
+		}
+
+		r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+			Id:      requestId,
+			Context: context.Background(),
+		})
+		if err != nil {
+			return errors.Forward(err, "83e82e13-08a4-47d2-b7a6-9ce4e7370af5")
+		}
+
+		usageDomain := usage.NewDomain(&usage.NewDomainInput{})
+
+		out, err := usageDomain.SelectBillingStatements(r, &usage.SelectBillingStatementsInput{
+			Month:          month,
+			OrganizationId: orgId,
+		})
+		if err != nil {
+			return errors.Forward(err, "6beded60-e4ca-4a0a-8843-d0df68462468")
+		}
+
+		var w io.Writer = os.Stdout
+
+		if path := viper.GetString("statements.out"); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return &errors.Object{
+					Id:     "8bf09ed6-a987-4840-8439-1bb4a23bcb42",
+					Code:   errors.Code_UNKNOWN,
+					Detail: "Failed to create output file.",
+					Cause:  err.Error(),
+					Err:    err,
+					Meta: map[string]any{
+						"path": path,
+					},
+				}
+			}
+
+			defer f.Close()
+
+			w = f
+		}
+
+		switch format {
+		case statementsFormatCSV:
+			err = writeStatementsCSV(w, out.Entities)
+		default:
+			err = writeStatementsJSON(w, out.Entities)
+		}
+		if err != nil {
+			return errors.Forward(err, "3bcd758f-5688-4caf-8a48-2a066adcca86")
+		}
+
+		log.Info().
+			Time("month", month).
+			Int("statements", len(out.Entities)).
+			Msg("Exported billing statements.")
+
+		return nil
+	},
+}
+
+func init() {
+	statementsCmd.Flags().String("month", "", "Month to bill (YYYY-MM). Defaults to the previous month.")
+	if err := viper.BindPFlag("statements.month", statementsCmd.Flags().Lookup("month")); err != nil {
+		panic(err)
+	}
+
+	statementsCmd.Flags().String("organization-id", "", "Organization to bill. Defaults to all organizations.")
+	if err := viper.BindPFlag("statements.organization-id", statementsCmd.Flags().Lookup("organization-id")); err != nil {
+		panic(err)
+	}
+
+	statementsCmd.Flags().String("format", statementsFormatJSON, "Output format (csv, json).")
+	if err := viper.BindPFlag("statements.format", statementsCmd.Flags().Lookup("format")); err != nil {
+		panic(err)
+	}
+
+	statementsCmd.Flags().String("out", "", "Output file. Defaults to stdout.")
+	if err := viper.BindPFlag("statements.out", statementsCmd.Flags().Lookup("out")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(statementsCmd)
+}
+
+func writeStatementsJSON(w io.Writer, statements []*entities.BillingStatement) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(statements); err != nil {
+		return &errors.Object{
+			Id:     "ee5cc689-545b-4e7a-8590-b1e10881d9de",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to encode statements.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+// writeStatementsCSV writes a row per organization, api key and layout.
+// Usage not tied to an api key has an empty api_key_id.
+func writeStatementsCSV(w io.Writer, statements []*entities.BillingStatement) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"organization_id",
+		"period_start",
+		"period_end",
+		"monthly_quota",
+		"api_key_id",
+		"layout",
+		"amount",
+	}); err != nil {
+		return &errors.Object{
+			Id:     "909fb694-62d5-4007-bacf-dfb11785ff98",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write header.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	for _, s := range statements {
+		for _, byApiKey := range s.ByApiKey {
+			apiKeyId := ""
+			if byApiKey.ApiKeyId != nil {
+				apiKeyId = byApiKey.ApiKeyId.String()
+			}
+
+			for _, layout := range entities.UsageLayouts {
+				amount := byApiKey.ByLayout[layout]
+				if amount == 0 {
+					continue
+				}
+
+				if err := cw.Write([]string{
+					s.OrganizationId.String(),
+					s.PeriodStart.Format(time.DateOnly),
+					s.PeriodEnd.Format(time.DateOnly),
+					strconv.FormatInt(s.Quota, 10),
+					apiKeyId,
+					layout,
+					strconv.FormatInt(amount, 10),
+				}); err != nil {
+					return &errors.Object{
+						Id:     "0f5243f4-540d-4b49-98ac-562c9f3f35a6",
+						Code:   errors.Code_UNKNOWN,
+						Detail: "Failed to write row.",
+						Cause:  err.Error(),
+						Err:    err,
+					}
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return &errors.Object{
+			Id:     "06a235e5-533d-4fb9-b332-8589ae8ddca1",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to flush rows.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+	api_conf "abodemine/projects/api/conf"
+	ci_conf "abodemine/projects/ci/conf"
+	datapipe_conf "abodemine/projects/datapipe/conf"
+	packer_conf "abodemine/projects/packer/conf"
+	saas_conf "abodemine/projects/saas/conf"
+	search_conf "abodemine/projects/search/conf"
+)
+
+// projectFiles returns the value the config file of each project is
+// parsed into.
+var projectFiles = map[string]func() any{
+	"api":      func() any { return new(api_conf.File) },
+	"ci":       func() any { return new(ci_conf.File) },
+	"datapipe": func() any { return new(datapipe_conf.File) },
+	"packer":   func() any { return new(packer_conf.File) },
+	"saas":     func() any { return new(saas_conf.File) },
+	"search":   func() any { return new(search_conf.File) },
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <file>",
+	Short: "Check a config file against the schema of its project.",
+	Long: `Check a config file against the schema of its project.
+
+The file is read as the servers read it, decrypted and with its
+variables and secrets expanded, then checked as ResolveConfig does:
+unknown keys, missing required keys, invalid durations and URLs are
+reported with the path of the key.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems, err := lintFile(cmd.Context(), viper.GetString("lint.project"), args[0])
+		if err != nil {
+			return errors.Forward(err, "6060304a-505f-476d-8226-8f1e43147d02")
+		}
+
+		if err := writeProblems(os.Stdout, problems); err != nil {
+			return errors.Forward(err, "c9cbe6e5-6165-4f48-a3b3-e46496fd6ec6")
+		}
+
+		if len(problems) > 0 {
+			return &errors.Object{
+				Id:     "24724930-ea4f-4fe0-8e64-7a01c62b5964",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Invalid config file.",
+				Meta: map[string]any{
+					"path":     args[0],
+					"problems": len(problems),
+				},
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	projects := make([]string, 0, len(projectFiles))
+	for k := range projectFiles {
+		projects = append(projects, k)
+	}
+
+	sort.Strings(projects)
+
+	lintCmd.Flags().String("project", "", "Project of the config file, one of "+strings.Join(projects, ", ")+".")
+	if err := viper.BindPFlag("lint.project", lintCmd.Flags().Lookup("project")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(lintCmd)
+}
+
+// lintFile returns the problems of the config file of the project.
+func lintFile(ctx context.Context, project, configPath string) ([]*errors.Object, error) {
+	newFile, ok := projectFiles[project]
+	if !ok {
+		return nil, &errors.Object{
+			Id:     "f2e39945-d256-420a-96a5-646cac2cdcba",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Unknown project.",
+			Path:   "/project",
+			Meta: map[string]any{
+				"project": project,
+			},
+		}
+	}
+
+	source, err := gconf.NewConfigSource(configPath, "")
+	if err != nil {
+		return nil, errors.Forward(err, "79f59fa4-ac79-4ccf-8e80-c937e65365b5")
+	}
+
+	body, err := source.Read(ctx)
+	if err != nil {
+		return nil, errors.Forward(err, "01b7f1b9-99aa-418c-839d-b5ae41322174")
+	}
+
+	problems, err := gconf.ValidateSchema(body, configPath, newFile())
+	if err != nil {
+		return nil, errors.Forward(err, "52c1ff29-75b8-4fdb-8b86-badd89322846")
+	}
+
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	// The schema does not check the types of the values.
+	if err := source.Decode(body, newFile()); err != nil {
+		return nil, errors.Forward(err, "20d09902-90b2-4c6c-be77-19fd106ea14d")
+	}
+
+	return nil, nil
+}
+
+func writeProblems(w io.Writer, problems []*errors.Object) error {
+	var b strings.Builder
+
+	for _, problem := range problems {
+		fmt.Fprintf(&b, "%s: %s\n", problem.Path, problem.Detail)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return &errors.Object{
+			Id:     "5a73874c-b1ff-4523-b6b8-8efd39bf6c65",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write problems.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
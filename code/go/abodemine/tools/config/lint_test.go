@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "Update the golden files.")
+
+// repoRoot is the root of the repository, from this package.
+const repoRoot = "../../../../.."
+
+// sampleEnv is the environment the sample configs are rendered with.
+// The optional variables are all set, so that every block is linted.
+var sampleEnv = map[string]string{
+	"ABODEMINE_ADMIN_PORT":              "9100",
+	"ABODEMINE_API_FLAGS":               "flag-a,flag-b",
+	"ABODEMINE_CONFIG_RELOAD_INTERVAL":  "1m",
+	"ABODEMINE_DATAPIPE_FLAGS":          "flag-a,flag-b",
+	"ABODEMINE_ERROR_REPORTER_FILE":     "/workspace/.local/errors.ndjson",
+	"ABODEMINE_METRICS_TEXTFILE_DIR":    "/workspace/.local/metrics",
+	"ABODEMINE_SAAS_FLAGS":              "flag-a,flag-b",
+	"ABODEMINE_TRACING_FILE":            "/workspace/.local/traces.ndjson",
+	"ABODEMINE_WORKSPACE":               "/workspace",
+	"LOCAL_CI":                          "false",
+	"OPENSEARCH_INITIAL_ADMIN_PASSWORD": "password",
+	"SENTRY_DSN":                        "https://key@sentry.example.com/1",
+}
+
+// shippedEnv is the build environment the shipped configs are
+// rendered with, besides the sample environment.
+var shippedEnv = map[string]string{
+	"ABODEMINE_NAMESPACE": "test",
+}
+
+// shippedTemplates are the config templates the build and infra
+// projects render with their params, by golden name.
+var shippedTemplates = map[string]struct {
+	project string
+	path    string
+	env     map[string]string
+}{
+	"build-go-api": {
+		project: "api",
+		path:    "build/servers/go-api/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "servers-go-api"},
+	},
+	"build-go-saas": {
+		project: "saas",
+		path:    "build/servers/go-saas/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "servers-go-saas"},
+	},
+	"build-go-datapipe-fetcher": {
+		project: "datapipe",
+		path:    "build/workers/go-datapipe/tasks/fetcher/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-datapipe", "ABODEMINE_TASK_NAME": "fetcher"},
+	},
+	"build-go-datapipe-loader": {
+		project: "datapipe",
+		path:    "build/workers/go-datapipe/tasks/loader/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-datapipe", "ABODEMINE_TASK_NAME": "loader"},
+	},
+	"build-go-datapipe-osloader": {
+		project: "datapipe",
+		path:    "build/workers/go-datapipe/tasks/osloader/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-datapipe", "ABODEMINE_TASK_NAME": "osloader"},
+	},
+	"build-go-datapipe-synther": {
+		project: "datapipe",
+		path:    "build/workers/go-datapipe/tasks/synther/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-datapipe", "ABODEMINE_TASK_NAME": "synther"},
+	},
+	"infra-go-datapipe-task-launcher": {
+		project: "datapipe",
+		path:    "infra/opentofu/projects/workers/go-datapipe/lambdas/task-launcher/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-datapipe", "ABODEMINE_LAMBDA_NAME": "task-launcher"},
+	},
+	"infra-go-packer-secure-download": {
+		project: "packer",
+		path:    "infra/opentofu/projects/workers/go-packer/lambdas/secure-download/etc/config.yaml.gotmpl",
+		env:     map[string]string{"ABODEMINE_PROJECT_SLUG": "workers-go-packer", "ABODEMINE_LAMBDA_NAME": "secure-download"},
+	},
+}
+
+type templateEnv map[string]string
+
+func (e templateEnv) Getenv(key string, def ...string) string {
+	if v := e[key]; v != "" {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return ""
+}
+
+type templateFilepath struct{}
+
+func (templateFilepath) Join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+type templatePath struct{}
+
+func (templatePath) Join(elem ...string) string {
+	return path.Join(elem...)
+}
+
+type templateStrings struct{}
+
+func (templateStrings) Split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+func (templateStrings) TrimSpace(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func readYaml(t *testing.T, name string) map[string]any {
+	t.Helper()
+
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	var v map[string]any
+	require.NoError(t, yaml.Unmarshal(b, &v))
+
+	return v
+}
+
+// renderTemplate renders the config template as gomplate does, with
+// the env datasource and the other datasources read from data.
+func renderTemplate(t *testing.T, name string, env map[string]string, data map[string]any) string {
+	t.Helper()
+
+	tmpl, err := template.New(filepath.Base(name)).
+		// The default of gomplate.
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"datasource": func(name string) any {
+				if name == "env" {
+					return env
+				}
+
+				return data
+			},
+			"env":      func() templateEnv { return templateEnv(env) },
+			"filepath": func() templateFilepath { return templateFilepath{} },
+			"path":     func() templatePath { return templatePath{} },
+			"strings":  func() templateStrings { return templateStrings{} },
+		}).
+		ParseFiles(name)
+	require.NoError(t, err)
+
+	out := filepath.Join(t.TempDir(), "config.yaml")
+
+	f, err := os.Create(out)
+	require.NoError(t, err)
+
+	require.NoError(t, tmpl.Execute(f, nil))
+	require.NoError(t, f.Close())
+
+	return out
+}
+
+// renderSample renders the config template of the project as gomplate
+// does in the docker project, with the default docker config.
+func renderSample(t *testing.T, project string) string {
+	t.Helper()
+
+	return renderTemplate(t,
+		filepath.Join("../../projects", project, "conf/config.yaml.gotmpl"),
+		sampleEnv,
+		readYaml(t, filepath.Join(repoRoot, "infra/docker/projects/am-mono/config.default.yaml")),
+	)
+}
+
+// renderShipped renders the shipped config template as the build
+// scripts do, with the sample params.
+func renderShipped(t *testing.T, name string) string {
+	t.Helper()
+
+	shipped := shippedTemplates[name]
+
+	env := make(map[string]string)
+
+	for _, m := range []map[string]string{sampleEnv, shippedEnv, shipped.env} {
+		for k, v := range m {
+			env[k] = v
+		}
+	}
+
+	return renderTemplate(t, filepath.Join(repoRoot, shipped.path), env, readYaml(t, "testdata/params.yaml"))
+}
+
+func checkGolden(t *testing.T, name string, problems string) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(problems), 0o644))
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(want), problems)
+}
+
+func lintGolden(t *testing.T, project, configPath string) string {
+	t.Helper()
+
+	problems, err := lintFile(context.Background(), project, configPath)
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, writeProblems(&b, problems))
+
+	return b.String()
+}
+
+func TestLint_Samples(t *testing.T) {
+	for project := range projectFiles {
+		t.Run(project, func(t *testing.T) {
+			checkGolden(t, project, lintGolden(t, project, renderSample(t, project)))
+		})
+	}
+}
+
+func TestLint_Shipped(t *testing.T) {
+	for name, shipped := range shippedTemplates {
+		t.Run(name, func(t *testing.T) {
+			checkGolden(t, name, lintGolden(t, shipped.project, renderShipped(t, name)))
+		})
+	}
+}
+
+func TestLint_Invalid(t *testing.T) {
+	checkGolden(t, "invalid", lintGolden(t, "saas", "testdata/invalid.yaml"))
+}
+
+func TestLint_UnknownProject(t *testing.T) {
+	_, err := lintFile(context.Background(), "unknown", "testdata/invalid.yaml")
+	require.Error(t, err)
+}
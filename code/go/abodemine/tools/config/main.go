@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"abodemine/lib/logging"
+)
+
+var mainCmd = &cobra.Command{
+	Use:          "config",
+	Short:        "Check the config files of the projects.",
+	SilenceUsage: true,
+}
+
+func main() {
+	logging.ExecuteCobraCommand(mainCmd)
+}
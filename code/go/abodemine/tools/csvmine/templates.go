@@ -88,6 +88,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -101,6 +102,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -114,6 +116,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -127,6 +130,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -140,6 +144,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -165,6 +170,7 @@ func (dr *{{ .StructName }}) New(headers map[int]string, fields []string) (entit
 					Id:    "{{ uuidv4 }}",
 					Code:  errors.Code_INVALID_ARGUMENT,
 					Cause: err.Error(),
+					Err:   err,
 					Meta: map[string]any{
 						"value": field,
 					},
@@ -225,6 +231,7 @@ func (dr *{{ .StructName }}) SQLValues() ([]any, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to generate UUID.",
 				Cause:  err.Error(),
+				Err:   err,
 			}
 		}
 		dr.AMId = u
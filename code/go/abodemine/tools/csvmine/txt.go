@@ -92,6 +92,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to unmarshal stat file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -137,6 +138,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 				Code:   errors.Code_INVALID_ARGUMENT,
 				Detail: "Failed to parse number.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"column_name": colName,
 					"value":       s,
@@ -263,6 +265,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 					Code:   errors.Code_INVALID_ARGUMENT,
 					Detail: "Failed to infer date format.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -280,6 +283,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 					Code:   errors.Code_INVALID_ARGUMENT,
 					Detail: "Failed to infer number type.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -290,6 +294,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 					Code:   errors.Code_INVALID_ARGUMENT,
 					Detail: "Failed to infer number type.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -370,6 +375,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_FAILED_PRECONDITION,
 			Detail: "Failed to create tmp directory.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -380,6 +386,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_FAILED_PRECONDITION,
 			Detail: "Failed to create go file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -393,6 +400,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute template.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -408,6 +416,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_FAILED_PRECONDITION,
 			Detail: "Failed to create go file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -421,6 +430,7 @@ func statToPartnerDataFile(in *statToPartnerDataFileInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to execute template.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errcatalog"
+	"abodemine/lib/logging"
+)
+
+// mainCmd regenerates the error catalog of lib/errors. It runs
+// through go generate from lib/errors.
+var mainCmd = &cobra.Command{
+	Use:          "errcatalog",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := viper.GetString("root")
+
+		result, err := errcatalog.Scan(root)
+		if err != nil {
+			return fmt.Errorf("failed to scan: %w", err)
+		}
+
+		if duplicates := result.Duplicates(); len(duplicates) > 0 {
+			var lines []string
+
+			for id, declarations := range duplicates {
+				for _, d := range declarations {
+					lines = append(lines, fmt.Sprintf("%s %s", id, d.Pos))
+				}
+			}
+
+			sort.Strings(lines)
+
+			return fmt.Errorf("duplicate error ids:\n%s", strings.Join(lines, "\n"))
+		}
+
+		b, err := errcatalog.Generate(result)
+		if err != nil {
+			return fmt.Errorf("failed to generate: %w", err)
+		}
+
+		out := filepath.Join(root, "lib", "errors", errcatalog.GeneratedFile)
+
+		if err := os.WriteFile(out, b, 0o644); err != nil {
+			return fmt.Errorf("failed to write: %w", err)
+		}
+
+		fmt.Printf("Wrote %d errors to %s.\n", len(result.Origins()), out)
+
+		return nil
+	},
+}
+
+func init() {
+	mainCmd.Flags().String("root", ".", "Path to the root of the module.")
+	if err := viper.BindPFlag("root", mainCmd.Flags().Lookup("root")); err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	logging.ExecuteCobraCommand(mainCmd)
+}
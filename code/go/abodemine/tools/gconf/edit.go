@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit <file>",
+	Short: "Edit an encrypted config file.",
+	Long: `Edit an encrypted config file.
+
+The file is decrypted into a private temp directory and opened with
+$VISUAL or $EDITOR, then encrypted again with the same parameters. In
+fields mode, the values that did not change are kept as they were.
+The files encrypted by openssl are encrypted again in file mode.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := decryptFile(args[0])
+		if err != nil {
+			return errors.Forward(err, "2e62faca-6aa2-40c6-a26e-bb49fb3df1dc")
+		}
+
+		dir, err := os.MkdirTemp("", "gconf-edit-")
+		if err != nil {
+			return &errors.Object{
+				Id:     "00a7392e-de39-49d7-ad5f-df51c6e8d91a",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to create temp directory.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		defer os.RemoveAll(dir)
+
+		// The extension of the config is kept for the editor.
+		tmp := filepath.Join(dir, filepath.Base(configPath(args[0])))
+
+		if err := os.WriteFile(tmp, in.plain, 0o600); err != nil {
+			return &errors.Object{
+				Id:     "337f5502-ef53-40dd-a87e-09f02d7e01a9",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to write temp file.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if err := runEditor(tmp); err != nil {
+			return errors.Forward(err, "1a54b7ba-6c4c-434d-936c-0d05a93c275a")
+		}
+
+		edited, err := os.ReadFile(tmp)
+		if err != nil {
+			return &errors.Object{
+				Id:     "f1e744c6-e49f-4713-8887-80a8117664ac",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to read temp file.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		if bytes.Equal(edited, in.plain) {
+			return nil
+		}
+
+		encryptIn := &gconf.EncryptConfigInput{
+			Path:     configPath(args[0]),
+			Body:     edited,
+			Pass:     in.pass,
+			Previous: in.encrypted,
+		}
+
+		if in.header != nil {
+			encryptIn.Iter = in.header.Iter
+			encryptIn.Mode = in.header.Mode
+		}
+
+		out, err := gconf.EncryptConfig(encryptIn)
+		if err != nil {
+			return errors.Forward(err, "782d7997-5427-4ff9-a630-f3f93b9c591b")
+		}
+
+		if err := replaceFile(args[0], out); err != nil {
+			return errors.Forward(err, "8b01ebb9-5b5b-407a-ae00-8c9703e39adb")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	mainCmd.AddCommand(editCmd)
+}
+
+func runEditor(name string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor may have arguments, e.g. "code --wait".
+	fields := strings.Fields(editor)
+
+	cmd := exec.Command(fields[0], append(fields[1:], name)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return &errors.Object{
+			Id:     "482edf82-d56d-4508-afa5-9c1ba74f7de7",
+			Code:   errors.Code_ABORTED,
+			Detail: "Failed to run editor.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"editor": editor,
+			},
+		}
+	}
+
+	return nil
+}
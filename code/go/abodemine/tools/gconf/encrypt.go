@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+)
+
+// opensslPrefix starts the files encrypted by openssl with a salt.
+const opensslPrefix = "Salted__"
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt <file>",
+	Short: "Encrypt a config file.",
+	Long: `Encrypt a config file.
+
+In file mode, the whole file is encrypted. In fields mode, only the
+values of a yaml file are, so that a diff shows the keys that changed
+without revealing their values.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pass, err := getPass()
+		if err != nil {
+			return errors.Forward(err, "c0271361-6c10-4b94-bf64-2b619b5529ea")
+		}
+
+		body, err := readFile(args[0])
+		if err != nil {
+			return errors.Forward(err, "04aa0eb4-61ca-43c6-8b7e-24628d7ae6df")
+		}
+
+		if gconf.IsEncryptedConfig(body) || bytes.HasPrefix(body, []byte(opensslPrefix)) {
+			return &errors.Object{
+				Id:     "345dde22-83da-42a5-a213-00d404fa5d63",
+				Code:   errors.Code_FAILED_PRECONDITION,
+				Detail: "Config file is already encrypted, use edit or rekey.",
+			}
+		}
+
+		out, err := gconf.EncryptConfig(&gconf.EncryptConfigInput{
+			Path: configPath(args[0]),
+			Body: body,
+			Pass: pass,
+			Iter: viper.GetInt("encrypt.iter"),
+			Mode: viper.GetString("encrypt.mode"),
+		})
+		if err != nil {
+			return errors.Forward(err, "f0004db8-d45d-4811-b301-247c63dfb454")
+		}
+
+		if err := writeOutput(args[0], out, viper.GetBool("encrypt.in-place")); err != nil {
+			return errors.Forward(err, "7acb3264-c3e8-4862-a7db-e4b6afc207b1")
+		}
+
+		return nil
+	},
+}
+
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt <file>",
+	Short: "Decrypt a config file.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := decryptFile(args[0])
+		if err != nil {
+			return errors.Forward(err, "9d1897dd-98be-4852-aabb-bdd2dbd7b950")
+		}
+
+		if err := writeOutput(args[0], out.plain, viper.GetBool("decrypt.in-place")); err != nil {
+			return errors.Forward(err, "288a4655-e48d-420a-aa18-ebc9c5100b13")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	encryptCmd.Flags().String("mode", gconf.EncryptMode_FILE, "Encryption mode, file or fields.")
+	if err := viper.BindPFlag("encrypt.mode", encryptCmd.Flags().Lookup("mode")); err != nil {
+		panic(err)
+	}
+
+	encryptCmd.Flags().Int("iter", gconf.DefaultEncryptIter, "PBKDF2 iteration count.")
+	if err := viper.BindPFlag("encrypt.iter", encryptCmd.Flags().Lookup("iter")); err != nil {
+		panic(err)
+	}
+
+	encryptCmd.Flags().Bool("in-place", false, "Replace the file instead of writing to stdout.")
+	if err := viper.BindPFlag("encrypt.in-place", encryptCmd.Flags().Lookup("in-place")); err != nil {
+		panic(err)
+	}
+
+	decryptCmd.Flags().Bool("in-place", false, "Replace the file instead of writing to stdout.")
+	if err := viper.BindPFlag("decrypt.in-place", decryptCmd.Flags().Lookup("in-place")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(encryptCmd, decryptCmd)
+}
+
+// configPath returns the path of the file without its .enc extension,
+// whose extension is then the format of the config.
+func configPath(name string) string {
+	return strings.TrimSuffix(name, ".enc")
+}
+
+type decryptFileOutput struct {
+	pass      string
+	encrypted []byte
+	plain     []byte
+
+	// header is nil for the files encrypted by openssl.
+	header *gconf.EncryptHeader
+}
+
+func decryptFile(name string) (*decryptFileOutput, error) {
+	pass, err := getPass()
+	if err != nil {
+		return nil, errors.Forward(err, "034e1d23-e290-4ed6-b73a-0d4cdc87c035")
+	}
+
+	iter, err := getLegacyIter()
+	if err != nil {
+		return nil, errors.Forward(err, "d8798985-6c55-41c4-9c56-6bc7ff5ad148")
+	}
+
+	body, err := readFile(name)
+	if err != nil {
+		return nil, errors.Forward(err, "ac8faff0-dfb1-4f94-b732-59293036c58a")
+	}
+
+	header, _, err := gconf.ParseEncryptHeader(body)
+	if err != nil {
+		return nil, errors.Forward(err, "4954625f-64ce-4df4-b659-8c1c2aeca266")
+	}
+
+	plain, err := gconf.DecryptConfigFile(body, pass, iter)
+	if err != nil {
+		return nil, errors.Forward(err, "e1402ff6-4d3d-47e5-8f5f-dea78a503e6d")
+	}
+
+	out := &decryptFileOutput{
+		pass:      pass,
+		encrypted: body,
+		plain:     plain,
+		header:    header,
+	}
+
+	return out, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/logging"
+)
+
+var mainCmd = &cobra.Command{
+	Use:   "gconf",
+	Short: "Encrypt, decrypt, edit and rekey the config files.",
+	Long: `Encrypt, decrypt, edit and rekey the config files.
+
+The passphrase is read from <env-key>_ENC_PASS, as the servers read it,
+e.g. ABODEMINE_API_CONFIG_PATH_ENC_PASS. The files encrypted by openssl,
+before the encryption header, are decrypted with the iteration count of
+<env-key>_ENC_ITER.`,
+	SilenceUsage: true,
+}
+
+func init() {
+	mainCmd.PersistentFlags().String("env-key", "", "Variable of the config file path, the prefix of the passphrase variables.")
+	if err := viper.BindPFlag("env-key", mainCmd.PersistentFlags().Lookup("env-key")); err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	logging.ExecuteCobraCommand(mainCmd)
+}
+
+// getenv returns the variable of the suffix, prefixed with the env key.
+func getenv(suffix string) string {
+	envKey := strings.TrimSpace(viper.GetString("env-key"))
+	if envKey == "" {
+		return ""
+	}
+
+	return strings.TrimSpace(os.Getenv(envKey + suffix))
+}
+
+func getPass() (string, error) {
+	pass := getenv("_ENC_PASS")
+	if pass == "" {
+		return "", &errors.Object{
+			Id:     "a587bf52-1cfc-46da-9bfa-5bfbbea4c319",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Missing passphrase, set --env-key and <env-key>_ENC_PASS.",
+		}
+	}
+
+	return pass, nil
+}
+
+// getLegacyIter returns the iteration count of the files encrypted by
+// openssl, or 0 if not set.
+func getLegacyIter() (int, error) {
+	iterStr := getenv("_ENC_ITER")
+	if iterStr == "" {
+		return 0, nil
+	}
+
+	iter, err := strconv.Atoi(iterStr)
+	if err != nil {
+		return 0, &errors.Object{
+			Id:     "48eba997-9683-4226-95cf-c59687930400",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to parse iteration count.",
+			Cause:  err.Error(),
+			Err:    err,
+			Meta: map[string]any{
+				"iter": iterStr,
+			},
+		}
+	}
+
+	return iter, nil
+}
+
+func readFile(name string) ([]byte, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, &errors.Object{
+			Id:     "71e14796-f2b9-4e8d-bf5a-fdfacf756da9",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to read config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return b, nil
+}
+
+// writeOutput writes the body to the file in place, or to stdout.
+func writeOutput(name string, body []byte, inPlace bool) error {
+	if !inPlace {
+		if _, err := os.Stdout.Write(body); err != nil {
+			return &errors.Object{
+				Id:     "232b6ab9-7b56-4a1c-a258-72a517106d14",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to write output.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		return nil
+	}
+
+	if err := replaceFile(name, body); err != nil {
+		return errors.Forward(err, "021c3d2a-bbc1-4ab6-bcb6-6a170597c7f7")
+	}
+
+	return nil
+}
+
+// replaceFile replaces the file with the body, keeping its mode, so
+// that it is never left half written.
+func replaceFile(name string, body []byte) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return &errors.Object{
+			Id:     "312316e8-d3ff-4048-a06c-4b6eb3700dde",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "Failed to stat config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return &errors.Object{
+			Id:     "db647235-6e83-4cd7-8691-cd843e9bdcec",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to create temp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(body); err != nil {
+		f.Close()
+
+		return &errors.Object{
+			Id:     "72aa1c83-d3d3-453d-b7bf-d3f7e12529bd",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to write temp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return &errors.Object{
+			Id:     "186f675a-d1d4-4947-9d7b-a7dfc902f0e1",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to close temp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := os.Chmod(f.Name(), info.Mode().Perm()); err != nil {
+		return &errors.Object{
+			Id:     "717103ef-d9eb-473c-96dc-62b9fe4187a3",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to set mode of temp file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	if err := os.Rename(f.Name(), name); err != nil {
+		return &errors.Object{
+			Id:     "83704a88-7d5d-4513-9af7-ac4d6ca34502",
+			Code:   errors.Code_UNKNOWN,
+			Detail: "Failed to replace config file.",
+			Cause:  err.Error(),
+			Err:    err,
+		}
+	}
+
+	return nil
+}
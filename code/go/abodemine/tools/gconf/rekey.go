@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+)
+
+var rekeyCmd = &cobra.Command{
+	Use:   "rekey <file>",
+	Short: "Encrypt a config file again with a new passphrase or parameters.",
+	Long: `Encrypt a config file again with a new passphrase or parameters.
+
+The file is decrypted with <env-key>_ENC_PASS, and encrypted with
+<env-key>_ENC_NEW_PASS if set, a new salt, and the iteration count of
+--iter, so that the parameters can be raised over time. The files
+encrypted by openssl are converted to the current format.
+
+The servers must be given the new passphrase when the file is
+deployed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := decryptFile(args[0])
+		if err != nil {
+			return errors.Forward(err, "e8ae1c56-9f89-4f13-a8f9-8432e2c8585e")
+		}
+
+		pass := in.pass
+		if v := getenv("_ENC_NEW_PASS"); v != "" {
+			pass = v
+		}
+
+		// The mode is kept unless set.
+		mode := viper.GetString("rekey.mode")
+		if mode == "" && in.header != nil {
+			mode = in.header.Mode
+		}
+
+		// No previous file is given, so that all the values are
+		// encrypted again with a new salt.
+		out, err := gconf.EncryptConfig(&gconf.EncryptConfigInput{
+			Path: configPath(args[0]),
+			Body: in.plain,
+			Pass: pass,
+			Iter: viper.GetInt("rekey.iter"),
+			Mode: mode,
+		})
+		if err != nil {
+			return errors.Forward(err, "5b9884be-a74e-48d0-8521-ec06147f4492")
+		}
+
+		if err := replaceFile(args[0], out); err != nil {
+			return errors.Forward(err, "c6b71041-66e0-4c15-9b48-7f167f9955a4")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rekeyCmd.Flags().String("mode", "", "Encryption mode, file or fields. Defaults to the mode of the file.")
+	if err := viper.BindPFlag("rekey.mode", rekeyCmd.Flags().Lookup("mode")); err != nil {
+		panic(err)
+	}
+
+	rekeyCmd.Flags().Int("iter", gconf.DefaultEncryptIter, "PBKDF2 iteration count.")
+	if err := viper.BindPFlag("rekey.iter", rekeyCmd.Flags().Lookup("iter")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(rekeyCmd)
+}
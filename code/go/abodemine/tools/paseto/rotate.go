@@ -0,0 +1,254 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"abodemine/lib/errors"
+	"abodemine/lib/gconf"
+)
+
+// defaultGracePeriod is used when the config has no token expiry.
+const defaultGracePeriod = 24 * time.Hour
+
+const (
+	keyTypeSymmetric  = "symmetric"
+	keyTypeAsymmetric = "asymmetric"
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate the key of a Paseto config and print the new config.",
+	Long: `Rotate the key of a Paseto config and print the new config.
+
+The signing key is replaced by a new key, or by the verification key
+selected with --key-id, and is kept as a verification key until the end
+of the grace period. Expired verification keys are dropped.
+
+Configs shared by several servers, e.g. token-exchange, must be rotated
+in two steps, so that no server receives tokens it cannot verify:
+
+  1. Run with --stage, and deploy the new verification key everywhere.
+  2. Run with --key-id set to the staged key, and deploy again.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := viper.GetString("rotate.name")
+		if name == "" {
+			return &errors.Object{
+				Id:     "856ad47e-2eb4-4718-ac4a-11003fe06e7e",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "Missing config name.",
+				Path:   "/name",
+			}
+		}
+
+		config := &gconf.Paseto{}
+
+		if path := viper.GetString("config"); path != "" {
+			file := new(struct {
+				Paseto map[string]*gconf.Paseto `json:"paseto,omitempty" yaml:"paseto,omitempty"`
+			})
+
+			source, err := gconf.NewConfigSource(path, "")
+			if err != nil {
+				return errors.Forward(err, "5ac66c04-bbc3-4bda-a302-dcfa3b9a7fc7")
+			}
+
+			// Only the paseto configs of the file are read.
+			source.SkipSchema = true
+
+			if err := source.Load(cmd.Context(), file); err != nil {
+				return errors.Forward(err, "a407f37e-dcc8-4e8c-867e-e30278f3517c")
+			}
+
+			if v, ok := file.Paseto[name]; ok && v != nil {
+				config = v
+			}
+		} else {
+			switch viper.GetString("rotate.type") {
+			case keyTypeSymmetric:
+				config.Type = gconf.Paseto_V4_SYMMETRIC
+			case keyTypeAsymmetric:
+				config.Type = gconf.Paseto_V4_ASYMMETRIC
+			default:
+				return &errors.Object{
+					Id:     "2fa565f5-b91b-4808-afe5-4ada09043c48",
+					Code:   errors.Code_INVALID_ARGUMENT,
+					Detail: "Invalid key type.",
+					Path:   "/type",
+				}
+			}
+		}
+
+		gracePeriod := viper.GetDuration("rotate.grace-period")
+		if gracePeriod <= 0 {
+			gracePeriod = config.Expire
+		}
+
+		if gracePeriod <= 0 {
+			gracePeriod = defaultGracePeriod
+		}
+
+		rotated, err := rotatePaseto(&rotatePasetoInput{
+			Config:      config,
+			KeyId:       viper.GetString("rotate.key-id"),
+			Stage:       viper.GetBool("rotate.stage"),
+			GracePeriod: gracePeriod,
+			Now:         time.Now().UTC(),
+		})
+		if err != nil {
+			return errors.Forward(err, "2125ae34-de0c-4a9b-b17d-0888147761e7")
+		}
+
+		// Validate the result as the servers would load it.
+		if _, err := gconf.LoadPaseto(rotated); err != nil {
+			return errors.Forward(err, "8ac93d3e-1acb-4e42-aab8-862163368b76")
+		}
+
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(map[string]any{
+			"paseto": map[string]*gconf.Paseto{
+				name: rotated,
+			},
+		}); err != nil {
+			return &errors.Object{
+				Id:     "37071ffd-3bf1-4ad5-932d-a8a0e0e9d824",
+				Code:   errors.Code_UNKNOWN,
+				Detail: "Failed to encode config.",
+				Cause:  err.Error(),
+				Err:    err,
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.Flags().String("name", "", "Name of the Paseto config, e.g. session or token-exchange.")
+	if err := viper.BindPFlag("rotate.name", rotateCmd.Flags().Lookup("name")); err != nil {
+		panic(err)
+	}
+
+	rotateCmd.Flags().String("type", keyTypeSymmetric, "Key type (symmetric, asymmetric), when the config is not read from --config.")
+	if err := viper.BindPFlag("rotate.type", rotateCmd.Flags().Lookup("type")); err != nil {
+		panic(err)
+	}
+
+	rotateCmd.Flags().String("key-id", "", "Id of the new key, or of the staged verification key to promote. Defaults to the current time.")
+	if err := viper.BindPFlag("rotate.key-id", rotateCmd.Flags().Lookup("key-id")); err != nil {
+		panic(err)
+	}
+
+	rotateCmd.Flags().Bool("stage", false, "Add the new key as a verification key, without signing with it.")
+	if err := viper.BindPFlag("rotate.stage", rotateCmd.Flags().Lookup("stage")); err != nil {
+		panic(err)
+	}
+
+	rotateCmd.Flags().Duration("grace-period", 0, "How long the replaced key is still accepted. Defaults to the token expiry of the config, or 24h.")
+	if err := viper.BindPFlag("rotate.grace-period", rotateCmd.Flags().Lookup("grace-period")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(rotateCmd)
+}
+
+type rotatePasetoInput struct {
+	Config      *gconf.Paseto
+	KeyId       string
+	Stage       bool
+	GracePeriod time.Duration
+	Now         time.Time
+}
+
+func rotatePaseto(in *rotatePasetoInput) (*gconf.Paseto, error) {
+	keyId := in.KeyId
+	if keyId == "" {
+		keyId = in.Now.Format("20060102T150405Z")
+	}
+
+	out := &gconf.Paseto{
+		Type:   in.Config.Type,
+		Expire: in.Config.Expire,
+		KeyId:  in.Config.KeyId,
+		Seed:   in.Config.Seed,
+		Key:    in.Config.Key,
+	}
+
+	if keyId == out.KeyId {
+		return nil, &errors.Object{
+			Id:     "97e3f946-4b7d-4eb6-a08e-b00ac1c55ce3",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The key id is already used by the signing key.",
+			Path:   "/key-id",
+		}
+	}
+
+	var newKey *gconf.PasetoKey
+
+	for _, v := range in.Config.VerificationKeys {
+		if v == nil || (!v.ExpiresAt.IsZero() && in.Now.After(v.ExpiresAt)) {
+			continue
+		}
+
+		if v.KeyId == keyId {
+			newKey = v
+			continue
+		}
+
+		out.VerificationKeys = append(out.VerificationKeys, v)
+	}
+
+	if newKey == nil {
+		newKey = &gconf.PasetoKey{KeyId: keyId}
+
+		switch in.Config.Type {
+		case gconf.Paseto_V4_SYMMETRIC:
+			newKey.Key = paseto.NewV4SymmetricKey().ExportHex()
+		case gconf.Paseto_V4_ASYMMETRIC:
+			newKey.Seed = paseto.NewV4AsymmetricSecretKey().ExportSeedHex()
+		default:
+			return nil, &errors.Object{
+				Id:     "aaf8bf02-2190-4fc6-b08c-3a96616ce0aa",
+				Code:   errors.Code_INVALID_ARGUMENT,
+				Detail: "The provided Paseto type is unknown.",
+				Path:   "/type",
+			}
+		}
+	} else if in.Stage {
+		return nil, &errors.Object{
+			Id:     "fba420a4-ac73-485b-bc63-0b1449e5b0ff",
+			Code:   errors.Code_INVALID_ARGUMENT,
+			Detail: "The key is already staged.",
+			Path:   "/key-id",
+		}
+	}
+
+	if in.Stage {
+		newKey.ExpiresAt = time.Time{}
+		out.VerificationKeys = append(out.VerificationKeys, newKey)
+		return out, nil
+	}
+
+	if out.Key != "" || out.Seed != "" {
+		out.VerificationKeys = append(out.VerificationKeys, &gconf.PasetoKey{
+			KeyId:     out.KeyId,
+			Seed:      out.Seed,
+			Key:       out.Key,
+			ExpiresAt: in.Now.Add(in.GracePeriod),
+		})
+	}
+
+	out.KeyId = newKey.KeyId
+	out.Seed = newKey.Seed
+	out.Key = newKey.Key
+
+	return out, nil
+}
@@ -41,6 +41,7 @@ var getCmd = &cobra.Command{
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to load AWS configuration.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -163,6 +164,7 @@ func getParams(in *getParamsInput) (*getParamsOutput, error) {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to get parameters.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -193,6 +195,7 @@ func getParams(in *getParamsInput) (*getParamsOutput, error) {
 							Code:   errors.Code_UNKNOWN,
 							Detail: "Failed to unmarshal JSON value.",
 							Cause:  err.Error(),
+							Err:    err,
 							Meta: map[string]any{
 								"value": *param.Value,
 							},
@@ -256,6 +259,7 @@ func getParams(in *getParamsInput) (*getParamsOutput, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to marshal parameters.",
 			Cause:  err.Error(),
+			Err:    err,
 			Meta: map[string]any{
 				"format": in.Format,
 			},
@@ -273,6 +277,7 @@ func getParams(in *getParamsInput) (*getParamsOutput, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to create output directory.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -282,6 +287,7 @@ func getParams(in *getParamsInput) (*getParamsOutput, error) {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to write output.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
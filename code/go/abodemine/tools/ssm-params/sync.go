@@ -45,6 +45,7 @@ var syncCmd = &cobra.Command{
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to load AWS configuration.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 
@@ -130,6 +131,7 @@ func syncParams(in *syncParamsInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to open updates file.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -144,6 +146,7 @@ func syncParams(in *syncParamsInput) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to unmarshal updates file.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"file_ext": updatesFileExt,
 				},
@@ -156,6 +159,7 @@ func syncParams(in *syncParamsInput) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to unmarshal updates file.",
 				Cause:  err.Error(),
+				Err:    err,
 				Meta: map[string]any{
 					"file_ext": updatesFileExt,
 				},
@@ -235,6 +239,7 @@ func syncParams(in *syncParamsInput) error {
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to marshal JSON.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -248,6 +253,7 @@ func syncParams(in *syncParamsInput) error {
 						Code:   errors.Code_UNKNOWN,
 						Detail: "Failed to marshal JSON.",
 						Cause:  err.Error(),
+						Err:    err,
 					}
 				}
 
@@ -275,6 +281,7 @@ func syncParams(in *syncParamsInput) error {
 			Code:   errors.Code_UNKNOWN,
 			Detail: "Failed to read user input.",
 			Cause:  err.Error(),
+			Err:    err,
 		}
 	}
 
@@ -428,6 +435,7 @@ func applyUpdateCommand(in *applyUpdateCommandInput) error {
 					Code:   errors.Code_UNKNOWN,
 					Detail: "Failed to marshal JSON.",
 					Cause:  err.Error(),
+					Err:    err,
 				}
 			}
 
@@ -448,6 +456,7 @@ func applyUpdateCommand(in *applyUpdateCommandInput) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to create parameter.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	case UpdateOptionUpdate:
@@ -463,6 +472,7 @@ func applyUpdateCommand(in *applyUpdateCommandInput) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to update parameter.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	case UpdateOptionDelete:
@@ -475,6 +485,7 @@ func applyUpdateCommand(in *applyUpdateCommandInput) error {
 				Code:   errors.Code_UNKNOWN,
 				Detail: "Failed to delete parameter.",
 				Cause:  err.Error(),
+				Err:    err,
 			}
 		}
 	}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"abodemine/domains/address"
+	"abodemine/domains/arc"
+	"abodemine/domains/assessor"
+	"abodemine/domains/avm"
+	"abodemine/domains/listings"
+	"abodemine/domains/property"
+	"abodemine/domains/recorder"
+	"abodemine/domains/timeline"
+	"abodemine/domains/token"
+	"abodemine/lib/app"
+	"abodemine/lib/errors"
+	"abodemine/lib/val"
+	"abodemine/projects/api/conf"
+	"abodemine/projects/api/domains/auth"
+	"abodemine/projects/api/domains/bulk"
+)
+
+var runCmd = &cobra.Command{
+	Use:          "run",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		config, err := conf.ResolveAndLoad(viper.GetString("config"))
+		if err != nil {
+			return errors.Forward(err, "2aadae45-d322-492c-a4af-db00feac5057")
+		}
+
+		workerId, err := val.NewUUID4()
+		if err != nil {
+			return errors.Forward(err, "b5876839-1573-4edb-ac20-05fccb7d03a6")
+		}
+
+		pollInterval := viper.GetDuration("run.poll-interval")
+
+		log.Info().
+			Str("build_id", app.BuildId()).
+			Str("build_version", app.BuildVersion()).
+			Bool("drain", viper.GetBool("run.drain")).
+			Dur("poll_interval", pollInterval).
+			Str("worker_id", workerId.String()).
+			Msg("Running bulk enricher.")
+
+		arcDomain := arc.NewDomain(&arc.NewDomainInput{
+			DeploymentEnvironment: config.File.DeploymentEnvironment,
+			AWS:                   config.AWS,
+			Flags:                 config.File.Flags,
+			OpenSearch:            config.OpenSearch,
+			Paseto:                config.Paseto,
+			PgxPool:               config.PGxPool,
+			Valkey:                config.Valkey,
+			ValkeyScript:          config.ValkeyScript,
+			Values:                config.Values,
+		})
+
+		authDomain := auth.NewDomain(&auth.NewDomainInput{
+			ArcDomain:   arcDomain,
+			TokenDomain: token.NewDomain(&token.NewDomainInput{}),
+		})
+
+		addressDomain := address.NewDomain(&address.NewDomainInput{})
+		recorderDomain := recorder.NewDomain(&recorder.NewDomainInput{})
+
+		listingsDomain := listings.NewDomain(&listings.NewDomainInput{
+			ArcDomain:     arcDomain,
+			AuthDomain:    authDomain,
+			AddressDomain: addressDomain,
+		})
+
+		propertyDomain := property.NewDomain(&property.NewDomainInput{
+			AddressDomain:  addressDomain,
+			AssessorDomain: assessor.NewDomain(&assessor.NewDomainInput{}),
+			AvmDomain:      avm.NewDomain(&avm.NewDomainInput{}),
+			ListingDomain:  listingsDomain,
+			RecorderDomain: recorderDomain,
+			TimelineDomain: timeline.NewDomain(&timeline.NewDomainInput{
+				ListingDomain:  listingsDomain,
+				RecorderDomain: recorderDomain,
+			}),
+		})
+
+		bulkDomain := bulk.NewDomain(&bulk.NewDomainInput{
+			Config:         config,
+			AddressDomain:  addressDomain,
+			AuthDomain:     authDomain,
+			PropertyDomain: propertyDomain,
+		})
+
+		for {
+			requestId, err := val.NewUUID7()
+			if err != nil {
+				return errors.Forward(err, "c75c58d7-dcbd-4336-a68a-ac0b45cc2bc8")
+			}
+
+			r, err := arcDomain.CreateRequest(&arc.CreateRequestInput{
+				Id:      requestId,
+				Context: ctx,
+			})
+			if err != nil {
+				return errors.Forward(err, "e794c8a3-1511-4fad-8323-f227fa99a846")
+			}
+
+			out, err := bulkDomain.ProcessNextBulkJob(r, &bulk.ProcessNextBulkJobInput{})
+			if err != nil {
+				return errors.Forward(err, "fb5976ba-8001-491c-b01d-f987e9e8d210")
+			}
+
+			if job := out.Entity; job != nil {
+				log.Info().
+					Str("bulk_job_id", job.Id.String()).
+					Str("status", job.Status).
+					Int("total_rows", job.TotalRows).
+					Int("enriched_rows", job.EnrichedRows).
+					Int("failed_rows", job.FailedRows).
+					Str("worker_id", workerId.String()).
+					Msg("Processed bulk job.")
+
+				continue
+			}
+
+			if viper.GetBool("run.drain") {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(pollInterval):
+			}
+		}
+	},
+}
+
+func init() {
+	runCmd.PersistentFlags().Bool("drain", false, "Exit once there are no pending jobs.")
+	if err := viper.BindPFlag("run.drain", runCmd.PersistentFlags().Lookup("drain")); err != nil {
+		panic(err)
+	}
+
+	runCmd.PersistentFlags().Duration("poll-interval", 10*time.Second, "Wait between checks for pending jobs.")
+	if err := viper.BindPFlag("run.poll-interval", runCmd.PersistentFlags().Lookup("poll-interval")); err != nil {
+		panic(err)
+	}
+
+	mainCmd.AddCommand(runCmd)
+}
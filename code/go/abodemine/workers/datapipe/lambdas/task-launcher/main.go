@@ -6,12 +6,15 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	aws_lambda "github.com/aws/aws-lambda-go/lambda"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"abodemine/domains/arc"
 	"abodemine/lib/app"
 	"abodemine/lib/errors"
 	"abodemine/lib/gconf"
 	"abodemine/lib/logging"
+	"abodemine/lib/tracing"
 	"abodemine/lib/val"
 	"abodemine/projects/datapipe/conf"
 	"abodemine/projects/datapipe/domains/lambda"
@@ -22,7 +25,7 @@ func main() {
 }
 
 func handler(ctx context.Context, event events.SQSEvent) error {
-	defer logging.FlushSentry()
+	defer logging.Flush()
 	if err := handlerFunc(ctx, event); err != nil {
 		log.Error().
 			Err(err).
@@ -53,6 +56,14 @@ func handlerFunc(ctx context.Context, event events.SQSEvent) error {
 		return errors.Forward(err, "dbf57f7a-8caf-4c98-8ab9-f4a3e7f55a8a")
 	}
 
+	// Flush the spans before the lambda is frozen.
+	defer tracing.Shutdown(context.Background())
+
+	ctx, span := tracing.Tracer().Start(ctx, "datapipe.task-launcher",
+		trace.WithAttributes(attribute.Int("datapipe.messages", len(event.Records))),
+	)
+	defer span.End()
+
 	requestDomain := arc.NewDomain(&arc.NewDomainInput{})
 	lambdaDomain := lambda.NewDomain(&lambda.NewDomainInput{
 		Config: config,
@@ -11,7 +11,8 @@ import (
 	"abodemine/domains/arc"
 	"abodemine/lib/app"
 	"abodemine/lib/errors"
-	"abodemine/lib/val"
+	"abodemine/lib/metrics"
+	"abodemine/lib/tracing"
 	"abodemine/projects/datapipe/conf"
 	"abodemine/projects/datapipe/domains/worker"
 )
@@ -27,7 +28,25 @@ var runCmd = &cobra.Command{
 			return errors.Forward(err, "c3597379-23e6-4185-bc18-07eecb5e4734")
 		}
 
-		requestId, err := val.NewUUID4()
+		// Flush the spans before the task exits.
+		defer tracing.Shutdown(context.Background())
+
+		// Continue the trace of the task launcher, if any.
+		ctx, span := tracing.Tracer().Start(tracing.ExtractEnv(ctx), "datapipe.fetcher")
+		defer span.End()
+
+		if dir := config.File.MetricsTextfileDir; dir != "" {
+			defer func() {
+				if err := metrics.WriteTextfile(dir, "datapipe_fetcher", worker.MetricsRegistry); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Failed to write metrics.")
+				}
+			}()
+		}
+
+		// Share the id of the run of the task launcher, if any.
+		requestId, err := arc.RunIdFromEnv()
 		if err != nil {
 			return errors.Forward(err, "0ba6ca58-a8c7-4a3d-ad00-3fad927251a7")
 		}
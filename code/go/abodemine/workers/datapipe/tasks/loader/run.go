@@ -13,7 +13,9 @@ import (
 	"abodemine/lib/app"
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
+	"abodemine/lib/metrics"
 	"abodemine/lib/storage"
+	"abodemine/lib/tracing"
 	"abodemine/lib/val"
 	"abodemine/projects/datapipe/conf"
 	"abodemine/projects/datapipe/domains/worker"
@@ -31,7 +33,25 @@ var runCmd = &cobra.Command{
 			return errors.Forward(err, "27efc9f8-485d-4d20-b918-c98143706463")
 		}
 
-		requestId, err := val.NewUUID4()
+		// Flush the spans before the task exits.
+		defer tracing.Shutdown(context.Background())
+
+		// Continue the trace of the task launcher, if any.
+		ctx, span := tracing.Tracer().Start(tracing.ExtractEnv(ctx), "datapipe.loader")
+		defer span.End()
+
+		if dir := config.File.MetricsTextfileDir; dir != "" {
+			defer func() {
+				if err := metrics.WriteTextfile(dir, "datapipe_loader", worker.MetricsRegistry); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Failed to write metrics.")
+				}
+			}()
+		}
+
+		// Share the id of the run of the task launcher, if any.
+		requestId, err := arc.RunIdFromEnv()
 		if err != nil {
 			return errors.Forward(err, "ca5bf013-8cd6-4494-a88e-df707b000ccc")
 		}
@@ -54,6 +74,8 @@ var runCmd = &cobra.Command{
 		arcDomain := arc.NewDomain(&arc.NewDomainInput{
 			DeploymentEnvironment: config.File.DeploymentEnvironment,
 			PgxPool:               config.PgxPool,
+			Valkey:                config.Valkey,
+			ValkeyScript:          config.ValkeyScript,
 		})
 
 		addressDomain := address.NewDomain(&address.NewDomainInput{})
@@ -13,7 +13,8 @@ import (
 	"abodemine/lib/app"
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
-	"abodemine/lib/val"
+	"abodemine/lib/metrics"
+	"abodemine/lib/tracing"
 	"abodemine/projects/datapipe/conf"
 	"abodemine/projects/datapipe/domains/worker"
 	"abodemine/repositories/opensearch"
@@ -30,7 +31,25 @@ var runCmd = &cobra.Command{
 			return errors.Forward(err, "a0d2d114-f337-4d08-b386-722f4c91bfc8")
 		}
 
-		requestId, err := val.NewUUID4()
+		// Flush the spans before the task exits.
+		defer tracing.Shutdown(context.Background())
+
+		// Continue the trace of the task launcher, if any.
+		ctx, span := tracing.Tracer().Start(tracing.ExtractEnv(ctx), "datapipe.osloader")
+		defer span.End()
+
+		if dir := config.File.MetricsTextfileDir; dir != "" {
+			defer func() {
+				if err := metrics.WriteTextfile(dir, "datapipe_osloader", worker.MetricsRegistry); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Failed to write metrics.")
+				}
+			}()
+		}
+
+		// Share the id of the run of the task launcher, if any.
+		requestId, err := arc.RunIdFromEnv()
 		if err != nil {
 			return errors.Forward(err, "a8fdbc7b-131f-4d13-a2d5-9744b3547b74")
 		}
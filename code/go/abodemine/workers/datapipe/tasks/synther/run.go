@@ -12,6 +12,8 @@ import (
 	"abodemine/lib/app"
 	"abodemine/lib/consts"
 	"abodemine/lib/errors"
+	"abodemine/lib/metrics"
+	"abodemine/lib/tracing"
 	"abodemine/lib/val"
 	"abodemine/projects/datapipe/conf"
 	"abodemine/projects/datapipe/domains/worker"
@@ -29,7 +31,25 @@ var runCmd = &cobra.Command{
 			return errors.Forward(err, "85b9a885-3bb1-49d4-8ea5-075fd7604c36")
 		}
 
-		requestId, err := val.NewUUID4()
+		// Flush the spans before the task exits.
+		defer tracing.Shutdown(context.Background())
+
+		// Continue the trace of the task launcher, if any.
+		ctx, span := tracing.Tracer().Start(tracing.ExtractEnv(ctx), "datapipe.synther")
+		defer span.End()
+
+		if dir := config.File.MetricsTextfileDir; dir != "" {
+			defer func() {
+				if err := metrics.WriteTextfile(dir, "datapipe_synther", worker.MetricsRegistry); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Failed to write metrics.")
+				}
+			}()
+		}
+
+		// Share the id of the run of the task launcher, if any.
+		requestId, err := arc.RunIdFromEnv()
 		if err != nil {
 			return errors.Forward(err, "03171f81-d877-4711-9c76-edf960aadfe9")
 		}
@@ -44,6 +64,8 @@ var runCmd = &cobra.Command{
 			DeploymentEnvironment: config.File.DeploymentEnvironment,
 			OpenSearch:            config.OpenSearch,
 			PgxPool:               config.PgxPool,
+			Valkey:                config.Valkey,
+			ValkeyScript:          config.ValkeyScript,
 		})
 
 		addressDomain := address.NewDomain(&address.NewDomainInput{})
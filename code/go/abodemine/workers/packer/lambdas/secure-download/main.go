@@ -23,7 +23,7 @@ func main() {
 }
 
 func handler(ctx context.Context, event events.APIGatewayV2HTTPRequest) (*events.APIGatewayV2HTTPResponse, error) {
-	defer logging.FlushSentry()
+	defer logging.Flush()
 
 	resp, err := handlerFunc(ctx, event)
 	if err != nil {
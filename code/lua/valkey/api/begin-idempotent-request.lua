@@ -0,0 +1,61 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+local state_field_name = "st"
+local fingerprint_field_name = "f"
+local owner_field_name = "o"
+local status_field_name = "c"
+local header_field_name = "h"
+local body_field_name = "b"
+
+-- argv[1]: Fingerprint of the request.
+-- argv[2]: Owner token of the caller.
+-- argv[3]: Lock TTL in milliseconds.
+--
+-- Returns STARTED when the caller now owns the key, MISMATCH
+-- when the key was used for another request, IN_PROGRESS when
+-- another caller owns the key, or DONE with the status, headers
+-- and body of the stored response.
+
+local hmget = server_call(
+	"HMGET",
+	keys[1],
+	state_field_name,
+	fingerprint_field_name
+)
+
+if not hmget[1] then
+	server_call(
+		"HSET",
+		keys[1],
+		state_field_name,
+		"p",
+		fingerprint_field_name,
+		argv[1],
+		owner_field_name,
+		argv[2]
+	)
+
+	server_call("PEXPIRE", keys[1], argv[3])
+
+	return { "STARTED" }
+end
+
+if hmget[2] ~= argv[1] then
+	return { "MISMATCH" }
+end
+
+if hmget[1] == "p" then
+	return { "IN_PROGRESS" }
+end
+
+local response = server_call(
+	"HMGET",
+	keys[1],
+	status_field_name,
+	header_field_name,
+	body_field_name
+)
+
+return { "DONE", response[1], response[2], response[3] }
@@ -0,0 +1,74 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- Generic cell rate algorithm (GCRA).
+-- Each key holds the theoretical arrival time (TAT) of its
+-- next request. Times are in microseconds.
+--
+-- argv[1]: Current time.
+-- argv[2n], argv[2n+1]: Emission interval and burst of keys[n].
+--
+-- The request is counted against all keys only if all of them
+-- allow it. Returns the status of the most restrictive key:
+-- allowed (0 or 1), burst, remaining, retry after and reset.
+
+local now = tonumber(argv[1])
+
+local allowed = 1
+local status = nil
+local new_tats = {}
+
+for i = 1, #keys do
+	-- Guard against a zero interval, which would divide by zero.
+	local interval = math.max(tonumber(argv[i * 2]) or 1, 1)
+	local burst = math.max(tonumber(argv[i * 2 + 1]) or 1, 1)
+	local tolerance = interval * (burst - 1)
+
+	local tat = tonumber(server_call("GET", keys[i]))
+
+	if tat == nil or tat < now then
+		tat = now
+	end
+
+	local key_status
+
+	if tat - now > tolerance then
+		key_status = {0, burst, 0, tat - now - tolerance, tat - now}
+
+		if allowed == 1 or key_status[4] > status[4] then
+			status = key_status
+		end
+
+		allowed = 0
+	else
+		local new_tat = tat + interval
+
+		new_tats[i] = new_tat
+		key_status = {1, burst, math.floor((now + tolerance - tat) / interval), 0, new_tat - now}
+
+		if allowed == 1 and (status == nil or key_status[3] < status[3]) then
+			status = key_status
+		end
+	end
+end
+
+if status == nil then
+	return {1, 0, 0, 0, 0}
+end
+
+if allowed == 0 then
+	return status
+end
+
+for i = 1, #keys do
+	server_call(
+		"SET",
+		keys[i],
+		string.format("%.0f", new_tats[i]),
+		"PX",
+		math.ceil((new_tats[i] - now) / 1000)
+	)
+end
+
+return status
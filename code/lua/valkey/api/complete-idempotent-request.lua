@@ -0,0 +1,43 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+local state_field_name = "st"
+local owner_field_name = "o"
+local status_field_name = "c"
+local header_field_name = "h"
+local body_field_name = "b"
+local transactions_field_name = "t"
+
+-- argv[1]: Owner token of the caller.
+-- argv[2]: Status code of the response.
+-- argv[3]: Headers of the response, as JSON.
+-- argv[4]: Body of the response.
+-- argv[5]: Quota transaction ids, as JSON.
+-- argv[6]: TTL of the response in milliseconds.
+--
+-- Returns 0 when the caller no longer owns the key, e.g.
+-- its lock expired, and the response was not stored.
+
+if server_call("HGET", keys[1], owner_field_name) ~= argv[1] then
+	return 0
+end
+
+server_call(
+	"HSET",
+	keys[1],
+	state_field_name,
+	"d",
+	status_field_name,
+	argv[2],
+	header_field_name,
+	argv[3],
+	body_field_name,
+	argv[4],
+	transactions_field_name,
+	argv[5]
+)
+
+server_call("PEXPIRE", keys[1], argv[6])
+
+return 1
@@ -0,0 +1,4 @@
+local keys = KEYS
+local server_call = server.call
+
+return server_call("DEL", keys[1])
@@ -0,0 +1,28 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+local state_field_name = "st"
+local owner_field_name = "o"
+
+-- argv[1]: Owner token of the caller.
+-- argv[2]: Lock TTL in milliseconds.
+--
+-- Extends the lock if the caller still owns the key and its
+-- response is not stored yet. Returns 0 when the caller no
+-- longer owns the key, e.g. its lock expired.
+
+local hmget = server_call(
+	"HMGET",
+	keys[1],
+	state_field_name,
+	owner_field_name
+)
+
+if hmget[1] ~= "p" or hmget[2] ~= argv[1] then
+	return 0
+end
+
+server_call("PEXPIRE", keys[1], argv[2])
+
+return 1
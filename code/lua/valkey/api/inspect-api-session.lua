@@ -0,0 +1,23 @@
+local keys = KEYS
+local server_call = server.call
+
+local invalid_field_name = "i"
+local quota_exhausted_field_name = "q"
+local session_field_name = "s"
+
+-- -2 when the key doesn't exist.
+local pttl = server_call("PTTL", keys[1])
+
+if pttl == -2 then
+	return { pttl }
+end
+
+local hmget = server_call(
+	"HMGET",
+	keys[1],
+	invalid_field_name,
+	quota_exhausted_field_name,
+	session_field_name
+)
+
+return { pttl, hmget[1], hmget[2], hmget[3] }
@@ -0,0 +1,16 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+local owner_field_name = "o"
+
+-- argv[1]: Owner token of the caller.
+--
+-- Deletes the key if the caller still owns it, so
+-- the request can be retried with the same key.
+
+if server_call("HGET", keys[1], owner_field_name) ~= argv[1] then
+	return 0
+end
+
+return server_call("DEL", keys[1])
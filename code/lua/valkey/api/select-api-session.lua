@@ -10,6 +10,7 @@ end
 
 local invalid_field_name = "i"
 local quota_exhausted_field_name = "q"
+local rate_limits_field_name = "l"
 local session_field_name = "s"
 
 local hmget = server_call(
@@ -17,7 +18,8 @@ local hmget = server_call(
 	keys[1],
 	invalid_field_name,
 	quota_exhausted_field_name,
-	session_field_name
+	session_field_name,
+	rate_limits_field_name
 )
 
 return hmget
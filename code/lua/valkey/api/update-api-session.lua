@@ -4,6 +4,7 @@ local server_call = server.call
 
 local invalid_field_name = "i"
 local quota_exhausted_field_name = "q"
+local rate_limits_field_name = "l"
 local session_field_name = "s"
 
 local hmset = server_call(
@@ -11,7 +12,8 @@ local hmset = server_call(
 	keys[1],
 	invalid_field_name, argv[1],
 	quota_exhausted_field_name, argv[2],
-	session_field_name, argv[3]
+	session_field_name, argv[3],
+	rate_limits_field_name, argv[5]
 )
 
 if hmset.ok ~= "OK" then
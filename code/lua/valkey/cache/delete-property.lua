@@ -0,0 +1,15 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- keys[1]: Layouts of the property.
+-- keys[2]: Generation of the property.
+--
+-- argv[1]: TTL of the generation, in seconds. It MUST outlive
+-- the requests that selected the layouts before the bump.
+
+server_call("INCR", keys[2])
+server_call("EXPIRE", keys[2], argv[1])
+server_call("DEL", keys[1])
+
+return "OK"
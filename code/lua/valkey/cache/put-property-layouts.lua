@@ -0,0 +1,33 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- keys[1]: Layouts of the property.
+-- keys[2]: Generation of the property.
+--
+-- The first argument is the TTL, the second the generation
+-- returned by select-property-layouts, followed by
+-- layout/value pairs.
+local ttl = argv[1]
+
+-- The layouts were read before an invalidation, so they
+-- may be stale and are not cached.
+local generation = server_call("GET", keys[2]) or ""
+
+if generation ~= argv[2] then
+	return "STALE"
+end
+
+server_call(
+	"HSET",
+	keys[1],
+	unpack(argv, 3)
+)
+
+-- Only the first fill sets the TTL, so no layout
+-- outlives it even if others are added later.
+if tonumber(server_call("TTL", keys[1])) < 0 then
+	server_call("EXPIRE", keys[1], ttl)
+end
+
+return "OK"
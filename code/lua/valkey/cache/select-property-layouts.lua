@@ -0,0 +1,18 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- keys[1]: Layouts of the property.
+-- keys[2]: Generation of the property.
+--
+-- Returns the layouts, followed by the generation, which
+-- put-property-layouts compares to skip stale fills.
+local values = server_call(
+	"HMGET",
+	keys[1],
+	unpack(argv)
+)
+
+values[#argv + 1] = server_call("GET", keys[2])
+
+return values
@@ -23,4 +23,19 @@ end
 
 server_call("EXPIRE", keys[1], argv[1])
 
+-- keys[2]: Optional index of the sessions of the user, a sorted
+-- set of session keys scored by their expiry in unix seconds.
+-- The index lives as long as its longest session.
+if keys[2] then
+	local now = tonumber(server_call("TIME")[1])
+	local ttl = tonumber(argv[1])
+
+	server_call("ZREMRANGEBYSCORE", keys[2], "-inf", now)
+	server_call("ZADD", keys[2], now + ttl, keys[1])
+
+	if tonumber(server_call("TTL", keys[2])) < ttl then
+		server_call("EXPIRE", keys[2], ttl)
+	end
+end
+
 return "OK"
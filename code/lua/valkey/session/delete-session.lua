@@ -1,4 +1,11 @@
 local keys = KEYS
 local server_call = server.call
 
-return server_call("DEL", keys[1]) or 0
+local deleted = server_call("DEL", keys[1]) or 0
+
+-- keys[2]: Optional index of the sessions of the user.
+if keys[2] then
+	server_call("ZREM", keys[2], keys[1])
+end
+
+return deleted
@@ -0,0 +1,20 @@
+local keys = KEYS
+local server_call = server.call
+
+-- keys[1]: Index of the sessions of the user.
+-- keys[2..n]: Session keys to delete.
+--
+-- Returns the number of deleted sessions.
+
+local deleted = 0
+
+for i = 2, #keys do
+	deleted = deleted + server_call("DEL", keys[i])
+	server_call("ZREM", keys[1], keys[i])
+end
+
+if tonumber(server_call("ZCARD", keys[1])) == 0 then
+	server_call("DEL", keys[1])
+end
+
+return deleted
@@ -0,0 +1,14 @@
+local keys = KEYS
+local server_call = server.call
+
+-- keys[1]: Index of the sessions of the user.
+--
+-- Drops expired sessions from the index, and returns the
+-- remaining session keys followed by their expiry in unix
+-- seconds.
+
+local now = tonumber(server_call("TIME")[1])
+
+server_call("ZREMRANGEBYSCORE", keys[1], "-inf", now)
+
+return server_call("ZRANGE", keys[1], 0, -1, "WITHSCORES")
@@ -0,0 +1,17 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- argv[1]: Lockout window in milliseconds.
+--
+-- Counts a failed attempt of the user. The window starts
+-- with the first failure, and is not extended by later ones.
+-- Returns the failed attempts in the window.
+
+local failures = server_call("INCR", keys[1])
+
+if failures == 1 then
+	server_call("PEXPIRE", keys[1], argv[1])
+end
+
+return failures
@@ -0,0 +1,18 @@
+local argv = ARGV
+local keys = KEYS
+local server_call = server.call
+
+-- argv[1]: Rate limit window in milliseconds.
+--
+-- Counts a one-time-password request of the email or the
+-- client address. The window starts with the first request,
+-- and is not extended by later ones.
+-- Returns the requests in the window.
+
+local requests = server_call("INCR", keys[1])
+
+if requests == 1 then
+	server_call("PEXPIRE", keys[1], argv[1])
+end
+
+return requests
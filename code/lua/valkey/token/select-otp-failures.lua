@@ -0,0 +1,5 @@
+local keys = KEYS
+local server_call = server.call
+
+-- Returns the failed attempts of the user, 0 if none.
+return tonumber(server_call("GET", keys[1])) or 0
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+alter table api_quotas
+	add column timeline_lo_enabled boolean not null default false;
+
+alter table api_quota_transactions
+	add column timeline_lo_amount integer not null default 0;
+
+-- Enable the layout for the AbodeMine org.
+update api_quotas
+set timeline_lo_enabled = true
+where organization_id = '019543c8-8fc8-7ab2-9d6b-982e4ccb11f5';
+
+-- +migrate Down
+
+alter table api_quota_transactions
+	drop column timeline_lo_amount;
+
+alter table api_quotas
+	drop column timeline_lo_enabled;
@@ -0,0 +1,48 @@
+-- +migrate Up
+
+--------------------------------------------------------------------------------
+-- Bulk Jobs.
+--------------------------------------------------------------------------------
+
+create table bulk_jobs (
+	id         uuid primary key,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	meta       jsonb,
+
+	organization_id uuid not null,
+	user_id         uuid,
+	api_key_id      uuid,
+	role_name       text not null,
+
+	job_status   smallint not null,
+	input_format smallint not null,
+	layouts      text[] not null,
+
+	input_object  text not null,
+	output_object text,
+
+	total_rows    integer not null default 0,
+	enriched_rows integer not null default 0,
+	failed_rows   integer not null default 0,
+
+	error_detail text,
+
+	download_token            text,
+	download_token_expires_at timestamp with time zone,
+
+	started_at  timestamp with time zone,
+	finished_at timestamp with time zone
+);
+
+create index bulk_jobs_organization_id
+	on bulk_jobs (organization_id, created_at);
+
+-- Workers claim the oldest pending job.
+create index bulk_jobs_pending
+	on bulk_jobs (created_at)
+	where job_status = 100;
+
+-- +migrate Down
+
+drop table bulk_jobs;
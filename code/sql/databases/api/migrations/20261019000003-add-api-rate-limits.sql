@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Rates are in requests per second, and bursts in requests.
+-- Null rates disable the limit. Key limits apply to each
+-- api key of the organization on its own.
+alter table api_quotas
+	add column org_rate_limit integer,
+	add column org_rate_burst integer,
+	add column key_rate_limit integer,
+	add column key_rate_burst integer;
+
+-- +migrate Down
+
+alter table api_quotas
+	drop column key_rate_burst,
+	drop column key_rate_limit,
+	drop column org_rate_burst,
+	drop column org_rate_limit;
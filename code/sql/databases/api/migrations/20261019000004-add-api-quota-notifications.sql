@@ -0,0 +1,39 @@
+-- +migrate Up
+
+-- Percentages of the daily and monthly quotas that, once
+-- crossed, record a notification for the organization.
+alter table api_quotas
+	add column notify_percents smallint[] not null default '{50,80,100}';
+
+--------------------------------------------------------------------------------
+-- Api Quota Notifications.
+--------------------------------------------------------------------------------
+
+create table api_quota_notifications (
+	id         uuid primary key,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	meta       jsonb,
+
+	organization_id uuid not null,
+	api_key_id      uuid,
+
+	-- Daily (100) or monthly (200).
+	quota_period smallint not null,
+	period_start date not null,
+	percent      smallint not null,
+
+	quota bigint not null,
+	usage bigint not null
+);
+
+-- A threshold is recorded once per period.
+create unique index api_quota_notifications_org_period_percent
+	on api_quota_notifications (organization_id, quota_period, period_start, percent);
+
+-- +migrate Down
+
+drop table api_quota_notifications;
+
+alter table api_quotas
+	drop column notify_percents;
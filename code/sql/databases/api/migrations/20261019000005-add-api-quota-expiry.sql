@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Organizations have no api access past expires_at.
+-- Null never expires.
+alter table api_quotas
+	add column expires_at timestamp with time zone;
+
+-- Quotas are provisioned with an upsert on the organization.
+drop index api_quotas_organization_id;
+
+create unique index api_quotas_organization_id
+	on api_quotas (organization_id);
+
+-- +migrate Down
+
+drop index api_quotas_organization_id;
+
+create index api_quotas_organization_id
+	on api_quotas (organization_id);
+
+alter table api_quotas
+	drop column expires_at;
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Workers hold a lease on the running jobs, refreshed while the
+-- job is processed. Running jobs past locked_until are claimed
+-- again, e.g. when the worker crashed.
+alter table bulk_jobs
+	add column locked_until timestamp with time zone,
+	add column heartbeat_at timestamp with time zone,
+	add column attempts     smallint not null default 0;
+
+create index bulk_jobs_running
+	on bulk_jobs (locked_until)
+	where job_status = 200;
+
+-- +migrate Down
+
+drop index bulk_jobs_running;
+
+alter table bulk_jobs
+	drop column locked_until,
+	drop column heartbeat_at,
+	drop column attempts;
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- One-time-password logins look users up by email.
+create index users_organization_id_lower_email on users (organization_id, lower(email));
+
+-- +migrate Down
+
+drop index users_organization_id_lower_email;
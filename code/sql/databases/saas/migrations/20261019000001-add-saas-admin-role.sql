@@ -0,0 +1,28 @@
+-- +migrate Up
+
+-- Admins manage the sessions and the roles of the users of their
+-- organization.
+insert into roles
+	(
+		id,
+		created_at,
+		updated_at,
+		name
+	)
+	values
+	(
+		'01a1535f-85de-738e-9255-a14284d54e89',
+		now(),
+		now(),
+		'saas_admin_user'
+	)
+;
+
+-- +migrate Down
+
+update users
+	set role_id = (select id from roles where name = 'saas_user')
+	where role_id = '01a1535f-85de-738e-9255-a14284d54e89'
+;
+
+delete from roles where id = '01a1535f-85de-738e-9255-a14284d54e89';
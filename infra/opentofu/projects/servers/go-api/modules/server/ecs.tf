@@ -10,7 +10,7 @@ resource "aws_lb_target_group" "main" {
   health_check {
     protocol            = "HTTPS"
     port                = var.project.containers.main.ports.http.port
-    path                = "/d5aecc76-03e9-4920-8ee5-30f3c390bc52"
+    path                = "/readyz"
     healthy_threshold   = 2
     unhealthy_threshold = 10
   }
@@ -45,6 +45,14 @@ resource "aws_security_group" "main" {
     security_groups = var.load_balancers.main.security_groups
   }
 
+  # Admin listener, for the metrics scrapers within the VPC.
+  ingress {
+    from_port   = var.project.containers.main.ports.admin.port
+    to_port     = var.project.containers.main.ports.admin.port
+    protocol    = "tcp"
+    cidr_blocks = [var.vpc.cidr_block]
+  }
+
   egress {
     from_port   = 0
     to_port     = 0
@@ -80,6 +80,7 @@ variable "vpc" {
   description = "The VPC configuration."
   type = object({
     id              = string
+    cidr_block      = string
     private_subnets = list(string)
   })
 }
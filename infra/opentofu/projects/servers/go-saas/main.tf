@@ -79,6 +79,7 @@ module "server" {
 
   vpc = {
     id              = data.terraform_remote_state.main.outputs.vpc.vpc_id
+    cidr_block      = data.terraform_remote_state.main.outputs.vpc.vpc_cidr_block
     private_subnets = data.terraform_remote_state.main.outputs.vpc.private_subnets
   }
 }